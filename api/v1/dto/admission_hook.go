@@ -0,0 +1,73 @@
+package dto
+
+import "time"
+
+type AdmissionHook struct {
+	ID          string                `json:"id" yaml:"id"`
+	UUID        string                `json:"uuid" yaml:"uuid"`
+	Version     uint                  `json:"version" yaml:"version"`
+	Metadata    AdmissionHookMetadata `json:"metadata" yaml:"metadata"`
+	Spec        AdmissionHookSpec     `json:"spec" yaml:"spec"`
+	Description string                `json:"description,omitempty" yaml:"description,omitempty"`
+	FilePath    string                `json:"filePath,omitempty" yaml:"filePath,omitempty"`
+	CreatedAt   time.Time             `json:"createdAt" yaml:"createdAt"`
+	UpdatedAt   time.Time             `json:"updatedAt" yaml:"updatedAt"`
+}
+
+type AdmissionHookMetadata struct {
+	Name   string            `json:"name" yaml:"name"`
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+// AdmissionHookSpec leaves out the secret, which is write only.
+type AdmissionHookSpec struct {
+	URL           string   `json:"url" yaml:"url"`
+	HasSecret     bool     `json:"hasSecret" yaml:"hasSecret"`
+	Kinds         []string `json:"kinds,omitempty" yaml:"kinds,omitempty"`
+	Operations    []string `json:"operations,omitempty" yaml:"operations,omitempty"`
+	FailurePolicy string   `json:"failurePolicy" yaml:"failurePolicy"`
+	Timeout       string   `json:"timeout" yaml:"timeout"`
+}
+
+type CreateAdmissionHookInput struct {
+	Metadata    AdmissionHookMetadataInput `json:"metadata" yaml:"metadata" validate:"required"`
+	Spec        AdmissionHookSpecInput     `json:"spec" yaml:"spec" validate:"required"`
+	Description string                     `json:"description" yaml:"description"`
+	FilePath    string                     `json:"filePath" yaml:"filePath"`
+}
+
+type AdmissionHookMetadataInput struct {
+	Name   string            `json:"name" yaml:"name" validate:"required,name"`
+	Labels map[string]string `json:"labels" yaml:"labels"`
+}
+
+type AdmissionHookSpecInput struct {
+	URL string `json:"url" yaml:"url" validate:"required,http_url"`
+	// Secret signs the reviews, the existing secret is kept when empty
+	Secret     string   `json:"secret" yaml:"secret"`
+	Kinds      []string `json:"kinds" yaml:"kinds" validate:"omitempty,unique,dive,admission_kind"`
+	Operations []string `json:"operations" yaml:"operations" validate:"omitempty,unique,dive,admission_operation"`
+	// FailurePolicy tells whether a change is denied (fail) or allowed (ignore) when the hook fails. Default: fail
+	FailurePolicy string `json:"failurePolicy" yaml:"failurePolicy" validate:"omitempty,oneof=fail ignore"`
+	// Timeout of a review. Default: 5s
+	Timeout string `json:"timeout" yaml:"timeout" validate:"omitempty,duration"`
+}
+
+type GetAdmissionHookInput struct {
+	Name string `uri:"name" validate:"required"`
+}
+
+type DeleteAdmissionHookInput struct {
+	Metadata AdmissionHookMetadataInput `json:"metadata" yaml:"metadata" validate:"required"`
+}
+
+type AdmissionVerdict struct {
+	AdmissionHook string `json:"admissionHook"`
+	Allowed       bool   `json:"allowed"`
+	Message       string `json:"message,omitempty"`
+}
+
+type ValidateAdmissionHookOutput struct {
+	AdmissionHook        *AdmissionHook `json:"admissionHook"`
+	AdmissionHookExisted *AdmissionHook `json:"admissionHookExisted"`
+}
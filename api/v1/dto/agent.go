@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+type RegisterAgentInput struct {
+	TenantID     uint64 `json:"tenantID" validate:"omitempty"`
+	IP           string `json:"ip" validate:"required,ip"`
+	Hostname     string `json:"hostname" validate:"required"`
+	AgentVersion string `json:"agentVersion" validate:"required"`
+}
+
+type AgentHeartbeatInput struct {
+	TenantID uint64 `json:"tenantID" validate:"omitempty"`
+	IP       string `json:"ip" validate:"required,ip"`
+	// Applied is the metadata of the policy fetched from fetchPolicies the agent currently enforces
+	Applied     HostEndpointPolicyMetadata `json:"applied"`
+	ApplyErrors []string                   `json:"applyErrors"`
+}
+
+type ListAgentsInput struct {
+	TenantID   uint64 `form:"tenantID" validate:"omitempty"`
+	StaleAfter string `form:"staleAfter" validate:"omitempty,duration"`
+}
+
+type Agent struct {
+	TenantID         uint64                     `json:"tenantID" yaml:"tenantID"`
+	IP               string                     `json:"ip" yaml:"ip"`
+	Hostname         string                     `json:"hostname" yaml:"hostname"`
+	AgentVersion     string                     `json:"agentVersion" yaml:"agentVersion"`
+	Applied          HostEndpointPolicyMetadata `json:"applied" yaml:"applied"`
+	ApplyErrors      []string                   `json:"applyErrors,omitempty" yaml:"applyErrors,omitempty"`
+	ExpectedRevision uint64                     `json:"expectedRevision" yaml:"expectedRevision"`
+	Stale            bool                       `json:"stale" yaml:"stale"`
+	OutOfSync        bool                       `json:"outOfSync" yaml:"outOfSync"`
+	Failing          bool                       `json:"failing" yaml:"failing"`
+	RegisteredAt     time.Time                  `json:"registeredAt" yaml:"registeredAt"`
+	LastHeartbeatAt  *time.Time                 `json:"lastHeartbeatAt,omitempty" yaml:"lastHeartbeatAt,omitempty"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Bundle struct {
+	// SchemaVersion is the schema version of the bundle, older bundles are upgraded when restored
+	SchemaVersion         int                    `json:"schemaVersion"`
+	CreatedAt             time.Time              `json:"createdAt"`
+	Tenants               []*Tenant              `json:"tenants"`
+	Tiers                 []*Tier                `json:"tiers"`
+	HostEndpoints         []*HostEndpoint        `json:"hostEndpoints"`
+	GlobalNetworkSets     []*GlobalNetworkSet    `json:"globalNetworkSets"`
+	NetworkSets           []*GlobalNetworkSet    `json:"networkSets"`
+	GlobalNetworkPolicies []*GlobalNetworkPolicy `json:"globalNetworkPolicies"`
+	NetworkPolicies       []*GlobalNetworkPolicy `json:"networkPolicies"`
+}
+
+type RestoreInput struct {
+	// Mode is how resources conflicting with stored ones are restored: skip keeps the stored ones, overwrite
+	// replaces them and fail restores nothing
+	Mode string `json:"mode" validate:"required,oneof=skip overwrite fail"`
+	// Bundle is a bundle of any schema version, as written by the backup
+	Bundle json.RawMessage `json:"bundle" validate:"required"`
+}
+
+// RestoreItemError is the error of an invalid resource of a bundle, the Index-th resource of its kind.
+type RestoreItemError struct {
+	Kind   string      `json:"kind"`
+	Index  int         `json:"index"`
+	Name   string      `json:"name"`
+	Error  string      `json:"error"`
+	Detail interface{} `json:"detail,omitempty"`
+}
+
+type RestoreResult struct {
+	Kind        string `json:"kind"`
+	Created     int    `json:"created"`
+	Overwritten int    `json:"overwritten"`
+	Skipped     int    `json:"skipped"`
+}
+
+type RestoreOutput struct {
+	Results []*RestoreResult `json:"results"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"encoding/json"
+)
+
+const (
+	BulkOperationUpsert = "upsert"
+	BulkOperationDelete = "delete"
+)
+
+type BulkApplyInput struct {
+	Items []*BulkItemInput `json:"items" validate:"required,min=1,dive"`
+	// Force deletes host endpoints and sets even if policy selectors lose their last match
+	Force bool `json:"force"`
+}
+
+type BulkItemInput struct {
+	Operation string `json:"operation" validate:"required,oneof=upsert delete"`
+	Kind      string `json:"kind" validate:"required,oneof=hostEndpoint globalNetworkSet globalNetworkPolicy"`
+	// Resource is the input of the create endpoint of the kind to upsert, e.g. a CreateHostEndpointInput, or the input
+	// of its delete endpoint to delete, e.g. a DeleteHostEndpointInput
+	Resource json.RawMessage `json:"resource" validate:"required"`
+}
+
+type BulkItemResult struct {
+	Index     int    `json:"index"`
+	Kind      string `json:"kind"`
+	Operation string `json:"operation"`
+	Name      string `json:"name"`
+	UUID      string `json:"uuid,omitempty"`
+	Version   uint   `json:"version,omitempty"`
+	// Action is created, updated, deleted or notFound
+	Action string      `json:"action,omitempty"`
+	Error  string      `json:"error,omitempty"`
+	Detail interface{} `json:"detail,omitempty"`
+}
+
+type BulkApplyOutput struct {
+	Items []*BulkItemResult `json:"items"`
+}
@@ -0,0 +1,64 @@
+package dto
+
+import "time"
+
+type EnrollmentToken struct {
+	ID          string                  `json:"id" yaml:"id"`
+	UUID        string                  `json:"uuid" yaml:"uuid"`
+	Version     uint                    `json:"version" yaml:"version"`
+	Metadata    EnrollmentTokenMetadata `json:"metadata" yaml:"metadata"`
+	Spec        EnrollmentTokenSpec     `json:"spec" yaml:"spec"`
+	Description string                  `json:"description,omitempty" yaml:"description,omitempty"`
+	FilePath    string                  `json:"filePath,omitempty" yaml:"filePath,omitempty"`
+	CreatedAt   time.Time               `json:"createdAt" yaml:"createdAt"`
+	UpdatedAt   time.Time               `json:"updatedAt" yaml:"updatedAt"`
+}
+
+type EnrollmentTokenMetadata struct {
+	Name   string            `json:"name" yaml:"name"`
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+type EnrollmentTokenSpec struct {
+	TenantID uint64 `json:"tenantID" yaml:"tenantID"`
+	// Token is only returned when the enrollment token is created
+	Token              string            `json:"token,omitempty" yaml:"token,omitempty"`
+	HasToken           bool              `json:"hasToken" yaml:"hasToken"`
+	AllowedCIDRs       []string          `json:"allowedCIDRs,omitempty" yaml:"allowedCIDRs,omitempty"`
+	LabelTemplates     map[string]string `json:"labelTemplates,omitempty" yaml:"labelTemplates,omitempty"`
+	AllowedAgentLabels []string          `json:"allowedAgentLabels,omitempty" yaml:"allowedAgentLabels,omitempty"`
+}
+
+type CreateEnrollmentTokenInput struct {
+	Metadata    EnrollmentTokenMetadataInput `json:"metadata" yaml:"metadata" validate:"required"`
+	Spec        EnrollmentTokenSpecInput     `json:"spec" yaml:"spec"`
+	Description string                       `json:"description" yaml:"description"`
+	FilePath    string                       `json:"filePath" yaml:"filePath"`
+}
+
+type EnrollmentTokenMetadataInput struct {
+	Name   string            `json:"name" yaml:"name" validate:"required,name"`
+	Labels map[string]string `json:"labels" yaml:"labels"`
+}
+
+type EnrollmentTokenSpecInput struct {
+	TenantID     uint64   `json:"tenantID" yaml:"tenantID" validate:"omitempty"`
+	AllowedCIDRs []string `json:"allowedCIDRs" yaml:"allowedCIDRs" validate:"omitempty,unique,dive,cidr"`
+	// LabelTemplates values are text/template executed with .Hostname, .OS, .InterfaceName, .IP and .TenantID
+	LabelTemplates map[string]string `json:"labelTemplates" yaml:"labelTemplates"`
+	// AllowedAgentLabels are the keys of the labels an agent may set itself
+	AllowedAgentLabels []string `json:"allowedAgentLabels" yaml:"allowedAgentLabels" validate:"omitempty,unique,dive,required"`
+}
+
+type GetEnrollmentTokenInput struct {
+	Name string `uri:"name" validate:"required"`
+}
+
+type DeleteEnrollmentTokenInput struct {
+	Metadata EnrollmentTokenMetadataInput `json:"metadata" yaml:"metadata" validate:"required"`
+}
+
+type ValidateEnrollmentTokenOutput struct {
+	EnrollmentToken        *EnrollmentToken `json:"enrollmentToken"`
+	EnrollmentTokenExisted *EnrollmentToken `json:"enrollmentTokenExisted"`
+}
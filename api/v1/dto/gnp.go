@@ -15,27 +15,39 @@ type GlobalNetworkPolicy struct {
 }
 
 type GNPMetadata struct {
-	Name   string            `json:"name" yaml:"name"`
-	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Name     string            `json:"name" yaml:"name"`
+	TenantID uint64            `json:"tenantID,omitempty" yaml:"tenantID,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
 }
 
 type GNPSpec struct {
-	Order    uint32        `json:"order" yaml:"order"`
+	Tier     string `json:"tier,omitempty" yaml:"tier,omitempty"`
+	Order    uint32 `json:"order" yaml:"order"`
+	Staged   bool   `json:"staged,omitempty" yaml:"staged,omitempty"`
+	Schedule `yaml:",inline"`
 	Selector string        `json:"selector,omitempty" yaml:"selector,omitempty"`
 	Ingress  []GNPSpecRule `json:"ingress,omitempty" yaml:"ingress,omitempty"`
 	Egress   []GNPSpecRule `json:"egress,omitempty" yaml:"egress,omitempty"`
 }
 
 type GNPSpecRule struct {
-	Metadata    map[string]string  `json:"metadata,omitempty" yaml:"metadata,omitempty"`
-	Action      string             `json:"action" yaml:"action"`
-	Protocol    interface{}        `json:"protocol,omitempty" yaml:"protocol,omitempty"`
-	NotProtocol interface{}        `json:"notProtocol,omitempty" yaml:"notProtocol,omitempty"`
-	IPVersion   *int               `json:"ipVersion,omitempty" yaml:"ipVersion,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Action      string            `json:"action" yaml:"action"`
+	Protocol    interface{}       `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	NotProtocol interface{}       `json:"notProtocol,omitempty" yaml:"notProtocol,omitempty"`
+	IPVersion   *int              `json:"ipVersion,omitempty" yaml:"ipVersion,omitempty"`
+	ICMP        *GNPSpecRuleICMP  `json:"icmp,omitempty" yaml:"icmp,omitempty"`
+	NotICMP     *GNPSpecRuleICMP  `json:"notICMP,omitempty" yaml:"notICMP,omitempty"`
+	Schedule    `yaml:",inline"`
 	Source      *GNPSpecRuleEntity `json:"source,omitempty" yaml:"source,omitempty"`
 	Destination *GNPSpecRuleEntity `json:"destination,omitempty" yaml:"destination,omitempty"`
 }
 
+type GNPSpecRuleICMP struct {
+	Type *int `json:"type,omitempty" yaml:"type,omitempty"`
+	Code *int `json:"code,omitempty" yaml:"code,omitempty"`
+}
+
 type GNPSpecRuleEntity struct {
 	Selector string        `json:"selector,omitempty" yaml:"selector,omitempty"`
 	Nets     []string      `json:"nets,omitempty" yaml:"nets,omitempty"`
@@ -57,20 +69,31 @@ type GNPMetadataInput struct {
 }
 
 type GNPSpecInput struct {
-	Order    *uint32            `json:"order" yaml:"order"`
-	Selector string             `json:"selector" yaml:"selector" validate:"omitempty,selector"`
-	Ingress  []GNPSpecRuleInput `json:"ingress" yaml:"ingress" validate:"omitempty,min=1,dive"`
-	Egress   []GNPSpecRuleInput `json:"egress" yaml:"egress" validate:"omitempty,min=1,dive"`
+	Tier          string  `json:"tier" yaml:"tier" validate:"omitempty,name"`
+	Order         *uint32 `json:"order" yaml:"order"`
+	Staged        bool    `json:"staged" yaml:"staged"`
+	ScheduleInput `yaml:",inline"`
+	Selector      string             `json:"selector" yaml:"selector" validate:"omitempty,selector"`
+	Ingress       []GNPSpecRuleInput `json:"ingress" yaml:"ingress" validate:"omitempty,min=1,dive"`
+	Egress        []GNPSpecRuleInput `json:"egress" yaml:"egress" validate:"omitempty,min=1,dive"`
 }
 
 type GNPSpecRuleInput struct {
-	Metadata    map[string]string       `json:"metadata" yaml:"metadata"`
-	Action      string                  `json:"action" yaml:"action" validate:"required,action"`
-	Protocol    interface{}             `json:"protocol" yaml:"protocol" validate:"omitempty,protocol"`
-	NotProtocol interface{}             `json:"notProtocol" yaml:"notProtocol" validate:"omitempty,protocol"`
-	IPVersion   *int                    `json:"ipVersion" yaml:"ipVersion" validate:"omitempty,ip_version"`
-	Source      *GNPSpecRuleEntityInput `json:"source" yaml:"source" validate:"omitempty"`
-	Destination *GNPSpecRuleEntityInput `json:"destination" yaml:"destination" validate:"omitempty"`
+	Metadata      map[string]string     `json:"metadata" yaml:"metadata"`
+	Action        string                `json:"action" yaml:"action" validate:"required,action"`
+	Protocol      interface{}           `json:"protocol" yaml:"protocol" validate:"omitempty,protocol"`
+	NotProtocol   interface{}           `json:"notProtocol" yaml:"notProtocol" validate:"omitempty,protocol"`
+	IPVersion     *int                  `json:"ipVersion" yaml:"ipVersion" validate:"omitempty,ip_version"`
+	ICMP          *GNPSpecRuleICMPInput `json:"icmp" yaml:"icmp" validate:"omitempty"`
+	NotICMP       *GNPSpecRuleICMPInput `json:"notICMP" yaml:"notICMP" validate:"omitempty"`
+	ScheduleInput `yaml:",inline"`
+	Source        *GNPSpecRuleEntityInput `json:"source" yaml:"source" validate:"omitempty"`
+	Destination   *GNPSpecRuleEntityInput `json:"destination" yaml:"destination" validate:"omitempty"`
+}
+
+type GNPSpecRuleICMPInput struct {
+	Type *int `json:"type" yaml:"type" validate:"omitempty,min=0,max=255"`
+	Code *int `json:"code" yaml:"code" validate:"omitempty,min=0,max=255"`
 }
 
 type GNPSpecRuleEntityInput struct {
@@ -90,11 +113,75 @@ type DeleteGlobalNetworkPolicyInput struct {
 }
 
 type ListGNPsInput struct {
-	IsOrder bool `form:"isOrder"`
+	IsOrder        bool   `form:"isOrder"`
+	ExpiringWithin string `form:"expiringWithin" validate:"omitempty,duration"`
 }
 
 type ValidateGlobalNetworkPolicyOutput struct {
 	GNP        *GlobalNetworkPolicy `json:"gnp"`
 	GNPExisted *GlobalNetworkPolicy `json:"gnpExisted"`
 	ParsedHEPs []*ParsedHEP         `json:"parsedHEPs"`
+	// UnmatchedSelectors are the rule selectors matching no host endpoint and no global network set
+	UnmatchedSelectors []*SelectorReference `json:"unmatchedSelectors,omitempty"`
+	// SelectorChange is identical, narrower, broader or different when the spec selector of the existing policy was
+	// rewritten
+	SelectorChange string `json:"selectorChange,omitempty"`
+	// RelatedSelectors are the other policies whose spec selector is identical to, narrower or broader than the
+	// spec selector
+	RelatedSelectors []*SelectorRelation `json:"relatedSelectors,omitempty"`
+	// RelatedSelectorsTruncated is set when there were too many policies to compare them all
+	RelatedSelectorsTruncated bool `json:"relatedSelectorsTruncated,omitempty"`
+	// LintFindings are the lint rules broken by the policy with the warn severity
+	LintFindings []*LintFinding `json:"lintFindings,omitempty"`
+	// AdmissionVerdicts are the verdicts of the admission hooks on the change
+	AdmissionVerdicts []*AdmissionVerdict `json:"admissionVerdicts,omitempty"`
+}
+
+type SelectorRelation struct {
+	GNPName  string `json:"gnpName"`
+	Selector string `json:"selector"`
+	Relation string `json:"relation"`
+}
+
+type SelectorReference struct {
+	GNPName  string `json:"gnpName"`
+	Field    string `json:"field"`
+	Selector string `json:"selector"`
+}
+
+type PromoteGlobalNetworkPolicyInput struct {
+	Metadata GNPMetadataInput `json:"metadata" yaml:"metadata" validate:"required"`
+}
+
+type GetGNPStagedReportInput struct {
+	Name string `uri:"name" validate:"required"`
+}
+
+type ReportStagedPoliciesInput struct {
+	TenantID uint64                    `json:"tenantID" validate:"omitempty"`
+	IP       string                    `json:"ip" validate:"required,ip"`
+	Reports  []StagedPolicyReportInput `json:"reports" validate:"required,min=1,dive"`
+}
+
+type StagedPolicyReportInput struct {
+	GNPUUID       string `json:"gnpUUID" validate:"required"`
+	DeniedPackets uint64 `json:"deniedPackets"`
+	DeniedBytes   uint64 `json:"deniedBytes"`
+}
+
+type StagedPolicyReport struct {
+	TenantID       uint64    `json:"tenantID" yaml:"tenantID"`
+	IP             string    `json:"ip" yaml:"ip"`
+	DeniedPackets  uint64    `json:"deniedPackets" yaml:"deniedPackets"`
+	DeniedBytes    uint64    `json:"deniedBytes" yaml:"deniedBytes"`
+	LastReportedAt time.Time `json:"lastReportedAt" yaml:"lastReportedAt"`
+}
+
+type StagedPolicyReportSummary struct {
+	GNPUUID       string                `json:"gnpUUID" yaml:"gnpUUID"`
+	GNPName       string                `json:"gnpName" yaml:"gnpName"`
+	Staged        bool                  `json:"staged" yaml:"staged"`
+	DeniedPackets uint64                `json:"deniedPackets" yaml:"deniedPackets"`
+	DeniedBytes   uint64                `json:"deniedBytes" yaml:"deniedBytes"`
+	Reports       []*StagedPolicyReport `json:"reports" yaml:"reports"`
 }
@@ -5,24 +5,57 @@ import (
 )
 
 type GlobalNetworkSet struct {
-	ID          string      `json:"id" yaml:"id"`
-	UUID        string      `json:"uuid" yaml:"uuid"`
-	Version     uint        `json:"version" yaml:"version"`
-	Metadata    GNSMetadata `json:"metadata" yaml:"metadata"`
-	Spec        GNSSpec     `json:"spec" yaml:"spec"`
-	Description string      `json:"description" yaml:"description"`
-	FilePath    string      `json:"filePath,omitempty" yaml:"filePath,omitempty"`
-	CreatedAt   time.Time   `json:"createdAt" yaml:"createdAt"`
-	UpdatedAt   time.Time   `json:"updatedAt" yaml:"updatedAt"`
+	ID          string       `json:"id" yaml:"id"`
+	UUID        string       `json:"uuid" yaml:"uuid"`
+	Version     uint         `json:"version" yaml:"version"`
+	Metadata    GNSMetadata  `json:"metadata" yaml:"metadata"`
+	Spec        GNSSpec      `json:"spec" yaml:"spec"`
+	Description string       `json:"description" yaml:"description"`
+	FilePath    string       `json:"filePath,omitempty" yaml:"filePath,omitempty"`
+	Status      *GNSStatus   `json:"status,omitempty" yaml:"status,omitempty"`
+	Resolved    *GNSResolved `json:"resolved,omitempty" yaml:"resolved,omitempty"`
+	CreatedAt   time.Time    `json:"createdAt" yaml:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt" yaml:"updatedAt"`
 }
 
 type GNSMetadata struct {
-	Name   string            `json:"name" yaml:"name"`
-	Labels map[string]string `json:"labels" yaml:"labels"`
+	Name     string            `json:"name" yaml:"name"`
+	TenantID uint64            `json:"tenantID,omitempty" yaml:"tenantID,omitempty"`
+	Labels   map[string]string `json:"labels" yaml:"labels"`
 }
 
 type GNSSpec struct {
-	Nets []string `json:"nets" yaml:"nets"`
+	Nets                 []string   `json:"nets" yaml:"nets"`
+	Source               *GNSSource `json:"source,omitempty" yaml:"source,omitempty"`
+	AllowedEgressDomains []string   `json:"allowedEgressDomains,omitempty" yaml:"allowedEgressDomains,omitempty"`
+}
+
+type GNSSource struct {
+	URL             string `json:"url,omitempty" yaml:"url,omitempty"`
+	File            string `json:"file,omitempty" yaml:"file,omitempty"`
+	Format          string `json:"format" yaml:"format"`
+	JSONPath        string `json:"jsonPath,omitempty" yaml:"jsonPath,omitempty"`
+	RefreshInterval string `json:"refreshInterval" yaml:"refreshInterval"`
+}
+
+type GNSStatus struct {
+	LastSyncAt       time.Time  `json:"lastSyncAt" yaml:"lastSyncAt"`
+	LastSuccessAt    *time.Time `json:"lastSuccessAt,omitempty" yaml:"lastSuccessAt,omitempty"`
+	LastError        string     `json:"lastError,omitempty" yaml:"lastError,omitempty"`
+	NetsCount        int        `json:"netsCount" yaml:"netsCount"`
+	InvalidNetsCount int        `json:"invalidNetsCount" yaml:"invalidNetsCount"`
+}
+
+type GNSResolved struct {
+	Version    uint                `json:"version" yaml:"version"`
+	ResolvedAt time.Time           `json:"resolvedAt" yaml:"resolvedAt"`
+	Domains    []GNSResolvedDomain `json:"domains" yaml:"domains"`
+}
+
+type GNSResolvedDomain struct {
+	Name      string   `json:"name" yaml:"name"`
+	Addresses []string `json:"addresses" yaml:"addresses"`
+	Error     string   `json:"error,omitempty" yaml:"error,omitempty"`
 }
 
 type CreateGlobalNetworkSetInput struct {
@@ -38,7 +71,17 @@ type GNSMetadataInput struct {
 }
 
 type GNSSpecInput struct {
-	Nets []string `json:"nets" yaml:"nets" validate:"min=1,unique"`
+	Nets                 []string        `json:"nets" yaml:"nets" validate:"omitempty,unique"`
+	Source               *GNSSourceInput `json:"source" yaml:"source" validate:"omitempty"`
+	AllowedEgressDomains []string        `json:"allowedEgressDomains" yaml:"allowedEgressDomains" validate:"omitempty,unique,dive,domain"`
+}
+
+type GNSSourceInput struct {
+	URL             string `json:"url" yaml:"url" validate:"omitempty,url"`
+	File            string `json:"file" yaml:"file"`
+	Format          string `json:"format" yaml:"format" validate:"omitempty,oneof=plain json"`
+	JSONPath        string `json:"jsonPath" yaml:"jsonPath"`
+	RefreshInterval string `json:"refreshInterval" yaml:"refreshInterval" validate:"required,duration"`
 }
 
 type ListGNSsInput struct{}
@@ -49,9 +92,16 @@ type GetGNSInput struct {
 
 type DeleteGlobalNetworkSetInput struct {
 	Metadata GNSMetadataInput `json:"metadata" yaml:"metadata" validate:"required"`
+	// Force deletes the set even if policy selectors lose their last match, it is sent as the force query param
+	Force bool `json:"-" yaml:"-"`
 }
 
 type ValidateGlobalNetworkSetOutput struct {
 	GNS        *GlobalNetworkSet `json:"gns"`
 	GNSExisted *GlobalNetworkSet `json:"gnsExisted"`
+	Warnings   []string          `json:"warnings,omitempty"`
+	// LintFindings are the lint rules broken by the set with the warn severity
+	LintFindings []*LintFinding `json:"lintFindings,omitempty"`
+	// AdmissionVerdicts are the verdicts of the admission hooks on the change
+	AdmissionVerdicts []*AdmissionVerdict `json:"admissionVerdicts,omitempty"`
 }
@@ -14,6 +14,16 @@ type HostEndpoint struct {
 	FilePath    string               `json:"filePath,omitempty" yaml:"filePath,omitempty"`
 	CreatedAt   time.Time            `json:"createdAt" yaml:"createdAt"`
 	UpdatedAt   time.Time            `json:"updatedAt" yaml:"updatedAt"`
+	// Registration is set when the host endpoint is registered by its agent
+	Registration *HostEndpointRegistration `json:"registration,omitempty" yaml:"registration,omitempty"`
+}
+
+type HostEndpointRegistration struct {
+	TokenName     string            `json:"tokenName" yaml:"tokenName"`
+	InterfaceName string            `json:"interfaceName,omitempty" yaml:"interfaceName,omitempty"`
+	IPs           []string          `json:"ips,omitempty" yaml:"ips,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	RegisteredAt  time.Time         `json:"registeredAt" yaml:"registeredAt"`
 }
 
 type HostEndpointMetadata struct {
@@ -47,6 +57,17 @@ type HostEndpointSpecInput struct {
 	IPs           []string `json:"ips" yaml:"ips" validate:"min=1,unique,dive,ip"`
 }
 
+type RegisterHostEndpointInput struct {
+	Token string `json:"token" validate:"required"`
+	// Name defaults to the hostname
+	Name          string            `json:"name" validate:"omitempty,name"`
+	Hostname      string            `json:"hostname" validate:"required_without=Name"`
+	OS            string            `json:"os"`
+	InterfaceName string            `json:"interfaceName"`
+	IPs           []string          `json:"ips" validate:"min=1,unique,dive,ip"`
+	Labels        map[string]string `json:"labels"`
+}
+
 type ListHostEndpointsInput struct {
 	TenantID *uint64 `form:"tenantID" yaml:"tenantID" validate:"omitempty"`
 	IP       *string `form:"ip" yaml:"ip" validate:"omitempty,ip"`
@@ -59,6 +80,8 @@ type GetHostEndpointInput struct {
 
 type DeleteHostEndpointInput struct {
 	Spec HostEndpointSpecInput `json:"spec" yaml:"spec" validate:"required"`
+	// Force deletes the host endpoint even if policy selectors lose their last match, it is sent as the force query param
+	Force bool `json:"-" yaml:"-"`
 }
 
 type FetchHostEndpointPoliciesInput struct {
@@ -67,23 +90,54 @@ type FetchHostEndpointPoliciesInput struct {
 }
 
 type HostEndpointPolicy struct {
-	MetaData   HostEndpointPolicyMetadata `json:"metadata"`
-	HEP        *HostEndpoint              `json:"hostEndpoint"`
-	ParsedGNPs []*ParsedGNP               `json:"parsedGNPs"`
-	ParsedHEPs []*ParsedHEP               `json:"parsedHEPs"`
-	ParsedGNSs []*ParsedGNS               `json:"parsedGNSs"`
+	MetaData    HostEndpointPolicyMetadata `json:"metadata"`
+	HEP         *HostEndpoint              `json:"hostEndpoint"`
+	ParsedTiers []*ParsedTier              `json:"parsedTiers"`
+	ParsedGNPs  []*ParsedGNP               `json:"parsedGNPs"`
+	ParsedHEPs  []*ParsedHEP               `json:"parsedHEPs"`
+	ParsedGNSs  []*ParsedGNS               `json:"parsedGNSs"`
 }
 
 type HostEndpointPolicyMetadata struct {
-	HEPVersions map[string]uint `json:"hepVersions"`
-	GNPVersions map[string]uint `json:"gnpVersions"`
-	GNSVersions map[string]uint `json:"gnsVersions"`
+	Revision    uint64            `json:"revision" yaml:"revision"`
+	HEPVersions map[string]uint   `json:"hepVersions" yaml:"hepVersions"`
+	GNPVersions map[string]uint   `json:"gnpVersions" yaml:"gnpVersions"`
+	GNSVersions map[string]uint   `json:"gnsVersions" yaml:"gnsVersions"`
+	GNPDigests  map[string]string `json:"gnpDigests" yaml:"gnpDigests"`
+}
+
+type FetchHostEndpointPolicyDeltaInput struct {
+	TenantID uint64                     `json:"tenantID" yaml:"tenantID"`
+	IP       string                     `json:"ip" yaml:"ip" validate:"required,ip"`
+	Known    HostEndpointPolicyMetadata `json:"known" yaml:"known"`
+}
+
+type HostEndpointPolicyDelta struct {
+	MetaData    HostEndpointPolicyMetadata `json:"metadata"`
+	HEP         *HostEndpoint              `json:"hostEndpoint"`
+	ParsedTiers []*ParsedTier              `json:"parsedTiers"`
+	GNPUUIDs    []string                   `json:"gnpUUIDs"`
+	ParsedGNPs  []*ParsedGNP               `json:"parsedGNPs"`
+	ParsedHEPs  []*ParsedHEP               `json:"parsedHEPs"`
+	ParsedGNSs  []*ParsedGNS               `json:"parsedGNSs"`
+	RemovedGNPs []string                   `json:"removedGNPs"`
+	RemovedHEPs []string                   `json:"removedHEPs"`
+	RemovedGNSs []string                   `json:"removedGNSs"`
+}
+
+type ParsedTier struct {
+	Name          string `json:"name"`
+	Order         uint32 `json:"order"`
+	DefaultAction string `json:"defaultAction"`
 }
 
 type ParsedGNP struct {
 	UUID          string        `json:"uuid"`
 	Version       uint          `json:"version"`
 	Name          string        `json:"name"`
+	TenantID      uint64        `json:"tenantID,omitempty"`
+	Tier          string        `json:"tier"`
+	IsStaged      bool          `json:"isStaged"`
 	InboundRules  []*ParsedRule `json:"inboundRules"`
 	OutboundRules []*ParsedRule `json:"outboundRules"`
 }
@@ -93,6 +147,10 @@ type ParsedRule struct {
 	IPVersion          *int        `json:"ipVersion"`
 	Protocol           interface{} `json:"protocol"`
 	IsProtocolNegative bool        `json:"isProtocolNegative"`
+	ICMPType           *int        `json:"icmpType"`
+	ICMPCode           *int        `json:"icmpCode"`
+	NotICMPType        *int        `json:"notICMPType"`
+	NotICMPCode        *int        `json:"notICMPCode"`
 	SrcNets            []string    `json:"srcNets"`
 	IsSrcNetNegative   bool        `json:"isSrcNetNegative"`
 	SrcGNSUUIDs        []string    `json:"srcGNSUUIDs"`
@@ -117,14 +175,18 @@ type ParsedHEP struct {
 }
 
 type ParsedGNS struct {
-	UUID   string   `json:"uuid"`
-	Name   string   `json:"name"`
-	NetsV4 []string `json:"netsV4"`
-	NetsV6 []string `json:"netsV6"`
+	UUID     string   `json:"uuid"`
+	Name     string   `json:"name"`
+	TenantID uint64   `json:"tenantID,omitempty"`
+	NetsV4   []string `json:"netsV4"`
+	NetsV6   []string `json:"netsV6"`
+	Domains  []string `json:"domains,omitempty"`
 }
 
 type ValidateHostEndpointOutput struct {
 	HEP        *HostEndpoint `json:"hep"`
 	HEPExisted *HostEndpoint `json:"hepExisted"`
 	ParsedGNPs []*ParsedGNP  `json:"parsedGNPs"`
+	// AdmissionVerdicts are the verdicts of the admission hooks on the change
+	AdmissionVerdicts []*AdmissionVerdict `json:"admissionVerdicts,omitempty"`
 }
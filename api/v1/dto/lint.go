@@ -0,0 +1,20 @@
+package dto
+
+import "fmt"
+
+type LintFinding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	FilePath string `json:"filePath,omitempty"`
+	// Field is the path of the offending field in the resource file, e.g. spec.ingress[0].destination.nets[1]
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (f *LintFinding) String() string {
+	location := f.Field
+	if f.FilePath != "" {
+		location = fmt.Sprintf("%s: %s", f.FilePath, f.Field)
+	}
+	return fmt.Sprintf("%s: %s [%s] %s", location, f.Severity, f.Rule, f.Message)
+}
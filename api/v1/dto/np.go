@@ -0,0 +1,42 @@
+package dto
+
+// A network policy is returned as a GlobalNetworkPolicy whose metadata holds its tenant.
+
+type CreateNetworkPolicyInput struct {
+	Metadata    NPMetadataInput `json:"metadata" yaml:"metadata" validate:"required"`
+	Spec        GNPSpecInput    `json:"spec" yaml:"spec" validate:"required"`
+	Description string          `json:"description" yaml:"description"`
+	FilePath    string          `json:"filePath" yaml:"filePath"`
+}
+
+type NPMetadataInput struct {
+	Name     string            `json:"name" yaml:"name" validate:"required,name"`
+	TenantID uint64            `json:"tenantID" yaml:"tenantID" validate:"omitempty"`
+	Labels   map[string]string `json:"labels" yaml:"labels"`
+}
+
+type ListNetworkPoliciesInput struct {
+	TenantID uint64 `form:"tenantID" validate:"omitempty"`
+}
+
+type GetNetworkPolicyInput struct {
+	TenantID uint64 `uri:"tenantID" validate:"required"`
+	Name     string `uri:"name" validate:"required"`
+}
+
+type DeleteNetworkPolicyInput struct {
+	Metadata NPMetadataInput `json:"metadata" yaml:"metadata" validate:"required"`
+}
+
+type ValidateNetworkPolicyOutput struct {
+	NP         *GlobalNetworkPolicy `json:"np"`
+	NPExisted  *GlobalNetworkPolicy `json:"npExisted"`
+	ParsedHEPs []*ParsedHEP         `json:"parsedHEPs"`
+	// UnmatchedSelectors are the rule selectors matching no host endpoint and no network set in the scope of the
+	// policy
+	UnmatchedSelectors []*SelectorReference `json:"unmatchedSelectors,omitempty"`
+	// LintFindings are the lint rules broken by the policy with the warn severity
+	LintFindings []*LintFinding `json:"lintFindings,omitempty"`
+	// AdmissionVerdicts are the verdicts of the admission hooks on the change
+	AdmissionVerdicts []*AdmissionVerdict `json:"admissionVerdicts,omitempty"`
+}
@@ -0,0 +1,46 @@
+package dto
+
+// A network set is returned as a GlobalNetworkSet whose metadata holds its tenant.
+
+type CreateNetworkSetInput struct {
+	Metadata    NSMetadataInput `json:"metadata" yaml:"metadata" validate:"required"`
+	Spec        NSSpecInput     `json:"spec" yaml:"spec"`
+	Description string          `json:"description" yaml:"description"`
+	FilePath    string          `json:"filePath" yaml:"filePath"`
+}
+
+type NSMetadataInput struct {
+	Name     string            `json:"name" yaml:"name" validate:"required,name"`
+	TenantID uint64            `json:"tenantID" yaml:"tenantID" validate:"omitempty"`
+	Labels   map[string]string `json:"labels" yaml:"labels"`
+}
+
+// NSSpecInput is the spec of a network set, which only holds static nets.
+type NSSpecInput struct {
+	Nets []string `json:"nets" yaml:"nets" validate:"required,min=1,unique"`
+}
+
+type ListNetworkSetsInput struct {
+	TenantID uint64 `form:"tenantID" validate:"omitempty"`
+}
+
+type GetNetworkSetInput struct {
+	TenantID uint64 `uri:"tenantID" validate:"required"`
+	Name     string `uri:"name" validate:"required"`
+}
+
+type DeleteNetworkSetInput struct {
+	Metadata NSMetadataInput `json:"metadata" yaml:"metadata" validate:"required"`
+	// Force deletes the set even if policy selectors lose their last match, it is sent as the force query param
+	Force bool `json:"-" yaml:"-"`
+}
+
+type ValidateNetworkSetOutput struct {
+	NS        *GlobalNetworkSet `json:"ns"`
+	NSExisted *GlobalNetworkSet `json:"nsExisted"`
+	Warnings  []string          `json:"warnings,omitempty"`
+	// LintFindings are the lint rules broken by the set with the warn severity
+	LintFindings []*LintFinding `json:"lintFindings,omitempty"`
+	// AdmissionVerdicts are the verdicts of the admission hooks on the change
+	AdmissionVerdicts []*AdmissionVerdict `json:"admissionVerdicts,omitempty"`
+}
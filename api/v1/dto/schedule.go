@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+type Schedule struct {
+	ActiveFrom  *time.Time       `json:"activeFrom,omitempty" yaml:"activeFrom,omitempty"`
+	ActiveUntil *time.Time       `json:"activeUntil,omitempty" yaml:"activeUntil,omitempty"`
+	Windows     []ScheduleWindow `json:"windows,omitempty" yaml:"windows,omitempty"`
+}
+
+type ScheduleWindow struct {
+	Cron     string `json:"cron" yaml:"cron"`
+	Duration string `json:"duration" yaml:"duration"`
+}
+
+type ScheduleInput struct {
+	ActiveFrom  *time.Time            `json:"activeFrom" yaml:"activeFrom"`
+	ActiveUntil *time.Time            `json:"activeUntil" yaml:"activeUntil"`
+	Windows     []ScheduleWindowInput `json:"windows" yaml:"windows" validate:"omitempty,dive"`
+}
+
+type ScheduleWindowInput struct {
+	// Cron is a five fields cron expression evaluated in UTC, the window opens at each time it matches
+	Cron     string `json:"cron" yaml:"cron" validate:"required,cron"`
+	Duration string `json:"duration" yaml:"duration" validate:"required,duration"`
+}
+
+type ScheduleEvent struct {
+	GNPUUID  string    `json:"gnpUUID"`
+	GNPName  string    `json:"gnpName"`
+	Active   bool      `json:"active"`
+	Revision uint64    `json:"revision"`
+	At       time.Time `json:"at"`
+}
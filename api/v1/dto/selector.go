@@ -0,0 +1,27 @@
+package dto
+
+type ExplainSelectorInput struct {
+	Selector string             `json:"selector" yaml:"selector" validate:"required,selector"`
+	HEP      *HEPReferenceInput `json:"hep" yaml:"hep" validate:"omitempty"`
+	Labels   map[string]string  `json:"labels" yaml:"labels"`
+}
+
+type HEPReferenceInput struct {
+	TenantID uint64 `json:"tenantID" yaml:"tenantID"`
+	IP       string `json:"ip" yaml:"ip" validate:"required,ip"`
+}
+
+type ExplainSelectorOutput struct {
+	Selector string            `json:"selector"`
+	HEP      *ParsedHEP        `json:"hep,omitempty"`
+	Labels   map[string]string `json:"labels"`
+	Matched  bool              `json:"matched"`
+	Trace    *SelectorTrace    `json:"trace"`
+}
+
+type SelectorTrace struct {
+	Expression string           `json:"expression"`
+	Result     bool             `json:"result"`
+	Reason     string           `json:"reason,omitempty"`
+	Children   []*SelectorTrace `json:"children,omitempty"`
+}
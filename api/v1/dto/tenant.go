@@ -0,0 +1,69 @@
+package dto
+
+import "time"
+
+type Tenant struct {
+	ID          string         `json:"id" yaml:"id"`
+	UUID        string         `json:"uuid" yaml:"uuid"`
+	Version     uint           `json:"version" yaml:"version"`
+	Metadata    TenantMetadata `json:"metadata" yaml:"metadata"`
+	Spec        TenantSpec     `json:"spec" yaml:"spec"`
+	Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+	FilePath    string         `json:"filePath,omitempty" yaml:"filePath,omitempty"`
+	CreatedAt   time.Time      `json:"createdAt" yaml:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt" yaml:"updatedAt"`
+}
+
+type TenantMetadata struct {
+	Name   string            `json:"name" yaml:"name"`
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+type TenantSpec struct {
+	TenantID uint64      `json:"tenantID" yaml:"tenantID"`
+	Quota    TenantQuota `json:"quota" yaml:"quota"`
+}
+
+type TenantQuota struct {
+	MaxHostEndpoints   uint32 `json:"maxHostEndpoints" yaml:"maxHostEndpoints"`
+	MaxNetworkPolicies uint32 `json:"maxNetworkPolicies" yaml:"maxNetworkPolicies"`
+}
+
+type CreateTenantInput struct {
+	Metadata    TenantMetadataInput `json:"metadata" yaml:"metadata" validate:"required"`
+	Spec        TenantSpecInput     `json:"spec" yaml:"spec" validate:"required"`
+	Description string              `json:"description" yaml:"description"`
+	FilePath    string              `json:"filePath" yaml:"filePath"`
+}
+
+type TenantMetadataInput struct {
+	Name   string            `json:"name" yaml:"name" validate:"required,name"`
+	Labels map[string]string `json:"labels" yaml:"labels"`
+}
+
+type TenantSpecInput struct {
+	TenantID uint64           `json:"tenantID" yaml:"tenantID" validate:"required"`
+	Quota    TenantQuotaInput `json:"quota" yaml:"quota"`
+}
+
+type TenantQuotaInput struct {
+	MaxHostEndpoints   uint32 `json:"maxHostEndpoints" yaml:"maxHostEndpoints"`
+	MaxNetworkPolicies uint32 `json:"maxNetworkPolicies" yaml:"maxNetworkPolicies"`
+}
+
+type GetTenantInput struct {
+	TenantID uint64 `uri:"tenantID" validate:"required"`
+}
+
+type DeleteTenantInput struct {
+	Spec DeleteTenantSpecInput `json:"spec" yaml:"spec" validate:"required"`
+}
+
+type DeleteTenantSpecInput struct {
+	TenantID uint64 `json:"tenantID" yaml:"tenantID" validate:"required"`
+}
+
+type ValidateTenantOutput struct {
+	Tenant        *Tenant `json:"tenant"`
+	TenantExisted *Tenant `json:"tenantExisted"`
+}
@@ -0,0 +1,55 @@
+package dto
+
+import "time"
+
+type Tier struct {
+	ID          string       `json:"id" yaml:"id"`
+	UUID        string       `json:"uuid" yaml:"uuid"`
+	Version     uint         `json:"version" yaml:"version"`
+	Metadata    TierMetadata `json:"metadata" yaml:"metadata"`
+	Spec        TierSpec     `json:"spec" yaml:"spec"`
+	Description string       `json:"description,omitempty" yaml:"description,omitempty"`
+	FilePath    string       `json:"filePath,omitempty" yaml:"filePath,omitempty"`
+	CreatedAt   time.Time    `json:"createdAt" yaml:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt" yaml:"updatedAt"`
+}
+
+type TierMetadata struct {
+	Name   string            `json:"name" yaml:"name"`
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+type TierSpec struct {
+	Order         uint32 `json:"order" yaml:"order"`
+	DefaultAction string `json:"defaultAction" yaml:"defaultAction"`
+}
+
+type CreateTierInput struct {
+	Metadata    TierMetadataInput `json:"metadata" yaml:"metadata" validate:"required"`
+	Spec        TierSpecInput     `json:"spec" yaml:"spec" validate:"required"`
+	Description string            `json:"description" yaml:"description"`
+	FilePath    string            `json:"filePath" yaml:"filePath"`
+}
+
+type TierMetadataInput struct {
+	Name   string            `json:"name" yaml:"name" validate:"required,name"`
+	Labels map[string]string `json:"labels" yaml:"labels"`
+}
+
+type TierSpecInput struct {
+	Order         *uint32 `json:"order" yaml:"order" validate:"required"`
+	DefaultAction string  `json:"defaultAction" yaml:"defaultAction" validate:"omitempty,tier_action"`
+}
+
+type GetTierInput struct {
+	Name string `uri:"name" validate:"required"`
+}
+
+type DeleteTierInput struct {
+	Metadata TierMetadataInput `json:"metadata" yaml:"metadata" validate:"required"`
+}
+
+type ValidateTierOutput struct {
+	Tier        *Tier `json:"tier"`
+	TierExisted *Tier `json:"tierExisted"`
+}
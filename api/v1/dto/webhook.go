@@ -0,0 +1,94 @@
+package dto
+
+import "time"
+
+type Webhook struct {
+	ID          string          `json:"id" yaml:"id"`
+	UUID        string          `json:"uuid" yaml:"uuid"`
+	Version     uint            `json:"version" yaml:"version"`
+	Metadata    WebhookMetadata `json:"metadata" yaml:"metadata"`
+	Spec        WebhookSpec     `json:"spec" yaml:"spec"`
+	Description string          `json:"description,omitempty" yaml:"description,omitempty"`
+	FilePath    string          `json:"filePath,omitempty" yaml:"filePath,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt" yaml:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt" yaml:"updatedAt"`
+}
+
+type WebhookMetadata struct {
+	Name   string            `json:"name" yaml:"name"`
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+// WebhookSpec leaves out the secret, which is write only.
+type WebhookSpec struct {
+	URL       string   `json:"url" yaml:"url"`
+	HasSecret bool     `json:"hasSecret" yaml:"hasSecret"`
+	Kinds     []string `json:"kinds,omitempty" yaml:"kinds,omitempty"`
+	Actions   []string `json:"actions,omitempty" yaml:"actions,omitempty"`
+	Selector  string   `json:"selector,omitempty" yaml:"selector,omitempty"`
+}
+
+type CreateWebhookInput struct {
+	Metadata    WebhookMetadataInput `json:"metadata" yaml:"metadata" validate:"required"`
+	Spec        WebhookSpecInput     `json:"spec" yaml:"spec" validate:"required"`
+	Description string               `json:"description" yaml:"description"`
+	FilePath    string               `json:"filePath" yaml:"filePath"`
+}
+
+type WebhookMetadataInput struct {
+	Name   string            `json:"name" yaml:"name" validate:"required,name"`
+	Labels map[string]string `json:"labels" yaml:"labels"`
+}
+
+type WebhookSpecInput struct {
+	URL string `json:"url" yaml:"url" validate:"required,http_url"`
+	// Secret signs the deliveries, the existing secret is kept when empty
+	Secret   string   `json:"secret" yaml:"secret"`
+	Kinds    []string `json:"kinds" yaml:"kinds" validate:"omitempty,unique,dive,webhook_kind"`
+	Actions  []string `json:"actions" yaml:"actions" validate:"omitempty,unique,dive,webhook_action"`
+	Selector string   `json:"selector" yaml:"selector" validate:"omitempty,selector"`
+}
+
+type GetWebhookInput struct {
+	Name string `uri:"name" validate:"required"`
+}
+
+type DeleteWebhookInput struct {
+	Metadata WebhookMetadataInput `json:"metadata" yaml:"metadata" validate:"required"`
+}
+
+type ValidateWebhookOutput struct {
+	Webhook        *Webhook `json:"webhook"`
+	WebhookExisted *Webhook `json:"webhookExisted"`
+}
+
+type WebhookDelivery struct {
+	UUID           string       `json:"uuid" yaml:"uuid"`
+	WebhookName    string       `json:"webhookName" yaml:"webhookName"`
+	Event          WebhookEvent `json:"event" yaml:"event"`
+	Status         string       `json:"status" yaml:"status"`
+	Attempts       int          `json:"attempts" yaml:"attempts"`
+	LastStatusCode int          `json:"lastStatusCode,omitempty" yaml:"lastStatusCode,omitempty"`
+	LastError      string       `json:"lastError,omitempty" yaml:"lastError,omitempty"`
+	NextAttemptAt  *time.Time   `json:"nextAttemptAt,omitempty" yaml:"nextAttemptAt,omitempty"`
+	DeliveredAt    *time.Time   `json:"deliveredAt,omitempty" yaml:"deliveredAt,omitempty"`
+	CreatedAt      time.Time    `json:"createdAt" yaml:"createdAt"`
+}
+
+type WebhookEvent struct {
+	ID         string            `json:"id" yaml:"id"`
+	Kind       string            `json:"kind" yaml:"kind"`
+	Action     string            `json:"action" yaml:"action"`
+	Name       string            `json:"name" yaml:"name"`
+	TenantID   uint64            `json:"tenantID,omitempty" yaml:"tenantID,omitempty"`
+	UUID       string            `json:"uuid" yaml:"uuid"`
+	Version    uint              `json:"version" yaml:"version"`
+	Labels     map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	OccurredAt time.Time         `json:"occurredAt" yaml:"occurredAt"`
+}
+
+type ListWebhookDeliveriesInput struct {
+	WebhookName string `form:"webhookName" yaml:"webhookName"`
+	Status      string `form:"status" yaml:"status" validate:"omitempty,oneof=pending succeeded failed"`
+	Limit       int64  `form:"limit" yaml:"limit" validate:"omitempty,min=1,max=1000"`
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/api/v1/mapper"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+type admissionHookService interface {
+	Create(ctx context.Context, input *model.CreateAdmissionHookInput) (*entity.AdmissionHook, *ierror.Error)
+	List(ctx context.Context) ([]*entity.AdmissionHook, *ierror.Error)
+	Get(ctx context.Context, name string) (*entity.AdmissionHook, *ierror.Error)
+	Delete(ctx context.Context, name string) *ierror.Error
+	Validate(ctx context.Context, input *model.CreateAdmissionHookInput) (*model.ValidateAdmissionHookOutput, *ierror.Error)
+}
+
+func NewAdmissionHook(s admissionHookService) *admissionHook {
+	return &admissionHook{
+		service: s,
+	}
+}
+
+type admissionHook struct {
+	service admissionHookService
+}
+
+func (h *admissionHook) Create(c *gin.Context) {
+	in := new(dto.CreateAdmissionHookInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	admissionHookEntity, ierr := h.service.Create(c.Request.Context(), mapper.ToCreateAdmissionHookInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToAdmissionHookDTO(admissionHookEntity))
+}
+
+func (h *admissionHook) List(c *gin.Context) {
+	admissionHooksEntity, ierr := h.service.List(c.Request.Context())
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToListAdmissionHookDTOs(admissionHooksEntity))
+}
+
+func (h *admissionHook) Get(c *gin.Context) {
+	in := new(dto.GetAdmissionHookInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	admissionHookEntity, ierr := h.service.Get(c.Request.Context(), in.Name)
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToAdmissionHookDTO(admissionHookEntity))
+}
+
+func (h *admissionHook) Delete(c *gin.Context) {
+	in := new(dto.DeleteAdmissionHookInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), in.Metadata.Name); err != nil {
+		httpbase.ReturnErrorResponse(c, err)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, nil)
+}
+
+func (h *admissionHook) Validate(c *gin.Context) {
+	in := new(dto.CreateAdmissionHookInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	validateAdmissionHookOutput, ierr := h.service.Validate(c.Request.Context(), mapper.ToCreateAdmissionHookInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToValidateAdmissionHookOutput(validateAdmissionHookOutput))
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/api/v1/mapper"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+type agentService interface {
+	Register(ctx context.Context, input *model.RegisterAgentInput) *ierror.Error
+	Heartbeat(ctx context.Context, input *model.AgentHeartbeatInput) *ierror.Error
+	List(ctx context.Context, input *model.ListAgentsInput) ([]*model.AgentStatus, *ierror.Error)
+}
+
+func NewAgent(s agentService) *agent {
+	return &agent{
+		service: s,
+	}
+}
+
+type agent struct {
+	service agentService
+}
+
+func (h *agent) Register(c *gin.Context) {
+	in := new(dto.RegisterAgentInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	if ierr := h.service.Register(c.Request.Context(), mapper.ToRegisterAgentInput(in)); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, nil)
+}
+
+func (h *agent) Heartbeat(c *gin.Context) {
+	in := new(dto.AgentHeartbeatInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	if ierr := h.service.Heartbeat(c.Request.Context(), mapper.ToAgentHeartbeatInput(in)); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, nil)
+}
+
+func (h *agent) List(c *gin.Context) {
+	in := new(dto.ListAgentsInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	statuses, ierr := h.service.List(c.Request.Context(), mapper.ToListAgentsInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToListAgentDTOs(statuses))
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/api/v1/mapper"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/backup"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+type backupService interface {
+	Backup(ctx context.Context) (*model.Bundle, *ierror.Error)
+	Restore(ctx context.Context, input *model.RestoreInput) (*model.RestoreOutput, *ierror.Error)
+}
+
+func NewBackup(s backupService) *backupHandler {
+	return &backupHandler{
+		service: s,
+	}
+}
+
+type backupHandler struct {
+	service backupService
+}
+
+func (h *backupHandler) Backup(c *gin.Context) {
+	bundle, ierr := h.service.Backup(c.Request.Context())
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToBundleDTO(bundle))
+}
+
+func (h *backupHandler) Restore(c *gin.Context) {
+	in := new(dto.RestoreInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	data, err := backup.Upgrade(in.Bundle)
+	if err != nil {
+		httpbase.ReturnErrorResponse(c, httpbase.ErrBadRequest(c.Request.Context(), "invalid bundle").SetDetail(err.Error()))
+		return
+	}
+	bundle := new(dto.Bundle)
+	if err = json.Unmarshal(data, bundle); err != nil {
+		httpbase.ReturnErrorResponse(c, httpbase.ErrBadRequest(c.Request.Context(), "invalid bundle").SetDetail(err.Error()))
+		return
+	}
+
+	if itemErrors := validateBundle(c.Request.Context(), bundle); len(itemErrors) > 0 {
+		httpbase.ReturnErrorResponse(c, httpbase.ErrValidateRequest(c.Request.Context(), "bundle is invalid").SetDetail(itemErrors))
+		return
+	}
+	restoreInput, err := mapper.ToRestoreInput(in.Mode, bundle)
+	if err != nil {
+		httpbase.ReturnErrorResponse(c, httpbase.ErrBadRequest(c.Request.Context(), "invalid bundle").SetDetail(err.Error()))
+		return
+	}
+
+	restoreOutput, ierr := h.service.Restore(c.Request.Context(), restoreInput)
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToRestoreOutputDTO(restoreOutput))
+}
+
+// validateBundle validates each resource of bundle as the input of the create endpoint of its kind, it returns the
+// errors of the invalid ones.
+func validateBundle(ctx context.Context, bundle *dto.Bundle) []*dto.RestoreItemError {
+	var itemErrors []*dto.RestoreItemError
+	validate := func(kind string, index int, name string, resource interface{}, input interface{}) {
+		if ierr := validateAsInput(ctx, resource, input); ierr != nil {
+			itemErrors = append(itemErrors, &dto.RestoreItemError{
+				Kind:   kind,
+				Index:  index,
+				Name:   name,
+				Error:  ierr.Message,
+				Detail: ierr.Detail,
+			})
+		}
+	}
+	for i, tenant := range bundle.Tenants {
+		validate(entity.WebhookKindTenant, i, tenant.Metadata.Name, tenant, new(dto.CreateTenantInput))
+	}
+	for i, tier := range bundle.Tiers {
+		validate(entity.WebhookKindTier, i, tier.Metadata.Name, tier, new(dto.CreateTierInput))
+	}
+	for i, hep := range bundle.HostEndpoints {
+		validate(entity.WebhookKindHostEndpoint, i, hep.Metadata.Name, hep, new(dto.CreateHostEndpointInput))
+	}
+	for i, gns := range bundle.GlobalNetworkSets {
+		validate(entity.WebhookKindGlobalNetworkSet, i, gns.Metadata.Name, gns, new(dto.CreateGlobalNetworkSetInput))
+	}
+	for i, ns := range bundle.NetworkSets {
+		validate(entity.WebhookKindNetworkSet, i, ns.Metadata.Name, ns, new(dto.CreateNetworkSetInput))
+	}
+	for i, gnp := range bundle.GlobalNetworkPolicies {
+		validate(entity.WebhookKindGlobalNetworkPolicy, i, gnp.Metadata.Name, gnp, new(dto.CreateGlobalNetworkPolicyInput))
+	}
+	for i, np := range bundle.NetworkPolicies {
+		validate(entity.WebhookKindNetworkPolicy, i, np.Metadata.Name, np, new(dto.CreateNetworkPolicyInput))
+	}
+	return itemErrors
+}
+
+// validateAsInput decodes resource into input, the input of the create endpoint of its kind, and validates it.
+func validateAsInput(ctx context.Context, resource interface{}, input interface{}) *ierror.Error {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return httpbase.ErrBindRequest(ctx, "malformed resource").SetDetail(err.Error())
+	}
+	if err = json.Unmarshal(data, input); err != nil {
+		return httpbase.ErrBindRequest(ctx, "malformed resource").SetDetail(err.Error())
+	}
+	return httpbase.ValidateStruct(ctx, input)
+}
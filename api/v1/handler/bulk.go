@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/api/v1/mapper"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+type bulkService interface {
+	Apply(ctx context.Context, input *model.BulkApplyInput) (*model.BulkApplyOutput, *ierror.Error)
+}
+
+func NewBulk(s bulkService) *bulk {
+	return &bulk{
+		service: s,
+	}
+}
+
+type bulk struct {
+	service bulkService
+}
+
+func (h *bulk) Apply(c *gin.Context) {
+	in := new(dto.BulkApplyInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	input := &model.BulkApplyInput{
+		Items: make([]*model.BulkItemInput, 0, len(in.Items)),
+		Force: in.Force,
+	}
+	results := make([]*dto.BulkItemResult, 0, len(in.Items))
+	var invalid bool
+	for i, item := range in.Items {
+		itemInput, ierr := toBulkItemInput(c.Request.Context(), item)
+		result := &dto.BulkItemResult{Index: i, Kind: item.Kind, Operation: item.Operation}
+		if ierr != nil {
+			result.Error = ierr.Message
+			result.Detail = ierr.Detail
+			invalid = true
+		}
+		results = append(results, result)
+		input.Items = append(input.Items, itemInput)
+	}
+	if invalid {
+		httpbase.ReturnErrorResponse(c, httpbase.ErrValidateRequest(c.Request.Context(), "bulk apply is invalid").SetDetail(results))
+		return
+	}
+
+	output, ierr := h.service.Apply(c.Request.Context(), input)
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToBulkApplyOutputDTO(output))
+}
+
+// toBulkItemInput decodes and validates the resource of item as the input of the create or delete endpoint of its
+// kind.
+func toBulkItemInput(ctx context.Context, item *dto.BulkItemInput) (*model.BulkItemInput, *ierror.Error) {
+	var resource interface{}
+	switch item.Operation + "/" + item.Kind {
+	case dto.BulkOperationUpsert + "/" + entity.WebhookKindHostEndpoint:
+		resource = new(dto.CreateHostEndpointInput)
+	case dto.BulkOperationUpsert + "/" + entity.WebhookKindGlobalNetworkSet:
+		resource = new(dto.CreateGlobalNetworkSetInput)
+	case dto.BulkOperationUpsert + "/" + entity.WebhookKindGlobalNetworkPolicy:
+		resource = new(dto.CreateGlobalNetworkPolicyInput)
+	case dto.BulkOperationDelete + "/" + entity.WebhookKindHostEndpoint:
+		resource = new(dto.DeleteHostEndpointInput)
+	case dto.BulkOperationDelete + "/" + entity.WebhookKindGlobalNetworkSet:
+		resource = new(dto.DeleteGlobalNetworkSetInput)
+	case dto.BulkOperationDelete + "/" + entity.WebhookKindGlobalNetworkPolicy:
+		resource = new(dto.DeleteGlobalNetworkPolicyInput)
+	}
+	if err := json.Unmarshal(item.Resource, resource); err != nil {
+		return nil, httpbase.ErrBindRequest(ctx, "malformed resource").SetDetail(err.Error())
+	}
+	if ierr := httpbase.ValidateStruct(ctx, resource); ierr != nil {
+		return nil, ierr
+	}
+
+	itemInput := &model.BulkItemInput{
+		Operation: item.Operation,
+		Kind:      item.Kind,
+	}
+	switch r := resource.(type) {
+	case *dto.CreateHostEndpointInput:
+		itemInput.HostEndpoint = mapper.ToCreateHostEndpointInput(r)
+	case *dto.CreateGlobalNetworkSetInput:
+		itemInput.GlobalNetworkSet = mapper.ToCreateGlobalNetworkSetInput(r)
+	case *dto.CreateGlobalNetworkPolicyInput:
+		itemInput.GlobalNetworkPolicy = mapper.ToCreateGlobalNetworkPolicyInput(r)
+	case *dto.DeleteHostEndpointInput:
+		itemInput.DeleteHostEndpoint = &model.DeleteHostEndpointInput{
+			TenantID: r.Spec.TenantID,
+			IP:       r.Spec.IP,
+			IPs:      r.Spec.IPs,
+		}
+	case *dto.DeleteGlobalNetworkSetInput:
+		itemInput.DeleteName = r.Metadata.Name
+	case *dto.DeleteGlobalNetworkPolicyInput:
+		itemInput.DeleteName = r.Metadata.Name
+	}
+	return itemInput, nil
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/api/v1/mapper"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+type enrollmentTokenService interface {
+	Create(ctx context.Context, input *model.CreateEnrollmentTokenInput) (*entity.EnrollmentToken, *ierror.Error)
+	List(ctx context.Context) ([]*entity.EnrollmentToken, *ierror.Error)
+	Get(ctx context.Context, name string) (*entity.EnrollmentToken, *ierror.Error)
+	Delete(ctx context.Context, name string) *ierror.Error
+	Validate(ctx context.Context, input *model.CreateEnrollmentTokenInput) (*model.ValidateEnrollmentTokenOutput, *ierror.Error)
+}
+
+func NewEnrollmentToken(s enrollmentTokenService) *enrollmentToken {
+	return &enrollmentToken{
+		service: s,
+	}
+}
+
+type enrollmentToken struct {
+	service enrollmentTokenService
+}
+
+func (h *enrollmentToken) Create(c *gin.Context) {
+	in := new(dto.CreateEnrollmentTokenInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	tokenEntity, ierr := h.service.Create(c.Request.Context(), mapper.ToCreateEnrollmentTokenInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToCreatedEnrollmentTokenDTO(tokenEntity))
+}
+
+func (h *enrollmentToken) List(c *gin.Context) {
+	tokensEntity, ierr := h.service.List(c.Request.Context())
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToListEnrollmentTokenDTOs(tokensEntity))
+}
+
+func (h *enrollmentToken) Get(c *gin.Context) {
+	in := new(dto.GetEnrollmentTokenInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	tokenEntity, ierr := h.service.Get(c.Request.Context(), in.Name)
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToEnrollmentTokenDTO(tokenEntity))
+}
+
+func (h *enrollmentToken) Delete(c *gin.Context) {
+	in := new(dto.DeleteEnrollmentTokenInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), in.Metadata.Name); err != nil {
+		httpbase.ReturnErrorResponse(c, err)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, nil)
+}
+
+func (h *enrollmentToken) Validate(c *gin.Context) {
+	in := new(dto.CreateEnrollmentTokenInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	validateEnrollmentTokenOutput, ierr := h.service.Validate(c.Request.Context(), mapper.ToCreateEnrollmentTokenInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToValidateEnrollmentTokenOutput(validateEnrollmentTokenOutput))
+}
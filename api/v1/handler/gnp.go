@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -20,6 +21,10 @@ type gnpService interface {
 	Get(ctx context.Context, name string) (*entity.GlobalNetworkPolicy, *ierror.Error)
 	Delete(ctx context.Context, name string) *ierror.Error
 	Validate(ctx context.Context, input *model.CreateGlobalNetworkPolicyInput) (*model.ValidateGlobalNetworkPolicyOutput, *ierror.Error)
+	Promote(ctx context.Context, name string) (*entity.GlobalNetworkPolicy, *ierror.Error)
+	ReportStaged(ctx context.Context, input *model.ReportStagedPoliciesInput) *ierror.Error
+	GetStagedReport(ctx context.Context, name string) (*model.StagedPolicyReportSummary, *ierror.Error)
+	SubscribeSchedule() (<-chan *model.ScheduleEvent, func())
 }
 
 func NewGNP(s gnpService) *gnp {
@@ -54,7 +59,7 @@ func (h *gnp) List(c *gin.Context) {
 		return
 	}
 
-	gnpsEntity, ierr := h.service.List(c.Request.Context(), &model.ListGNPsInput{IsOrder: in.IsOrder})
+	gnpsEntity, ierr := h.service.List(c.Request.Context(), mapper.ToListGNPsInput(in))
 	if ierr != nil {
 		httpbase.ReturnErrorResponse(c, ierr)
 		return
@@ -106,3 +111,66 @@ func (h *gnp) Validate(c *gin.Context) {
 
 	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToValidateGlobalNetworkPolicyOutput(validateGlobalNetworkPolicy))
 }
+
+func (h *gnp) Promote(c *gin.Context) {
+	in := new(dto.PromoteGlobalNetworkPolicyInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	gnpEntity, ierr := h.service.Promote(c.Request.Context(), in.Metadata.Name)
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToGlobalNetworkPolicyDTO(gnpEntity))
+}
+
+func (h *gnp) ReportStaged(c *gin.Context) {
+	in := new(dto.ReportStagedPoliciesInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	if ierr := h.service.ReportStaged(c.Request.Context(), mapper.ToReportStagedPoliciesInput(in)); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, nil)
+}
+
+func (h *gnp) GetStagedReport(c *gin.Context) {
+	in := new(dto.GetGNPStagedReportInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	summary, ierr := h.service.GetStagedReport(c.Request.Context(), in.Name)
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToStagedPolicyReportSummaryDTO(summary))
+}
+
+// WatchSchedule streams as server-sent events the policies whose schedule opens or closes.
+func (h *gnp) WatchSchedule(c *gin.Context) {
+	events, unsubscribe := h.service.SubscribeSchedule()
+	defer unsubscribe()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent("schedule", mapper.ToScheduleEventDTO(event))
+			return true
+		}
+	})
+}
@@ -18,7 +18,7 @@ type gnsService interface {
 	Create(ctx context.Context, input *model.CreateGlobalNetworkSetInput) (*entity.GlobalNetworkSet, *ierror.Error)
 	List(ctx context.Context) ([]*entity.GlobalNetworkSet, *ierror.Error)
 	Get(ctx context.Context, name string) (*entity.GlobalNetworkSet, *ierror.Error)
-	Delete(ctx context.Context, name string) *ierror.Error
+	Delete(ctx context.Context, name string, force bool) *ierror.Error
 	Validate(ctx context.Context, input *model.CreateGlobalNetworkSetInput) (*model.ValidateGlobalNetworkSetOutput, *ierror.Error)
 }
 
@@ -77,8 +77,13 @@ func (h *gns) Delete(c *gin.Context) {
 		httpbase.ReturnErrorResponse(c, ierr)
 		return
 	}
+	force, ierr := httpbase.BindQueryBool(c, "force")
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
 
-	if err := h.service.Delete(c.Request.Context(), in.Metadata.Name); err != nil {
+	if err := h.service.Delete(c.Request.Context(), in.Metadata.Name, force); err != nil {
 		httpbase.ReturnErrorResponse(c, err)
 		return
 	}
@@ -20,7 +20,9 @@ type hepService interface {
 	Get(ctx context.Context, input *model.GetHostEndpointInput) (*entity.HostEndpoint, *ierror.Error)
 	Delete(ctx context.Context, input *model.DeleteHostEndpointInput) *ierror.Error
 	FetchPolicies(ctx context.Context, input *model.ListHostEndpointsInput) ([]*model.HostEndpointPolicy, *ierror.Error)
+	FetchPolicyDelta(ctx context.Context, input *model.FetchHostEndpointPolicyDeltaInput) (*model.HostEndpointPolicyDelta, *ierror.Error)
 	Validate(ctx context.Context, in *model.CreateHostEndpointInput) (*model.ValidateHostEndpointOutput, *ierror.Error)
+	Register(ctx context.Context, input *model.RegisterHostEndpointInput) (*entity.HostEndpoint, *ierror.Error)
 }
 
 func NewHEP(s hepService) *hep {
@@ -48,6 +50,21 @@ func (h *hep) Create(c *gin.Context) {
 	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToHostEndpointDTO(hepEntity))
 }
 
+func (h *hep) Register(c *gin.Context) {
+	in := new(dto.RegisterHostEndpointInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	hepEntity, ierr := h.service.Register(c.Request.Context(), mapper.ToRegisterHostEndpointInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToHostEndpointDTO(hepEntity))
+}
+
 func (h *hep) List(c *gin.Context) {
 	in := new(dto.ListHostEndpointsInput)
 	if ierr := httpbase.BindInput(c, in); ierr != nil {
@@ -84,11 +101,17 @@ func (h *hep) Delete(c *gin.Context) {
 		httpbase.ReturnErrorResponse(c, ierr)
 		return
 	}
+	force, ierr := httpbase.BindQueryBool(c, "force")
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
 
 	if err := h.service.Delete(c.Request.Context(), &model.DeleteHostEndpointInput{
 		TenantID: in.Spec.TenantID,
 		IP:       in.Spec.IP,
 		IPs:      in.Spec.IPs,
+		Force:    force,
 	}); err != nil {
 		httpbase.ReturnErrorResponse(c, err)
 		return
@@ -110,6 +133,20 @@ func (h *hep) FetchPolicies(c *gin.Context) {
 	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToFetchHEPPoliciesOutput(hostEndpointPolicies))
 }
 
+func (h *hep) FetchPolicyDelta(c *gin.Context) {
+	in := new(dto.FetchHostEndpointPolicyDeltaInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	hostEndpointPolicyDelta, ierr := h.service.FetchPolicyDelta(c.Request.Context(), mapper.ToFetchHostEndpointPolicyDeltaInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToFetchHEPPolicyDeltaOutput(hostEndpointPolicyDelta))
+}
+
 func (h *hep) Validate(c *gin.Context) {
 	in := new(dto.CreateHostEndpointInput)
 	if ierr := httpbase.BindInput(c, in); ierr != nil {
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/api/v1/mapper"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+type npService interface {
+	Create(ctx context.Context, input *model.CreateGlobalNetworkPolicyInput) (*entity.GlobalNetworkPolicy, *ierror.Error)
+	List(ctx context.Context, tenantID uint64) ([]*entity.GlobalNetworkPolicy, *ierror.Error)
+	Get(ctx context.Context, tenantID uint64, name string) (*entity.GlobalNetworkPolicy, *ierror.Error)
+	Delete(ctx context.Context, tenantID uint64, name string) *ierror.Error
+	Validate(ctx context.Context, input *model.CreateGlobalNetworkPolicyInput) (*model.ValidateGlobalNetworkPolicyOutput, *ierror.Error)
+}
+
+func NewNP(s npService) *np {
+	return &np{
+		service: s,
+	}
+}
+
+type np struct {
+	service npService
+}
+
+func (h *np) Create(c *gin.Context) {
+	in := new(dto.CreateNetworkPolicyInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	npEntity, ierr := h.service.Create(c.Request.Context(), mapper.ToCreateNetworkPolicyInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToGlobalNetworkPolicyDTO(npEntity))
+}
+
+func (h *np) List(c *gin.Context) {
+	in := new(dto.ListNetworkPoliciesInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	npsEntity, ierr := h.service.List(c.Request.Context(), in.TenantID)
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToListGlobalNetworkPolicyDTOs(npsEntity))
+}
+
+func (h *np) Get(c *gin.Context) {
+	in := new(dto.GetNetworkPolicyInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	npEntity, ierr := h.service.Get(c.Request.Context(), in.TenantID, in.Name)
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToGlobalNetworkPolicyDTO(npEntity))
+}
+
+func (h *np) Delete(c *gin.Context) {
+	in := new(dto.DeleteNetworkPolicyInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), in.Metadata.TenantID, in.Metadata.Name); err != nil {
+		httpbase.ReturnErrorResponse(c, err)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, nil)
+}
+
+func (h *np) Validate(c *gin.Context) {
+	in := new(dto.CreateNetworkPolicyInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	validateNetworkPolicyOutput, ierr := h.service.Validate(c.Request.Context(), mapper.ToCreateNetworkPolicyInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToValidateNetworkPolicyOutput(validateNetworkPolicyOutput))
+}
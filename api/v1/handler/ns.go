@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/api/v1/mapper"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+type nsService interface {
+	Create(ctx context.Context, input *model.CreateGlobalNetworkSetInput) (*entity.GlobalNetworkSet, *ierror.Error)
+	List(ctx context.Context, tenantID uint64) ([]*entity.GlobalNetworkSet, *ierror.Error)
+	Get(ctx context.Context, tenantID uint64, name string) (*entity.GlobalNetworkSet, *ierror.Error)
+	Delete(ctx context.Context, tenantID uint64, name string, force bool) *ierror.Error
+	Validate(ctx context.Context, input *model.CreateGlobalNetworkSetInput) (*model.ValidateGlobalNetworkSetOutput, *ierror.Error)
+}
+
+func NewNS(s nsService) *ns {
+	return &ns{
+		service: s,
+	}
+}
+
+type ns struct {
+	service nsService
+}
+
+func (h *ns) Create(c *gin.Context) {
+	in := new(dto.CreateNetworkSetInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	nsEntity, ierr := h.service.Create(c.Request.Context(), mapper.ToCreateNetworkSetInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToGlobalNetworkSetDTO(nsEntity))
+}
+
+func (h *ns) List(c *gin.Context) {
+	in := new(dto.ListNetworkSetsInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	nssEntity, ierr := h.service.List(c.Request.Context(), in.TenantID)
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToListGlobalNetworkSetDTOs(nssEntity))
+}
+
+func (h *ns) Get(c *gin.Context) {
+	in := new(dto.GetNetworkSetInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	nsEntity, ierr := h.service.Get(c.Request.Context(), in.TenantID, in.Name)
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToGlobalNetworkSetDTO(nsEntity))
+}
+
+func (h *ns) Delete(c *gin.Context) {
+	in := new(dto.DeleteNetworkSetInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	force, ierr := httpbase.BindQueryBool(c, "force")
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), in.Metadata.TenantID, in.Metadata.Name, force); err != nil {
+		httpbase.ReturnErrorResponse(c, err)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, nil)
+}
+
+func (h *ns) Validate(c *gin.Context) {
+	in := new(dto.CreateNetworkSetInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	validateNetworkSetOutput, ierr := h.service.Validate(c.Request.Context(), mapper.ToCreateNetworkSetInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToValidateNetworkSetOutput(validateNetworkSetOutput))
+}
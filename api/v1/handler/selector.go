@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/api/v1/mapper"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+type selectorService interface {
+	Explain(ctx context.Context, input *model.ExplainSelectorInput) (*model.ExplainSelectorOutput, *ierror.Error)
+}
+
+func NewSelector(s selectorService) *selector {
+	return &selector{
+		service: s,
+	}
+}
+
+type selector struct {
+	service selectorService
+}
+
+func (h *selector) Explain(c *gin.Context) {
+	in := new(dto.ExplainSelectorInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	explainOutput, ierr := h.service.Explain(c.Request.Context(), mapper.ToExplainSelectorInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToExplainSelectorOutput(explainOutput))
+}
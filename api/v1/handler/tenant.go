@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/api/v1/mapper"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+type tenantService interface {
+	Create(ctx context.Context, input *model.CreateTenantInput) (*entity.Tenant, *ierror.Error)
+	List(ctx context.Context) ([]*entity.Tenant, *ierror.Error)
+	Get(ctx context.Context, tenantID uint64) (*entity.Tenant, *ierror.Error)
+	Delete(ctx context.Context, tenantID uint64) *ierror.Error
+	Validate(ctx context.Context, input *model.CreateTenantInput) (*model.ValidateTenantOutput, *ierror.Error)
+}
+
+func NewTenant(s tenantService) *tenant {
+	return &tenant{
+		service: s,
+	}
+}
+
+type tenant struct {
+	service tenantService
+}
+
+func (h *tenant) Create(c *gin.Context) {
+	in := new(dto.CreateTenantInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	tenantEntity, ierr := h.service.Create(c.Request.Context(), mapper.ToCreateTenantInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToTenantDTO(tenantEntity))
+}
+
+func (h *tenant) List(c *gin.Context) {
+	tenantsEntity, ierr := h.service.List(c.Request.Context())
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToListTenantDTOs(tenantsEntity))
+}
+
+func (h *tenant) Get(c *gin.Context) {
+	in := new(dto.GetTenantInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	tenantEntity, ierr := h.service.Get(c.Request.Context(), in.TenantID)
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToTenantDTO(tenantEntity))
+}
+
+func (h *tenant) Delete(c *gin.Context) {
+	in := new(dto.DeleteTenantInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), in.Spec.TenantID); err != nil {
+		httpbase.ReturnErrorResponse(c, err)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, nil)
+}
+
+func (h *tenant) Validate(c *gin.Context) {
+	in := new(dto.CreateTenantInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	validateTenantOutput, ierr := h.service.Validate(c.Request.Context(), mapper.ToCreateTenantInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToValidateTenantOutput(validateTenantOutput))
+}
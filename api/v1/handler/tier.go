@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/api/v1/mapper"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+type tierService interface {
+	Create(ctx context.Context, input *model.CreateTierInput) (*entity.Tier, *ierror.Error)
+	List(ctx context.Context) ([]*entity.Tier, *ierror.Error)
+	Get(ctx context.Context, name string) (*entity.Tier, *ierror.Error)
+	Delete(ctx context.Context, name string) *ierror.Error
+	Validate(ctx context.Context, input *model.CreateTierInput) (*model.ValidateTierOutput, *ierror.Error)
+}
+
+func NewTier(s tierService) *tier {
+	return &tier{
+		service: s,
+	}
+}
+
+type tier struct {
+	service tierService
+}
+
+func (h *tier) Create(c *gin.Context) {
+	in := new(dto.CreateTierInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	tierEntity, ierr := h.service.Create(c.Request.Context(), mapper.ToCreateTierInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToTierDTO(tierEntity))
+}
+
+func (h *tier) List(c *gin.Context) {
+	tiersEntity, ierr := h.service.List(c.Request.Context())
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToListTierDTOs(tiersEntity))
+}
+
+func (h *tier) Get(c *gin.Context) {
+	in := new(dto.GetTierInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	tierEntity, ierr := h.service.Get(c.Request.Context(), in.Name)
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToTierDTO(tierEntity))
+}
+
+func (h *tier) Delete(c *gin.Context) {
+	in := new(dto.DeleteTierInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), in.Metadata.Name); err != nil {
+		httpbase.ReturnErrorResponse(c, err)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, nil)
+}
+
+func (h *tier) Validate(c *gin.Context) {
+	in := new(dto.CreateTierInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	validateTierOutput, ierr := h.service.Validate(c.Request.Context(), mapper.ToCreateTierInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToValidateTierOutput(validateTierOutput))
+}
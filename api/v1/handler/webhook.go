@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/api/v1/mapper"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+type webhookService interface {
+	Create(ctx context.Context, input *model.CreateWebhookInput) (*entity.Webhook, *ierror.Error)
+	List(ctx context.Context) ([]*entity.Webhook, *ierror.Error)
+	Get(ctx context.Context, name string) (*entity.Webhook, *ierror.Error)
+	Delete(ctx context.Context, name string) *ierror.Error
+	Validate(ctx context.Context, input *model.CreateWebhookInput) (*model.ValidateWebhookOutput, *ierror.Error)
+	ListDeliveries(ctx context.Context, input *model.ListWebhookDeliveriesInput) ([]*entity.WebhookDelivery, *ierror.Error)
+}
+
+func NewWebhook(s webhookService) *webhook {
+	return &webhook{
+		service: s,
+	}
+}
+
+type webhook struct {
+	service webhookService
+}
+
+func (h *webhook) Create(c *gin.Context) {
+	in := new(dto.CreateWebhookInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	webhookEntity, ierr := h.service.Create(c.Request.Context(), mapper.ToCreateWebhookInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToWebhookDTO(webhookEntity))
+}
+
+func (h *webhook) List(c *gin.Context) {
+	webhooksEntity, ierr := h.service.List(c.Request.Context())
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToListWebhookDTOs(webhooksEntity))
+}
+
+func (h *webhook) Get(c *gin.Context) {
+	in := new(dto.GetWebhookInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	webhookEntity, ierr := h.service.Get(c.Request.Context(), in.Name)
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToWebhookDTO(webhookEntity))
+}
+
+func (h *webhook) Delete(c *gin.Context) {
+	in := new(dto.DeleteWebhookInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), in.Metadata.Name); err != nil {
+		httpbase.ReturnErrorResponse(c, err)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, nil)
+}
+
+func (h *webhook) Validate(c *gin.Context) {
+	in := new(dto.CreateWebhookInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	validateWebhookOutput, ierr := h.service.Validate(c.Request.Context(), mapper.ToCreateWebhookInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToValidateWebhookOutput(validateWebhookOutput))
+}
+
+func (h *webhook) ListDeliveries(c *gin.Context) {
+	in := new(dto.ListWebhookDeliveriesInput)
+	if ierr := httpbase.BindInput(c, in); ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+
+	deliveriesEntity, ierr := h.service.ListDeliveries(c.Request.Context(), mapper.ToListWebhookDeliveriesInput(in))
+	if ierr != nil {
+		httpbase.ReturnErrorResponse(c, ierr)
+		return
+	}
+	httpbase.ReturnSuccessResponse(c, http.StatusOK, mapper.ToListWebhookDeliveryDTOs(deliveriesEntity))
+}
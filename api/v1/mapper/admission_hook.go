@@ -0,0 +1,83 @@
+package mapper
+
+import (
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+func ToListAdmissionHookDTOs(admissionHooks []*entity.AdmissionHook) []*dto.AdmissionHook {
+	admissionHookDTOs := make([]*dto.AdmissionHook, 0, len(admissionHooks))
+	for _, admissionHook := range admissionHooks {
+		admissionHookDTOs = append(admissionHookDTOs, ToAdmissionHookDTO(admissionHook))
+	}
+	return admissionHookDTOs
+}
+
+func ToAdmissionHookDTO(admissionHook *entity.AdmissionHook) *dto.AdmissionHook {
+	if admissionHook == nil {
+		return nil
+	}
+	return &dto.AdmissionHook{
+		ID:      admissionHook.ID.Hex(),
+		UUID:    admissionHook.UUID,
+		Version: admissionHook.Version,
+		Metadata: dto.AdmissionHookMetadata{
+			Name:   admissionHook.Metadata.Name,
+			Labels: admissionHook.Metadata.Labels,
+		},
+		Spec: dto.AdmissionHookSpec{
+			URL:           admissionHook.Spec.URL,
+			HasSecret:     admissionHook.Spec.Secret != "",
+			Kinds:         admissionHook.Spec.Kinds,
+			Operations:    admissionHook.Spec.Operations,
+			FailurePolicy: admissionHook.Spec.FailurePolicy,
+			Timeout:       admissionHook.Spec.Timeout,
+		},
+		Description: admissionHook.Description,
+		FilePath:    admissionHook.FilePath,
+		CreatedAt:   admissionHook.CreatedAt.Local(),
+		UpdatedAt:   admissionHook.UpdatedAt.Local(),
+	}
+}
+
+func ToAdmissionVerdictDTOs(verdicts []model.AdmissionVerdict) []*dto.AdmissionVerdict {
+	if len(verdicts) == 0 {
+		return nil
+	}
+	verdictDTOs := make([]*dto.AdmissionVerdict, len(verdicts))
+	for i, verdict := range verdicts {
+		verdictDTOs[i] = &dto.AdmissionVerdict{
+			AdmissionHook: verdict.AdmissionHook,
+			Allowed:       verdict.Allowed,
+			Message:       verdict.Message,
+		}
+	}
+	return verdictDTOs
+}
+
+func ToCreateAdmissionHookInput(in *dto.CreateAdmissionHookInput) *model.CreateAdmissionHookInput {
+	return &model.CreateAdmissionHookInput{
+		Metadata: model.AdmissionHookMetadataInput{
+			Name:   in.Metadata.Name,
+			Labels: in.Metadata.Labels,
+		},
+		Spec: model.AdmissionHookSpecInput{
+			URL:           in.Spec.URL,
+			Secret:        in.Spec.Secret,
+			Kinds:         in.Spec.Kinds,
+			Operations:    in.Spec.Operations,
+			FailurePolicy: in.Spec.FailurePolicy,
+			Timeout:       in.Spec.Timeout,
+		},
+		Description: in.Description,
+		FilePath:    in.FilePath,
+	}
+}
+
+func ToValidateAdmissionHookOutput(validateAdmissionHookOutput *model.ValidateAdmissionHookOutput) *dto.ValidateAdmissionHookOutput {
+	return &dto.ValidateAdmissionHookOutput{
+		AdmissionHook:        ToAdmissionHookDTO(validateAdmissionHookOutput.AdmissionHook),
+		AdmissionHookExisted: ToAdmissionHookDTO(validateAdmissionHookOutput.AdmissionHookExisted),
+	}
+}
@@ -0,0 +1,76 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/net"
+)
+
+func ToRegisterAgentInput(in *dto.RegisterAgentInput) *model.RegisterAgentInput {
+	return &model.RegisterAgentInput{
+		TenantID:     in.TenantID,
+		IP:           in.IP,
+		Hostname:     in.Hostname,
+		AgentVersion: in.AgentVersion,
+	}
+}
+
+func ToAgentHeartbeatInput(in *dto.AgentHeartbeatInput) *model.AgentHeartbeatInput {
+	return &model.AgentHeartbeatInput{
+		TenantID: in.TenantID,
+		IP:       in.IP,
+		Applied: model.HostEndpointPolicyMetadata{
+			Revision:    in.Applied.Revision,
+			GNPVersions: in.Applied.GNPVersions,
+			HEPVersions: in.Applied.HEPVersions,
+			GNSVersions: in.Applied.GNSVersions,
+		},
+		ApplyErrors: in.ApplyErrors,
+	}
+}
+
+func ToListAgentsInput(in *dto.ListAgentsInput) *model.ListAgentsInput {
+	// staleAfter is validated as a duration
+	staleAfter, _ := time.ParseDuration(in.StaleAfter)
+	return &model.ListAgentsInput{
+		TenantID:   in.TenantID,
+		StaleAfter: staleAfter,
+	}
+}
+
+func ToListAgentDTOs(statuses []*model.AgentStatus) []*dto.Agent {
+	agentDTOs := make([]*dto.Agent, 0, len(statuses))
+	for _, status := range statuses {
+		agentDTOs = append(agentDTOs, ToAgentDTO(status))
+	}
+	return agentDTOs
+}
+
+func ToAgentDTO(status *model.AgentStatus) *dto.Agent {
+	var lastHeartbeatAt *time.Time
+	if status.Agent.LastHeartbeatAt != nil {
+		t := status.Agent.LastHeartbeatAt.Local()
+		lastHeartbeatAt = &t
+	}
+	return &dto.Agent{
+		TenantID:     status.Agent.TenantID,
+		IP:           net.IntToIP(status.Agent.IP).String(),
+		Hostname:     status.Agent.Hostname,
+		AgentVersion: status.Agent.AgentVersion,
+		Applied: dto.HostEndpointPolicyMetadata{
+			Revision:    status.Agent.Applied.Revision,
+			GNPVersions: status.Agent.Applied.GNPVersions,
+			HEPVersions: status.Agent.Applied.HEPVersions,
+			GNSVersions: status.Agent.Applied.GNSVersions,
+		},
+		ApplyErrors:      status.Agent.ApplyErrors,
+		ExpectedRevision: status.ExpectedRevision,
+		Stale:            status.Stale,
+		OutOfSync:        status.OutOfSync,
+		Failing:          status.Failing,
+		RegisteredAt:     status.Agent.RegisteredAt.Local(),
+		LastHeartbeatAt:  lastHeartbeatAt,
+	}
+}
@@ -0,0 +1,328 @@
+package mapper
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/backup"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/net"
+)
+
+func ToBundleDTO(bundle *model.Bundle) *dto.Bundle {
+	return &dto.Bundle{
+		SchemaVersion:         backup.SchemaVersion,
+		CreatedAt:             bundle.CreatedAt.Local(),
+		Tenants:               ToListTenantDTOs(bundle.Tenants),
+		Tiers:                 ToListTierDTOs(bundle.Tiers),
+		HostEndpoints:         ToListHostEndpointDTOs(bundle.HostEndpoints),
+		GlobalNetworkSets:     ToListGlobalNetworkSetDTOs(bundle.GlobalNetworkSets),
+		NetworkSets:           ToListGlobalNetworkSetDTOs(bundle.NetworkSets),
+		GlobalNetworkPolicies: ToListGlobalNetworkPolicyDTOs(bundle.GlobalNetworkPolicies),
+		NetworkPolicies:       ToListGlobalNetworkPolicyDTOs(bundle.NetworkPolicies),
+	}
+}
+
+// ToRestoreInput maps a bundle upgraded to the current schema version. It fails on a host endpoint without a valid ip.
+func ToRestoreInput(mode string, in *dto.Bundle) (*model.RestoreInput, error) {
+	bundle := &model.Bundle{
+		CreatedAt:             in.CreatedAt,
+		Tenants:               make([]*entity.Tenant, 0, len(in.Tenants)),
+		Tiers:                 make([]*entity.Tier, 0, len(in.Tiers)),
+		HostEndpoints:         make([]*entity.HostEndpoint, 0, len(in.HostEndpoints)),
+		GlobalNetworkSets:     make([]*entity.GlobalNetworkSet, 0, len(in.GlobalNetworkSets)),
+		NetworkSets:           make([]*entity.GlobalNetworkSet, 0, len(in.NetworkSets)),
+		GlobalNetworkPolicies: make([]*entity.GlobalNetworkPolicy, 0, len(in.GlobalNetworkPolicies)),
+		NetworkPolicies:       make([]*entity.GlobalNetworkPolicy, 0, len(in.NetworkPolicies)),
+	}
+	for _, tenant := range in.Tenants {
+		bundle.Tenants = append(bundle.Tenants, toTenantEntity(tenant))
+	}
+	for _, tier := range in.Tiers {
+		bundle.Tiers = append(bundle.Tiers, toTierEntity(tier))
+	}
+	for i, hep := range in.HostEndpoints {
+		hepEntity, err := toHostEndpointEntity(hep)
+		if err != nil {
+			return nil, fmt.Errorf("host endpoint %d: %w", i, err)
+		}
+		bundle.HostEndpoints = append(bundle.HostEndpoints, hepEntity)
+	}
+	for _, gns := range in.GlobalNetworkSets {
+		bundle.GlobalNetworkSets = append(bundle.GlobalNetworkSets, toGlobalNetworkSetEntity(gns))
+	}
+	for _, ns := range in.NetworkSets {
+		bundle.NetworkSets = append(bundle.NetworkSets, toGlobalNetworkSetEntity(ns))
+	}
+	for _, gnp := range in.GlobalNetworkPolicies {
+		bundle.GlobalNetworkPolicies = append(bundle.GlobalNetworkPolicies, toGlobalNetworkPolicyEntity(gnp))
+	}
+	for _, np := range in.NetworkPolicies {
+		bundle.NetworkPolicies = append(bundle.NetworkPolicies, toGlobalNetworkPolicyEntity(np))
+	}
+	return &model.RestoreInput{
+		Mode:   mode,
+		Bundle: bundle,
+	}, nil
+}
+
+func ToRestoreOutputDTO(output *model.RestoreOutput) *dto.RestoreOutput {
+	results := make([]*dto.RestoreResult, 0, len(output.Results))
+	for _, result := range output.Results {
+		results = append(results, &dto.RestoreResult{
+			Kind:        result.Kind,
+			Created:     result.Created,
+			Overwritten: result.Overwritten,
+			Skipped:     result.Skipped,
+		})
+	}
+	return &dto.RestoreOutput{Results: results}
+}
+
+// toObjectID returns the object id of hex, a new one if hex is not valid.
+func toObjectID(hex string) primitive.ObjectID {
+	id, err := primitive.ObjectIDFromHex(hex)
+	if err != nil {
+		return primitive.NewObjectID()
+	}
+	return id
+}
+
+func toTenantEntity(in *dto.Tenant) *entity.Tenant {
+	return &entity.Tenant{
+		ID:      toObjectID(in.ID),
+		UUID:    in.UUID,
+		Version: in.Version,
+		Metadata: entity.TenantMetadata{
+			Name:   in.Metadata.Name,
+			Labels: in.Metadata.Labels,
+		},
+		Spec: entity.TenantSpec{
+			TenantID: in.Spec.TenantID,
+			Quota: entity.TenantQuota{
+				MaxHostEndpoints:   in.Spec.Quota.MaxHostEndpoints,
+				MaxNetworkPolicies: in.Spec.Quota.MaxNetworkPolicies,
+			},
+		},
+		Description: in.Description,
+		FilePath:    in.FilePath,
+		CreatedAt:   in.CreatedAt,
+		UpdatedAt:   in.UpdatedAt,
+	}
+}
+
+func toTierEntity(in *dto.Tier) *entity.Tier {
+	return &entity.Tier{
+		ID:      toObjectID(in.ID),
+		UUID:    in.UUID,
+		Version: in.Version,
+		Metadata: entity.TierMetadata{
+			Name:   in.Metadata.Name,
+			Labels: in.Metadata.Labels,
+		},
+		Spec: entity.TierSpec{
+			Order:         in.Spec.Order,
+			DefaultAction: in.Spec.DefaultAction,
+		},
+		Description: in.Description,
+		FilePath:    in.FilePath,
+		CreatedAt:   in.CreatedAt,
+		UpdatedAt:   in.UpdatedAt,
+	}
+}
+
+// toHostEndpointEntity maps a host endpoint whose ip defaults to its first ip version 4, as when it is created.
+func toHostEndpointEntity(in *dto.HostEndpoint) (*entity.HostEndpoint, error) {
+	ipString := in.Spec.IP
+	if ipString == "" {
+		for _, s := range in.Spec.IPs {
+			if parsedIP := net.ParseIP(s); parsedIP != nil && parsedIP.Version() == entity.IPVersion4 {
+				ipString = s
+				break
+			}
+		}
+	}
+	ip := net.ParseIP(ipString)
+	if ip == nil {
+		return nil, fmt.Errorf("malformed ip %q", ipString)
+	}
+	var registration *entity.HostEndpointRegistration
+	if in.Registration != nil {
+		registration = &entity.HostEndpointRegistration{
+			TokenName:     in.Registration.TokenName,
+			InterfaceName: in.Registration.InterfaceName,
+			IPs:           in.Registration.IPs,
+			Labels:        in.Registration.Labels,
+			RegisteredAt:  in.Registration.RegisteredAt,
+		}
+	}
+	return &entity.HostEndpoint{
+		ID:      toObjectID(in.ID),
+		UUID:    in.UUID,
+		Version: in.Version,
+		Metadata: entity.HostEndpointMetadata{
+			Name:   in.Metadata.Name,
+			Labels: in.Metadata.Labels,
+		},
+		Spec: entity.HostEndpointSpec{
+			InterfaceName: in.Spec.InterfaceName,
+			IP:            net.IPToInt(*ip),
+			TenantID:      in.Spec.TenantID,
+			IPs:           in.Spec.IPs,
+		},
+		Description:  in.Description,
+		FilePath:     in.FilePath,
+		CreatedAt:    in.CreatedAt,
+		UpdatedAt:    in.UpdatedAt,
+		Registration: registration,
+	}, nil
+}
+
+func toGlobalNetworkSetEntity(in *dto.GlobalNetworkSet) *entity.GlobalNetworkSet {
+	var source *entity.GNSSource
+	if in.Spec.Source != nil {
+		source = &entity.GNSSource{
+			URL:             in.Spec.Source.URL,
+			File:            in.Spec.Source.File,
+			Format:          in.Spec.Source.Format,
+			JSONPath:        in.Spec.Source.JSONPath,
+			RefreshInterval: in.Spec.Source.RefreshInterval,
+		}
+	}
+	var status *entity.GNSStatus
+	if in.Status != nil {
+		status = &entity.GNSStatus{
+			LastSyncAt:       in.Status.LastSyncAt,
+			LastSuccessAt:    in.Status.LastSuccessAt,
+			LastError:        in.Status.LastError,
+			NetsCount:        in.Status.NetsCount,
+			InvalidNetsCount: in.Status.InvalidNetsCount,
+		}
+	}
+	var resolved *entity.GNSResolved
+	if in.Resolved != nil {
+		resolved = &entity.GNSResolved{
+			Version:    in.Resolved.Version,
+			ResolvedAt: in.Resolved.ResolvedAt,
+		}
+		for _, domain := range in.Resolved.Domains {
+			resolved.Domains = append(resolved.Domains, entity.GNSResolvedDomain{
+				Name:      domain.Name,
+				Addresses: domain.Addresses,
+				Error:     domain.Error,
+			})
+		}
+	}
+	return &entity.GlobalNetworkSet{
+		ID:      toObjectID(in.ID),
+		UUID:    in.UUID,
+		Version: in.Version,
+		Metadata: entity.GNSMetadata{
+			Name:     in.Metadata.Name,
+			TenantID: in.Metadata.TenantID,
+			Labels:   in.Metadata.Labels,
+		},
+		Spec: entity.GNSSpec{
+			Nets:                 in.Spec.Nets,
+			Source:               source,
+			AllowedEgressDomains: in.Spec.AllowedEgressDomains,
+		},
+		Description: in.Description,
+		FilePath:    in.FilePath,
+		Status:      status,
+		Resolved:    resolved,
+		CreatedAt:   in.CreatedAt,
+		UpdatedAt:   in.UpdatedAt,
+	}
+}
+
+func toGlobalNetworkPolicyEntity(in *dto.GlobalNetworkPolicy) *entity.GlobalNetworkPolicy {
+	var specIngress []entity.GNPSpecRule
+	for _, rule := range in.Spec.Ingress {
+		specIngress = append(specIngress, toRuleEntity(rule))
+	}
+
+	var specEgress []entity.GNPSpecRule
+	for _, rule := range in.Spec.Egress {
+		specEgress = append(specEgress, toRuleEntity(rule))
+	}
+	return &entity.GlobalNetworkPolicy{
+		ID:      toObjectID(in.ID),
+		UUID:    in.UUID,
+		Version: in.Version,
+		Metadata: entity.GNPMetadata{
+			Name:     in.Metadata.Name,
+			TenantID: in.Metadata.TenantID,
+			Labels:   in.Metadata.Labels,
+		},
+		Spec: entity.GNPSpec{
+			Tier:     in.Spec.Tier,
+			Order:    in.Spec.Order,
+			Staged:   in.Spec.Staged,
+			Schedule: toScheduleEntity(in.Spec.Schedule),
+			Selector: in.Spec.Selector,
+			Ingress:  specIngress,
+			Egress:   specEgress,
+		},
+		Description: in.Description,
+		FilePath:    in.FilePath,
+		CreatedAt:   in.CreatedAt,
+		UpdatedAt:   in.UpdatedAt,
+	}
+}
+
+func toRuleEntity(rule dto.GNPSpecRule) entity.GNPSpecRule {
+	return entity.GNPSpecRule{
+		Metadata:    rule.Metadata,
+		Action:      rule.Action,
+		IPVersion:   rule.IPVersion,
+		Protocol:    rule.Protocol,
+		NotProtocol: rule.NotProtocol,
+		ICMP:        toRuleICMPEntity(rule.ICMP),
+		NotICMP:     toRuleICMPEntity(rule.NotICMP),
+		Schedule:    toScheduleEntity(rule.Schedule),
+		Source:      toRuleEntityEntity(rule.Source),
+		Destination: toRuleEntityEntity(rule.Destination),
+	}
+}
+
+func toRuleICMPEntity(icmp *dto.GNPSpecRuleICMP) *entity.GNPSpecRuleICMP {
+	if icmp == nil {
+		return nil
+	}
+	return &entity.GNPSpecRuleICMP{
+		Type: icmp.Type,
+		Code: icmp.Code,
+	}
+}
+
+func toRuleEntityEntity(ruleEntity *dto.GNPSpecRuleEntity) *entity.GNPSpecRuleEntity {
+	if ruleEntity == nil {
+		return nil
+	}
+	return &entity.GNPSpecRuleEntity{
+		Selector: ruleEntity.Selector,
+		Nets:     ruleEntity.Nets,
+		NotNets:  ruleEntity.NotNets,
+		Ports:    ruleEntity.Ports,
+		NotPorts: ruleEntity.NotPorts,
+	}
+}
+
+func toScheduleEntity(s dto.Schedule) entity.Schedule {
+	var windows []entity.ScheduleWindow
+	for _, w := range s.Windows {
+		windows = append(windows, entity.ScheduleWindow{
+			Cron:     w.Cron,
+			Duration: w.Duration,
+		})
+	}
+	return entity.Schedule{
+		ActiveFrom:  s.ActiveFrom,
+		ActiveUntil: s.ActiveUntil,
+		Windows:     windows,
+	}
+}
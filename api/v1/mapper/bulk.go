@@ -0,0 +1,24 @@
+package mapper
+
+import (
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/domain/model"
+)
+
+func ToBulkApplyOutputDTO(output *model.BulkApplyOutput) *dto.BulkApplyOutput {
+	results := make([]*dto.BulkItemResult, 0, len(output.Items))
+	for _, result := range output.Items {
+		results = append(results, &dto.BulkItemResult{
+			Index:     result.Index,
+			Kind:      result.Kind,
+			Operation: result.Operation,
+			Name:      result.Name,
+			UUID:      result.UUID,
+			Version:   result.Version,
+			Action:    result.Action,
+			Error:     result.Error,
+			Detail:    result.Detail,
+		})
+	}
+	return &dto.BulkApplyOutput{Items: results}
+}
@@ -0,0 +1,75 @@
+package mapper
+
+import (
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+func ToListEnrollmentTokenDTOs(tokens []*entity.EnrollmentToken) []*dto.EnrollmentToken {
+	tokenDTOs := make([]*dto.EnrollmentToken, 0, len(tokens))
+	for _, token := range tokens {
+		tokenDTOs = append(tokenDTOs, ToEnrollmentTokenDTO(token))
+	}
+	return tokenDTOs
+}
+
+func ToEnrollmentTokenDTO(token *entity.EnrollmentToken) *dto.EnrollmentToken {
+	if token == nil {
+		return nil
+	}
+	return &dto.EnrollmentToken{
+		ID:      token.ID.Hex(),
+		UUID:    token.UUID,
+		Version: token.Version,
+		Metadata: dto.EnrollmentTokenMetadata{
+			Name:   token.Metadata.Name,
+			Labels: token.Metadata.Labels,
+		},
+		Spec: dto.EnrollmentTokenSpec{
+			TenantID:           token.Spec.TenantID,
+			HasToken:           token.Spec.Token != "",
+			AllowedCIDRs:       token.Spec.AllowedCIDRs,
+			LabelTemplates:     token.Spec.LabelTemplates,
+			AllowedAgentLabels: token.Spec.AllowedAgentLabels,
+		},
+		Description: token.Description,
+		FilePath:    token.FilePath,
+		CreatedAt:   token.CreatedAt.Local(),
+		UpdatedAt:   token.UpdatedAt.Local(),
+	}
+}
+
+// ToCreatedEnrollmentTokenDTO is ToEnrollmentTokenDTO with the token, it is only returned once when the enrollment token
+// is created.
+func ToCreatedEnrollmentTokenDTO(token *entity.EnrollmentToken) *dto.EnrollmentToken {
+	tokenDTO := ToEnrollmentTokenDTO(token)
+	if tokenDTO != nil {
+		tokenDTO.Spec.Token = token.Spec.Token
+	}
+	return tokenDTO
+}
+
+func ToCreateEnrollmentTokenInput(in *dto.CreateEnrollmentTokenInput) *model.CreateEnrollmentTokenInput {
+	return &model.CreateEnrollmentTokenInput{
+		Metadata: model.EnrollmentTokenMetadataInput{
+			Name:   in.Metadata.Name,
+			Labels: in.Metadata.Labels,
+		},
+		Spec: model.EnrollmentTokenSpecInput{
+			TenantID:           in.Spec.TenantID,
+			AllowedCIDRs:       in.Spec.AllowedCIDRs,
+			LabelTemplates:     in.Spec.LabelTemplates,
+			AllowedAgentLabels: in.Spec.AllowedAgentLabels,
+		},
+		Description: in.Description,
+		FilePath:    in.FilePath,
+	}
+}
+
+func ToValidateEnrollmentTokenOutput(validateEnrollmentTokenOutput *model.ValidateEnrollmentTokenOutput) *dto.ValidateEnrollmentTokenOutput {
+	return &dto.ValidateEnrollmentTokenOutput{
+		EnrollmentToken:        ToEnrollmentTokenDTO(validateEnrollmentTokenOutput.EnrollmentToken),
+		EnrollmentTokenExisted: ToEnrollmentTokenDTO(validateEnrollmentTokenOutput.EnrollmentTokenExisted),
+	}
+}
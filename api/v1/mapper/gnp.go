@@ -1,9 +1,12 @@
 package mapper
 
 import (
+	"time"
+
 	"github.com/bamboo-firewall/be/api/v1/dto"
 	"github.com/bamboo-firewall/be/domain/model"
 	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/net"
 )
 
 func ToListGlobalNetworkPolicyDTOs(gnps []*entity.GlobalNetworkPolicy) []*dto.GlobalNetworkPolicy {
@@ -33,11 +36,15 @@ func ToGlobalNetworkPolicyDTO(gnp *entity.GlobalNetworkPolicy) *dto.GlobalNetwor
 		UUID:    gnp.UUID,
 		Version: gnp.Version,
 		Metadata: dto.GNPMetadata{
-			Name:   gnp.Metadata.Name,
-			Labels: gnp.Metadata.Labels,
+			Name:     gnp.Metadata.Name,
+			TenantID: gnp.Metadata.TenantID,
+			Labels:   gnp.Metadata.Labels,
 		},
 		Spec: dto.GNPSpec{
+			Tier:     gnp.Spec.Tier,
 			Order:    gnp.Spec.Order,
+			Staged:   gnp.Spec.Staged,
+			Schedule: toScheduleDTO(gnp.Spec.Schedule),
 			Selector: gnp.Spec.Selector,
 			Ingress:  specIngress,
 			Egress:   specEgress,
@@ -56,11 +63,24 @@ func toRuleDTO(rule entity.GNPSpecRule) dto.GNPSpecRule {
 		Protocol:    rule.Protocol,
 		NotProtocol: rule.NotProtocol,
 		IPVersion:   rule.IPVersion,
+		ICMP:        toRuleICMPDTO(rule.ICMP),
+		NotICMP:     toRuleICMPDTO(rule.NotICMP),
+		Schedule:    toScheduleDTO(rule.Schedule),
 		Source:      toRuleEntityDTO(rule.Source),
 		Destination: toRuleEntityDTO(rule.Destination),
 	}
 }
 
+func toRuleICMPDTO(icmp *entity.GNPSpecRuleICMP) *dto.GNPSpecRuleICMP {
+	if icmp == nil {
+		return nil
+	}
+	return &dto.GNPSpecRuleICMP{
+		Type: icmp.Type,
+		Code: icmp.Code,
+	}
+}
+
 func toRuleEntityDTO(ruleEntity *entity.GNPSpecRuleEntity) *dto.GNPSpecRuleEntity {
 	if ruleEntity == nil {
 		return nil
@@ -91,10 +111,13 @@ func ToCreateGlobalNetworkPolicyInput(in *dto.CreateGlobalNetworkPolicyInput) *m
 			Labels: in.Metadata.Labels,
 		},
 		Spec: model.GNPSpecInput{
-			Order:    in.Spec.Order,
-			Selector: in.Spec.Selector,
-			Ingress:  specIngress,
-			Egress:   specEgress,
+			Tier:          in.Spec.Tier,
+			Order:         in.Spec.Order,
+			Staged:        in.Spec.Staged,
+			ScheduleInput: toScheduleInput(in.Spec.ScheduleInput),
+			Selector:      in.Spec.Selector,
+			Ingress:       specIngress,
+			Egress:        specEgress,
 		},
 		Description: in.Description,
 		FilePath:    in.FilePath,
@@ -103,13 +126,26 @@ func ToCreateGlobalNetworkPolicyInput(in *dto.CreateGlobalNetworkPolicyInput) *m
 
 func toRuleInput(rule dto.GNPSpecRuleInput) model.GNPSpecRuleInput {
 	return model.GNPSpecRuleInput{
-		Metadata:    rule.Metadata,
-		Action:      rule.Action,
-		Protocol:    rule.Protocol,
-		NotProtocol: rule.NotProtocol,
-		IPVersion:   rule.IPVersion,
-		Source:      toRuleEntityInput(rule.Source),
-		Destination: toRuleEntityInput(rule.Destination),
+		Metadata:      rule.Metadata,
+		Action:        rule.Action,
+		Protocol:      rule.Protocol,
+		NotProtocol:   rule.NotProtocol,
+		IPVersion:     rule.IPVersion,
+		ICMP:          toRuleICMPInput(rule.ICMP),
+		NotICMP:       toRuleICMPInput(rule.NotICMP),
+		ScheduleInput: toScheduleInput(rule.ScheduleInput),
+		Source:        toRuleEntityInput(rule.Source),
+		Destination:   toRuleEntityInput(rule.Destination),
+	}
+}
+
+func toRuleICMPInput(icmp *dto.GNPSpecRuleICMPInput) *model.GNPSpecRuleICMPInput {
+	if icmp == nil {
+		return nil
+	}
+	return &model.GNPSpecRuleICMPInput{
+		Type: icmp.Type,
+		Code: icmp.Code,
 	}
 }
 
@@ -136,8 +172,124 @@ func ToValidateGlobalNetworkPolicyOutput(validateGlobalNetworkPolicyOutput *mode
 	}
 
 	return &dto.ValidateGlobalNetworkPolicyOutput{
-		GNP:        ToGlobalNetworkPolicyDTO(validateGlobalNetworkPolicyOutput.GNP),
-		GNPExisted: ToGlobalNetworkPolicyDTO(validateGlobalNetworkPolicyOutput.GNPExisted),
-		ParsedHEPs: parsedHEPDTOs,
+		GNP:                       ToGlobalNetworkPolicyDTO(validateGlobalNetworkPolicyOutput.GNP),
+		GNPExisted:                ToGlobalNetworkPolicyDTO(validateGlobalNetworkPolicyOutput.GNPExisted),
+		ParsedHEPs:                parsedHEPDTOs,
+		UnmatchedSelectors:        ToSelectorReferenceDTOs(validateGlobalNetworkPolicyOutput.UnmatchedSelectors),
+		SelectorChange:            validateGlobalNetworkPolicyOutput.SelectorChange,
+		RelatedSelectors:          toSelectorRelationDTOs(validateGlobalNetworkPolicyOutput.RelatedSelectors),
+		RelatedSelectorsTruncated: validateGlobalNetworkPolicyOutput.RelatedSelectorsTruncated,
+		LintFindings:              ToLintFindingDTOs(validateGlobalNetworkPolicyOutput.LintFindings),
+		AdmissionVerdicts:         ToAdmissionVerdictDTOs(validateGlobalNetworkPolicyOutput.AdmissionVerdicts),
+	}
+}
+
+func toSelectorRelationDTOs(relations []*model.SelectorRelation) []*dto.SelectorRelation {
+	relationDTOs := make([]*dto.SelectorRelation, 0, len(relations))
+	for _, relation := range relations {
+		relationDTOs = append(relationDTOs, &dto.SelectorRelation{
+			GNPName:  relation.GNPName,
+			Selector: relation.Selector,
+			Relation: relation.Relation,
+		})
+	}
+	return relationDTOs
+}
+
+func ToSelectorReferenceDTOs(refs []*model.SelectorReference) []*dto.SelectorReference {
+	refDTOs := make([]*dto.SelectorReference, 0, len(refs))
+	for _, ref := range refs {
+		refDTOs = append(refDTOs, &dto.SelectorReference{
+			GNPName:  ref.GNPName,
+			Field:    ref.Field,
+			Selector: ref.Selector,
+		})
+	}
+	return refDTOs
+}
+
+func ToReportStagedPoliciesInput(in *dto.ReportStagedPoliciesInput) *model.ReportStagedPoliciesInput {
+	reports := make([]model.StagedPolicyReportInput, 0, len(in.Reports))
+	for _, report := range in.Reports {
+		reports = append(reports, model.StagedPolicyReportInput{
+			GNPUUID:       report.GNPUUID,
+			DeniedPackets: report.DeniedPackets,
+			DeniedBytes:   report.DeniedBytes,
+		})
+	}
+	return &model.ReportStagedPoliciesInput{
+		TenantID: in.TenantID,
+		IP:       in.IP,
+		Reports:  reports,
+	}
+}
+
+func ToStagedPolicyReportSummaryDTO(summary *model.StagedPolicyReportSummary) *dto.StagedPolicyReportSummary {
+	reportDTOs := make([]*dto.StagedPolicyReport, 0, len(summary.Reports))
+	for _, report := range summary.Reports {
+		reportDTOs = append(reportDTOs, &dto.StagedPolicyReport{
+			TenantID:       report.TenantID,
+			IP:             net.IntToIP(report.IP).String(),
+			DeniedPackets:  report.DeniedPackets,
+			DeniedBytes:    report.DeniedBytes,
+			LastReportedAt: report.LastReportedAt.Local(),
+		})
+	}
+	return &dto.StagedPolicyReportSummary{
+		GNPUUID:       summary.GNP.UUID,
+		GNPName:       summary.GNP.Metadata.Name,
+		Staged:        summary.GNP.Spec.Staged,
+		DeniedPackets: summary.DeniedPackets,
+		DeniedBytes:   summary.DeniedBytes,
+		Reports:       reportDTOs,
+	}
+}
+
+func toScheduleDTO(s entity.Schedule) dto.Schedule {
+	var windows []dto.ScheduleWindow
+	for _, w := range s.Windows {
+		windows = append(windows, dto.ScheduleWindow{
+			Cron:     w.Cron,
+			Duration: w.Duration,
+		})
+	}
+	return dto.Schedule{
+		ActiveFrom:  s.ActiveFrom,
+		ActiveUntil: s.ActiveUntil,
+		Windows:     windows,
+	}
+}
+
+func toScheduleInput(in dto.ScheduleInput) model.ScheduleInput {
+	var windows []model.ScheduleWindowInput
+	for _, w := range in.Windows {
+		windows = append(windows, model.ScheduleWindowInput{
+			Cron:     w.Cron,
+			Duration: w.Duration,
+		})
+	}
+	return model.ScheduleInput{
+		ActiveFrom:  in.ActiveFrom,
+		ActiveUntil: in.ActiveUntil,
+		Windows:     windows,
+	}
+}
+
+func ToListGNPsInput(in *dto.ListGNPsInput) *model.ListGNPsInput {
+	// expiringWithin is validated as a duration
+	expiringWithin, _ := time.ParseDuration(in.ExpiringWithin)
+	return &model.ListGNPsInput{
+		IsOrder:        in.IsOrder,
+		ExpiringWithin: expiringWithin,
+	}
+}
+
+func ToScheduleEventDTO(event *model.ScheduleEvent) *dto.ScheduleEvent {
+	return &dto.ScheduleEvent{
+		GNPUUID:  event.GNPUUID,
+		GNPName:  event.GNPName,
+		Active:   event.Active,
+		Revision: event.Revision,
+		At:       event.At.Local(),
 	}
 }
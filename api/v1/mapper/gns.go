@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"time"
+
 	"github.com/bamboo-firewall/be/api/v1/dto"
 	"github.com/bamboo-firewall/be/domain/model"
 	"github.com/bamboo-firewall/be/pkg/entity"
@@ -23,19 +25,74 @@ func ToGlobalNetworkSetDTO(gns *entity.GlobalNetworkSet) *dto.GlobalNetworkSet {
 		UUID:    gns.UUID,
 		Version: gns.Version,
 		Metadata: dto.GNSMetadata{
-			Name:   gns.Metadata.Name,
-			Labels: gns.Metadata.Labels,
+			Name:     gns.Metadata.Name,
+			TenantID: gns.Metadata.TenantID,
+			Labels:   gns.Metadata.Labels,
 		},
 		Spec: dto.GNSSpec{
-			Nets: gns.Spec.Nets,
+			Nets:                 gns.Spec.Nets,
+			Source:               toGNSSourceDTO(gns.Spec.Source),
+			AllowedEgressDomains: gns.Spec.AllowedEgressDomains,
 		},
 		Description: gns.Description,
 		FilePath:    gns.FilePath,
+		Status:      toGNSStatusDTO(gns.Status),
+		Resolved:    toGNSResolvedDTO(gns.Resolved),
 		CreatedAt:   gns.CreatedAt.Local(),
 		UpdatedAt:   gns.UpdatedAt.Local(),
 	}
 }
 
+func toGNSSourceDTO(source *entity.GNSSource) *dto.GNSSource {
+	if source == nil {
+		return nil
+	}
+	return &dto.GNSSource{
+		URL:             source.URL,
+		File:            source.File,
+		Format:          source.Format,
+		JSONPath:        source.JSONPath,
+		RefreshInterval: source.RefreshInterval,
+	}
+}
+
+func toGNSStatusDTO(status *entity.GNSStatus) *dto.GNSStatus {
+	if status == nil {
+		return nil
+	}
+	var lastSuccessAt *time.Time
+	if status.LastSuccessAt != nil {
+		t := status.LastSuccessAt.Local()
+		lastSuccessAt = &t
+	}
+	return &dto.GNSStatus{
+		LastSyncAt:       status.LastSyncAt.Local(),
+		LastSuccessAt:    lastSuccessAt,
+		LastError:        status.LastError,
+		NetsCount:        status.NetsCount,
+		InvalidNetsCount: status.InvalidNetsCount,
+	}
+}
+
+func toGNSResolvedDTO(resolved *entity.GNSResolved) *dto.GNSResolved {
+	if resolved == nil {
+		return nil
+	}
+	domains := make([]dto.GNSResolvedDomain, 0, len(resolved.Domains))
+	for _, domain := range resolved.Domains {
+		domains = append(domains, dto.GNSResolvedDomain{
+			Name:      domain.Name,
+			Addresses: domain.Addresses,
+			Error:     domain.Error,
+		})
+	}
+	return &dto.GNSResolved{
+		Version:    resolved.Version,
+		ResolvedAt: resolved.ResolvedAt.Local(),
+		Domains:    domains,
+	}
+}
+
 func ToCreateGlobalNetworkSetInput(in *dto.CreateGlobalNetworkSetInput) *model.CreateGlobalNetworkSetInput {
 	return &model.CreateGlobalNetworkSetInput{
 		Metadata: model.GNSMetadataInput{
@@ -43,16 +100,34 @@ func ToCreateGlobalNetworkSetInput(in *dto.CreateGlobalNetworkSetInput) *model.C
 			Labels: in.Metadata.Labels,
 		},
 		Spec: model.GNSSpecInput{
-			Nets: in.Spec.Nets,
+			Nets:                 in.Spec.Nets,
+			Source:               toGNSSourceInput(in.Spec.Source),
+			AllowedEgressDomains: in.Spec.AllowedEgressDomains,
 		},
 		Description: in.Description,
 		FilePath:    in.FilePath,
 	}
 }
 
+func toGNSSourceInput(in *dto.GNSSourceInput) *model.GNSSourceInput {
+	if in == nil {
+		return nil
+	}
+	return &model.GNSSourceInput{
+		URL:             in.URL,
+		File:            in.File,
+		Format:          in.Format,
+		JSONPath:        in.JSONPath,
+		RefreshInterval: in.RefreshInterval,
+	}
+}
+
 func ToValidateGlobalNetworkSetOutput(validateGlobalNetworkSetOutput *model.ValidateGlobalNetworkSetOutput) *dto.ValidateGlobalNetworkSetOutput {
 	return &dto.ValidateGlobalNetworkSetOutput{
-		GNS:        ToGlobalNetworkSetDTO(validateGlobalNetworkSetOutput.GNS),
-		GNSExisted: ToGlobalNetworkSetDTO(validateGlobalNetworkSetOutput.GNSExisted),
+		GNS:               ToGlobalNetworkSetDTO(validateGlobalNetworkSetOutput.GNS),
+		GNSExisted:        ToGlobalNetworkSetDTO(validateGlobalNetworkSetOutput.GNSExisted),
+		Warnings:          validateGlobalNetworkSetOutput.Warnings,
+		LintFindings:      ToLintFindingDTOs(validateGlobalNetworkSetOutput.LintFindings),
+		AdmissionVerdicts: ToAdmissionVerdictDTOs(validateGlobalNetworkSetOutput.AdmissionVerdicts),
 	}
 }
@@ -33,10 +33,36 @@ func ToHostEndpointDTO(hep *entity.HostEndpoint) *dto.HostEndpoint {
 			IP:            net.IntToIP(hep.Spec.IP).String(),
 			IPs:           hep.Spec.IPs,
 		},
-		Description: hep.Description,
-		FilePath:    hep.FilePath,
-		CreatedAt:   hep.CreatedAt.Local(),
-		UpdatedAt:   hep.UpdatedAt.Local(),
+		Description:  hep.Description,
+		FilePath:     hep.FilePath,
+		CreatedAt:    hep.CreatedAt.Local(),
+		UpdatedAt:    hep.UpdatedAt.Local(),
+		Registration: toHostEndpointRegistrationDTO(hep.Registration),
+	}
+}
+
+func toHostEndpointRegistrationDTO(registration *entity.HostEndpointRegistration) *dto.HostEndpointRegistration {
+	if registration == nil {
+		return nil
+	}
+	return &dto.HostEndpointRegistration{
+		TokenName:     registration.TokenName,
+		InterfaceName: registration.InterfaceName,
+		IPs:           registration.IPs,
+		Labels:        registration.Labels,
+		RegisteredAt:  registration.RegisteredAt.Local(),
+	}
+}
+
+func ToRegisterHostEndpointInput(in *dto.RegisterHostEndpointInput) *model.RegisterHostEndpointInput {
+	return &model.RegisterHostEndpointInput{
+		Token:         in.Token,
+		Name:          in.Name,
+		Hostname:      in.Hostname,
+		OS:            in.OS,
+		InterfaceName: in.InterfaceName,
+		IPs:           in.IPs,
+		Labels:        in.Labels,
 	}
 }
 
@@ -108,6 +134,14 @@ func ToFetchHEPPoliciesOutput(hepPolicies []*model.HostEndpointPolicy) []*dto.Ho
 }
 
 func ToFetchHEPPolicyOutput(hostEndpointPolicy *model.HostEndpointPolicy) *dto.HostEndpointPolicy {
+	parsedTierDTOs := make([]*dto.ParsedTier, len(hostEndpointPolicy.ParsedTiers))
+	for i, tier := range hostEndpointPolicy.ParsedTiers {
+		parsedTierDTOs[i] = &dto.ParsedTier{
+			Name:          tier.Name,
+			Order:         tier.Order,
+			DefaultAction: tier.DefaultAction,
+		}
+	}
 	parsedGNPDTOs := make([]*dto.ParsedGNP, len(hostEndpointPolicy.ParsedGNPs))
 	for i, policy := range hostEndpointPolicy.ParsedGNPs {
 		parsedGNPDTOs[i] = toParsedGNPDTO(policy)
@@ -121,15 +155,75 @@ func ToFetchHEPPolicyOutput(hostEndpointPolicy *model.HostEndpointPolicy) *dto.H
 		parsedGNSDTOs[i] = toParsedGNSDTO(set)
 	}
 	return &dto.HostEndpointPolicy{
-		MetaData: dto.HostEndpointPolicyMetadata{
-			HEPVersions: hostEndpointPolicy.MetaData.HEPVersions,
-			GNPVersions: hostEndpointPolicy.MetaData.GNPVersions,
-			GNSVersions: hostEndpointPolicy.MetaData.GNSVersions,
+		MetaData:    toHostEndpointPolicyMetadataDTO(hostEndpointPolicy.MetaData),
+		HEP:         ToHostEndpointDTO(hostEndpointPolicy.HEP),
+		ParsedTiers: parsedTierDTOs,
+		ParsedGNPs:  parsedGNPDTOs,
+		ParsedHEPs:  parsedHEPDTOs,
+		ParsedGNSs:  parsedGNSDTOs,
+	}
+}
+
+func toHostEndpointPolicyMetadataDTO(metadata model.HostEndpointPolicyMetadata) dto.HostEndpointPolicyMetadata {
+	return dto.HostEndpointPolicyMetadata{
+		Revision:    metadata.Revision,
+		HEPVersions: metadata.HEPVersions,
+		GNPVersions: metadata.GNPVersions,
+		GNSVersions: metadata.GNSVersions,
+		GNPDigests:  metadata.GNPDigests,
+	}
+}
+
+func ToFetchHostEndpointPolicyDeltaInput(in *dto.FetchHostEndpointPolicyDeltaInput) *model.FetchHostEndpointPolicyDeltaInput {
+	var ipInt uint32
+	if netIP := net.ParseIP(in.IP); netIP != nil {
+		ipInt = net.IPToInt(*netIP)
+	}
+	return &model.FetchHostEndpointPolicyDeltaInput{
+		TenantID: in.TenantID,
+		IP:       ipInt,
+		Known: model.HostEndpointPolicyMetadata{
+			Revision:    in.Known.Revision,
+			HEPVersions: in.Known.HEPVersions,
+			GNPVersions: in.Known.GNPVersions,
+			GNSVersions: in.Known.GNSVersions,
+			GNPDigests:  in.Known.GNPDigests,
 		},
-		HEP:        ToHostEndpointDTO(hostEndpointPolicy.HEP),
-		ParsedGNPs: parsedGNPDTOs,
-		ParsedHEPs: parsedHEPDTOs,
-		ParsedGNSs: parsedGNSDTOs,
+	}
+}
+
+func ToFetchHEPPolicyDeltaOutput(delta *model.HostEndpointPolicyDelta) *dto.HostEndpointPolicyDelta {
+	parsedTierDTOs := make([]*dto.ParsedTier, len(delta.ParsedTiers))
+	for i, tier := range delta.ParsedTiers {
+		parsedTierDTOs[i] = &dto.ParsedTier{
+			Name:          tier.Name,
+			Order:         tier.Order,
+			DefaultAction: tier.DefaultAction,
+		}
+	}
+	parsedGNPDTOs := make([]*dto.ParsedGNP, len(delta.ParsedGNPs))
+	for i, policy := range delta.ParsedGNPs {
+		parsedGNPDTOs[i] = toParsedGNPDTO(policy)
+	}
+	parsedHEPDTOs := make([]*dto.ParsedHEP, len(delta.ParsedHEPs))
+	for i, endpoint := range delta.ParsedHEPs {
+		parsedHEPDTOs[i] = toParsedHEPDTO(endpoint)
+	}
+	parsedGNSDTOs := make([]*dto.ParsedGNS, len(delta.ParsedGNSs))
+	for i, set := range delta.ParsedGNSs {
+		parsedGNSDTOs[i] = toParsedGNSDTO(set)
+	}
+	return &dto.HostEndpointPolicyDelta{
+		MetaData:    toHostEndpointPolicyMetadataDTO(delta.MetaData),
+		HEP:         ToHostEndpointDTO(delta.HEP),
+		ParsedTiers: parsedTierDTOs,
+		GNPUUIDs:    delta.GNPUUIDs,
+		ParsedGNPs:  parsedGNPDTOs,
+		ParsedHEPs:  parsedHEPDTOs,
+		ParsedGNSs:  parsedGNSDTOs,
+		RemovedGNPs: delta.RemovedGNPs,
+		RemovedHEPs: delta.RemovedHEPs,
+		RemovedGNSs: delta.RemovedGNSs,
 	}
 }
 
@@ -146,6 +240,9 @@ func toParsedGNPDTO(parsedGNP *model.ParsedGNP) *dto.ParsedGNP {
 		UUID:          parsedGNP.UUID,
 		Version:       parsedGNP.Version,
 		Name:          parsedGNP.Name,
+		TenantID:      parsedGNP.TenantID,
+		Tier:          parsedGNP.Tier,
+		IsStaged:      parsedGNP.IsStaged,
 		InboundRules:  inboundRules,
 		OutboundRules: outboundRules,
 	}
@@ -157,6 +254,10 @@ func toParsedRuleDTO(parsedRule *model.ParsedRule) *dto.ParsedRule {
 		IPVersion:          parsedRule.IPVersion,
 		Protocol:           parsedRule.Protocol,
 		IsProtocolNegative: parsedRule.IsProtocolNegative,
+		ICMPType:           parsedRule.ICMPType,
+		ICMPCode:           parsedRule.ICMPCode,
+		NotICMPType:        parsedRule.NotICMPType,
+		NotICMPCode:        parsedRule.NotICMPCode,
 		SrcNets:            parsedRule.SrcNets,
 		IsSrcNetNegative:   parsedRule.IsSrcNetNegative,
 		SrcGNSUUIDs:        parsedRule.SrcGNSUUIDs,
@@ -183,10 +284,12 @@ func toParsedHEPDTO(parsedHEP *model.ParsedHEP) *dto.ParsedHEP {
 
 func toParsedGNSDTO(parsedGNS *model.ParsedGNS) *dto.ParsedGNS {
 	return &dto.ParsedGNS{
-		UUID:   parsedGNS.UUID,
-		Name:   parsedGNS.Name,
-		NetsV4: parsedGNS.NetsV4,
-		NetsV6: parsedGNS.NetsV6,
+		UUID:     parsedGNS.UUID,
+		Name:     parsedGNS.Name,
+		TenantID: parsedGNS.TenantID,
+		NetsV4:   parsedGNS.NetsV4,
+		NetsV6:   parsedGNS.NetsV6,
+		Domains:  parsedGNS.Domains,
 	}
 }
 
@@ -196,8 +299,9 @@ func ToValidateHEPOutput(validateHEPOutput *model.ValidateHostEndpointOutput) *d
 		parsedGNPDTOs[i] = toParsedGNPDTO(policy)
 	}
 	return &dto.ValidateHostEndpointOutput{
-		HEP:        ToHostEndpointDTO(validateHEPOutput.HEP),
-		HEPExisted: ToHostEndpointDTO(validateHEPOutput.HEPExisted),
-		ParsedGNPs: parsedGNPDTOs,
+		HEP:               ToHostEndpointDTO(validateHEPOutput.HEP),
+		HEPExisted:        ToHostEndpointDTO(validateHEPOutput.HEPExisted),
+		ParsedGNPs:        parsedGNPDTOs,
+		AdmissionVerdicts: ToAdmissionVerdictDTOs(validateHEPOutput.AdmissionVerdicts),
 	}
 }
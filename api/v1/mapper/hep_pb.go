@@ -0,0 +1,203 @@
+package mapper
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bamboo-firewall/be/api/v1/pb"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/net"
+)
+
+func FromPBHostEndpointPolicyMetadata(metadata *pb.HostEndpointPolicyMetadata) model.HostEndpointPolicyMetadata {
+	if metadata == nil {
+		return model.HostEndpointPolicyMetadata{}
+	}
+	return model.HostEndpointPolicyMetadata{
+		Revision:    metadata.GetRevision(),
+		HEPVersions: fromPBVersions(metadata.GetHepVersions()),
+		GNPVersions: fromPBVersions(metadata.GetGnpVersions()),
+		GNSVersions: fromPBVersions(metadata.GetGnsVersions()),
+		GNPDigests:  metadata.GetGnpDigests(),
+	}
+}
+
+func ToPBHostEndpointPolicy(hostEndpointPolicy *model.HostEndpointPolicy) *pb.HostEndpointPolicy {
+	return &pb.HostEndpointPolicy{
+		Metadata:     toPBHostEndpointPolicyMetadata(hostEndpointPolicy.MetaData),
+		HostEndpoint: toPBHostEndpoint(hostEndpointPolicy.HEP),
+		ParsedTiers:  toPBParsedTiers(hostEndpointPolicy.ParsedTiers),
+		ParsedGnps:   toPBParsedGNPs(hostEndpointPolicy.ParsedGNPs),
+		ParsedHeps:   toPBParsedHEPs(hostEndpointPolicy.ParsedHEPs),
+		ParsedGnss:   toPBParsedGNSs(hostEndpointPolicy.ParsedGNSs),
+	}
+}
+
+func ToPBHostEndpointPolicyDelta(delta *model.HostEndpointPolicyDelta) *pb.HostEndpointPolicyDelta {
+	return &pb.HostEndpointPolicyDelta{
+		Metadata:     toPBHostEndpointPolicyMetadata(delta.MetaData),
+		HostEndpoint: toPBHostEndpoint(delta.HEP),
+		ParsedTiers:  toPBParsedTiers(delta.ParsedTiers),
+		GnpUuids:     delta.GNPUUIDs,
+		ParsedGnps:   toPBParsedGNPs(delta.ParsedGNPs),
+		ParsedHeps:   toPBParsedHEPs(delta.ParsedHEPs),
+		ParsedGnss:   toPBParsedGNSs(delta.ParsedGNSs),
+		RemovedGnps:  delta.RemovedGNPs,
+		RemovedHeps:  delta.RemovedHEPs,
+		RemovedGnss:  delta.RemovedGNSs,
+	}
+}
+
+func toPBHostEndpointPolicyMetadata(metadata model.HostEndpointPolicyMetadata) *pb.HostEndpointPolicyMetadata {
+	return &pb.HostEndpointPolicyMetadata{
+		Revision:    metadata.Revision,
+		HepVersions: toPBVersions(metadata.HEPVersions),
+		GnpVersions: toPBVersions(metadata.GNPVersions),
+		GnsVersions: toPBVersions(metadata.GNSVersions),
+		GnpDigests:  metadata.GNPDigests,
+	}
+}
+
+func toPBVersions(versions map[string]uint) map[string]uint64 {
+	result := make(map[string]uint64, len(versions))
+	for uuid, version := range versions {
+		result[uuid] = uint64(version)
+	}
+	return result
+}
+
+func fromPBVersions(versions map[string]uint64) map[string]uint {
+	result := make(map[string]uint, len(versions))
+	for uuid, version := range versions {
+		result[uuid] = uint(version)
+	}
+	return result
+}
+
+func toPBHostEndpoint(hep *entity.HostEndpoint) *pb.HostEndpoint {
+	if hep == nil {
+		return nil
+	}
+	return &pb.HostEndpoint{
+		Id:            hep.ID.Hex(),
+		Uuid:          hep.UUID,
+		Version:       uint64(hep.Version),
+		Name:          hep.Metadata.Name,
+		Labels:        hep.Metadata.Labels,
+		InterfaceName: hep.Spec.InterfaceName,
+		TenantId:      hep.Spec.TenantID,
+		Ip:            net.IntToIP(hep.Spec.IP).String(),
+		Ips:           hep.Spec.IPs,
+		Description:   hep.Description,
+		CreatedAt:     timestamppb.New(hep.CreatedAt),
+		UpdatedAt:     timestamppb.New(hep.UpdatedAt),
+	}
+}
+
+func toPBParsedTiers(tiers []*model.ParsedTier) []*pb.ParsedTier {
+	result := make([]*pb.ParsedTier, len(tiers))
+	for i, tier := range tiers {
+		result[i] = &pb.ParsedTier{
+			Name:          tier.Name,
+			Order:         tier.Order,
+			DefaultAction: tier.DefaultAction,
+		}
+	}
+	return result
+}
+
+func toPBParsedGNPs(policies []*model.ParsedGNP) []*pb.ParsedGNP {
+	result := make([]*pb.ParsedGNP, len(policies))
+	for i, policy := range policies {
+		result[i] = &pb.ParsedGNP{
+			Uuid:          policy.UUID,
+			Version:       uint64(policy.Version),
+			Name:          policy.Name,
+			TenantId:      policy.TenantID,
+			Tier:          policy.Tier,
+			IsStaged:      policy.IsStaged,
+			InboundRules:  toPBParsedRules(policy.InboundRules),
+			OutboundRules: toPBParsedRules(policy.OutboundRules),
+		}
+	}
+	return result
+}
+
+func toPBParsedRules(rules []*model.ParsedRule) []*pb.ParsedRule {
+	result := make([]*pb.ParsedRule, len(rules))
+	for i, rule := range rules {
+		pbRule := &pb.ParsedRule{
+			Action:             rule.Action,
+			IpVersion:          toPBInt32(rule.IPVersion),
+			IsProtocolNegative: rule.IsProtocolNegative,
+			IcmpType:           toPBInt32(rule.ICMPType),
+			IcmpCode:           toPBInt32(rule.ICMPCode),
+			NotIcmpType:        toPBInt32(rule.NotICMPType),
+			NotIcmpCode:        toPBInt32(rule.NotICMPCode),
+			SrcNets:            rule.SrcNets,
+			IsSrcNetNegative:   rule.IsSrcNetNegative,
+			SrcGnsUuids:        rule.SrcGNSUUIDs,
+			SrcHepUuids:        rule.SrcHEPUUIDs,
+			SrcPorts:           rule.SrcPorts,
+			IsSrcPortNegative:  rule.IsSrcPortNegative,
+			DstNets:            rule.DstNets,
+			IsDstNetNegative:   rule.IsDstNetNegative,
+			DstGnsUuids:        rule.DstGNSUUIDs,
+			DstHepUuids:        rule.DstHEPUUIDs,
+			DstPorts:           rule.DstPorts,
+			IsDstPortNegative:  rule.IsDstPortNegative,
+		}
+		switch protocol := rule.Protocol.(type) {
+		case string:
+			pbRule.Protocol = &pb.ParsedRule_ProtocolName{ProtocolName: protocol}
+		case int:
+			pbRule.Protocol = &pb.ParsedRule_ProtocolNumber{ProtocolNumber: int32(protocol)}
+		case int32:
+			pbRule.Protocol = &pb.ParsedRule_ProtocolNumber{ProtocolNumber: protocol}
+		case int64:
+			pbRule.Protocol = &pb.ParsedRule_ProtocolNumber{ProtocolNumber: int32(protocol)}
+		case float64:
+			pbRule.Protocol = &pb.ParsedRule_ProtocolNumber{ProtocolNumber: int32(protocol)}
+		}
+		result[i] = pbRule
+	}
+	return result
+}
+
+func toPBInt32(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	i := int32(*v)
+	return &i
+}
+
+func toPBParsedHEPs(endpoints []*model.ParsedHEP) []*pb.ParsedHEP {
+	result := make([]*pb.ParsedHEP, len(endpoints))
+	for i, endpoint := range endpoints {
+		result[i] = &pb.ParsedHEP{
+			Uuid:     endpoint.UUID,
+			Name:     endpoint.Name,
+			TenantId: endpoint.TenantID,
+			Ip:       endpoint.IP,
+			IpsV4:    endpoint.IPsV4,
+			IpsV6:    endpoint.IPsV6,
+		}
+	}
+	return result
+}
+
+func toPBParsedGNSs(sets []*model.ParsedGNS) []*pb.ParsedGNS {
+	result := make([]*pb.ParsedGNS, len(sets))
+	for i, set := range sets {
+		result[i] = &pb.ParsedGNS{
+			Uuid:     set.UUID,
+			Name:     set.Name,
+			TenantId: set.TenantID,
+			NetsV4:   set.NetsV4,
+			NetsV6:   set.NetsV6,
+			Domains:  set.Domains,
+		}
+	}
+	return result
+}
@@ -0,0 +1,120 @@
+package mapper
+
+import (
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/validator"
+)
+
+func ToLintFindingDTOs(findings []*validator.LintFinding) []*dto.LintFinding {
+	if len(findings) == 0 {
+		return nil
+	}
+	findingDTOs := make([]*dto.LintFinding, len(findings))
+	for i, finding := range findings {
+		findingDTOs[i] = &dto.LintFinding{
+			Rule:     finding.Rule,
+			Severity: string(finding.Severity),
+			FilePath: finding.FilePath,
+			Field:    finding.Field,
+			Message:  finding.Message,
+		}
+	}
+	return findingDTOs
+}
+
+// ToLintPolicyEntity returns the policy of input with the fields checked by the linter, for linting files offline.
+func ToLintPolicyEntity(in *model.CreateGlobalNetworkPolicyInput) *entity.GlobalNetworkPolicy {
+	return &entity.GlobalNetworkPolicy{
+		Metadata: entity.GNPMetadata{
+			Name:     in.Metadata.Name,
+			TenantID: in.Metadata.TenantID,
+			Labels:   in.Metadata.Labels,
+		},
+		Spec: entity.GNPSpec{
+			Tier:     in.Spec.Tier,
+			Staged:   in.Spec.Staged,
+			Schedule: toLintSchedule(in.Spec.ScheduleInput),
+			Selector: in.Spec.Selector,
+			Ingress:  toLintRules(in.Spec.Ingress),
+			Egress:   toLintRules(in.Spec.Egress),
+		},
+		Description: in.Description,
+		FilePath:    in.FilePath,
+	}
+}
+
+func toLintRules(rules []model.GNPSpecRuleInput) []entity.GNPSpecRule {
+	var ruleEntities []entity.GNPSpecRule
+	for _, rule := range rules {
+		ruleEntities = append(ruleEntities, entity.GNPSpecRule{
+			Metadata:    rule.Metadata,
+			Action:      rule.Action,
+			IPVersion:   rule.IPVersion,
+			Protocol:    rule.Protocol,
+			NotProtocol: rule.NotProtocol,
+			ICMP:        toLintRuleICMP(rule.ICMP),
+			NotICMP:     toLintRuleICMP(rule.NotICMP),
+			Schedule:    toLintSchedule(rule.ScheduleInput),
+			Source:      toLintRuleEntity(rule.Source),
+			Destination: toLintRuleEntity(rule.Destination),
+		})
+	}
+	return ruleEntities
+}
+
+func toLintRuleICMP(icmp *model.GNPSpecRuleICMPInput) *entity.GNPSpecRuleICMP {
+	if icmp == nil {
+		return nil
+	}
+	return &entity.GNPSpecRuleICMP{
+		Type: icmp.Type,
+		Code: icmp.Code,
+	}
+}
+
+func toLintRuleEntity(ruleEntity *model.GNPSpecRuleEntityInput) *entity.GNPSpecRuleEntity {
+	if ruleEntity == nil {
+		return nil
+	}
+	return &entity.GNPSpecRuleEntity{
+		Selector: ruleEntity.Selector,
+		Nets:     ruleEntity.Nets,
+		NotNets:  ruleEntity.NotNets,
+		Ports:    ruleEntity.Ports,
+		NotPorts: ruleEntity.NotPorts,
+	}
+}
+
+func toLintSchedule(in model.ScheduleInput) entity.Schedule {
+	var windows []entity.ScheduleWindow
+	for _, w := range in.Windows {
+		windows = append(windows, entity.ScheduleWindow{
+			Cron:     w.Cron,
+			Duration: w.Duration,
+		})
+	}
+	return entity.Schedule{
+		ActiveFrom:  in.ActiveFrom,
+		ActiveUntil: in.ActiveUntil,
+		Windows:     windows,
+	}
+}
+
+// ToLintSetEntity returns the set of input with the fields checked by the linter, for linting files offline.
+func ToLintSetEntity(in *model.CreateGlobalNetworkSetInput) *entity.GlobalNetworkSet {
+	return &entity.GlobalNetworkSet{
+		Metadata: entity.GNSMetadata{
+			Name:     in.Metadata.Name,
+			TenantID: in.Metadata.TenantID,
+			Labels:   in.Metadata.Labels,
+		},
+		Spec: entity.GNSSpec{
+			Nets:                 in.Spec.Nets,
+			AllowedEgressDomains: in.Spec.AllowedEgressDomains,
+		},
+		Description: in.Description,
+		FilePath:    in.FilePath,
+	}
+}
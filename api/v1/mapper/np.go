@@ -0,0 +1,32 @@
+package mapper
+
+import (
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/domain/model"
+)
+
+func ToCreateNetworkPolicyInput(in *dto.CreateNetworkPolicyInput) *model.CreateGlobalNetworkPolicyInput {
+	input := ToCreateGlobalNetworkPolicyInput(&dto.CreateGlobalNetworkPolicyInput{
+		Spec:        in.Spec,
+		Description: in.Description,
+		FilePath:    in.FilePath,
+	})
+	input.Metadata = model.GNPMetadataInput{
+		Name:     in.Metadata.Name,
+		TenantID: in.Metadata.TenantID,
+		Labels:   in.Metadata.Labels,
+	}
+	return input
+}
+
+func ToValidateNetworkPolicyOutput(validateNetworkPolicyOutput *model.ValidateGlobalNetworkPolicyOutput) *dto.ValidateNetworkPolicyOutput {
+	output := ToValidateGlobalNetworkPolicyOutput(validateNetworkPolicyOutput)
+	return &dto.ValidateNetworkPolicyOutput{
+		NP:                 output.GNP,
+		NPExisted:          output.GNPExisted,
+		ParsedHEPs:         output.ParsedHEPs,
+		UnmatchedSelectors: output.UnmatchedSelectors,
+		LintFindings:       output.LintFindings,
+		AdmissionVerdicts:  output.AdmissionVerdicts,
+	}
+}
@@ -0,0 +1,31 @@
+package mapper
+
+import (
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/domain/model"
+)
+
+func ToCreateNetworkSetInput(in *dto.CreateNetworkSetInput) *model.CreateGlobalNetworkSetInput {
+	return &model.CreateGlobalNetworkSetInput{
+		Metadata: model.GNSMetadataInput{
+			Name:     in.Metadata.Name,
+			TenantID: in.Metadata.TenantID,
+			Labels:   in.Metadata.Labels,
+		},
+		Spec: model.GNSSpecInput{
+			Nets: in.Spec.Nets,
+		},
+		Description: in.Description,
+		FilePath:    in.FilePath,
+	}
+}
+
+func ToValidateNetworkSetOutput(validateNetworkSetOutput *model.ValidateGlobalNetworkSetOutput) *dto.ValidateNetworkSetOutput {
+	return &dto.ValidateNetworkSetOutput{
+		NS:                ToGlobalNetworkSetDTO(validateNetworkSetOutput.GNS),
+		NSExisted:         ToGlobalNetworkSetDTO(validateNetworkSetOutput.GNSExisted),
+		Warnings:          validateNetworkSetOutput.Warnings,
+		LintFindings:      ToLintFindingDTOs(validateNetworkSetOutput.LintFindings),
+		AdmissionVerdicts: ToAdmissionVerdictDTOs(validateNetworkSetOutput.AdmissionVerdicts),
+	}
+}
@@ -0,0 +1,52 @@
+package mapper
+
+import (
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/net"
+	"github.com/bamboo-firewall/be/pkg/selector"
+)
+
+func ToExplainSelectorInput(in *dto.ExplainSelectorInput) *model.ExplainSelectorInput {
+	input := &model.ExplainSelectorInput{
+		Selector: in.Selector,
+		Labels:   in.Labels,
+	}
+	if in.HEP != nil {
+		input.TenantID = in.HEP.TenantID
+		input.IP = in.HEP.IP
+	}
+	return input
+}
+
+func ToExplainSelectorOutput(out *model.ExplainSelectorOutput) *dto.ExplainSelectorOutput {
+	output := &dto.ExplainSelectorOutput{
+		Selector: out.Selector,
+		Labels:   out.Labels,
+		Matched:  out.Trace.Result,
+		Trace:    toSelectorTraceDTO(out.Trace),
+	}
+	if out.HEP != nil {
+		output.HEP = &dto.ParsedHEP{
+			UUID:     out.HEP.UUID,
+			Name:     out.HEP.Metadata.Name,
+			TenantID: out.HEP.Spec.TenantID,
+			IP:       net.IntToIP(out.HEP.Spec.IP).String(),
+			IPsV4:    out.HEP.Spec.IPsV4,
+			IPsV6:    out.HEP.Spec.IPsV6,
+		}
+	}
+	return output
+}
+
+func toSelectorTraceDTO(trace *selector.Trace) *dto.SelectorTrace {
+	traceDTO := &dto.SelectorTrace{
+		Expression: trace.Expression,
+		Result:     trace.Result,
+		Reason:     trace.Reason,
+	}
+	for _, child := range trace.Children {
+		traceDTO.Children = append(traceDTO.Children, toSelectorTraceDTO(child))
+	}
+	return traceDTO
+}
@@ -0,0 +1,66 @@
+package mapper
+
+import (
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+func ToListTenantDTOs(tenants []*entity.Tenant) []*dto.Tenant {
+	tenantDTOs := make([]*dto.Tenant, 0, len(tenants))
+	for _, tenant := range tenants {
+		tenantDTOs = append(tenantDTOs, ToTenantDTO(tenant))
+	}
+	return tenantDTOs
+}
+
+func ToTenantDTO(tenant *entity.Tenant) *dto.Tenant {
+	if tenant == nil {
+		return nil
+	}
+	return &dto.Tenant{
+		ID:      tenant.ID.Hex(),
+		UUID:    tenant.UUID,
+		Version: tenant.Version,
+		Metadata: dto.TenantMetadata{
+			Name:   tenant.Metadata.Name,
+			Labels: tenant.Metadata.Labels,
+		},
+		Spec: dto.TenantSpec{
+			TenantID: tenant.Spec.TenantID,
+			Quota: dto.TenantQuota{
+				MaxHostEndpoints:   tenant.Spec.Quota.MaxHostEndpoints,
+				MaxNetworkPolicies: tenant.Spec.Quota.MaxNetworkPolicies,
+			},
+		},
+		Description: tenant.Description,
+		FilePath:    tenant.FilePath,
+		CreatedAt:   tenant.CreatedAt.Local(),
+		UpdatedAt:   tenant.UpdatedAt.Local(),
+	}
+}
+
+func ToCreateTenantInput(in *dto.CreateTenantInput) *model.CreateTenantInput {
+	return &model.CreateTenantInput{
+		Metadata: model.TenantMetadataInput{
+			Name:   in.Metadata.Name,
+			Labels: in.Metadata.Labels,
+		},
+		Spec: model.TenantSpecInput{
+			TenantID: in.Spec.TenantID,
+			Quota: model.TenantQuotaInput{
+				MaxHostEndpoints:   in.Spec.Quota.MaxHostEndpoints,
+				MaxNetworkPolicies: in.Spec.Quota.MaxNetworkPolicies,
+			},
+		},
+		Description: in.Description,
+		FilePath:    in.FilePath,
+	}
+}
+
+func ToValidateTenantOutput(validateTenantOutput *model.ValidateTenantOutput) *dto.ValidateTenantOutput {
+	return &dto.ValidateTenantOutput{
+		Tenant:        ToTenantDTO(validateTenantOutput.Tenant),
+		TenantExisted: ToTenantDTO(validateTenantOutput.TenantExisted),
+	}
+}
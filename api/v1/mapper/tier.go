@@ -0,0 +1,60 @@
+package mapper
+
+import (
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+func ToListTierDTOs(tiers []*entity.Tier) []*dto.Tier {
+	tierDTOs := make([]*dto.Tier, 0, len(tiers))
+	for _, tier := range tiers {
+		tierDTOs = append(tierDTOs, ToTierDTO(tier))
+	}
+	return tierDTOs
+}
+
+func ToTierDTO(tier *entity.Tier) *dto.Tier {
+	if tier == nil {
+		return nil
+	}
+	return &dto.Tier{
+		ID:      tier.ID.Hex(),
+		UUID:    tier.UUID,
+		Version: tier.Version,
+		Metadata: dto.TierMetadata{
+			Name:   tier.Metadata.Name,
+			Labels: tier.Metadata.Labels,
+		},
+		Spec: dto.TierSpec{
+			Order:         tier.Spec.Order,
+			DefaultAction: tier.Spec.DefaultAction,
+		},
+		Description: tier.Description,
+		FilePath:    tier.FilePath,
+		CreatedAt:   tier.CreatedAt.Local(),
+		UpdatedAt:   tier.UpdatedAt.Local(),
+	}
+}
+
+func ToCreateTierInput(in *dto.CreateTierInput) *model.CreateTierInput {
+	return &model.CreateTierInput{
+		Metadata: model.TierMetadataInput{
+			Name:   in.Metadata.Name,
+			Labels: in.Metadata.Labels,
+		},
+		Spec: model.TierSpecInput{
+			Order:         in.Spec.Order,
+			DefaultAction: in.Spec.DefaultAction,
+		},
+		Description: in.Description,
+		FilePath:    in.FilePath,
+	}
+}
+
+func ToValidateTierOutput(validateTierOutput *model.ValidateTierOutput) *dto.ValidateTierOutput {
+	return &dto.ValidateTierOutput{
+		Tier:        ToTierDTO(validateTierOutput.Tier),
+		TierExisted: ToTierDTO(validateTierOutput.TierExisted),
+	}
+}
@@ -0,0 +1,114 @@
+package mapper
+
+import (
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+func ToListWebhookDTOs(webhooks []*entity.Webhook) []*dto.Webhook {
+	webhookDTOs := make([]*dto.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		webhookDTOs = append(webhookDTOs, ToWebhookDTO(webhook))
+	}
+	return webhookDTOs
+}
+
+func ToWebhookDTO(webhook *entity.Webhook) *dto.Webhook {
+	if webhook == nil {
+		return nil
+	}
+	return &dto.Webhook{
+		ID:      webhook.ID.Hex(),
+		UUID:    webhook.UUID,
+		Version: webhook.Version,
+		Metadata: dto.WebhookMetadata{
+			Name:   webhook.Metadata.Name,
+			Labels: webhook.Metadata.Labels,
+		},
+		Spec: dto.WebhookSpec{
+			URL:       webhook.Spec.URL,
+			HasSecret: webhook.Spec.Secret != "",
+			Kinds:     webhook.Spec.Kinds,
+			Actions:   webhook.Spec.Actions,
+			Selector:  webhook.Spec.Selector,
+		},
+		Description: webhook.Description,
+		FilePath:    webhook.FilePath,
+		CreatedAt:   webhook.CreatedAt.Local(),
+		UpdatedAt:   webhook.UpdatedAt.Local(),
+	}
+}
+
+func ToCreateWebhookInput(in *dto.CreateWebhookInput) *model.CreateWebhookInput {
+	return &model.CreateWebhookInput{
+		Metadata: model.WebhookMetadataInput{
+			Name:   in.Metadata.Name,
+			Labels: in.Metadata.Labels,
+		},
+		Spec: model.WebhookSpecInput{
+			URL:      in.Spec.URL,
+			Secret:   in.Spec.Secret,
+			Kinds:    in.Spec.Kinds,
+			Actions:  in.Spec.Actions,
+			Selector: in.Spec.Selector,
+		},
+		Description: in.Description,
+		FilePath:    in.FilePath,
+	}
+}
+
+func ToValidateWebhookOutput(validateWebhookOutput *model.ValidateWebhookOutput) *dto.ValidateWebhookOutput {
+	return &dto.ValidateWebhookOutput{
+		Webhook:        ToWebhookDTO(validateWebhookOutput.Webhook),
+		WebhookExisted: ToWebhookDTO(validateWebhookOutput.WebhookExisted),
+	}
+}
+
+func ToListWebhookDeliveriesInput(in *dto.ListWebhookDeliveriesInput) *model.ListWebhookDeliveriesInput {
+	return &model.ListWebhookDeliveriesInput{
+		WebhookName: in.WebhookName,
+		Status:      in.Status,
+		Limit:       in.Limit,
+	}
+}
+
+func ToListWebhookDeliveryDTOs(deliveries []*entity.WebhookDelivery) []*dto.WebhookDelivery {
+	deliveryDTOs := make([]*dto.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveryDTOs = append(deliveryDTOs, toWebhookDeliveryDTO(delivery))
+	}
+	return deliveryDTOs
+}
+
+func toWebhookDeliveryDTO(delivery *entity.WebhookDelivery) *dto.WebhookDelivery {
+	deliveryDTO := &dto.WebhookDelivery{
+		UUID:        delivery.UUID,
+		WebhookName: delivery.WebhookName,
+		Event: dto.WebhookEvent{
+			ID:         delivery.Event.ID,
+			Kind:       delivery.Event.Kind,
+			Action:     delivery.Event.Action,
+			Name:       delivery.Event.Name,
+			TenantID:   delivery.Event.TenantID,
+			UUID:       delivery.Event.UUID,
+			Version:    delivery.Event.Version,
+			Labels:     delivery.Event.Labels,
+			OccurredAt: delivery.Event.OccurredAt.Local(),
+		},
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.Local(),
+	}
+	if delivery.Status == entity.WebhookDeliveryStatusPending {
+		nextAttemptAt := delivery.NextAttemptAt.Local()
+		deliveryDTO.NextAttemptAt = &nextAttemptAt
+	}
+	if delivery.DeliveredAt != nil {
+		deliveredAt := delivery.DeliveredAt.Local()
+		deliveryDTO.DeliveredAt = &deliveredAt
+	}
+	return deliveryDTO
+}
@@ -0,0 +1,1638 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        v5.27.1
+// source: agent.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FetchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TenantId uint64 `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Ip       string `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+}
+
+func (x *FetchRequest) Reset() {
+	*x = FetchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FetchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchRequest) ProtoMessage() {}
+
+func (x *FetchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchRequest.ProtoReflect.Descriptor instead.
+func (*FetchRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FetchRequest) GetTenantId() uint64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *FetchRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+type WatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TenantId uint64 `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Ip       string `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	// known is the metadata of the policy the agent applied, empty to receive the whole policy
+	Known *HostEndpointPolicyMetadata `protobuf:"bytes,3,opt,name=known,proto3" json:"known,omitempty"`
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WatchRequest) GetTenantId() uint64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *WatchRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetKnown() *HostEndpointPolicyMetadata {
+	if x != nil {
+		return x.Known
+	}
+	return nil
+}
+
+type HostEndpointPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata     *HostEndpointPolicyMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	HostEndpoint *HostEndpoint               `protobuf:"bytes,2,opt,name=host_endpoint,json=hostEndpoint,proto3" json:"host_endpoint,omitempty"`
+	ParsedTiers  []*ParsedTier               `protobuf:"bytes,3,rep,name=parsed_tiers,json=parsedTiers,proto3" json:"parsed_tiers,omitempty"`
+	ParsedGnps   []*ParsedGNP                `protobuf:"bytes,4,rep,name=parsed_gnps,json=parsedGnps,proto3" json:"parsed_gnps,omitempty"`
+	ParsedHeps   []*ParsedHEP                `protobuf:"bytes,5,rep,name=parsed_heps,json=parsedHeps,proto3" json:"parsed_heps,omitempty"`
+	ParsedGnss   []*ParsedGNS                `protobuf:"bytes,6,rep,name=parsed_gnss,json=parsedGnss,proto3" json:"parsed_gnss,omitempty"`
+}
+
+func (x *HostEndpointPolicy) Reset() {
+	*x = HostEndpointPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostEndpointPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostEndpointPolicy) ProtoMessage() {}
+
+func (x *HostEndpointPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostEndpointPolicy.ProtoReflect.Descriptor instead.
+func (*HostEndpointPolicy) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HostEndpointPolicy) GetMetadata() *HostEndpointPolicyMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *HostEndpointPolicy) GetHostEndpoint() *HostEndpoint {
+	if x != nil {
+		return x.HostEndpoint
+	}
+	return nil
+}
+
+func (x *HostEndpointPolicy) GetParsedTiers() []*ParsedTier {
+	if x != nil {
+		return x.ParsedTiers
+	}
+	return nil
+}
+
+func (x *HostEndpointPolicy) GetParsedGnps() []*ParsedGNP {
+	if x != nil {
+		return x.ParsedGnps
+	}
+	return nil
+}
+
+func (x *HostEndpointPolicy) GetParsedHeps() []*ParsedHEP {
+	if x != nil {
+		return x.ParsedHeps
+	}
+	return nil
+}
+
+func (x *HostEndpointPolicy) GetParsedGnss() []*ParsedGNS {
+	if x != nil {
+		return x.ParsedGnss
+	}
+	return nil
+}
+
+type HostEndpointPolicyDelta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata     *HostEndpointPolicyMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	HostEndpoint *HostEndpoint               `protobuf:"bytes,2,opt,name=host_endpoint,json=hostEndpoint,proto3" json:"host_endpoint,omitempty"`
+	ParsedTiers  []*ParsedTier               `protobuf:"bytes,3,rep,name=parsed_tiers,json=parsedTiers,proto3" json:"parsed_tiers,omitempty"`
+	// gnp_uuids is the uuids of all the policies applied, in evaluation order
+	GnpUuids []string `protobuf:"bytes,4,rep,name=gnp_uuids,json=gnpUuids,proto3" json:"gnp_uuids,omitempty"`
+	// parsed_gnps, parsed_heps and parsed_gnss are the added or changed ones
+	ParsedGnps  []*ParsedGNP `protobuf:"bytes,5,rep,name=parsed_gnps,json=parsedGnps,proto3" json:"parsed_gnps,omitempty"`
+	ParsedHeps  []*ParsedHEP `protobuf:"bytes,6,rep,name=parsed_heps,json=parsedHeps,proto3" json:"parsed_heps,omitempty"`
+	ParsedGnss  []*ParsedGNS `protobuf:"bytes,7,rep,name=parsed_gnss,json=parsedGnss,proto3" json:"parsed_gnss,omitempty"`
+	RemovedGnps []string     `protobuf:"bytes,8,rep,name=removed_gnps,json=removedGnps,proto3" json:"removed_gnps,omitempty"`
+	RemovedHeps []string     `protobuf:"bytes,9,rep,name=removed_heps,json=removedHeps,proto3" json:"removed_heps,omitempty"`
+	RemovedGnss []string     `protobuf:"bytes,10,rep,name=removed_gnss,json=removedGnss,proto3" json:"removed_gnss,omitempty"`
+}
+
+func (x *HostEndpointPolicyDelta) Reset() {
+	*x = HostEndpointPolicyDelta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostEndpointPolicyDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostEndpointPolicyDelta) ProtoMessage() {}
+
+func (x *HostEndpointPolicyDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostEndpointPolicyDelta.ProtoReflect.Descriptor instead.
+func (*HostEndpointPolicyDelta) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HostEndpointPolicyDelta) GetMetadata() *HostEndpointPolicyMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *HostEndpointPolicyDelta) GetHostEndpoint() *HostEndpoint {
+	if x != nil {
+		return x.HostEndpoint
+	}
+	return nil
+}
+
+func (x *HostEndpointPolicyDelta) GetParsedTiers() []*ParsedTier {
+	if x != nil {
+		return x.ParsedTiers
+	}
+	return nil
+}
+
+func (x *HostEndpointPolicyDelta) GetGnpUuids() []string {
+	if x != nil {
+		return x.GnpUuids
+	}
+	return nil
+}
+
+func (x *HostEndpointPolicyDelta) GetParsedGnps() []*ParsedGNP {
+	if x != nil {
+		return x.ParsedGnps
+	}
+	return nil
+}
+
+func (x *HostEndpointPolicyDelta) GetParsedHeps() []*ParsedHEP {
+	if x != nil {
+		return x.ParsedHeps
+	}
+	return nil
+}
+
+func (x *HostEndpointPolicyDelta) GetParsedGnss() []*ParsedGNS {
+	if x != nil {
+		return x.ParsedGnss
+	}
+	return nil
+}
+
+func (x *HostEndpointPolicyDelta) GetRemovedGnps() []string {
+	if x != nil {
+		return x.RemovedGnps
+	}
+	return nil
+}
+
+func (x *HostEndpointPolicyDelta) GetRemovedHeps() []string {
+	if x != nil {
+		return x.RemovedHeps
+	}
+	return nil
+}
+
+func (x *HostEndpointPolicyDelta) GetRemovedGnss() []string {
+	if x != nil {
+		return x.RemovedGnss
+	}
+	return nil
+}
+
+type HostEndpointPolicyMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Revision    uint64            `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	HepVersions map[string]uint64 `protobuf:"bytes,2,rep,name=hep_versions,json=hepVersions,proto3" json:"hep_versions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	GnpVersions map[string]uint64 `protobuf:"bytes,3,rep,name=gnp_versions,json=gnpVersions,proto3" json:"gnp_versions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	GnsVersions map[string]uint64 `protobuf:"bytes,4,rep,name=gns_versions,json=gnsVersions,proto3" json:"gns_versions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	GnpDigests  map[string]string `protobuf:"bytes,5,rep,name=gnp_digests,json=gnpDigests,proto3" json:"gnp_digests,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *HostEndpointPolicyMetadata) Reset() {
+	*x = HostEndpointPolicyMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostEndpointPolicyMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostEndpointPolicyMetadata) ProtoMessage() {}
+
+func (x *HostEndpointPolicyMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostEndpointPolicyMetadata.ProtoReflect.Descriptor instead.
+func (*HostEndpointPolicyMetadata) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HostEndpointPolicyMetadata) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *HostEndpointPolicyMetadata) GetHepVersions() map[string]uint64 {
+	if x != nil {
+		return x.HepVersions
+	}
+	return nil
+}
+
+func (x *HostEndpointPolicyMetadata) GetGnpVersions() map[string]uint64 {
+	if x != nil {
+		return x.GnpVersions
+	}
+	return nil
+}
+
+func (x *HostEndpointPolicyMetadata) GetGnsVersions() map[string]uint64 {
+	if x != nil {
+		return x.GnsVersions
+	}
+	return nil
+}
+
+func (x *HostEndpointPolicyMetadata) GetGnpDigests() map[string]string {
+	if x != nil {
+		return x.GnpDigests
+	}
+	return nil
+}
+
+type HostEndpoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Uuid          string                 `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Version       uint64                 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	InterfaceName string                 `protobuf:"bytes,6,opt,name=interface_name,json=interfaceName,proto3" json:"interface_name,omitempty"`
+	TenantId      uint64                 `protobuf:"varint,7,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Ip            string                 `protobuf:"bytes,8,opt,name=ip,proto3" json:"ip,omitempty"`
+	Ips           []string               `protobuf:"bytes,9,rep,name=ips,proto3" json:"ips,omitempty"`
+	Description   string                 `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *HostEndpoint) Reset() {
+	*x = HostEndpoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostEndpoint) ProtoMessage() {}
+
+func (x *HostEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostEndpoint.ProtoReflect.Descriptor instead.
+func (*HostEndpoint) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HostEndpoint) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HostEndpoint) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *HostEndpoint) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *HostEndpoint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HostEndpoint) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *HostEndpoint) GetInterfaceName() string {
+	if x != nil {
+		return x.InterfaceName
+	}
+	return ""
+}
+
+func (x *HostEndpoint) GetTenantId() uint64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *HostEndpoint) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *HostEndpoint) GetIps() []string {
+	if x != nil {
+		return x.Ips
+	}
+	return nil
+}
+
+func (x *HostEndpoint) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *HostEndpoint) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *HostEndpoint) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ParsedTier struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Order         uint32 `protobuf:"varint,2,opt,name=order,proto3" json:"order,omitempty"`
+	DefaultAction string `protobuf:"bytes,3,opt,name=default_action,json=defaultAction,proto3" json:"default_action,omitempty"`
+}
+
+func (x *ParsedTier) Reset() {
+	*x = ParsedTier{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParsedTier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParsedTier) ProtoMessage() {}
+
+func (x *ParsedTier) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParsedTier.ProtoReflect.Descriptor instead.
+func (*ParsedTier) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ParsedTier) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ParsedTier) GetOrder() uint32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+func (x *ParsedTier) GetDefaultAction() string {
+	if x != nil {
+		return x.DefaultAction
+	}
+	return ""
+}
+
+type ParsedGNP struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid          string        `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Version       uint64        `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Name          string        `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	TenantId      uint64        `protobuf:"varint,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Tier          string        `protobuf:"bytes,5,opt,name=tier,proto3" json:"tier,omitempty"`
+	IsStaged      bool          `protobuf:"varint,6,opt,name=is_staged,json=isStaged,proto3" json:"is_staged,omitempty"`
+	InboundRules  []*ParsedRule `protobuf:"bytes,7,rep,name=inbound_rules,json=inboundRules,proto3" json:"inbound_rules,omitempty"`
+	OutboundRules []*ParsedRule `protobuf:"bytes,8,rep,name=outbound_rules,json=outboundRules,proto3" json:"outbound_rules,omitempty"`
+}
+
+func (x *ParsedGNP) Reset() {
+	*x = ParsedGNP{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParsedGNP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParsedGNP) ProtoMessage() {}
+
+func (x *ParsedGNP) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParsedGNP.ProtoReflect.Descriptor instead.
+func (*ParsedGNP) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ParsedGNP) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *ParsedGNP) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ParsedGNP) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ParsedGNP) GetTenantId() uint64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *ParsedGNP) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *ParsedGNP) GetIsStaged() bool {
+	if x != nil {
+		return x.IsStaged
+	}
+	return false
+}
+
+func (x *ParsedGNP) GetInboundRules() []*ParsedRule {
+	if x != nil {
+		return x.InboundRules
+	}
+	return nil
+}
+
+func (x *ParsedGNP) GetOutboundRules() []*ParsedRule {
+	if x != nil {
+		return x.OutboundRules
+	}
+	return nil
+}
+
+type ParsedRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Action    string `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	IpVersion *int32 `protobuf:"varint,2,opt,name=ip_version,json=ipVersion,proto3,oneof" json:"ip_version,omitempty"`
+	// protocol is unset when the rule matches any protocol
+	//
+	// Types that are assignable to Protocol:
+	//	*ParsedRule_ProtocolNumber
+	//	*ParsedRule_ProtocolName
+	Protocol           isParsedRule_Protocol `protobuf_oneof:"protocol"`
+	IsProtocolNegative bool                  `protobuf:"varint,5,opt,name=is_protocol_negative,json=isProtocolNegative,proto3" json:"is_protocol_negative,omitempty"`
+	IcmpType           *int32                `protobuf:"varint,6,opt,name=icmp_type,json=icmpType,proto3,oneof" json:"icmp_type,omitempty"`
+	IcmpCode           *int32                `protobuf:"varint,7,opt,name=icmp_code,json=icmpCode,proto3,oneof" json:"icmp_code,omitempty"`
+	NotIcmpType        *int32                `protobuf:"varint,8,opt,name=not_icmp_type,json=notIcmpType,proto3,oneof" json:"not_icmp_type,omitempty"`
+	NotIcmpCode        *int32                `protobuf:"varint,9,opt,name=not_icmp_code,json=notIcmpCode,proto3,oneof" json:"not_icmp_code,omitempty"`
+	SrcNets            []string              `protobuf:"bytes,10,rep,name=src_nets,json=srcNets,proto3" json:"src_nets,omitempty"`
+	IsSrcNetNegative   bool                  `protobuf:"varint,11,opt,name=is_src_net_negative,json=isSrcNetNegative,proto3" json:"is_src_net_negative,omitempty"`
+	SrcGnsUuids        []string              `protobuf:"bytes,12,rep,name=src_gns_uuids,json=srcGnsUuids,proto3" json:"src_gns_uuids,omitempty"`
+	SrcHepUuids        []string              `protobuf:"bytes,13,rep,name=src_hep_uuids,json=srcHepUuids,proto3" json:"src_hep_uuids,omitempty"`
+	SrcPorts           []string              `protobuf:"bytes,14,rep,name=src_ports,json=srcPorts,proto3" json:"src_ports,omitempty"`
+	IsSrcPortNegative  bool                  `protobuf:"varint,15,opt,name=is_src_port_negative,json=isSrcPortNegative,proto3" json:"is_src_port_negative,omitempty"`
+	DstNets            []string              `protobuf:"bytes,16,rep,name=dst_nets,json=dstNets,proto3" json:"dst_nets,omitempty"`
+	IsDstNetNegative   bool                  `protobuf:"varint,17,opt,name=is_dst_net_negative,json=isDstNetNegative,proto3" json:"is_dst_net_negative,omitempty"`
+	DstGnsUuids        []string              `protobuf:"bytes,18,rep,name=dst_gns_uuids,json=dstGnsUuids,proto3" json:"dst_gns_uuids,omitempty"`
+	DstHepUuids        []string              `protobuf:"bytes,19,rep,name=dst_hep_uuids,json=dstHepUuids,proto3" json:"dst_hep_uuids,omitempty"`
+	DstPorts           []string              `protobuf:"bytes,20,rep,name=dst_ports,json=dstPorts,proto3" json:"dst_ports,omitempty"`
+	IsDstPortNegative  bool                  `protobuf:"varint,21,opt,name=is_dst_port_negative,json=isDstPortNegative,proto3" json:"is_dst_port_negative,omitempty"`
+}
+
+func (x *ParsedRule) Reset() {
+	*x = ParsedRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParsedRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParsedRule) ProtoMessage() {}
+
+func (x *ParsedRule) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParsedRule.ProtoReflect.Descriptor instead.
+func (*ParsedRule) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ParsedRule) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ParsedRule) GetIpVersion() int32 {
+	if x != nil && x.IpVersion != nil {
+		return *x.IpVersion
+	}
+	return 0
+}
+
+func (m *ParsedRule) GetProtocol() isParsedRule_Protocol {
+	if m != nil {
+		return m.Protocol
+	}
+	return nil
+}
+
+func (x *ParsedRule) GetProtocolNumber() int32 {
+	if x, ok := x.GetProtocol().(*ParsedRule_ProtocolNumber); ok {
+		return x.ProtocolNumber
+	}
+	return 0
+}
+
+func (x *ParsedRule) GetProtocolName() string {
+	if x, ok := x.GetProtocol().(*ParsedRule_ProtocolName); ok {
+		return x.ProtocolName
+	}
+	return ""
+}
+
+func (x *ParsedRule) GetIsProtocolNegative() bool {
+	if x != nil {
+		return x.IsProtocolNegative
+	}
+	return false
+}
+
+func (x *ParsedRule) GetIcmpType() int32 {
+	if x != nil && x.IcmpType != nil {
+		return *x.IcmpType
+	}
+	return 0
+}
+
+func (x *ParsedRule) GetIcmpCode() int32 {
+	if x != nil && x.IcmpCode != nil {
+		return *x.IcmpCode
+	}
+	return 0
+}
+
+func (x *ParsedRule) GetNotIcmpType() int32 {
+	if x != nil && x.NotIcmpType != nil {
+		return *x.NotIcmpType
+	}
+	return 0
+}
+
+func (x *ParsedRule) GetNotIcmpCode() int32 {
+	if x != nil && x.NotIcmpCode != nil {
+		return *x.NotIcmpCode
+	}
+	return 0
+}
+
+func (x *ParsedRule) GetSrcNets() []string {
+	if x != nil {
+		return x.SrcNets
+	}
+	return nil
+}
+
+func (x *ParsedRule) GetIsSrcNetNegative() bool {
+	if x != nil {
+		return x.IsSrcNetNegative
+	}
+	return false
+}
+
+func (x *ParsedRule) GetSrcGnsUuids() []string {
+	if x != nil {
+		return x.SrcGnsUuids
+	}
+	return nil
+}
+
+func (x *ParsedRule) GetSrcHepUuids() []string {
+	if x != nil {
+		return x.SrcHepUuids
+	}
+	return nil
+}
+
+func (x *ParsedRule) GetSrcPorts() []string {
+	if x != nil {
+		return x.SrcPorts
+	}
+	return nil
+}
+
+func (x *ParsedRule) GetIsSrcPortNegative() bool {
+	if x != nil {
+		return x.IsSrcPortNegative
+	}
+	return false
+}
+
+func (x *ParsedRule) GetDstNets() []string {
+	if x != nil {
+		return x.DstNets
+	}
+	return nil
+}
+
+func (x *ParsedRule) GetIsDstNetNegative() bool {
+	if x != nil {
+		return x.IsDstNetNegative
+	}
+	return false
+}
+
+func (x *ParsedRule) GetDstGnsUuids() []string {
+	if x != nil {
+		return x.DstGnsUuids
+	}
+	return nil
+}
+
+func (x *ParsedRule) GetDstHepUuids() []string {
+	if x != nil {
+		return x.DstHepUuids
+	}
+	return nil
+}
+
+func (x *ParsedRule) GetDstPorts() []string {
+	if x != nil {
+		return x.DstPorts
+	}
+	return nil
+}
+
+func (x *ParsedRule) GetIsDstPortNegative() bool {
+	if x != nil {
+		return x.IsDstPortNegative
+	}
+	return false
+}
+
+type isParsedRule_Protocol interface {
+	isParsedRule_Protocol()
+}
+
+type ParsedRule_ProtocolNumber struct {
+	ProtocolNumber int32 `protobuf:"varint,3,opt,name=protocol_number,json=protocolNumber,proto3,oneof"`
+}
+
+type ParsedRule_ProtocolName struct {
+	ProtocolName string `protobuf:"bytes,4,opt,name=protocol_name,json=protocolName,proto3,oneof"`
+}
+
+func (*ParsedRule_ProtocolNumber) isParsedRule_Protocol() {}
+
+func (*ParsedRule_ProtocolName) isParsedRule_Protocol() {}
+
+type ParsedHEP struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid     string   `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Name     string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TenantId uint64   `protobuf:"varint,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Ip       string   `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
+	IpsV4    []string `protobuf:"bytes,5,rep,name=ips_v4,json=ipsV4,proto3" json:"ips_v4,omitempty"`
+	IpsV6    []string `protobuf:"bytes,6,rep,name=ips_v6,json=ipsV6,proto3" json:"ips_v6,omitempty"`
+}
+
+func (x *ParsedHEP) Reset() {
+	*x = ParsedHEP{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParsedHEP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParsedHEP) ProtoMessage() {}
+
+func (x *ParsedHEP) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParsedHEP.ProtoReflect.Descriptor instead.
+func (*ParsedHEP) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ParsedHEP) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *ParsedHEP) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ParsedHEP) GetTenantId() uint64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *ParsedHEP) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *ParsedHEP) GetIpsV4() []string {
+	if x != nil {
+		return x.IpsV4
+	}
+	return nil
+}
+
+func (x *ParsedHEP) GetIpsV6() []string {
+	if x != nil {
+		return x.IpsV6
+	}
+	return nil
+}
+
+type ParsedGNS struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid     string   `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Name     string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TenantId uint64   `protobuf:"varint,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	NetsV4   []string `protobuf:"bytes,4,rep,name=nets_v4,json=netsV4,proto3" json:"nets_v4,omitempty"`
+	NetsV6   []string `protobuf:"bytes,5,rep,name=nets_v6,json=netsV6,proto3" json:"nets_v6,omitempty"`
+	Domains  []string `protobuf:"bytes,6,rep,name=domains,proto3" json:"domains,omitempty"`
+}
+
+func (x *ParsedGNS) Reset() {
+	*x = ParsedGNS{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParsedGNS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParsedGNS) ProtoMessage() {}
+
+func (x *ParsedGNS) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParsedGNS.ProtoReflect.Descriptor instead.
+func (*ParsedGNS) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ParsedGNS) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *ParsedGNS) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ParsedGNS) GetTenantId() uint64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *ParsedGNS) GetNetsV4() []string {
+	if x != nil {
+		return x.NetsV4
+	}
+	return nil
+}
+
+func (x *ParsedGNS) GetNetsV6() []string {
+	if x != nil {
+		return x.NetsV6
+	}
+	return nil
+}
+
+func (x *ParsedGNS) GetDomains() []string {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
+}
+
+var File_agent_proto protoreflect.FileDescriptor
+
+var file_agent_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x62,
+	0x61, 0x6d, 0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x3b, 0x0a, 0x0c, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x22, 0x80,
+	0x01, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x43, 0x0a, 0x05,
+	0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x62, 0x61,
+	0x6d, 0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x48, 0x6f, 0x73, 0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x05, 0x6b, 0x6e, 0x6f, 0x77,
+	0x6e, 0x22, 0xa4, 0x03, 0x0a, 0x12, 0x48, 0x6f, 0x73, 0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x49, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x62, 0x61, 0x6d,
+	0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48,
+	0x6f, 0x73, 0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x44, 0x0a, 0x0d, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x65, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x62, 0x61, 0x6d,
+	0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48,
+	0x6f, 0x73, 0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0c, 0x68, 0x6f, 0x73,
+	0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x40, 0x0a, 0x0c, 0x70, 0x61, 0x72,
+	0x73, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x64, 0x54, 0x69, 0x65, 0x72, 0x52, 0x0b,
+	0x70, 0x61, 0x72, 0x73, 0x65, 0x64, 0x54, 0x69, 0x65, 0x72, 0x73, 0x12, 0x3d, 0x0a, 0x0b, 0x70,
+	0x61, 0x72, 0x73, 0x65, 0x64, 0x5f, 0x67, 0x6e, 0x70, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x64, 0x47, 0x4e, 0x50, 0x52, 0x0a,
+	0x70, 0x61, 0x72, 0x73, 0x65, 0x64, 0x47, 0x6e, 0x70, 0x73, 0x12, 0x3d, 0x0a, 0x0b, 0x70, 0x61,
+	0x72, 0x73, 0x65, 0x64, 0x5f, 0x68, 0x65, 0x70, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x64, 0x48, 0x45, 0x50, 0x52, 0x0a, 0x70,
+	0x61, 0x72, 0x73, 0x65, 0x64, 0x48, 0x65, 0x70, 0x73, 0x12, 0x3d, 0x0a, 0x0b, 0x70, 0x61, 0x72,
+	0x73, 0x65, 0x64, 0x5f, 0x67, 0x6e, 0x73, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x64, 0x47, 0x4e, 0x53, 0x52, 0x0a, 0x70, 0x61,
+	0x72, 0x73, 0x65, 0x64, 0x47, 0x6e, 0x73, 0x73, 0x22, 0xaf, 0x04, 0x0a, 0x17, 0x48, 0x6f, 0x73,
+	0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x44,
+	0x65, 0x6c, 0x74, 0x61, 0x12, 0x49, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f, 0x6f, 0x66,
+	0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x45,
+	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x44, 0x0a, 0x0d, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f, 0x6f, 0x66,
+	0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x45,
+	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0c, 0x68, 0x6f, 0x73, 0x74, 0x45, 0x6e, 0x64,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x40, 0x0a, 0x0c, 0x70, 0x61, 0x72, 0x73, 0x65, 0x64, 0x5f,
+	0x74, 0x69, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x62, 0x61,
+	0x6d, 0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x61, 0x72, 0x73, 0x65, 0x64, 0x54, 0x69, 0x65, 0x72, 0x52, 0x0b, 0x70, 0x61, 0x72, 0x73,
+	0x65, 0x64, 0x54, 0x69, 0x65, 0x72, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6e, 0x70, 0x5f, 0x75,
+	0x75, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x67, 0x6e, 0x70, 0x55,
+	0x75, 0x69, 0x64, 0x73, 0x12, 0x3d, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x73, 0x65, 0x64, 0x5f, 0x67,
+	0x6e, 0x70, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62, 0x61, 0x6d, 0x62,
+	0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61,
+	0x72, 0x73, 0x65, 0x64, 0x47, 0x4e, 0x50, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x73, 0x65, 0x64, 0x47,
+	0x6e, 0x70, 0x73, 0x12, 0x3d, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x73, 0x65, 0x64, 0x5f, 0x68, 0x65,
+	0x70, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f,
+	0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72,
+	0x73, 0x65, 0x64, 0x48, 0x45, 0x50, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x73, 0x65, 0x64, 0x48, 0x65,
+	0x70, 0x73, 0x12, 0x3d, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x73, 0x65, 0x64, 0x5f, 0x67, 0x6e, 0x73,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f, 0x6f,
+	0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x73,
+	0x65, 0x64, 0x47, 0x4e, 0x53, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x73, 0x65, 0x64, 0x47, 0x6e, 0x73,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x5f, 0x67, 0x6e, 0x70,
+	0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64,
+	0x47, 0x6e, 0x70, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x5f,
+	0x68, 0x65, 0x70, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x64, 0x48, 0x65, 0x70, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x64, 0x5f, 0x67, 0x6e, 0x73, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x72,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x47, 0x6e, 0x73, 0x73, 0x22, 0xc0, 0x05, 0x0a, 0x1a, 0x48,
+	0x6f, 0x73, 0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x61, 0x0a, 0x0c, 0x68, 0x65, 0x70, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x62, 0x61,
+	0x6d, 0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x48, 0x6f, 0x73, 0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x48, 0x65, 0x70, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x68, 0x65, 0x70,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x61, 0x0a, 0x0c, 0x67, 0x6e, 0x70, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3e,
+	0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x47, 0x6e,
+	0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b,
+	0x67, 0x6e, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x61, 0x0a, 0x0c, 0x67,
+	0x6e, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x3e, 0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x2e, 0x47, 0x6e, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0b, 0x67, 0x6e, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x5e,
+	0x0a, 0x0b, 0x67, 0x6e, 0x70, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x45, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x2e, 0x47, 0x6e, 0x70, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0a, 0x67, 0x6e, 0x70, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x1a, 0x3e,
+	0x0a, 0x10, 0x48, 0x65, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e,
+	0x0a, 0x10, 0x47, 0x6e, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e,
+	0x0a, 0x10, 0x47, 0x6e, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d,
+	0x0a, 0x0f, 0x47, 0x6e, 0x70, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xde, 0x03,
+	0x0a, 0x0c, 0x48, 0x6f, 0x73, 0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75,
+	0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x43, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2b, 0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61,
+	0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x70, 0x73,
+	0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x70, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a,
+	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5d,
+	0x0a, 0x0a, 0x50, 0x61, 0x72, 0x73, 0x65, 0x64, 0x54, 0x69, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xa5, 0x02,
+	0x0a, 0x09, 0x50, 0x61, 0x72, 0x73, 0x65, 0x64, 0x47, 0x4e, 0x50, 0x12, 0x12, 0x0a, 0x04, 0x75,
+	0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69,
+	0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x69, 0x65, 0x72, 0x12, 0x1b,
+	0x0a, 0x09, 0x69, 0x73, 0x5f, 0x73, 0x74, 0x61, 0x67, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x69, 0x73, 0x53, 0x74, 0x61, 0x67, 0x65, 0x64, 0x12, 0x42, 0x0a, 0x0d, 0x69,
+	0x6e, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x64, 0x52, 0x75, 0x6c,
+	0x65, 0x52, 0x0c, 0x69, 0x6e, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12,
+	0x44, 0x0a, 0x0e, 0x6f, 0x75, 0x74, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x72, 0x75, 0x6c, 0x65,
+	0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f, 0x6f,
+	0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x73,
+	0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x0d, 0x6f, 0x75, 0x74, 0x62, 0x6f, 0x75, 0x6e, 0x64,
+	0x52, 0x75, 0x6c, 0x65, 0x73, 0x22, 0xfd, 0x06, 0x0a, 0x0a, 0x50, 0x61, 0x72, 0x73, 0x65, 0x64,
+	0x52, 0x75, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0a,
+	0x69, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x48, 0x01, 0x52, 0x09, 0x69, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01,
+	0x12, 0x29, 0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x0e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x0d, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x69, 0x73, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
+	0x6c, 0x5f, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x12, 0x69, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4e, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x12, 0x20, 0x0a, 0x09, 0x69, 0x63, 0x6d, 0x70, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x48, 0x02, 0x52, 0x08, 0x69, 0x63, 0x6d, 0x70, 0x54,
+	0x79, 0x70, 0x65, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x69, 0x63, 0x6d, 0x70, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x48, 0x03, 0x52, 0x08, 0x69, 0x63, 0x6d,
+	0x70, 0x43, 0x6f, 0x64, 0x65, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x0d, 0x6e, 0x6f, 0x74, 0x5f,
+	0x69, 0x63, 0x6d, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x48,
+	0x04, 0x52, 0x0b, 0x6e, 0x6f, 0x74, 0x49, 0x63, 0x6d, 0x70, 0x54, 0x79, 0x70, 0x65, 0x88, 0x01,
+	0x01, 0x12, 0x27, 0x0a, 0x0d, 0x6e, 0x6f, 0x74, 0x5f, 0x69, 0x63, 0x6d, 0x70, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x48, 0x05, 0x52, 0x0b, 0x6e, 0x6f, 0x74, 0x49,
+	0x63, 0x6d, 0x70, 0x43, 0x6f, 0x64, 0x65, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x72,
+	0x63, 0x5f, 0x6e, 0x65, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x72,
+	0x63, 0x4e, 0x65, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x13, 0x69, 0x73, 0x5f, 0x73, 0x72, 0x63, 0x5f,
+	0x6e, 0x65, 0x74, 0x5f, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x10, 0x69, 0x73, 0x53, 0x72, 0x63, 0x4e, 0x65, 0x74, 0x4e, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x72, 0x63, 0x5f, 0x67, 0x6e, 0x73, 0x5f,
+	0x75, 0x75, 0x69, 0x64, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x72, 0x63,
+	0x47, 0x6e, 0x73, 0x55, 0x75, 0x69, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x72, 0x63, 0x5f,
+	0x68, 0x65, 0x70, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0b, 0x73, 0x72, 0x63, 0x48, 0x65, 0x70, 0x55, 0x75, 0x69, 0x64, 0x73, 0x12, 0x1b, 0x0a, 0x09,
+	0x73, 0x72, 0x63, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x73, 0x72, 0x63, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x14, 0x69, 0x73, 0x5f,
+	0x73, 0x72, 0x63, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76,
+	0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x69, 0x73, 0x53, 0x72, 0x63, 0x50, 0x6f,
+	0x72, 0x74, 0x4e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x64, 0x73,
+	0x74, 0x5f, 0x6e, 0x65, 0x74, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x73,
+	0x74, 0x4e, 0x65, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x13, 0x69, 0x73, 0x5f, 0x64, 0x73, 0x74, 0x5f,
+	0x6e, 0x65, 0x74, 0x5f, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x10, 0x69, 0x73, 0x44, 0x73, 0x74, 0x4e, 0x65, 0x74, 0x4e, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x64, 0x73, 0x74, 0x5f, 0x67, 0x6e, 0x73, 0x5f,
+	0x75, 0x75, 0x69, 0x64, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x73, 0x74,
+	0x47, 0x6e, 0x73, 0x55, 0x75, 0x69, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x64, 0x73, 0x74, 0x5f,
+	0x68, 0x65, 0x70, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x73, 0x74, 0x48, 0x65, 0x70, 0x55, 0x75, 0x69, 0x64, 0x73, 0x12, 0x1b, 0x0a, 0x09,
+	0x64, 0x73, 0x74, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x64, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x14, 0x69, 0x73, 0x5f,
+	0x64, 0x73, 0x74, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76,
+	0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x69, 0x73, 0x44, 0x73, 0x74, 0x50, 0x6f,
+	0x72, 0x74, 0x4e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x69, 0x70, 0x5f, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x69, 0x63, 0x6d, 0x70, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x69, 0x63, 0x6d, 0x70, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x69, 0x63, 0x6d, 0x70, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x69, 0x63, 0x6d, 0x70,
+	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x8e, 0x01, 0x0a, 0x09, 0x50, 0x61, 0x72, 0x73, 0x65, 0x64,
+	0x48, 0x45, 0x50, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74,
+	0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
+	0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x70, 0x73, 0x5f,
+	0x76, 0x34, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x69, 0x70, 0x73, 0x56, 0x34, 0x12,
+	0x15, 0x0a, 0x06, 0x69, 0x70, 0x73, 0x5f, 0x76, 0x36, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x05, 0x69, 0x70, 0x73, 0x56, 0x36, 0x22, 0x9c, 0x01, 0x0a, 0x09, 0x50, 0x61, 0x72, 0x73, 0x65,
+	0x64, 0x47, 0x4e, 0x53, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x65, 0x74,
+	0x73, 0x5f, 0x76, 0x34, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x65, 0x74, 0x73,
+	0x56, 0x34, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x73, 0x5f, 0x76, 0x36, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x65, 0x74, 0x73, 0x56, 0x36, 0x12, 0x18, 0x0a, 0x07, 0x64,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x73, 0x32, 0xb6, 0x01, 0x0a, 0x0b, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x4f, 0x0a, 0x05, 0x46, 0x65, 0x74, 0x63, 0x68, 0x12, 0x1f,
+	0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x25, 0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x56, 0x0a, 0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12,
+	0x1f, 0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2a, 0x2e, 0x62, 0x61, 0x6d, 0x62, 0x6f, 0x6f, 0x66, 0x77, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x30, 0x01, 0x42, 0x29,
+	0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x61, 0x6d,
+	0x62, 0x6f, 0x6f, 0x2d, 0x66, 0x69, 0x72, 0x65, 0x77, 0x61, 0x6c, 0x6c, 0x2f, 0x62, 0x65, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_agent_proto_rawDescOnce sync.Once
+	file_agent_proto_rawDescData = file_agent_proto_rawDesc
+)
+
+func file_agent_proto_rawDescGZIP() []byte {
+	file_agent_proto_rawDescOnce.Do(func() {
+		file_agent_proto_rawDescData = protoimpl.X.CompressGZIP(file_agent_proto_rawDescData)
+	})
+	return file_agent_proto_rawDescData
+}
+
+var file_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_agent_proto_goTypes = []interface{}{
+	(*FetchRequest)(nil),               // 0: bamboofw.agent.v1.FetchRequest
+	(*WatchRequest)(nil),               // 1: bamboofw.agent.v1.WatchRequest
+	(*HostEndpointPolicy)(nil),         // 2: bamboofw.agent.v1.HostEndpointPolicy
+	(*HostEndpointPolicyDelta)(nil),    // 3: bamboofw.agent.v1.HostEndpointPolicyDelta
+	(*HostEndpointPolicyMetadata)(nil), // 4: bamboofw.agent.v1.HostEndpointPolicyMetadata
+	(*HostEndpoint)(nil),               // 5: bamboofw.agent.v1.HostEndpoint
+	(*ParsedTier)(nil),                 // 6: bamboofw.agent.v1.ParsedTier
+	(*ParsedGNP)(nil),                  // 7: bamboofw.agent.v1.ParsedGNP
+	(*ParsedRule)(nil),                 // 8: bamboofw.agent.v1.ParsedRule
+	(*ParsedHEP)(nil),                  // 9: bamboofw.agent.v1.ParsedHEP
+	(*ParsedGNS)(nil),                  // 10: bamboofw.agent.v1.ParsedGNS
+	nil,                                // 11: bamboofw.agent.v1.HostEndpointPolicyMetadata.HepVersionsEntry
+	nil,                                // 12: bamboofw.agent.v1.HostEndpointPolicyMetadata.GnpVersionsEntry
+	nil,                                // 13: bamboofw.agent.v1.HostEndpointPolicyMetadata.GnsVersionsEntry
+	nil,                                // 14: bamboofw.agent.v1.HostEndpointPolicyMetadata.GnpDigestsEntry
+	nil,                                // 15: bamboofw.agent.v1.HostEndpoint.LabelsEntry
+	(*timestamppb.Timestamp)(nil),      // 16: google.protobuf.Timestamp
+}
+var file_agent_proto_depIdxs = []int32{
+	4,  // 0: bamboofw.agent.v1.WatchRequest.known:type_name -> bamboofw.agent.v1.HostEndpointPolicyMetadata
+	4,  // 1: bamboofw.agent.v1.HostEndpointPolicy.metadata:type_name -> bamboofw.agent.v1.HostEndpointPolicyMetadata
+	5,  // 2: bamboofw.agent.v1.HostEndpointPolicy.host_endpoint:type_name -> bamboofw.agent.v1.HostEndpoint
+	6,  // 3: bamboofw.agent.v1.HostEndpointPolicy.parsed_tiers:type_name -> bamboofw.agent.v1.ParsedTier
+	7,  // 4: bamboofw.agent.v1.HostEndpointPolicy.parsed_gnps:type_name -> bamboofw.agent.v1.ParsedGNP
+	9,  // 5: bamboofw.agent.v1.HostEndpointPolicy.parsed_heps:type_name -> bamboofw.agent.v1.ParsedHEP
+	10, // 6: bamboofw.agent.v1.HostEndpointPolicy.parsed_gnss:type_name -> bamboofw.agent.v1.ParsedGNS
+	4,  // 7: bamboofw.agent.v1.HostEndpointPolicyDelta.metadata:type_name -> bamboofw.agent.v1.HostEndpointPolicyMetadata
+	5,  // 8: bamboofw.agent.v1.HostEndpointPolicyDelta.host_endpoint:type_name -> bamboofw.agent.v1.HostEndpoint
+	6,  // 9: bamboofw.agent.v1.HostEndpointPolicyDelta.parsed_tiers:type_name -> bamboofw.agent.v1.ParsedTier
+	7,  // 10: bamboofw.agent.v1.HostEndpointPolicyDelta.parsed_gnps:type_name -> bamboofw.agent.v1.ParsedGNP
+	9,  // 11: bamboofw.agent.v1.HostEndpointPolicyDelta.parsed_heps:type_name -> bamboofw.agent.v1.ParsedHEP
+	10, // 12: bamboofw.agent.v1.HostEndpointPolicyDelta.parsed_gnss:type_name -> bamboofw.agent.v1.ParsedGNS
+	11, // 13: bamboofw.agent.v1.HostEndpointPolicyMetadata.hep_versions:type_name -> bamboofw.agent.v1.HostEndpointPolicyMetadata.HepVersionsEntry
+	12, // 14: bamboofw.agent.v1.HostEndpointPolicyMetadata.gnp_versions:type_name -> bamboofw.agent.v1.HostEndpointPolicyMetadata.GnpVersionsEntry
+	13, // 15: bamboofw.agent.v1.HostEndpointPolicyMetadata.gns_versions:type_name -> bamboofw.agent.v1.HostEndpointPolicyMetadata.GnsVersionsEntry
+	14, // 16: bamboofw.agent.v1.HostEndpointPolicyMetadata.gnp_digests:type_name -> bamboofw.agent.v1.HostEndpointPolicyMetadata.GnpDigestsEntry
+	15, // 17: bamboofw.agent.v1.HostEndpoint.labels:type_name -> bamboofw.agent.v1.HostEndpoint.LabelsEntry
+	16, // 18: bamboofw.agent.v1.HostEndpoint.created_at:type_name -> google.protobuf.Timestamp
+	16, // 19: bamboofw.agent.v1.HostEndpoint.updated_at:type_name -> google.protobuf.Timestamp
+	8,  // 20: bamboofw.agent.v1.ParsedGNP.inbound_rules:type_name -> bamboofw.agent.v1.ParsedRule
+	8,  // 21: bamboofw.agent.v1.ParsedGNP.outbound_rules:type_name -> bamboofw.agent.v1.ParsedRule
+	0,  // 22: bamboofw.agent.v1.AgentPolicy.Fetch:input_type -> bamboofw.agent.v1.FetchRequest
+	1,  // 23: bamboofw.agent.v1.AgentPolicy.Watch:input_type -> bamboofw.agent.v1.WatchRequest
+	2,  // 24: bamboofw.agent.v1.AgentPolicy.Fetch:output_type -> bamboofw.agent.v1.HostEndpointPolicy
+	3,  // 25: bamboofw.agent.v1.AgentPolicy.Watch:output_type -> bamboofw.agent.v1.HostEndpointPolicyDelta
+	24, // [24:26] is the sub-list for method output_type
+	22, // [22:24] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_agent_proto_init() }
+func file_agent_proto_init() {
+	if File_agent_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_agent_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostEndpointPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostEndpointPolicyDelta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostEndpointPolicyMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostEndpoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParsedTier); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParsedGNP); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParsedRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParsedHEP); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParsedGNS); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_agent_proto_msgTypes[8].OneofWrappers = []interface{}{
+		(*ParsedRule_ProtocolNumber)(nil),
+		(*ParsedRule_ProtocolName)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_agent_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_agent_proto_goTypes,
+		DependencyIndexes: file_agent_proto_depIdxs,
+		MessageInfos:      file_agent_proto_msgTypes,
+	}.Build()
+	File_agent_proto = out.File
+	file_agent_proto_rawDesc = nil
+	file_agent_proto_goTypes = nil
+	file_agent_proto_depIdxs = nil
+}
@@ -0,0 +1,175 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.27.1
+// source: agent.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AgentPolicy_Fetch_FullMethodName = "/bamboofw.agent.v1.AgentPolicy/Fetch"
+	AgentPolicy_Watch_FullMethodName = "/bamboofw.agent.v1.AgentPolicy/Watch"
+)
+
+// AgentPolicyClient is the client API for AgentPolicy service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AgentPolicy serves the policies of host endpoints to the agents. It mirrors the internal http api
+// /api/internal/v1/hostEndpoints/fetchPolicies.
+type AgentPolicyClient interface {
+	// Fetch returns the whole policy of a host endpoint.
+	Fetch(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (*HostEndpointPolicy, error)
+	// Watch sends the policy of a host endpoint as a delta of the known metadata, then a delta of the previous message
+	// every time the policy changes. The stream ends with NOT_FOUND when the host endpoint is deleted.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HostEndpointPolicyDelta], error)
+}
+
+type agentPolicyClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAgentPolicyClient(cc grpc.ClientConnInterface) AgentPolicyClient {
+	return &agentPolicyClient{cc}
+}
+
+func (c *agentPolicyClient) Fetch(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (*HostEndpointPolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HostEndpointPolicy)
+	err := c.cc.Invoke(ctx, AgentPolicy_Fetch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentPolicyClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HostEndpointPolicyDelta], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentPolicy_ServiceDesc.Streams[0], AgentPolicy_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, HostEndpointPolicyDelta]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentPolicy_WatchClient = grpc.ServerStreamingClient[HostEndpointPolicyDelta]
+
+// AgentPolicyServer is the server API for AgentPolicy service.
+// All implementations must embed UnimplementedAgentPolicyServer
+// for forward compatibility.
+//
+// AgentPolicy serves the policies of host endpoints to the agents. It mirrors the internal http api
+// /api/internal/v1/hostEndpoints/fetchPolicies.
+type AgentPolicyServer interface {
+	// Fetch returns the whole policy of a host endpoint.
+	Fetch(context.Context, *FetchRequest) (*HostEndpointPolicy, error)
+	// Watch sends the policy of a host endpoint as a delta of the known metadata, then a delta of the previous message
+	// every time the policy changes. The stream ends with NOT_FOUND when the host endpoint is deleted.
+	Watch(*WatchRequest, grpc.ServerStreamingServer[HostEndpointPolicyDelta]) error
+	mustEmbedUnimplementedAgentPolicyServer()
+}
+
+// UnimplementedAgentPolicyServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAgentPolicyServer struct{}
+
+func (UnimplementedAgentPolicyServer) Fetch(context.Context, *FetchRequest) (*HostEndpointPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fetch not implemented")
+}
+func (UnimplementedAgentPolicyServer) Watch(*WatchRequest, grpc.ServerStreamingServer[HostEndpointPolicyDelta]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedAgentPolicyServer) mustEmbedUnimplementedAgentPolicyServer() {}
+func (UnimplementedAgentPolicyServer) testEmbeddedByValue()                     {}
+
+// UnsafeAgentPolicyServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AgentPolicyServer will
+// result in compilation errors.
+type UnsafeAgentPolicyServer interface {
+	mustEmbedUnimplementedAgentPolicyServer()
+}
+
+func RegisterAgentPolicyServer(s grpc.ServiceRegistrar, srv AgentPolicyServer) {
+	// If the following call pancis, it indicates UnimplementedAgentPolicyServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AgentPolicy_ServiceDesc, srv)
+}
+
+func _AgentPolicy_Fetch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentPolicyServer).Fetch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentPolicy_Fetch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentPolicyServer).Fetch(ctx, req.(*FetchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentPolicy_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentPolicyServer).Watch(m, &grpc.GenericServerStream[WatchRequest, HostEndpointPolicyDelta]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentPolicy_WatchServer = grpc.ServerStreamingServer[HostEndpointPolicyDelta]
+
+// AgentPolicy_ServiceDesc is the grpc.ServiceDesc for AgentPolicy service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AgentPolicy_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bamboofw.agent.v1.AgentPolicy",
+	HandlerType: (*AgentPolicyServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Fetch",
+			Handler:    _AgentPolicy_Fetch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _AgentPolicy_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "agent.proto",
+}
@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bamboo-firewall/be/api/v1/mapper"
+	"github.com/bamboo-firewall/be/api/v1/pb"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	"github.com/bamboo-firewall/be/pkg/net"
+)
+
+type hepService interface {
+	FetchPolicies(ctx context.Context, input *model.ListHostEndpointsInput) ([]*model.HostEndpointPolicy, *ierror.Error)
+	WatchPolicyDelta(ctx context.Context, input *model.FetchHostEndpointPolicyDeltaInput, send func(*model.HostEndpointPolicyDelta) error) *ierror.Error
+}
+
+func NewAgentPolicy(s hepService) *agentPolicy {
+	return &agentPolicy{
+		service: s,
+	}
+}
+
+// agentPolicy serves the same policies as the http handler FetchPolicies of the host endpoints over grpc.
+type agentPolicy struct {
+	pb.UnimplementedAgentPolicyServer
+	service hepService
+}
+
+func (a *agentPolicy) Fetch(ctx context.Context, req *pb.FetchRequest) (*pb.HostEndpointPolicy, error) {
+	ip, err := parseIP(req.GetIp())
+	if err != nil {
+		return nil, err
+	}
+	tenantID := req.GetTenantId()
+	hostEndpointPolicies, ierr := a.service.FetchPolicies(ctx, &model.ListHostEndpointsInput{
+		TenantID: &tenantID,
+		IP:       &ip,
+	})
+	if ierr != nil {
+		return nil, toStatus(ierr)
+	}
+	if len(hostEndpointPolicies) == 0 {
+		return nil, status.Error(codes.NotFound, "host endpoint not found")
+	}
+	return mapper.ToPBHostEndpointPolicy(hostEndpointPolicies[0]), nil
+}
+
+func (a *agentPolicy) Watch(req *pb.WatchRequest, stream pb.AgentPolicy_WatchServer) error {
+	ip, err := parseIP(req.GetIp())
+	if err != nil {
+		return err
+	}
+	input := &model.FetchHostEndpointPolicyDeltaInput{
+		TenantID: req.GetTenantId(),
+		IP:       ip,
+		Known:    mapper.FromPBHostEndpointPolicyMetadata(req.GetKnown()),
+	}
+	ierr := a.service.WatchPolicyDelta(stream.Context(), input, func(delta *model.HostEndpointPolicyDelta) error {
+		return stream.Send(mapper.ToPBHostEndpointPolicyDelta(delta))
+	})
+	if ierr != nil {
+		return toStatus(ierr)
+	}
+	return nil
+}
+
+func parseIP(ip string) (uint32, error) {
+	netIP := net.ParseIP(ip)
+	if netIP == nil {
+		return 0, status.Errorf(codes.InvalidArgument, "malformed ip %q", ip)
+	}
+	return net.IPToInt(*netIP), nil
+}
@@ -0,0 +1,215 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/bamboo-firewall/be/api/v1/pb"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/agentclient"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	bbnet "github.com/bamboo-firewall/be/pkg/net"
+)
+
+const (
+	testToken    = "token"
+	testTenantID = 1
+	testIP       = "10.0.0.1"
+)
+
+// fakeHEPService has the policy of the host endpoint testIP of testTenantID, the enrollment token testToken allows
+// its tenant only.
+type fakeHEPService struct{}
+
+func (fakeHEPService) FetchPolicies(_ context.Context, input *model.ListHostEndpointsInput) ([]*model.HostEndpointPolicy, *ierror.Error) {
+	if *input.TenantID != testTenantID || *input.IP != testIPInt() {
+		return nil, nil
+	}
+	return []*model.HostEndpointPolicy{testPolicy(1)}, nil
+}
+
+func (fakeHEPService) WatchPolicyDelta(ctx context.Context, input *model.FetchHostEndpointPolicyDeltaInput,
+	send func(*model.HostEndpointPolicyDelta) error) *ierror.Error {
+	if input.IP != testIPInt() {
+		return httpbase.ErrNotFound(ctx, "not found")
+	}
+	for revision := input.Known.Revision + 1; revision <= input.Known.Revision+2; revision++ {
+		policy := testPolicy(revision)
+		if err := send(&model.HostEndpointPolicyDelta{MetaData: policy.MetaData, HEP: policy.HEP}); err != nil {
+			return httpbase.ErrInternal(ctx, err.Error())
+		}
+	}
+	return nil
+}
+
+func (fakeHEPService) AuthorizeAgent(ctx context.Context, token string, tenantID uint64, _ string) *ierror.Error {
+	if token != testToken {
+		return httpbase.ErrUnauthorized(ctx, "invalid enrollment token")
+	}
+	if tenantID != testTenantID {
+		return httpbase.ErrForbidden(ctx, "tenant is not allowed by the enrollment token")
+	}
+	return nil
+}
+
+func testIPInt() uint32 {
+	return bbnet.IPToInt(*bbnet.ParseIP(testIP))
+}
+
+func testPolicy(revision uint64) *model.HostEndpointPolicy {
+	return &model.HostEndpointPolicy{
+		MetaData: model.HostEndpointPolicyMetadata{Revision: revision},
+		HEP: &entity.HostEndpoint{
+			UUID:     "web",
+			Metadata: entity.HostEndpointMetadata{Name: "web"},
+			Spec:     entity.HostEndpointSpec{TenantID: testTenantID, IP: testIPInt(), IPs: []string{testIP}},
+		},
+	}
+}
+
+// newTestClient returns a client of the agent api served over an in-memory connection.
+func newTestClient(t *testing.T, opts ...grpc.DialOption) *agentclient.Client {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	service := fakeHEPService{}
+	auth := NewAgentAuth(service)
+	server := grpc.NewServer(grpc.UnaryInterceptor(auth.Unary), grpc.StreamInterceptor(auth.Stream))
+	pb.RegisterAgentPolicyServer(server, NewAgentPolicy(service))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	opts = append(opts, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}))
+	client, err := agentclient.New("passthrough:///bufconn", opts...)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestFetch(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []grpc.DialOption
+		tenantID uint64
+		ip       string
+		wantCode codes.Code
+	}{
+		{
+			name:     "fetched",
+			opts:     []grpc.DialOption{agentclient.WithToken(testToken)},
+			tenantID: testTenantID,
+			ip:       testIP,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "missing token",
+			tenantID: testTenantID,
+			ip:       testIP,
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "invalid token",
+			opts:     []grpc.DialOption{agentclient.WithToken("other")},
+			tenantID: testTenantID,
+			ip:       testIP,
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "tenant not allowed",
+			opts:     []grpc.DialOption{agentclient.WithToken(testToken)},
+			tenantID: 2,
+			ip:       testIP,
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "malformed ip",
+			opts:     []grpc.DialOption{agentclient.WithToken(testToken)},
+			tenantID: testTenantID,
+			ip:       "web",
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "not found",
+			opts:     []grpc.DialOption{agentclient.WithToken(testToken)},
+			tenantID: testTenantID,
+			ip:       "10.0.0.2",
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, tt.opts...)
+			policy, err := client.Fetch(context.Background(), tt.tenantID, tt.ip)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("Fetch() code = %v, want %v, err: %v", code, tt.wantCode, err)
+			}
+			if err == nil && policy.GetHostEndpoint().GetIp() != testIP {
+				t.Errorf("Fetch() host endpoint ip = %q, want %q", policy.GetHostEndpoint().GetIp(), testIP)
+			}
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	client := newTestClient(t, agentclient.WithToken(testToken))
+	var revisions []uint64
+	err := client.Watch(context.Background(), testTenantID, testIP, &pb.HostEndpointPolicyMetadata{Revision: 3},
+		func(delta *pb.HostEndpointPolicyDelta) error {
+			revisions = append(revisions, delta.GetMetadata().GetRevision())
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("Watch() error: %v", err)
+	}
+	if len(revisions) != 2 || revisions[0] != 4 || revisions[1] != 5 {
+		t.Errorf("Watch() received revisions %v, want [4 5]", revisions)
+	}
+}
+
+func TestWatchUnauthorized(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []grpc.DialOption
+		tenantID uint64
+		wantCode codes.Code
+	}{
+		{
+			name:     "missing token",
+			tenantID: testTenantID,
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "tenant not allowed",
+			opts:     []grpc.DialOption{agentclient.WithToken(testToken)},
+			tenantID: 2,
+			wantCode: codes.PermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, tt.opts...)
+			handled := false
+			err := client.Watch(context.Background(), tt.tenantID, testIP, nil, func(*pb.HostEndpointPolicyDelta) error {
+				handled = true
+				return nil
+			})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("Watch() code = %v, want %v, err: %v", code, tt.wantCode, err)
+			}
+			if handled {
+				t.Error("Watch() received a delta while not authorized")
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+// MetadataAuthorization is the metadata key of the enrollment token of an agent, as "Bearer <token>".
+const MetadataAuthorization = "authorization"
+
+const bearerPrefix = "Bearer "
+
+type agentAuthorizer interface {
+	AuthorizeAgent(ctx context.Context, token string, tenantID uint64, ip string) *ierror.Error
+}
+
+// agentRequest is a request for the policy of a host endpoint.
+type agentRequest interface {
+	GetTenantId() uint64
+	GetIp() string
+}
+
+// NewAgentAuth returns the interceptors which only let an agent presenting an enrollment token get the policy of the
+// host endpoints the token allows to register.
+func NewAgentAuth(s agentAuthorizer) *agentAuth {
+	return &agentAuth{
+		service: s,
+	}
+}
+
+type agentAuth struct {
+	service agentAuthorizer
+}
+
+func (a *agentAuth) Unary(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.authorize(ctx, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *agentAuth) Stream(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &authorizedStream{ServerStream: stream, auth: a})
+}
+
+func (a *agentAuth) authorize(ctx context.Context, req interface{}) error {
+	token, err := bearerToken(ctx)
+	if err != nil {
+		return err
+	}
+	r, ok := req.(agentRequest)
+	if !ok {
+		return status.Error(codes.PermissionDenied, "request is not allowed by the enrollment token")
+	}
+	if ierr := a.service.AuthorizeAgent(ctx, token, r.GetTenantId(), r.GetIp()); ierr != nil {
+		return toStatus(ierr)
+	}
+	return nil
+}
+
+// authorizedStream authorizes each request received on the stream.
+type authorizedStream struct {
+	grpc.ServerStream
+	auth *agentAuth
+}
+
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.auth.authorize(s.Context(), m)
+}
+
+func bearerToken(ctx context.Context) (string, error) {
+	values := metadata.ValueFromIncomingContext(ctx, MetadataAuthorization)
+	if len(values) == 0 || !strings.HasPrefix(values[0], bearerPrefix) {
+		return "", status.Error(codes.Unauthenticated, "missing enrollment token")
+	}
+	return strings.TrimPrefix(values[0], bearerPrefix), nil
+}
@@ -0,0 +1,28 @@
+package rpc
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+// toStatus converts an error of the service layer to a grpc status by its http status.
+func toStatus(ierr *ierror.Error) error {
+	code := codes.Internal
+	switch ierr.HTTPStatusCode {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.AlreadyExists
+	}
+	return status.Error(code, ierr.Error())
+}
@@ -0,0 +1,131 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/cmd/bamboofwcli/command/common"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/client"
+)
+
+var backupOutput string
+
+var backupCMD = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up the policy database",
+	Long: `The backup command writes a consistent snapshot of the policy database as a JSON bundle: tenants, tiers,
+host endpoints, sets and policies. The bundle holds its schema version, so that it can be restored by later
+versions.`,
+	Example: `  # Back up to bundle.json
+  bbfw backup > bundle.json
+
+  # Back up to a file
+  bbfw backup -o bundle.json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runBackup(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var (
+	restoreFile string
+	restoreMode string
+)
+
+var restoreCMD = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore the policy database from a backup bundle",
+	Long: `The restore command writes the resources of a bundle written by backup, in a single transaction.
+A resource conflicts with a stored one of the same uuid or name. The mode decides how conflicts are handled:
+  fail       restore nothing if any resource conflicts (default)
+  skip       keep the stored resources
+  overwrite  replace the stored resources`,
+	Example: `  # Restore into an empty database
+  bbfw restore -f bundle.json
+
+  # Restore over the stored resources
+  bbfw restore -f bundle.json --mode overwrite`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runRestore(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	backupCMD.Flags().StringVarP(&backupOutput, "output", "o", "", "file to write the bundle to, stdout if not set")
+
+	restoreCMD.Flags().StringVarP(&restoreFile, "filename", "f", "", "bundle file written by backup")
+	restoreCMD.Flags().StringVar(&restoreMode, "mode", model.RestoreModeFail, "conflict mode: fail, skip or overwrite")
+	restoreCMD.MarkFlagRequired("filename")
+}
+
+func runBackup() error {
+	apiServer := client.NewAPIServer(os.Getenv(common.APIServerENV))
+	bundle, err := apiServer.Backup(context.Background())
+	if err != nil {
+		return err
+	}
+	var out bytes.Buffer
+	if err = json.Indent(&out, bundle, "", "  "); err != nil {
+		return fmt.Errorf("invalid bundle: %w", err)
+	}
+	out.WriteByte('\n')
+
+	if backupOutput == "" {
+		_, err = os.Stdout.Write(out.Bytes())
+		return err
+	}
+	if err = os.WriteFile(backupOutput, out.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	return nil
+}
+
+func runRestore() error {
+	bundle, err := os.ReadFile(restoreFile)
+	if err != nil {
+		return fmt.Errorf("read bundle: %w", err)
+	}
+	if !json.Valid(bundle) {
+		return fmt.Errorf("bundle %s is not valid json", restoreFile)
+	}
+
+	apiServer := client.NewAPIServer(os.Getenv(common.APIServerENV))
+	output, err := apiServer.Restore(context.Background(), &dto.RestoreInput{
+		Mode:   restoreMode,
+		Bundle: bundle,
+	})
+	if err != nil {
+		return err
+	}
+	return printRestoreResults(output.Results)
+}
+
+func printRestoreResults(results []*dto.RestoreResult) error {
+	tmpl, err := template.New("restore").Parse("KIND\tCREATED\tOVERWRITTEN\tSKIPPED\t\n" +
+		"{{range .}}{{.Kind}}\t{{.Created}}\t{{.Overwritten}}\t{{.Skipped}}\t\n{{end}}")
+	if err != nil {
+		return fmt.Errorf("parse restore template: %w", err)
+	}
+	writer := tabwriter.NewWriter(os.Stdout, 5, 1, 3, ' ', 0)
+	if err = tmpl.Execute(writer, results); err != nil {
+		return fmt.Errorf("execute restore template: %w", err)
+	}
+	writer.Flush()
+	return nil
+}
@@ -29,6 +29,24 @@ func GetResourceMgrByType(resourceType string) (resourcemanager.Resource, error)
 		return resourcemanager.NewGNS(), nil
 	case "globalnetworkpolicy", "gnp":
 		return resourcemanager.NewGNP(), nil
+	case "tier":
+		return resourcemanager.NewTier(), nil
+	case "networkpolicy", "np":
+		return resourcemanager.NewNP(), nil
+	case "networkset", "ns":
+		return resourcemanager.NewNS(), nil
+	case "tenant":
+		return resourcemanager.NewTenant(), nil
+	case "enrollmenttoken", "et":
+		return resourcemanager.NewEnrollmentToken(), nil
+	case "agent", "agents":
+		return resourcemanager.NewAgent(), nil
+	case "webhook", "webhooks", "wh":
+		return resourcemanager.NewWebhook(), nil
+	case "webhookdelivery", "webhookdeliveries", "webhook-delivery", "webhook-deliveries":
+		return resourcemanager.NewWebhookDelivery(), nil
+	case "admissionhook", "admissionhooks", "ah":
+		return resourcemanager.NewAdmissionHook(), nil
 	default:
 		return nil, fmt.Errorf("unknown resource type: %s", resourceType)
 	}
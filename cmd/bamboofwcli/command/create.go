@@ -2,8 +2,12 @@ package command
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"text/tabwriter"
+	"text/template"
 
 	"github.com/spf13/cobra"
 
@@ -11,9 +15,14 @@ import (
 	"github.com/bamboo-firewall/be/cmd/bamboofwcli/command/common"
 	"github.com/bamboo-firewall/be/cmd/bamboofwcli/command/resourcemanager"
 	"github.com/bamboo-firewall/be/pkg/client"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
 )
 
-var fileCreates []string
+var (
+	fileCreates  []string
+	createNoBulk bool
+)
 
 var createCMD = &cobra.Command{
 	Use:   "create [resourceType]",
@@ -23,12 +32,25 @@ var createCMD = &cobra.Command{
   Resource type available:
     * HostEndpoint(or hep)
     * GlobalNetworkSet(or gns)
-    * GlobalNetworkPolicy(or gnp)`,
+    * GlobalNetworkPolicy(or gnp)
+    * Tier
+    * NetworkPolicy(or np)
+    * NetworkSet(or ns)
+    * Tenant
+    * EnrollmentToken(or et)
+    * Webhook(or wh)
+    * AdmissionHook(or ah)
+
+  Host endpoints, global network sets and global network policies are created in one bulk request: either all of
+  them are applied or none is. Use --no-bulk to create them one by one instead.`,
 	Example: `  # Create a global network policy
   bbfw create gnp -f policy.yaml
 
   # Create many global network policy
-  bbfw create gnp -f policy1.yaml -f policy2.yaml`,
+  bbfw create gnp -f policy1.yaml -f policy2.yaml
+
+  # Create many global network policy one by one, keeping the ones that succeed
+  bbfw create gnp -f policy1.yaml -f policy2.yaml --no-bulk`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := create(cmd, args); err != nil {
@@ -40,6 +62,7 @@ var createCMD = &cobra.Command{
 
 func init() {
 	createCMD.Flags().StringArrayVarP(&fileCreates, "file", "f", []string{}, "file to read")
+	createCMD.Flags().BoolVar(&createNoBulk, "no-bulk", false, "create host endpoints, sets and policies one by one instead of in one bulk request")
 	createCMD.MarkFlagRequired("file")
 }
 
@@ -58,6 +81,20 @@ func create(cmd *cobra.Command, args []string) error {
 		resources, err = common.GetResourceFilesByFileNames[dto.CreateGlobalNetworkSetInput](fileCreates)
 	case resourcemanager.ResourceTypeGNP:
 		resources, err = common.GetResourceFilesByFileNames[dto.CreateGlobalNetworkPolicyInput](fileCreates)
+	case resourcemanager.ResourceTypeTier:
+		resources, err = common.GetResourceFilesByFileNames[dto.CreateTierInput](fileCreates)
+	case resourcemanager.ResourceTypeNP:
+		resources, err = common.GetResourceFilesByFileNames[dto.CreateNetworkPolicyInput](fileCreates)
+	case resourcemanager.ResourceTypeNS:
+		resources, err = common.GetResourceFilesByFileNames[dto.CreateNetworkSetInput](fileCreates)
+	case resourcemanager.ResourceTypeTenant:
+		resources, err = common.GetResourceFilesByFileNames[dto.CreateTenantInput](fileCreates)
+	case resourcemanager.ResourceTypeEnrollmentToken:
+		resources, err = common.GetResourceFilesByFileNames[dto.CreateEnrollmentTokenInput](fileCreates)
+	case resourcemanager.ResourceTypeWebhook:
+		resources, err = common.GetResourceFilesByFileNames[dto.CreateWebhookInput](fileCreates)
+	case resourcemanager.ResourceTypeAdmissionHook:
+		resources, err = common.GetResourceFilesByFileNames[dto.CreateAdmissionHookInput](fileCreates)
 	default:
 		return fmt.Errorf("unsupported resource type: %s", resourceType)
 	}
@@ -66,6 +103,10 @@ func create(cmd *cobra.Command, args []string) error {
 	}
 
 	apiServer := client.NewAPIServer(os.Getenv(common.APIServerENV))
+	if kind, ok := bulkKinds[resourceMgr.GetResourceType()]; ok && !createNoBulk {
+		return createBulk(apiServer, kind, resources)
+	}
+
 	var numHandled int
 	for _, r := range resources {
 		err = resourceMgr.Create(context.Background(), apiServer, r.FilePath, r.Content)
@@ -80,3 +121,95 @@ func create(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Total: %d resources. Success: %d. Fail: %d.\n", len(resources), numHandled, len(resources)-numHandled)
 	return nil
 }
+
+var bulkKinds = map[resourcemanager.ResourceType]string{
+	resourcemanager.ResourceTypeHEP: entity.WebhookKindHostEndpoint,
+	resourcemanager.ResourceTypeGNS: entity.WebhookKindGlobalNetworkSet,
+	resourcemanager.ResourceTypeGNP: entity.WebhookKindGlobalNetworkPolicy,
+}
+
+func createBulk(apiServer resourcemanager.APIServer, kind string, resources []*common.ResourceFile) error {
+	input := &dto.BulkApplyInput{
+		Items: make([]*dto.BulkItemInput, 0, len(resources)),
+	}
+	for _, r := range resources {
+		switch content := r.Content.(type) {
+		case *dto.CreateHostEndpointInput:
+			content.FilePath = r.FilePath
+		case *dto.CreateGlobalNetworkSetInput:
+			content.FilePath = r.FilePath
+		case *dto.CreateGlobalNetworkPolicyInput:
+			content.FilePath = r.FilePath
+		}
+		resource, err := json.Marshal(r.Content)
+		if err != nil {
+			return fmt.Errorf("marshal resource %s: %w", r.Name, err)
+		}
+		input.Items = append(input.Items, &dto.BulkItemInput{
+			Operation: dto.BulkOperationUpsert,
+			Kind:      kind,
+			Resource:  resource,
+		})
+	}
+
+	output, err := apiServer.BulkApply(context.Background(), input)
+	if err != nil {
+		var ierr *ierror.Error
+		if !errors.As(err, &ierr) {
+			return err
+		}
+		var results []*dto.BulkItemResult
+		data, marshalErr := json.Marshal(ierr.Detail)
+		if marshalErr == nil {
+			marshalErr = json.Unmarshal(data, &results)
+		}
+		if marshalErr != nil || len(results) == 0 {
+			return err
+		}
+		if err = printBulkResults(resources, results); err != nil {
+			return err
+		}
+		fmt.Printf("Total: %d resources. None created: %s.\n", len(resources), ierr.Message)
+		return nil
+	}
+	if err = printBulkResults(resources, output.Items); err != nil {
+		return err
+	}
+	fmt.Printf("Total: %d resources. Success: %d. Fail: 0.\n", len(resources), len(resources))
+	return nil
+}
+
+// printBulkResults prints the result of each item of a bulk request along with the file it was read from.
+func printBulkResults(resources []*common.ResourceFile, results []*dto.BulkItemResult) error {
+	type row struct {
+		File   string
+		Name   string
+		Action string
+		Error  string
+	}
+	rows := make([]row, 0, len(results))
+	for _, result := range results {
+		var file string
+		if result.Index >= 0 && result.Index < len(resources) {
+			file = resources[result.Index].Name
+		}
+		rows = append(rows, row{
+			File:   file,
+			Name:   result.Name,
+			Action: result.Action,
+			Error:  result.Error,
+		})
+	}
+
+	tmpl, err := template.New("bulk").Parse("FILE\tNAME\tACTION\tERROR\t\n" +
+		"{{range .}}{{.File}}\t{{.Name}}\t{{.Action}}\t{{.Error}}\t\n{{end}}")
+	if err != nil {
+		return fmt.Errorf("parse bulk template: %w", err)
+	}
+	writer := tabwriter.NewWriter(os.Stdout, 5, 1, 3, ' ', 0)
+	if err = tmpl.Execute(writer, rows); err != nil {
+		return fmt.Errorf("execute bulk template: %w", err)
+	}
+	writer.Flush()
+	return nil
+}
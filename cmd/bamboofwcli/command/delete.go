@@ -2,8 +2,11 @@ package command
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
@@ -11,12 +14,15 @@ import (
 	"github.com/bamboo-firewall/be/cmd/bamboofwcli/command/common"
 	"github.com/bamboo-firewall/be/cmd/bamboofwcli/command/resourcemanager"
 	"github.com/bamboo-firewall/be/pkg/client"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
 )
 
 var (
 	deleteHEPByTenantID uint64
 	deleteHEPByIP       string
 	fileDeletes         []string
+	deleteForce         bool
 )
 
 var deleteCMD = &cobra.Command{
@@ -28,7 +34,14 @@ by tenantID,IP(Host Endpoint) or filename.
   Resource type available:
     * HostEndpoint(or hep)
     * GlobalNetworkSet(or gns)
-    * GlobalNetworkPolicy(or gnp)`,
+    * GlobalNetworkPolicy(or gnp)
+    * Tier
+    * NetworkPolicy(or np)
+    * NetworkSet(or ns)
+    * Tenant
+    * EnrollmentToken(or et)
+    * Webhook(or wh)
+    * AdmissionHook(or ah)`,
 	Example: `  # Delete a policy with name
   bbfw delete gnp allow_ssh
 
@@ -47,11 +60,23 @@ by tenantID,IP(Host Endpoint) or filename.
   # Delete many sets with filename
   bbfw delete gns -f server.yaml -f vm.yaml
 
+  # Delete a tier with name
+  bbfw delete tier security
+
+  # Delete a tenant with id
+  bbfw delete tenant 2
+
   # Delete a hep with tenantID and ip
   bbfw delete hep --tenantID=1 --ip=192.168.1.1
 
   # Delete many heps with filename
   bbfw delete hep -f server.yaml -f vm.yaml
+
+  # Delete a network policy of tenant 2 with name
+  bbfw delete np allow_web --tenantID=2
+
+  # Delete a set even if it is the last match of policy selectors
+  bbfw delete gns server --force
 `,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
@@ -63,9 +88,10 @@ by tenantID,IP(Host Endpoint) or filename.
 }
 
 func init() {
-	deleteCMD.Flags().Uint64Var(&deleteHEPByTenantID, "tenantID", 0, "HEP: get by tenantID")
+	deleteCMD.Flags().Uint64Var(&deleteHEPByTenantID, "tenantID", 0, "HEP, NP, NS: delete by tenantID")
 	deleteCMD.Flags().StringVar(&deleteHEPByIP, "ip", "", "HEP: get by ip")
 	deleteCMD.Flags().StringArrayVarP(&fileDeletes, "file", "f", []string{}, "file to read")
+	deleteCMD.Flags().BoolVar(&deleteForce, "force", false, "HEP, GNS, NS: delete even if policy selectors lose their last match")
 }
 
 func deleteResources(cmd *cobra.Command, args []string) error {
@@ -101,6 +127,20 @@ func deleteResources(cmd *cobra.Command, args []string) error {
 			resources, err = common.GetResourceFilesByFileNames[dto.DeleteGlobalNetworkSetInput](fileDeletes)
 		case resourcemanager.ResourceTypeGNP:
 			resources, err = common.GetResourceFilesByFileNames[dto.DeleteGlobalNetworkPolicyInput](fileDeletes)
+		case resourcemanager.ResourceTypeTier:
+			resources, err = common.GetResourceFilesByFileNames[dto.DeleteTierInput](fileDeletes)
+		case resourcemanager.ResourceTypeNP:
+			resources, err = common.GetResourceFilesByFileNames[dto.DeleteNetworkPolicyInput](fileDeletes)
+		case resourcemanager.ResourceTypeNS:
+			resources, err = common.GetResourceFilesByFileNames[dto.DeleteNetworkSetInput](fileDeletes)
+		case resourcemanager.ResourceTypeTenant:
+			resources, err = common.GetResourceFilesByFileNames[dto.DeleteTenantInput](fileDeletes)
+		case resourcemanager.ResourceTypeEnrollmentToken:
+			resources, err = common.GetResourceFilesByFileNames[dto.DeleteEnrollmentTokenInput](fileDeletes)
+		case resourcemanager.ResourceTypeWebhook:
+			resources, err = common.GetResourceFilesByFileNames[dto.DeleteWebhookInput](fileDeletes)
+		case resourcemanager.ResourceTypeAdmissionHook:
+			resources, err = common.GetResourceFilesByFileNames[dto.DeleteAdmissionHookInput](fileDeletes)
 		default:
 			return fmt.Errorf("unsupported resource type: %s", resourceType)
 		}
@@ -129,6 +169,75 @@ func deleteResources(cmd *cobra.Command, args []string) error {
 						},
 					},
 				})
+			case resourcemanager.ResourceTypeTier:
+				resources = append(resources, &common.ResourceFile{
+					Name: name,
+					Content: &dto.DeleteTierInput{
+						Metadata: dto.TierMetadataInput{
+							Name: name,
+						},
+					},
+				})
+			case resourcemanager.ResourceTypeNP:
+				resources = append(resources, &common.ResourceFile{
+					Name: name,
+					Content: &dto.DeleteNetworkPolicyInput{
+						Metadata: dto.NPMetadataInput{
+							Name:     name,
+							TenantID: deleteHEPByTenantID,
+						},
+					},
+				})
+			case resourcemanager.ResourceTypeNS:
+				resources = append(resources, &common.ResourceFile{
+					Name: name,
+					Content: &dto.DeleteNetworkSetInput{
+						Metadata: dto.NSMetadataInput{
+							Name:     name,
+							TenantID: deleteHEPByTenantID,
+						},
+					},
+				})
+			case resourcemanager.ResourceTypeEnrollmentToken:
+				resources = append(resources, &common.ResourceFile{
+					Name: name,
+					Content: &dto.DeleteEnrollmentTokenInput{
+						Metadata: dto.EnrollmentTokenMetadataInput{
+							Name: name,
+						},
+					},
+				})
+			case resourcemanager.ResourceTypeWebhook:
+				resources = append(resources, &common.ResourceFile{
+					Name: name,
+					Content: &dto.DeleteWebhookInput{
+						Metadata: dto.WebhookMetadataInput{
+							Name: name,
+						},
+					},
+				})
+			case resourcemanager.ResourceTypeAdmissionHook:
+				resources = append(resources, &common.ResourceFile{
+					Name: name,
+					Content: &dto.DeleteAdmissionHookInput{
+						Metadata: dto.AdmissionHookMetadataInput{
+							Name: name,
+						},
+					},
+				})
+			case resourcemanager.ResourceTypeTenant:
+				tenantID, errParse := strconv.ParseUint(name, 10, 64)
+				if errParse != nil || tenantID == 0 {
+					return fmt.Errorf("invalid tenant id: %s", name)
+				}
+				resources = append(resources, &common.ResourceFile{
+					Name: name,
+					Content: &dto.DeleteTenantInput{
+						Spec: dto.DeleteTenantSpecInput{
+							TenantID: tenantID,
+						},
+					},
+				})
 			default:
 				return fmt.Errorf("unsupported resource type: %s", resourceType)
 			}
@@ -151,9 +260,23 @@ func deleteResources(cmd *cobra.Command, args []string) error {
 	apiServer := client.NewAPIServer(os.Getenv(common.APIServerENV))
 	var numHandled int
 	for _, r := range resources {
+		switch content := r.Content.(type) {
+		case *dto.DeleteHostEndpointInput:
+			content.Force = deleteForce
+		case *dto.DeleteGlobalNetworkSetInput:
+			content.Force = deleteForce
+		case *dto.DeleteNetworkSetInput:
+			content.Force = deleteForce
+		}
 		err = resourceMgr.Delete(context.Background(), apiServer, r.Content)
 		if err != nil {
-			fmt.Printf("fail to delete resource %s from: %v\n", r.Name, err)
+			var ierr *ierror.Error
+			if errors.As(err, &ierr) && ierr.Code == httpbase.ErrorCodeConflict {
+				fmt.Printf("fail to delete resource %s: %s\n", r.Name, ierr.Message)
+				printSelectorReferences(ierr.Detail)
+			} else {
+				fmt.Printf("fail to delete resource %s from: %v\n", r.Name, err)
+			}
 		} else {
 			fmt.Printf("successsfully deleted resource from %s\n", r.Name)
 			numHandled++
@@ -163,3 +286,19 @@ func deleteResources(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Total: %d resources. Success: %d. Fail: %d.\n", len(resources), numHandled, len(resources)-numHandled)
 	return nil
 }
+
+// printSelectorReferences prints the selectors detailed by a conflict error.
+func printSelectorReferences(detail interface{}) {
+	var refs []*dto.SelectorReference
+	data, err := json.Marshal(detail)
+	if err == nil {
+		err = json.Unmarshal(data, &refs)
+	}
+	if err != nil {
+		fmt.Printf("%v\n", detail)
+		return
+	}
+	for _, ref := range refs {
+		fmt.Printf("  policy %s %s: %s\n", ref.GNPName, ref.Field, ref.Selector)
+	}
+}
@@ -0,0 +1,125 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/cmd/bamboofwcli/command/common"
+	"github.com/bamboo-firewall/be/pkg/client"
+)
+
+var (
+	explainHEP    string
+	explainLabels map[string]string
+)
+
+var explainCMD = &cobra.Command{
+	Use:   "explain selector [expression]",
+	Short: "Explain why a host endpoint does or does not match a selector",
+	Long: `The explain command evaluates a selector against the labels of a host endpoint, or against labels,
+and prints whether each sub-expression is true or false.`,
+	Example: `  # Explain a selector for the host endpoint 192.168.1.1 of tenant 1
+  bbfw explain selector 'role == "db" && env in {"prod", "staging"}' --hep 1/192.168.1.1
+
+  # Explain a selector for the host endpoint 192.168.1.1 of the default tenant
+  bbfw explain selector 'has(role)' --hep 192.168.1.1
+
+  # Explain a selector for labels
+  bbfw explain selector 'role == "db"' --labels role=web,env=prod`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := explain(cmd, args); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	explainCMD.Flags().StringVar(&explainHEP, "hep", "", "host endpoint as tenantID/ip or ip of the default tenant")
+	explainCMD.Flags().StringToStringVar(&explainLabels, "labels", nil, "labels as key=value pairs")
+}
+
+func explain(cmd *cobra.Command, args []string) error {
+	if args[0] != "selector" {
+		return fmt.Errorf("unsupported explain type: %s", args[0])
+	}
+	input := &dto.ExplainSelectorInput{
+		Selector: args[1],
+	}
+	hepChanged, labelsChanged := cmd.Flags().Changed("hep"), cmd.Flags().Changed("labels")
+	switch {
+	case hepChanged && labelsChanged:
+		return fmt.Errorf("cannot use hep with labels")
+	case hepChanged:
+		hepReference, err := parseHEPReference(explainHEP)
+		if err != nil {
+			return err
+		}
+		input.HEP = hepReference
+	case labelsChanged:
+		input.Labels = explainLabels
+		if input.Labels == nil {
+			input.Labels = map[string]string{}
+		}
+	default:
+		return fmt.Errorf("must specify hep or labels")
+	}
+
+	apiServer := client.NewAPIServer(os.Getenv(common.APIServerENV))
+	output, err := apiServer.ExplainSelector(context.Background(), input)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Selector: %s\n", output.Selector)
+	if output.HEP != nil {
+		fmt.Printf("Host endpoint: %s (tenantID %d, ip %s)\n", output.HEP.Name, output.HEP.TenantID, output.HEP.IP)
+	}
+	labelPairs := make([]string, 0, len(output.Labels))
+	for key, value := range output.Labels {
+		labelPairs = append(labelPairs, fmt.Sprintf("%s=%s", key, value))
+	}
+	slices.Sort(labelPairs)
+	fmt.Printf("Labels: %s\n", strings.Join(labelPairs, ", "))
+	if output.Matched {
+		fmt.Printf("Result: match\n")
+	} else {
+		fmt.Printf("Result: no match\n")
+	}
+	printSelectorTrace(output.Trace, 0)
+	return nil
+}
+
+func parseHEPReference(s string) (*dto.HEPReferenceInput, error) {
+	tenant, ip, found := strings.Cut(s, "/")
+	if !found {
+		return &dto.HEPReferenceInput{IP: s}, nil
+	}
+	tenantID, err := strconv.ParseUint(tenant, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenantID %q of hep %s", tenant, s)
+	}
+	return &dto.HEPReferenceInput{TenantID: tenantID, IP: ip}, nil
+}
+
+func printSelectorTrace(trace *dto.SelectorTrace, depth int) {
+	if trace == nil {
+		return
+	}
+	line := fmt.Sprintf("%s[%t] %s", strings.Repeat("  ", depth), trace.Result, trace.Expression)
+	if trace.Reason != "" {
+		line += fmt.Sprintf("  (%s)", trace.Reason)
+	}
+	fmt.Println(line)
+	for _, child := range trace.Children {
+		printSelectorTrace(child, depth+1)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -42,6 +43,24 @@ var getCMD = &cobra.Command{
 
   # Get a global network set by name with json output format
   bbfw get gns my_set -o json
+
+  # Get a tier by name
+  bbfw get tier security
+
+  # Get a network policy of tenant 2 by name
+  bbfw get np allow_web --tenantID=2
+
+  # Get a network set of tenant 2 by name
+  bbfw get ns office --tenantID=2
+
+  # Get a tenant by id
+  bbfw get tenant 2
+
+  # Get an enrollment token with its token by name
+  bbfw get et datacenter1
+
+  # Get a webhook by name
+  bbfw get webhook audit
 `,
 	Args: cobra.MaximumNArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
@@ -53,7 +72,7 @@ var getCMD = &cobra.Command{
 }
 
 func init() {
-	getCMD.Flags().Uint64Var(&getHEPByTenantID, "tenantID", 0, "HEP: get by tenantID")
+	getCMD.Flags().Uint64Var(&getHEPByTenantID, "tenantID", 0, "HEP, NP, NS: get by tenantID")
 	getCMD.Flags().StringVar(&getHEPByIP, "ip", "", "HEP: get by ip")
 	getCMD.Flags().StringVarP(&outputFormat, "output", "o", "", "output format(yaml|json). Default: yaml")
 }
@@ -90,6 +109,42 @@ func get(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("no resource name provided")
 		}
 		input = &dto.GetGNPInput{Name: resourceName}
+	case resourcemanager.ResourceTypeTier:
+		if resourceName == "" {
+			return fmt.Errorf("no resource name provided")
+		}
+		input = &dto.GetTierInput{Name: resourceName}
+	case resourcemanager.ResourceTypeNP:
+		if resourceName == "" || getHEPByTenantID == 0 {
+			return fmt.Errorf("resource name and tenantID are required")
+		}
+		input = &dto.GetNetworkPolicyInput{TenantID: getHEPByTenantID, Name: resourceName}
+	case resourcemanager.ResourceTypeNS:
+		if resourceName == "" || getHEPByTenantID == 0 {
+			return fmt.Errorf("resource name and tenantID are required")
+		}
+		input = &dto.GetNetworkSetInput{TenantID: getHEPByTenantID, Name: resourceName}
+	case resourcemanager.ResourceTypeEnrollmentToken:
+		if resourceName == "" {
+			return fmt.Errorf("no resource name provided")
+		}
+		input = &dto.GetEnrollmentTokenInput{Name: resourceName}
+	case resourcemanager.ResourceTypeWebhook:
+		if resourceName == "" {
+			return fmt.Errorf("no resource name provided")
+		}
+		input = &dto.GetWebhookInput{Name: resourceName}
+	case resourcemanager.ResourceTypeAdmissionHook:
+		if resourceName == "" {
+			return fmt.Errorf("no resource name provided")
+		}
+		input = &dto.GetAdmissionHookInput{Name: resourceName}
+	case resourcemanager.ResourceTypeTenant:
+		tenantID, errParse := strconv.ParseUint(resourceName, 10, 64)
+		if errParse != nil || tenantID == 0 {
+			return fmt.Errorf("tenant id is required")
+		}
+		input = &dto.GetTenantInput{TenantID: tenantID}
 	default:
 		return fmt.Errorf("unsupported resource type: %s", resourceType)
 	}
@@ -0,0 +1,142 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/api/v1/mapper"
+	"github.com/bamboo-firewall/be/cmd/bamboofwcli/command/common"
+	"github.com/bamboo-firewall/be/cmd/bamboofwcli/command/resourcemanager"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/validator"
+)
+
+var (
+	fileLints      []string
+	lintPolicies   []string
+	lintSeverities string
+)
+
+var lintCMD = &cobra.Command{
+	Use:   "lint [resourceType]",
+	Short: "Check policy and set files against the built-in lint rules",
+	Long: fmt.Sprintf(`The lint command checks global network policy, network policy, global network set and network set files
+against the built-in lint rules without calling the api server. It fails if a rule with the error severity is broken.
+
+Rules: %s
+Severities: error, warn, off`, strings.Join(validator.LintRules(), ", ")),
+	Example: `  # Lint global network policies
+  bbfw lint gnp -f policy1.yaml -f policy2.yaml
+
+  # Lint global network sets, sets matched by no rule selector of the policies are unused
+  bbfw lint gns -f set.yaml --policies policy1.yaml,policy2.yaml
+
+  # Lint with custom severities
+  bbfw lint gnp -f policy.yaml --severity broad-net=error,missing-owner-label=off`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := lint(cmd, args); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	lintCMD.Flags().StringSliceVarP(&fileLints, "file", "f", []string{}, "resource file path")
+	lintCMD.Flags().StringSliceVar(&lintPolicies, "policies", []string{}, "policy file paths the sets are used by")
+	lintCMD.Flags().StringVar(&lintSeverities, "severity", "", "comma separated rule=severity overriding the default severities")
+	lintCMD.MarkFlagRequired("file")
+}
+
+func lint(cmd *cobra.Command, args []string) error {
+	resourceType := args[0]
+	resourceMgr, err := common.GetResourceMgrByType(resourceType)
+	if err != nil {
+		return fmt.Errorf("get resource by type: %s", resourceType)
+	}
+	severities, err := validator.ParseLintSeverities(lintSeverities)
+	if err != nil {
+		return err
+	}
+	linter := validator.NewLinter(severities)
+
+	var findings []*validator.LintFinding
+	switch resourceMgr.GetResourceType() {
+	case resourcemanager.ResourceTypeGNP:
+		resources, err := common.GetResourceFilesByFileNames[dto.CreateGlobalNetworkPolicyInput](fileLints)
+		if err != nil {
+			return err
+		}
+		for _, r := range resources {
+			input := mapper.ToCreateGlobalNetworkPolicyInput(r.Content.(*dto.CreateGlobalNetworkPolicyInput))
+			input.FilePath = r.Name
+			findings = append(findings, linter.LintPolicy(mapper.ToLintPolicyEntity(input))...)
+		}
+	case resourcemanager.ResourceTypeNP:
+		resources, err := common.GetResourceFilesByFileNames[dto.CreateNetworkPolicyInput](fileLints)
+		if err != nil {
+			return err
+		}
+		for _, r := range resources {
+			input := mapper.ToCreateNetworkPolicyInput(r.Content.(*dto.CreateNetworkPolicyInput))
+			input.FilePath = r.Name
+			findings = append(findings, linter.LintPolicy(mapper.ToLintPolicyEntity(input))...)
+		}
+	case resourcemanager.ResourceTypeGNS:
+		policies, err := lintPolicyEntities(lintPolicies)
+		if err != nil {
+			return err
+		}
+		resources, err := common.GetResourceFilesByFileNames[dto.CreateGlobalNetworkSetInput](fileLints)
+		if err != nil {
+			return err
+		}
+		for _, r := range resources {
+			input := mapper.ToCreateGlobalNetworkSetInput(r.Content.(*dto.CreateGlobalNetworkSetInput))
+			input.FilePath = r.Name
+			findings = append(findings, linter.LintSet(mapper.ToLintSetEntity(input), policies)...)
+		}
+	case resourcemanager.ResourceTypeNS:
+		policies, err := lintPolicyEntities(lintPolicies)
+		if err != nil {
+			return err
+		}
+		resources, err := common.GetResourceFilesByFileNames[dto.CreateNetworkSetInput](fileLints)
+		if err != nil {
+			return err
+		}
+		for _, r := range resources {
+			input := mapper.ToCreateNetworkSetInput(r.Content.(*dto.CreateNetworkSetInput))
+			input.FilePath = r.Name
+			findings = append(findings, linter.LintSet(mapper.ToLintSetEntity(input), policies)...)
+		}
+	default:
+		return fmt.Errorf("lint is not supported for resource type: %s", resourceType)
+	}
+
+	for _, finding := range findings {
+		fmt.Println(finding)
+	}
+	if validator.HasLintError(findings) {
+		return fmt.Errorf("lint failed")
+	}
+	return nil
+}
+
+// lintPolicyEntities reads global network policy and network policy files, a global network policy has no tenant.
+func lintPolicyEntities(fileNames []string) ([]*entity.GlobalNetworkPolicy, error) {
+	resources, err := common.GetResourceFilesByFileNames[dto.CreateNetworkPolicyInput](fileNames)
+	if err != nil {
+		return nil, err
+	}
+	policies := make([]*entity.GlobalNetworkPolicy, 0, len(resources))
+	for _, r := range resources {
+		policies = append(policies, mapper.ToLintPolicyEntity(mapper.ToCreateNetworkPolicyInput(r.Content.(*dto.CreateNetworkPolicyInput))))
+	}
+	return policies, nil
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"text/tabwriter"
 	"text/template"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -20,7 +21,14 @@ var (
 	ListHEPsByTenantID uint64
 	ListHEPsByIP       string
 
-	ListGNPsByIsOrder bool
+	ListGNPsByIsOrder        bool
+	ListGNPsByExpiringWithin string
+
+	ListAgentsByStaleAfter string
+
+	ListWebhookDeliveriesByWebhook string
+	ListWebhookDeliveriesByStatus  string
+	ListWebhookDeliveriesLimit     int64
 )
 
 var listCMD = &cobra.Command{
@@ -32,9 +40,18 @@ var listCMD = &cobra.Command{
   # List global network policy
   bbfw list gnp
 
+  # List tiers
+  bbfw list tier
+
+  # List tenants
+  bbfw list tenant
+
   # List global network policy with order
   bbfw list gnp --isOrder
 
+  # List global network policy expiring within a day
+  bbfw list gnp --expiring-within 24h
+
   # List host endpoint
   bbfw list hep
 
@@ -44,6 +61,21 @@ var listCMD = &cobra.Command{
   # List host endpoint with IP
   bbfw list hep --ip=192.168.0.1
 
+  # List network policies of tenant 2
+  bbfw list np --tenantID=2
+
+  # List network sets of all tenants
+  bbfw list ns
+
+  # List agents, with the ones without heartbeat for 5 minutes as stale
+  bbfw list agents --stale-after 5m
+
+  # List webhooks
+  bbfw list webhook
+
+  # List the failed deliveries of webhook audit
+  bbfw list webhook-deliveries --webhook audit --status failed
+
   # List host endpoint with tenantID and IP
   bbfw list hep --tenantID=1 --ip=192.168.0.1,
 `,
@@ -57,9 +89,14 @@ var listCMD = &cobra.Command{
 }
 
 func init() {
-	listCMD.Flags().Uint64Var(&ListHEPsByTenantID, "tenantID", 0, "Host Endpoint: filter by TenantID")
+	listCMD.Flags().Uint64Var(&ListHEPsByTenantID, "tenantID", 0, "Host Endpoint, Network Policy, Network Set, Agent: filter by TenantID")
 	listCMD.Flags().StringVar(&ListHEPsByIP, "ip", "", "Host Endpoint: filter by IP")
 	listCMD.Flags().BoolVar(&ListGNPsByIsOrder, "isOrder", false, "Global Network Policy: filter by Order")
+	listCMD.Flags().StringVar(&ListGNPsByExpiringWithin, "expiring-within", "", "Global Network Policy: filter by policy or rule reaching its activeUntil or closing its schedule windows within the duration(e.g. 24h)")
+	listCMD.Flags().StringVar(&ListAgentsByStaleAfter, "stale-after", "", "Agent: duration without heartbeat after which an agent is stale(e.g. 5m). Default: 3m")
+	listCMD.Flags().StringVar(&ListWebhookDeliveriesByWebhook, "webhook", "", "Webhook Delivery: filter by webhook name")
+	listCMD.Flags().StringVar(&ListWebhookDeliveriesByStatus, "status", "", "Webhook Delivery: filter by status(pending|succeeded|failed)")
+	listCMD.Flags().Int64Var(&ListWebhookDeliveriesLimit, "limit", 0, "Webhook Delivery: maximum number of the newest deliveries. Default: 100")
 }
 
 func list(cmd *cobra.Command, args []string) error {
@@ -81,8 +118,35 @@ func list(cmd *cobra.Command, args []string) error {
 		}
 		input = listHEPsInput
 	case resourcemanager.ResourceTypeGNS:
+	case resourcemanager.ResourceTypeTier:
+	case resourcemanager.ResourceTypeTenant:
+	case resourcemanager.ResourceTypeEnrollmentToken:
+	case resourcemanager.ResourceTypeWebhook:
+	case resourcemanager.ResourceTypeAdmissionHook:
 	case resourcemanager.ResourceTypeGNP:
-		input = &dto.ListGNPsInput{IsOrder: ListGNPsByIsOrder}
+		if ListGNPsByExpiringWithin != "" {
+			if _, err = time.ParseDuration(ListGNPsByExpiringWithin); err != nil {
+				return fmt.Errorf("invalid expiring-within: %w", err)
+			}
+		}
+		input = &dto.ListGNPsInput{IsOrder: ListGNPsByIsOrder, ExpiringWithin: ListGNPsByExpiringWithin}
+	case resourcemanager.ResourceTypeNP:
+		input = &dto.ListNetworkPoliciesInput{TenantID: ListHEPsByTenantID}
+	case resourcemanager.ResourceTypeNS:
+		input = &dto.ListNetworkSetsInput{TenantID: ListHEPsByTenantID}
+	case resourcemanager.ResourceTypeAgent:
+		if ListAgentsByStaleAfter != "" {
+			if _, err = time.ParseDuration(ListAgentsByStaleAfter); err != nil {
+				return fmt.Errorf("invalid stale-after: %w", err)
+			}
+		}
+		input = &dto.ListAgentsInput{TenantID: ListHEPsByTenantID, StaleAfter: ListAgentsByStaleAfter}
+	case resourcemanager.ResourceTypeWebhookDelivery:
+		input = &dto.ListWebhookDeliveriesInput{
+			WebhookName: ListWebhookDeliveriesByWebhook,
+			Status:      ListWebhookDeliveriesByStatus,
+			Limit:       ListWebhookDeliveriesLimit,
+		}
 	default:
 		return fmt.Errorf("unsupported resources type: %s", resourceType)
 	}
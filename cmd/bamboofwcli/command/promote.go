@@ -0,0 +1,97 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/cmd/bamboofwcli/command/common"
+	"github.com/bamboo-firewall/be/cmd/bamboofwcli/command/resourcemanager"
+	"github.com/bamboo-firewall/be/pkg/client"
+)
+
+var promoteCMD = &cobra.Command{
+	Use:   "promote [resourceType] [name...]",
+	Short: "Promote staged resources to enforced",
+	Long: `The promote command is used to enforce staged global network policies.
+Traffic the policy would have denied while staged is printed before promoting.
+
+  Resource type available:
+    * GlobalNetworkPolicy(or gnp)`,
+	Example: `  # Promote a staged policy
+  bbfw promote gnp deny_ssh
+
+  # Promote many staged policies
+  bbfw promote gnp deny_ssh deny_ping`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := promote(cmd, args); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func promote(cmd *cobra.Command, args []string) error {
+	resourceType := args[0]
+	resourceMgr, err := common.GetResourceMgrByType(resourceType)
+	if err != nil {
+		return err
+	}
+	if resourceMgr.GetResourceType() != resourcemanager.ResourceTypeGNP {
+		return fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
+
+	apiServer := client.NewAPIServer(os.Getenv(common.APIServerENV))
+	var numHandled int
+	for _, name := range args[1:] {
+		summary, errReport := apiServer.GetGNPStagedReport(context.Background(), &dto.GetGNPStagedReportInput{Name: name})
+		if errReport != nil {
+			fmt.Printf("Fail to get staged report of %s. Error: %v\n", name, errReport)
+			continue
+		}
+		if !summary.Staged {
+			fmt.Printf("Resource %s is not staged\n", name)
+			continue
+		}
+		fmt.Printf("Resource %s would have denied %d packets (%d bytes) on %d host endpoints\n",
+			name, summary.DeniedPackets, summary.DeniedBytes, len(summary.Reports))
+		if len(summary.Reports) > 0 {
+			if errReport = printStagedPolicyReports(summary.Reports); errReport != nil {
+				fmt.Printf("Fail to print staged report. Error: %v\n", errReport)
+			}
+		}
+
+		_, err = apiServer.PromoteGNP(context.Background(), &dto.PromoteGlobalNetworkPolicyInput{
+			Metadata: dto.GNPMetadataInput{Name: name},
+		})
+		if err != nil {
+			fmt.Printf("Fail to promote resource: %s. Error: %v\n", name, err)
+		} else {
+			fmt.Printf("Successsfully promoted resource %s\n", name)
+			numHandled++
+		}
+	}
+	fmt.Printf("Total: %d resources. Success: %d. Fail: %d.\n", len(args)-1, numHandled, len(args)-1-numHandled)
+	return nil
+}
+
+func printStagedPolicyReports(reports []*dto.StagedPolicyReport) error {
+	tmpl, err := template.New("promote").Parse("TENANT_ID\tIP\tDENIED_PACKETS\tDENIED_BYTES\tLAST_REPORTED_AT\t\n" +
+		"{{range .}}{{.TenantID}}\t{{.IP}}\t{{.DeniedPackets}}\t{{.DeniedBytes}}\t{{.LastReportedAt}}\t\n{{end}}")
+	if err != nil {
+		return fmt.Errorf("parse promote template: %w", err)
+	}
+	writer := tabwriter.NewWriter(os.Stdout, 5, 1, 3, ' ', 0)
+	if err = tmpl.Execute(writer, reports); err != nil {
+		return fmt.Errorf("execute promote template: %w", err)
+	}
+	writer.Flush()
+	fmt.Printf("\n")
+	return nil
+}
@@ -0,0 +1,61 @@
+package resourcemanager
+
+import (
+	"context"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+)
+
+func NewAdmissionHook() Resource {
+	return &admissionHook{}
+}
+
+type admissionHook struct {
+}
+
+func (a *admissionHook) Create(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) error {
+	r := resource.(*dto.CreateAdmissionHookInput)
+	r.FilePath = filePath
+	return apiServer.CreateAdmissionHook(ctx, r)
+}
+
+func (a *admissionHook) List(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	return apiServer.ListAdmissionHooks(ctx)
+}
+
+func (a *admissionHook) Get(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.GetAdmissionHookInput)
+	return apiServer.GetAdmissionHook(ctx, r)
+}
+
+func (a *admissionHook) Delete(ctx context.Context, apiServer APIServer, resource interface{}) error {
+	r := resource.(*dto.DeleteAdmissionHookInput)
+	return apiServer.DeleteAdmissionHook(ctx, r)
+}
+
+func (a *admissionHook) Validate(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.CreateAdmissionHookInput)
+	r.FilePath = filePath
+	return apiServer.ValidateAdmissionHook(ctx, r)
+}
+
+func (a *admissionHook) GetResourceType() ResourceType {
+	return ResourceTypeAdmissionHook
+}
+
+func (a *admissionHook) GetHeader() []string {
+	return []string{"UUID", "NAME", "URL", "KINDS", "OPERATIONS", "FAILURE_POLICY", "TIMEOUT", "VERSION"}
+}
+
+func (a *admissionHook) GetHeaderMap() map[string]string {
+	return map[string]string{
+		"UUID":           "{{.UUID}}",
+		"NAME":           "{{.Metadata.Name}}",
+		"URL":            "{{.Spec.URL}}",
+		"KINDS":          "{{.Spec.Kinds}}",
+		"OPERATIONS":     "{{.Spec.Operations}}",
+		"FAILURE_POLICY": "{{.Spec.FailurePolicy}}",
+		"TIMEOUT":        "{{.Spec.Timeout}}",
+		"VERSION":        "{{.Version}}",
+	}
+}
@@ -0,0 +1,62 @@
+package resourcemanager
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+)
+
+var errAgentReadOnly = errors.New("agents register themselves and can only be listed")
+
+func NewAgent() Resource {
+	return &agent{}
+}
+
+type agent struct {
+}
+
+func (a *agent) Create(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) error {
+	return errAgentReadOnly
+}
+
+func (a *agent) List(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.ListAgentsInput)
+	return apiServer.ListAgents(ctx, r)
+}
+
+func (a *agent) Get(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	return nil, errAgentReadOnly
+}
+
+func (a *agent) Delete(ctx context.Context, apiServer APIServer, resource interface{}) error {
+	return errAgentReadOnly
+}
+
+func (a *agent) Validate(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) (interface{}, error) {
+	return nil, errAgentReadOnly
+}
+
+func (a *agent) GetResourceType() ResourceType {
+	return ResourceTypeAgent
+}
+
+func (a *agent) GetHeader() []string {
+	return []string{"TENANT_ID", "IP", "HOSTNAME", "AGENT_VERSION", "APPLIED_REVISION", "EXPECTED_REVISION", "STALE",
+		"OUT_OF_SYNC", "FAILING", "LAST_HEARTBEAT"}
+}
+
+func (a *agent) GetHeaderMap() map[string]string {
+	return map[string]string{
+		"TENANT_ID":         "{{.TenantID}}",
+		"IP":                "{{.IP}}",
+		"HOSTNAME":          "{{.Hostname}}",
+		"AGENT_VERSION":     "{{.AgentVersion}}",
+		"APPLIED_REVISION":  "{{.Applied.Revision}}",
+		"EXPECTED_REVISION": "{{.ExpectedRevision}}",
+		"STALE":             "{{.Stale}}",
+		"OUT_OF_SYNC":       "{{.OutOfSync}}",
+		"FAILING":           "{{.Failing}}",
+		"LAST_HEARTBEAT":    "{{if .LastHeartbeatAt}}{{.LastHeartbeatAt.Format \"2006-01-02T15:04:05Z07:00\"}}{{else}}-{{end}}",
+	}
+}
@@ -0,0 +1,65 @@
+package resourcemanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+)
+
+func NewEnrollmentToken() Resource {
+	return &enrollmentToken{}
+}
+
+type enrollmentToken struct {
+}
+
+func (t *enrollmentToken) Create(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) error {
+	r := resource.(*dto.CreateEnrollmentTokenInput)
+	r.FilePath = filePath
+	token, err := apiServer.CreateEnrollmentToken(ctx, r)
+	if err != nil {
+		return err
+	}
+	// the token is only returned when it is created
+	fmt.Printf("Enrollment token %s: %s\n", token.Metadata.Name, token.Spec.Token)
+	return nil
+}
+
+func (t *enrollmentToken) List(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	return apiServer.ListEnrollmentTokens(ctx)
+}
+
+func (t *enrollmentToken) Get(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.GetEnrollmentTokenInput)
+	return apiServer.GetEnrollmentToken(ctx, r)
+}
+
+func (t *enrollmentToken) Delete(ctx context.Context, apiServer APIServer, resource interface{}) error {
+	r := resource.(*dto.DeleteEnrollmentTokenInput)
+	return apiServer.DeleteEnrollmentToken(ctx, r)
+}
+
+func (t *enrollmentToken) Validate(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.CreateEnrollmentTokenInput)
+	r.FilePath = filePath
+	return apiServer.ValidateEnrollmentToken(ctx, r)
+}
+
+func (t *enrollmentToken) GetResourceType() ResourceType {
+	return ResourceTypeEnrollmentToken
+}
+
+func (t *enrollmentToken) GetHeader() []string {
+	return []string{"UUID", "NAME", "TENANT_ID", "ALLOWED_CIDRS", "VERSION"}
+}
+
+func (t *enrollmentToken) GetHeaderMap() map[string]string {
+	return map[string]string{
+		"UUID":          "{{.UUID}}",
+		"NAME":          "{{.Metadata.Name}}",
+		"TENANT_ID":     "{{.Spec.TenantID}}",
+		"ALLOWED_CIDRS": "{{.Spec.AllowedCIDRs}}",
+		"VERSION":       "{{.Version}}",
+	}
+}
@@ -45,14 +45,17 @@ func (p *gnp) GetResourceType() ResourceType {
 }
 
 func (p *gnp) GetHeader() []string {
-	return []string{"UUID", "NAME", "ORDER", "VERSION"}
+	return []string{"UUID", "NAME", "TIER", "ORDER", "STAGED", "ACTIVE_UNTIL", "VERSION"}
 }
 
 func (p *gnp) GetHeaderMap() map[string]string {
 	return map[string]string{
-		"UUID":    "{{.UUID}}",
-		"NAME":    "{{.Metadata.Name}}",
-		"ORDER":   "{{.Spec.Order}}",
-		"VERSION": "{{.Version}}",
+		"UUID":         "{{.UUID}}",
+		"NAME":         "{{.Metadata.Name}}",
+		"TIER":         "{{if .Spec.Tier}}{{.Spec.Tier}}{{else}}default{{end}}",
+		"ORDER":        "{{.Spec.Order}}",
+		"STAGED":       "{{.Spec.Staged}}",
+		"ACTIVE_UNTIL": "{{with .Spec.ActiveUntil}}{{.Local.Format \"2006-01-02T15:04:05Z07:00\"}}{{end}}",
+		"VERSION":      "{{.Version}}",
 	}
 }
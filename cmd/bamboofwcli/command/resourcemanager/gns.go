@@ -44,14 +44,17 @@ func (s *gns) GetResourceType() ResourceType {
 }
 
 func (s *gns) GetHeader() []string {
-	return []string{"UUID", "NAME", "NETS", "VERSION"}
+	return []string{"UUID", "NAME", "NETS", "DOMAINS", "SOURCE", "LAST_SYNC", "VERSION"}
 }
 
 func (s *gns) GetHeaderMap() map[string]string {
 	return map[string]string{
-		"UUID":    "{{.UUID}}",
-		"NAME":    "{{.Metadata.Name}}",
-		"NETS":    "{{.Spec.Nets}}",
-		"VERSION": "{{.Version}}",
+		"UUID":      "{{.UUID}}",
+		"NAME":      "{{.Metadata.Name}}",
+		"NETS":      "{{.Spec.Nets}}",
+		"DOMAINS":   "{{.Spec.AllowedEgressDomains}}",
+		"SOURCE":    "{{with .Spec.Source}}{{.URL}}{{.File}}{{end}}",
+		"LAST_SYNC": "{{with .Status}}{{.LastSyncAt.Format \"2006-01-02T15:04:05Z07:00\"}}{{if .LastError}} ({{.LastError}}){{end}}{{end}}",
+		"VERSION":   "{{.Version}}",
 	}
 }
@@ -0,0 +1,61 @@
+package resourcemanager
+
+import (
+	"context"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+)
+
+func NewNP() Resource {
+	return &np{}
+}
+
+type np struct {
+}
+
+func (p *np) Create(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) error {
+	r := resource.(*dto.CreateNetworkPolicyInput)
+	r.FilePath = filePath
+	return apiServer.CreateNetworkPolicy(ctx, r)
+}
+
+func (p *np) List(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.ListNetworkPoliciesInput)
+	return apiServer.ListNetworkPolicies(ctx, r)
+}
+
+func (p *np) Get(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.GetNetworkPolicyInput)
+	return apiServer.GetNetworkPolicy(ctx, r)
+}
+
+func (p *np) Delete(ctx context.Context, apiServer APIServer, resource interface{}) error {
+	r := resource.(*dto.DeleteNetworkPolicyInput)
+	return apiServer.DeleteNetworkPolicy(ctx, r)
+}
+
+func (p *np) Validate(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.CreateNetworkPolicyInput)
+	r.FilePath = filePath
+	return apiServer.ValidateNetworkPolicy(ctx, r)
+}
+
+func (p *np) GetResourceType() ResourceType {
+	return ResourceTypeNP
+}
+
+func (p *np) GetHeader() []string {
+	return []string{"UUID", "TENANT_ID", "NAME", "TIER", "ORDER", "STAGED", "VERSION"}
+}
+
+func (p *np) GetHeaderMap() map[string]string {
+	return map[string]string{
+		"UUID":      "{{.UUID}}",
+		"TENANT_ID": "{{.Metadata.TenantID}}",
+		"NAME":      "{{.Metadata.Name}}",
+		"TIER":      "{{if .Spec.Tier}}{{.Spec.Tier}}{{else}}default{{end}}",
+		"ORDER":     "{{.Spec.Order}}",
+		"STAGED":    "{{.Spec.Staged}}",
+		"VERSION":   "{{.Version}}",
+	}
+}
@@ -0,0 +1,59 @@
+package resourcemanager
+
+import (
+	"context"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+)
+
+func NewNS() Resource {
+	return &ns{}
+}
+
+type ns struct {
+}
+
+func (s *ns) Create(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) error {
+	r := resource.(*dto.CreateNetworkSetInput)
+	r.FilePath = filePath
+	return apiServer.CreateNetworkSet(ctx, r)
+}
+
+func (s *ns) List(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.ListNetworkSetsInput)
+	return apiServer.ListNetworkSets(ctx, r)
+}
+
+func (s *ns) Get(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.GetNetworkSetInput)
+	return apiServer.GetNetworkSet(ctx, r)
+}
+
+func (s *ns) Delete(ctx context.Context, apiServer APIServer, resource interface{}) error {
+	r := resource.(*dto.DeleteNetworkSetInput)
+	return apiServer.DeleteNetworkSet(ctx, r)
+}
+
+func (s *ns) Validate(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.CreateNetworkSetInput)
+	r.FilePath = filePath
+	return apiServer.ValidateNetworkSet(ctx, r)
+}
+
+func (s *ns) GetResourceType() ResourceType {
+	return ResourceTypeNS
+}
+
+func (s *ns) GetHeader() []string {
+	return []string{"UUID", "TENANT_ID", "NAME", "NETS", "VERSION"}
+}
+
+func (s *ns) GetHeaderMap() map[string]string {
+	return map[string]string{
+		"UUID":      "{{.UUID}}",
+		"TENANT_ID": "{{.Metadata.TenantID}}",
+		"NAME":      "{{.Metadata.Name}}",
+		"NETS":      "{{.Spec.Nets}}",
+		"VERSION":   "{{.Version}}",
+	}
+}
@@ -13,6 +13,15 @@ const (
 	ResourceTypeHEP
 	ResourceTypeGNS
 	ResourceTypeGNP
+	ResourceTypeTier
+	ResourceTypeNP
+	ResourceTypeNS
+	ResourceTypeTenant
+	ResourceTypeAgent
+	ResourceTypeEnrollmentToken
+	ResourceTypeWebhook
+	ResourceTypeWebhookDelivery
+	ResourceTypeAdmissionHook
 )
 
 type Resource interface {
@@ -42,4 +51,42 @@ type APIServer interface {
 	ValidateHostEndpoint(ctx context.Context, input *dto.CreateHostEndpointInput) (*dto.ValidateHostEndpointOutput, error)
 	ValidateGlobalNetworkPolicy(ctx context.Context, input *dto.CreateGlobalNetworkPolicyInput) (*dto.ValidateGlobalNetworkPolicyOutput, error)
 	ValidateGlobalNetworkSet(ctx context.Context, input *dto.CreateGlobalNetworkSetInput) (*dto.ValidateGlobalNetworkSetOutput, error)
+	CreateTier(ctx context.Context, input *dto.CreateTierInput) error
+	ListTiers(ctx context.Context) ([]*dto.Tier, error)
+	GetTier(ctx context.Context, input *dto.GetTierInput) (*dto.Tier, error)
+	DeleteTier(ctx context.Context, input *dto.DeleteTierInput) error
+	ValidateTier(ctx context.Context, input *dto.CreateTierInput) (*dto.ValidateTierOutput, error)
+	CreateNetworkPolicy(ctx context.Context, input *dto.CreateNetworkPolicyInput) error
+	ListNetworkPolicies(ctx context.Context, input *dto.ListNetworkPoliciesInput) ([]*dto.GlobalNetworkPolicy, error)
+	GetNetworkPolicy(ctx context.Context, input *dto.GetNetworkPolicyInput) (*dto.GlobalNetworkPolicy, error)
+	DeleteNetworkPolicy(ctx context.Context, input *dto.DeleteNetworkPolicyInput) error
+	ValidateNetworkPolicy(ctx context.Context, input *dto.CreateNetworkPolicyInput) (*dto.ValidateNetworkPolicyOutput, error)
+	CreateNetworkSet(ctx context.Context, input *dto.CreateNetworkSetInput) error
+	ListNetworkSets(ctx context.Context, input *dto.ListNetworkSetsInput) ([]*dto.GlobalNetworkSet, error)
+	GetNetworkSet(ctx context.Context, input *dto.GetNetworkSetInput) (*dto.GlobalNetworkSet, error)
+	DeleteNetworkSet(ctx context.Context, input *dto.DeleteNetworkSetInput) error
+	ValidateNetworkSet(ctx context.Context, input *dto.CreateNetworkSetInput) (*dto.ValidateNetworkSetOutput, error)
+	CreateTenant(ctx context.Context, input *dto.CreateTenantInput) error
+	ListTenants(ctx context.Context) ([]*dto.Tenant, error)
+	GetTenant(ctx context.Context, input *dto.GetTenantInput) (*dto.Tenant, error)
+	DeleteTenant(ctx context.Context, input *dto.DeleteTenantInput) error
+	ValidateTenant(ctx context.Context, input *dto.CreateTenantInput) (*dto.ValidateTenantOutput, error)
+	ListAgents(ctx context.Context, input *dto.ListAgentsInput) ([]*dto.Agent, error)
+	CreateEnrollmentToken(ctx context.Context, input *dto.CreateEnrollmentTokenInput) (*dto.EnrollmentToken, error)
+	ListEnrollmentTokens(ctx context.Context) ([]*dto.EnrollmentToken, error)
+	GetEnrollmentToken(ctx context.Context, input *dto.GetEnrollmentTokenInput) (*dto.EnrollmentToken, error)
+	DeleteEnrollmentToken(ctx context.Context, input *dto.DeleteEnrollmentTokenInput) error
+	ValidateEnrollmentToken(ctx context.Context, input *dto.CreateEnrollmentTokenInput) (*dto.ValidateEnrollmentTokenOutput, error)
+	CreateWebhook(ctx context.Context, input *dto.CreateWebhookInput) error
+	ListWebhooks(ctx context.Context) ([]*dto.Webhook, error)
+	GetWebhook(ctx context.Context, input *dto.GetWebhookInput) (*dto.Webhook, error)
+	DeleteWebhook(ctx context.Context, input *dto.DeleteWebhookInput) error
+	ValidateWebhook(ctx context.Context, input *dto.CreateWebhookInput) (*dto.ValidateWebhookOutput, error)
+	ListWebhookDeliveries(ctx context.Context, input *dto.ListWebhookDeliveriesInput) ([]*dto.WebhookDelivery, error)
+	CreateAdmissionHook(ctx context.Context, input *dto.CreateAdmissionHookInput) error
+	ListAdmissionHooks(ctx context.Context) ([]*dto.AdmissionHook, error)
+	GetAdmissionHook(ctx context.Context, input *dto.GetAdmissionHookInput) (*dto.AdmissionHook, error)
+	DeleteAdmissionHook(ctx context.Context, input *dto.DeleteAdmissionHookInput) error
+	ValidateAdmissionHook(ctx context.Context, input *dto.CreateAdmissionHookInput) (*dto.ValidateAdmissionHookOutput, error)
+	BulkApply(ctx context.Context, input *dto.BulkApplyInput) (*dto.BulkApplyOutput, error)
 }
@@ -0,0 +1,59 @@
+package resourcemanager
+
+import (
+	"context"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+)
+
+func NewTenant() Resource {
+	return &tenant{}
+}
+
+type tenant struct {
+}
+
+func (t *tenant) Create(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) error {
+	r := resource.(*dto.CreateTenantInput)
+	r.FilePath = filePath
+	return apiServer.CreateTenant(ctx, r)
+}
+
+func (t *tenant) List(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	return apiServer.ListTenants(ctx)
+}
+
+func (t *tenant) Get(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.GetTenantInput)
+	return apiServer.GetTenant(ctx, r)
+}
+
+func (t *tenant) Delete(ctx context.Context, apiServer APIServer, resource interface{}) error {
+	r := resource.(*dto.DeleteTenantInput)
+	return apiServer.DeleteTenant(ctx, r)
+}
+
+func (t *tenant) Validate(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.CreateTenantInput)
+	r.FilePath = filePath
+	return apiServer.ValidateTenant(ctx, r)
+}
+
+func (t *tenant) GetResourceType() ResourceType {
+	return ResourceTypeTenant
+}
+
+func (t *tenant) GetHeader() []string {
+	return []string{"UUID", "TENANT_ID", "NAME", "MAX_HEPS", "MAX_NPS", "VERSION"}
+}
+
+func (t *tenant) GetHeaderMap() map[string]string {
+	return map[string]string{
+		"UUID":      "{{.UUID}}",
+		"TENANT_ID": "{{.Spec.TenantID}}",
+		"NAME":      "{{.Metadata.Name}}",
+		"MAX_HEPS":  "{{.Spec.Quota.MaxHostEndpoints}}",
+		"MAX_NPS":   "{{.Spec.Quota.MaxNetworkPolicies}}",
+		"VERSION":   "{{.Version}}",
+	}
+}
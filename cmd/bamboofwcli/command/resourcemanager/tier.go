@@ -0,0 +1,58 @@
+package resourcemanager
+
+import (
+	"context"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+)
+
+func NewTier() Resource {
+	return &tier{}
+}
+
+type tier struct {
+}
+
+func (t *tier) Create(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) error {
+	r := resource.(*dto.CreateTierInput)
+	r.FilePath = filePath
+	return apiServer.CreateTier(ctx, r)
+}
+
+func (t *tier) List(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	return apiServer.ListTiers(ctx)
+}
+
+func (t *tier) Get(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.GetTierInput)
+	return apiServer.GetTier(ctx, r)
+}
+
+func (t *tier) Delete(ctx context.Context, apiServer APIServer, resource interface{}) error {
+	r := resource.(*dto.DeleteTierInput)
+	return apiServer.DeleteTier(ctx, r)
+}
+
+func (t *tier) Validate(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.CreateTierInput)
+	r.FilePath = filePath
+	return apiServer.ValidateTier(ctx, r)
+}
+
+func (t *tier) GetResourceType() ResourceType {
+	return ResourceTypeTier
+}
+
+func (t *tier) GetHeader() []string {
+	return []string{"UUID", "NAME", "ORDER", "DEFAULT_ACTION", "VERSION"}
+}
+
+func (t *tier) GetHeaderMap() map[string]string {
+	return map[string]string{
+		"UUID":           "{{.UUID}}",
+		"NAME":           "{{.Metadata.Name}}",
+		"ORDER":          "{{.Spec.Order}}",
+		"DEFAULT_ACTION": "{{.Spec.DefaultAction}}",
+		"VERSION":        "{{.Version}}",
+	}
+}
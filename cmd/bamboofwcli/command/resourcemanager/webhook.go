@@ -0,0 +1,60 @@
+package resourcemanager
+
+import (
+	"context"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+)
+
+func NewWebhook() Resource {
+	return &webhook{}
+}
+
+type webhook struct {
+}
+
+func (w *webhook) Create(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) error {
+	r := resource.(*dto.CreateWebhookInput)
+	r.FilePath = filePath
+	return apiServer.CreateWebhook(ctx, r)
+}
+
+func (w *webhook) List(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	return apiServer.ListWebhooks(ctx)
+}
+
+func (w *webhook) Get(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.GetWebhookInput)
+	return apiServer.GetWebhook(ctx, r)
+}
+
+func (w *webhook) Delete(ctx context.Context, apiServer APIServer, resource interface{}) error {
+	r := resource.(*dto.DeleteWebhookInput)
+	return apiServer.DeleteWebhook(ctx, r)
+}
+
+func (w *webhook) Validate(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.CreateWebhookInput)
+	r.FilePath = filePath
+	return apiServer.ValidateWebhook(ctx, r)
+}
+
+func (w *webhook) GetResourceType() ResourceType {
+	return ResourceTypeWebhook
+}
+
+func (w *webhook) GetHeader() []string {
+	return []string{"UUID", "NAME", "URL", "KINDS", "ACTIONS", "SELECTOR", "VERSION"}
+}
+
+func (w *webhook) GetHeaderMap() map[string]string {
+	return map[string]string{
+		"UUID":     "{{.UUID}}",
+		"NAME":     "{{.Metadata.Name}}",
+		"URL":      "{{.Spec.URL}}",
+		"KINDS":    "{{.Spec.Kinds}}",
+		"ACTIONS":  "{{.Spec.Actions}}",
+		"SELECTOR": "{{.Spec.Selector}}",
+		"VERSION":  "{{.Version}}",
+	}
+}
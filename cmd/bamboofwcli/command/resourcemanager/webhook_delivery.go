@@ -0,0 +1,61 @@
+package resourcemanager
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+)
+
+var errWebhookDeliveryReadOnly = errors.New("webhook deliveries are made by the server and can only be listed")
+
+func NewWebhookDelivery() Resource {
+	return &webhookDelivery{}
+}
+
+type webhookDelivery struct {
+}
+
+func (w *webhookDelivery) Create(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) error {
+	return errWebhookDeliveryReadOnly
+}
+
+func (w *webhookDelivery) List(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	r := resource.(*dto.ListWebhookDeliveriesInput)
+	return apiServer.ListWebhookDeliveries(ctx, r)
+}
+
+func (w *webhookDelivery) Get(ctx context.Context, apiServer APIServer, resource interface{}) (interface{}, error) {
+	return nil, errWebhookDeliveryReadOnly
+}
+
+func (w *webhookDelivery) Delete(ctx context.Context, apiServer APIServer, resource interface{}) error {
+	return errWebhookDeliveryReadOnly
+}
+
+func (w *webhookDelivery) Validate(ctx context.Context, apiServer APIServer, filePath string, resource interface{}) (interface{}, error) {
+	return nil, errWebhookDeliveryReadOnly
+}
+
+func (w *webhookDelivery) GetResourceType() ResourceType {
+	return ResourceTypeWebhookDelivery
+}
+
+func (w *webhookDelivery) GetHeader() []string {
+	return []string{"UUID", "WEBHOOK", "EVENT", "RESOURCE", "STATUS", "ATTEMPTS", "LAST_STATUS_CODE", "LAST_ERROR",
+		"CREATED_AT"}
+}
+
+func (w *webhookDelivery) GetHeaderMap() map[string]string {
+	return map[string]string{
+		"UUID":             "{{.UUID}}",
+		"WEBHOOK":          "{{.WebhookName}}",
+		"EVENT":            "{{.Event.Kind}}.{{.Event.Action}}",
+		"RESOURCE":         "{{.Event.Name}}",
+		"STATUS":           "{{.Status}}",
+		"ATTEMPTS":         "{{.Attempts}}",
+		"LAST_STATUS_CODE": "{{if .LastStatusCode}}{{.LastStatusCode}}{{else}}-{{end}}",
+		"LAST_ERROR":       "{{if .LastError}}{{.LastError}}{{else}}-{{end}}",
+		"CREATED_AT":       "{{.CreatedAt.Format \"2006-01-02T15:04:05Z07:00\"}}",
+	}
+}
@@ -26,6 +26,11 @@ func Execute() {
 	rootCMD.AddCommand(getCMD)
 	rootCMD.AddCommand(deleteCMD)
 	rootCMD.AddCommand(validateCommand)
+	rootCMD.AddCommand(lintCMD)
+	rootCMD.AddCommand(promoteCMD)
+	rootCMD.AddCommand(explainCMD)
+	rootCMD.AddCommand(backupCMD)
+	rootCMD.AddCommand(restoreCMD)
 	rootCMD.AddCommand(versionCMD)
 
 	rootCMD.AddCommand(&cobra.Command{
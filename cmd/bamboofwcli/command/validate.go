@@ -22,13 +22,28 @@ import (
 	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
 )
 
-var fileValidates []string
+var (
+	fileValidates    []string
+	validateOffline  bool
+	validateSnapshot string
+)
 
 var validateCommand = &cobra.Command{
 	Use:   "validate [resourceType]",
 	Short: "validate resource by filename",
-	Long:  ``,
-	Args:  cobra.ExactArgs(1),
+	Long: `The validate command validates resources against the api server. In offline mode the resources are only
+checked by the validators of the api server, the selectors of policies are resolved against the host endpoints and
+sets of the snapshot: a directory holding a sub directory of files per resource type, e.g. hep/, gns/ and ns/, or a
+bundle written by backup.`,
+	Example: `  # Validate a global network policy against the api server
+  bbfw validate gnp -f policy.yaml
+
+  # Validate a global network policy without the api server
+  bbfw validate gnp -f policy.yaml --offline --snapshot ./snapshot
+
+  # Validate a global network policy against a backup bundle
+  bbfw validate gnp -f policy.yaml --offline --snapshot bundle.json`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := validate(cmd, args); err != nil {
 			fmt.Fprintf(os.Stderr, "error: %v\n", err)
@@ -39,6 +54,8 @@ var validateCommand = &cobra.Command{
 
 func init() {
 	validateCommand.Flags().StringSliceVarP(&fileValidates, "file", "f", []string{}, "resource file path")
+	validateCommand.Flags().BoolVar(&validateOffline, "offline", false, "validate without the api server")
+	validateCommand.Flags().StringVar(&validateSnapshot, "snapshot", "", "directory of host endpoint and set files, or backup bundle, to resolve selectors against in offline mode")
 	validateCommand.MarkFlagRequired("file")
 }
 
@@ -57,12 +74,32 @@ func validate(cmd *cobra.Command, args []string) error {
 		resources, err = common.GetResourceFilesByFileNames[dto.CreateGlobalNetworkSetInput](fileValidates)
 	case resourcemanager.ResourceTypeGNP:
 		resources, err = common.GetResourceFilesByFileNames[dto.CreateGlobalNetworkPolicyInput](fileValidates)
+	case resourcemanager.ResourceTypeTier:
+		resources, err = common.GetResourceFilesByFileNames[dto.CreateTierInput](fileValidates)
+	case resourcemanager.ResourceTypeNP:
+		resources, err = common.GetResourceFilesByFileNames[dto.CreateNetworkPolicyInput](fileValidates)
+	case resourcemanager.ResourceTypeNS:
+		resources, err = common.GetResourceFilesByFileNames[dto.CreateNetworkSetInput](fileValidates)
+	case resourcemanager.ResourceTypeTenant:
+		resources, err = common.GetResourceFilesByFileNames[dto.CreateTenantInput](fileValidates)
+	case resourcemanager.ResourceTypeEnrollmentToken:
+		resources, err = common.GetResourceFilesByFileNames[dto.CreateEnrollmentTokenInput](fileValidates)
+	case resourcemanager.ResourceTypeWebhook:
+		resources, err = common.GetResourceFilesByFileNames[dto.CreateWebhookInput](fileValidates)
+	case resourcemanager.ResourceTypeAdmissionHook:
+		resources, err = common.GetResourceFilesByFileNames[dto.CreateAdmissionHookInput](fileValidates)
 	default:
 		return fmt.Errorf("invalid resource type: %s", resourceType)
 	}
 	if err != nil {
 		return err
 	}
+	if validateOffline {
+		return validateResourcesOffline(resourceMgr.GetResourceType(), resources)
+	}
+	if validateSnapshot != "" {
+		return fmt.Errorf("snapshot is only used in offline mode")
+	}
 
 	apiServer := client.NewAPIServer(os.Getenv(common.APIServerENV))
 	for _, r := range resources {
@@ -71,17 +108,8 @@ func validate(cmd *cobra.Command, args []string) error {
 		if errValidate != nil {
 			var ierr *ierror.Error
 			if errors.As(errValidate, &ierr) {
-				if ierr.Code == httpbase.ErrorCodeBadRequest {
-					fmt.Printf("Resoure invalid. Detail:\n")
-					var buf bytes.Buffer
-					encoder := json.NewEncoder(&buf)
-					encoder.SetEscapeHTML(false)
-					encoder.SetIndent("", "  ")
-					if errValidate = encoder.Encode(ierr.Detail); errValidate != nil {
-						fmt.Printf("Error encoding detail: %v. Error: %v\n", ierr.Detail, errValidate)
-					} else {
-						fmt.Printf("%s\n", buf.String())
-					}
+				if ierr.Code == httpbase.ErrorCodeBadRequest || ierr.Code == httpbase.ErrorCodeValidateRequest {
+					printInvalidDetail(ierr.Detail)
 					continue
 				}
 			}
@@ -132,11 +160,16 @@ func validate(cmd *cobra.Command, args []string) error {
 			if len(validateHEPOutput.ParsedGNPs) > 0 {
 				fmt.Printf("Resource will have %d global network policies:\n", len(validateHEPOutput.ParsedGNPs))
 				for _, policy := range validateHEPOutput.ParsedGNPs {
-					fmt.Printf("%s\n", policy.Name)
+					if policy.TenantID != 0 {
+						fmt.Printf("%s (network policy of tenant %d)\n", policy.Name, policy.TenantID)
+					} else {
+						fmt.Printf("%s\n", policy.Name)
+					}
 				}
 			} else {
 				fmt.Printf("Resource willn't have any global network policies.\n")
 			}
+			printAdmissionVerdicts(validateHEPOutput.AdmissionVerdicts)
 		case resourcemanager.ResourceTypeGNP:
 			validateGNPOutput, ok := validateOutput.(*dto.ValidateGlobalNetworkPolicyOutput)
 			if !ok {
@@ -157,10 +190,20 @@ func validate(cmd *cobra.Command, args []string) error {
 				} else {
 					fmt.Printf("Resouce willn't change.\n")
 				}
+				if validateGNPOutput.SelectorChange != "" {
+					fmt.Printf("New selector is %s the current one.\n", selectorRelationText(validateGNPOutput.SelectorChange))
+				}
 			} else {
 				fmt.Printf("Resource doesn't exist and will be create new one.\n")
 			}
 
+			for _, relation := range validateGNPOutput.RelatedSelectors {
+				fmt.Printf("Selector is %s policy %s: %s\n", selectorRelationText(relation.Relation), relation.GNPName, relation.Selector)
+			}
+			if validateGNPOutput.RelatedSelectorsTruncated {
+				fmt.Printf("Selector was not compared with every policy, there are too many.\n")
+			}
+
 			if len(validateGNPOutput.ParsedHEPs) > 0 {
 				fmt.Printf("Resource will be match %d host endpoints:\n", len(validateGNPOutput.ParsedHEPs))
 				if errValidate = printParsedHEPs(validateGNPOutput.ParsedHEPs); errValidate != nil {
@@ -169,6 +212,15 @@ func validate(cmd *cobra.Command, args []string) error {
 			} else {
 				fmt.Printf("Resouce willn't be match with any host endpoint.\n")
 			}
+
+			if len(validateGNPOutput.UnmatchedSelectors) > 0 {
+				fmt.Printf("Warning: %d rule selectors match no host endpoint or global network set:\n", len(validateGNPOutput.UnmatchedSelectors))
+				for _, ref := range validateGNPOutput.UnmatchedSelectors {
+					fmt.Printf("  %s: %s\n", ref.Field, ref.Selector)
+				}
+			}
+			printLintFindings(validateGNPOutput.LintFindings)
+			printAdmissionVerdicts(validateGNPOutput.AdmissionVerdicts)
 		case resourcemanager.ResourceTypeGNS:
 			validateGNSOutput, ok := validateOutput.(*dto.ValidateGlobalNetworkSetOutput)
 			if !ok {
@@ -193,6 +245,203 @@ func validate(cmd *cobra.Command, args []string) error {
 			} else {
 				fmt.Printf("Resource doesn't exist and will be create new one.\n")
 			}
+
+			for _, warning := range validateGNSOutput.Warnings {
+				fmt.Printf("Warning: %s\n", warning)
+			}
+			printLintFindings(validateGNSOutput.LintFindings)
+			printAdmissionVerdicts(validateGNSOutput.AdmissionVerdicts)
+		case resourcemanager.ResourceTypeNP:
+			validateNPOutput, ok := validateOutput.(*dto.ValidateNetworkPolicyOutput)
+			if !ok {
+				fmt.Printf("invalid validate output. Raw: %v", validateNPOutput)
+				break
+			}
+			if validateNPOutput.NPExisted != nil {
+				patch, errDiff := jsondiff.Compare(validateNPOutput.NPExisted, validateNPOutput.NP, jsondiffOpts...)
+				if errDiff != nil {
+					fmt.Printf("Fail to compare NP. Error: %v\n", errDiff)
+					break
+				}
+				if patch != nil {
+					fmt.Printf("Resource will change:\n")
+					if errDiff = printDiff(patch); errDiff != nil {
+						fmt.Printf("Fail to print diff. Error: %v\n", errDiff)
+					}
+				} else {
+					fmt.Printf("Resouce willn't change.\n")
+				}
+			} else {
+				fmt.Printf("Resource doesn't exist and will be create new one.\n")
+			}
+
+			if len(validateNPOutput.ParsedHEPs) > 0 {
+				fmt.Printf("Resource will be match %d host endpoints:\n", len(validateNPOutput.ParsedHEPs))
+				if errValidate = printParsedHEPs(validateNPOutput.ParsedHEPs); errValidate != nil {
+					fmt.Printf("Fail to print related host endpoint. Error: %v\n", errValidate)
+				}
+			} else {
+				fmt.Printf("Resouce willn't be match with any host endpoint.\n")
+			}
+
+			if len(validateNPOutput.UnmatchedSelectors) > 0 {
+				fmt.Printf("Warning: %d rule selectors match no host endpoint or network set of the tenant:\n", len(validateNPOutput.UnmatchedSelectors))
+				for _, ref := range validateNPOutput.UnmatchedSelectors {
+					fmt.Printf("  %s: %s\n", ref.Field, ref.Selector)
+				}
+			}
+			printLintFindings(validateNPOutput.LintFindings)
+			printAdmissionVerdicts(validateNPOutput.AdmissionVerdicts)
+		case resourcemanager.ResourceTypeNS:
+			validateNSOutput, ok := validateOutput.(*dto.ValidateNetworkSetOutput)
+			if !ok {
+				fmt.Printf("invalid validate output. Raw: %v", validateNSOutput)
+				break
+			}
+
+			if validateNSOutput.NSExisted != nil {
+				patch, errDiff := jsondiff.Compare(validateNSOutput.NSExisted, validateNSOutput.NS, jsondiffOpts...)
+				if errDiff != nil {
+					fmt.Printf("Fail to compare NS. Error: %v\n", errDiff)
+					break
+				}
+				if patch != nil {
+					fmt.Printf("Resource will change:\n")
+					if errDiff = printDiff(patch); errDiff != nil {
+						fmt.Printf("Fail to print diff. Error: %v\n", errDiff)
+					}
+				} else {
+					fmt.Printf("Resouce willn't change.\n")
+				}
+			} else {
+				fmt.Printf("Resource doesn't exist and will be create new one.\n")
+			}
+
+			for _, warning := range validateNSOutput.Warnings {
+				fmt.Printf("Warning: %s\n", warning)
+			}
+			printLintFindings(validateNSOutput.LintFindings)
+			printAdmissionVerdicts(validateNSOutput.AdmissionVerdicts)
+		case resourcemanager.ResourceTypeTier:
+			validateTierOutput, ok := validateOutput.(*dto.ValidateTierOutput)
+			if !ok {
+				fmt.Printf("invalid validate output. Raw: %v", validateTierOutput)
+				break
+			}
+
+			if validateTierOutput.TierExisted != nil {
+				patch, errDiff := jsondiff.Compare(validateTierOutput.TierExisted, validateTierOutput.Tier, jsondiffOpts...)
+				if errDiff != nil {
+					fmt.Printf("Fail to compare tier. Error: %v\n", errDiff)
+					break
+				}
+				if patch != nil {
+					fmt.Printf("Resource will change:\n")
+					if errDiff = printDiff(patch); errDiff != nil {
+						fmt.Printf("Fail to print diff. Error: %v\n", errDiff)
+					}
+				} else {
+					fmt.Printf("Resouce willn't change.\n")
+				}
+			} else {
+				fmt.Printf("Resource doesn't exist and will be create new one.\n")
+			}
+		case resourcemanager.ResourceTypeEnrollmentToken:
+			validateTokenOutput, ok := validateOutput.(*dto.ValidateEnrollmentTokenOutput)
+			if !ok {
+				fmt.Printf("invalid validate output. Raw: %v", validateTokenOutput)
+				break
+			}
+
+			if validateTokenOutput.EnrollmentTokenExisted != nil {
+				patch, errDiff := jsondiff.Compare(validateTokenOutput.EnrollmentTokenExisted, validateTokenOutput.EnrollmentToken, jsondiffOpts...)
+				if errDiff != nil {
+					fmt.Printf("Fail to compare enrollment token. Error: %v\n", errDiff)
+					break
+				}
+				if patch != nil {
+					fmt.Printf("Resource will change:\n")
+					if errDiff = printDiff(patch); errDiff != nil {
+						fmt.Printf("Fail to print diff. Error: %v\n", errDiff)
+					}
+				} else {
+					fmt.Printf("Resouce willn't change.\n")
+				}
+			} else {
+				fmt.Printf("Resource doesn't exist and will be create new one.\n")
+			}
+		case resourcemanager.ResourceTypeWebhook:
+			validateWebhookOutput, ok := validateOutput.(*dto.ValidateWebhookOutput)
+			if !ok {
+				fmt.Printf("invalid validate output. Raw: %v", validateWebhookOutput)
+				break
+			}
+
+			if validateWebhookOutput.WebhookExisted != nil {
+				patch, errDiff := jsondiff.Compare(validateWebhookOutput.WebhookExisted, validateWebhookOutput.Webhook, jsondiffOpts...)
+				if errDiff != nil {
+					fmt.Printf("Fail to compare webhook. Error: %v\n", errDiff)
+					break
+				}
+				if patch != nil {
+					fmt.Printf("Resource will change:\n")
+					if errDiff = printDiff(patch); errDiff != nil {
+						fmt.Printf("Fail to print diff. Error: %v\n", errDiff)
+					}
+				} else {
+					fmt.Printf("Resouce willn't change.\n")
+				}
+			} else {
+				fmt.Printf("Resource doesn't exist and will be create new one.\n")
+			}
+		case resourcemanager.ResourceTypeAdmissionHook:
+			validateAdmissionHookOutput, ok := validateOutput.(*dto.ValidateAdmissionHookOutput)
+			if !ok {
+				fmt.Printf("invalid validate output. Raw: %v", validateAdmissionHookOutput)
+				break
+			}
+
+			if validateAdmissionHookOutput.AdmissionHookExisted != nil {
+				patch, errDiff := jsondiff.Compare(validateAdmissionHookOutput.AdmissionHookExisted, validateAdmissionHookOutput.AdmissionHook, jsondiffOpts...)
+				if errDiff != nil {
+					fmt.Printf("Fail to compare admission hook. Error: %v\n", errDiff)
+					break
+				}
+				if patch != nil {
+					fmt.Printf("Resource will change:\n")
+					if errDiff = printDiff(patch); errDiff != nil {
+						fmt.Printf("Fail to print diff. Error: %v\n", errDiff)
+					}
+				} else {
+					fmt.Printf("Resouce willn't change.\n")
+				}
+			} else {
+				fmt.Printf("Resource doesn't exist and will be create new one.\n")
+			}
+		case resourcemanager.ResourceTypeTenant:
+			validateTenantOutput, ok := validateOutput.(*dto.ValidateTenantOutput)
+			if !ok {
+				fmt.Printf("invalid validate output. Raw: %v", validateTenantOutput)
+				break
+			}
+
+			if validateTenantOutput.TenantExisted != nil {
+				patch, errDiff := jsondiff.Compare(validateTenantOutput.TenantExisted, validateTenantOutput.Tenant, jsondiffOpts...)
+				if errDiff != nil {
+					fmt.Printf("Fail to compare tenant. Error: %v\n", errDiff)
+					break
+				}
+				if patch != nil {
+					fmt.Printf("Resource will change:\n")
+					if errDiff = printDiff(patch); errDiff != nil {
+						fmt.Printf("Fail to print diff. Error: %v\n", errDiff)
+					}
+				} else {
+					fmt.Printf("Resouce willn't change.\n")
+				}
+			} else {
+				fmt.Printf("Resource doesn't exist and will be create new one.\n")
+			}
 		default:
 			return fmt.Errorf("invalid resource type: %s", resourceType)
 		}
@@ -269,3 +518,48 @@ func printParsedHEPs(parsedHEPs []*dto.ParsedHEP) error {
 	fmt.Printf("\n")
 	return nil
 }
+
+func selectorRelationText(relation string) string {
+	switch relation {
+	case "identical":
+		return "identical to"
+	case "narrower":
+		return "strictly narrower than"
+	case "broader":
+		return "strictly broader than"
+	}
+	return "different from"
+}
+
+func printLintFindings(findings []*dto.LintFinding) {
+	if len(findings) == 0 {
+		return
+	}
+	fmt.Printf("Warning: %d lint findings:\n", len(findings))
+	for _, finding := range findings {
+		fmt.Printf("  %s\n", finding)
+	}
+}
+
+// printAdmissionVerdicts prints the verdicts of the admission hooks denying the change.
+func printAdmissionVerdicts(verdicts []*dto.AdmissionVerdict) {
+	for _, verdict := range verdicts {
+		if verdict.Allowed {
+			continue
+		}
+		fmt.Printf("Denied by admission hook %s: %s\n", verdict.AdmissionHook, verdict.Message)
+	}
+}
+
+func printInvalidDetail(detail interface{}) {
+	fmt.Printf("Resource is invalid. Detail:\n")
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(detail); err != nil {
+		fmt.Printf("Error encoding detail: %v. Error: %v\n", detail, err)
+	} else {
+		fmt.Printf("%s\n", buf.String())
+	}
+}
@@ -0,0 +1,292 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/api/v1/mapper"
+	"github.com/bamboo-firewall/be/cmd/bamboofwcli/command/common"
+	"github.com/bamboo-firewall/be/cmd/bamboofwcli/command/resourcemanager"
+	"github.com/bamboo-firewall/be/pkg/backup"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/selector"
+	"github.com/bamboo-firewall/be/pkg/validator"
+)
+
+// offlineSnapshot holds the host endpoints and sets selectors are resolved against in offline mode.
+type offlineSnapshot struct {
+	heps []*dto.ParsedHEP
+	// hepLabels are the labels of heps, by index
+	hepLabels []map[string]string
+	sets      []*entity.GlobalNetworkSet
+}
+
+// loadOfflineSnapshot reads a directory holding a sub directory of files per resource type, named as the resource
+// type, e.g. hep/, gns/ and ns/, or a bundle file written by backup.
+func loadOfflineSnapshot(dir string) (*offlineSnapshot, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot %q: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return loadBundleSnapshot(dir)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot directory %q: %w", dir, err)
+	}
+	snapshot := &offlineSnapshot{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		resourceMgr, err := common.GetResourceMgrByType(entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("snapshot directory %q: %w", entry.Name(), err)
+		}
+		fileNames, err := snapshotFileNames(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		switch resourceMgr.GetResourceType() {
+		case resourcemanager.ResourceTypeHEP:
+			resources, err := common.GetResourceFilesByFileNames[dto.CreateHostEndpointInput](fileNames)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range resources {
+				input := r.Content.(*dto.CreateHostEndpointInput)
+				tenantID := input.Spec.TenantID
+				if tenantID == 0 {
+					tenantID = entity.DefaultTenantID
+				}
+				snapshot.heps = append(snapshot.heps, &dto.ParsedHEP{
+					TenantID: tenantID,
+					Name:     input.Metadata.Name,
+					IP:       offlineHEPIP(input.Spec.IP, input.Spec.IPs),
+				})
+				snapshot.hepLabels = append(snapshot.hepLabels, input.Metadata.Labels)
+			}
+		case resourcemanager.ResourceTypeGNS:
+			resources, err := common.GetResourceFilesByFileNames[dto.CreateGlobalNetworkSetInput](fileNames)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range resources {
+				input := mapper.ToCreateGlobalNetworkSetInput(r.Content.(*dto.CreateGlobalNetworkSetInput))
+				snapshot.sets = append(snapshot.sets, mapper.ToLintSetEntity(input))
+			}
+		case resourcemanager.ResourceTypeNS:
+			resources, err := common.GetResourceFilesByFileNames[dto.CreateNetworkSetInput](fileNames)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range resources {
+				input := mapper.ToCreateNetworkSetInput(r.Content.(*dto.CreateNetworkSetInput))
+				if input.Metadata.TenantID == 0 {
+					input.Metadata.TenantID = entity.DefaultTenantID
+				}
+				snapshot.sets = append(snapshot.sets, mapper.ToLintSetEntity(input))
+			}
+		default:
+			return nil, fmt.Errorf("snapshot directory %q: only host endpoints and sets are supported", entry.Name())
+		}
+	}
+	return snapshot, nil
+}
+
+// loadBundleSnapshot reads the host endpoints and sets of a bundle of any schema version.
+func loadBundleSnapshot(fileName string) (*offlineSnapshot, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot bundle %q: %w", fileName, err)
+	}
+	if data, err = backup.Upgrade(data); err != nil {
+		return nil, fmt.Errorf("snapshot bundle %q: %w", fileName, err)
+	}
+	bundle := new(dto.Bundle)
+	if err = json.Unmarshal(data, bundle); err != nil {
+		return nil, fmt.Errorf("decode snapshot bundle %q: %w", fileName, err)
+	}
+
+	snapshot := &offlineSnapshot{}
+	for _, hep := range bundle.HostEndpoints {
+		snapshot.heps = append(snapshot.heps, &dto.ParsedHEP{
+			TenantID: hep.Spec.TenantID,
+			Name:     hep.Metadata.Name,
+			IP:       offlineHEPIP(hep.Spec.IP, hep.Spec.IPs),
+		})
+		snapshot.hepLabels = append(snapshot.hepLabels, hep.Metadata.Labels)
+	}
+	for _, set := range slices.Concat(bundle.GlobalNetworkSets, bundle.NetworkSets) {
+		snapshot.sets = append(snapshot.sets, &entity.GlobalNetworkSet{
+			Metadata: entity.GNSMetadata{
+				Name:     set.Metadata.Name,
+				TenantID: set.Metadata.TenantID,
+				Labels:   set.Metadata.Labels,
+			},
+		})
+	}
+	return snapshot, nil
+}
+
+// offlineHEPIP returns the ip of a host endpoint, which defaults to its first ip version 4 as the api server does.
+func offlineHEPIP(ip string, ips []string) string {
+	if ip != "" {
+		return ip
+	}
+	for _, ipString := range ips {
+		if addr, err := netip.ParseAddr(ipString); err == nil && addr.Is4() {
+			return ipString
+		}
+	}
+	return ""
+}
+
+// snapshotFileNames returns the yaml and json files of dir.
+func snapshotFileNames(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot directory %q: %w", dir, err)
+	}
+	var fileNames []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch common.FileExtension(strings.TrimLeft(filepath.Ext(entry.Name()), ".")) {
+		case common.FileExtensionYAML, common.FileExtensionYML, common.FileExtensionJSON:
+			fileNames = append(fileNames, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return fileNames, nil
+}
+
+// matchedHEPs returns the host endpoints sel matches in the scope of policy: a global network policy may select the
+// host endpoints of any tenant, a network policy only the ones of its tenant.
+func (s *offlineSnapshot) matchedHEPs(policy *entity.GlobalNetworkPolicy, sel selector.Selector) []*dto.ParsedHEP {
+	var matched []*dto.ParsedHEP
+	for i, hep := range s.heps {
+		if policy.IsNamespaced() && policy.Metadata.TenantID != hep.TenantID {
+			continue
+		}
+		if sel.EvaluateLabels(selector.WithTenant(s.hepLabels[i], hep.TenantID)) {
+			matched = append(matched, hep)
+		}
+	}
+	return matched
+}
+
+// unmatchedRuleSelectors returns the rule selectors of policy which match no host endpoint and no set in its scope.
+func (s *offlineSnapshot) unmatchedRuleSelectors(policy *entity.GlobalNetworkPolicy) []*dto.SelectorReference {
+	var unmatched []*dto.SelectorReference
+	var check = func(field string, ruleEntity *entity.GNPSpecRuleEntity) {
+		if ruleEntity == nil || ruleEntity.Selector == "" {
+			return
+		}
+		sel, err := selector.Parse(ruleEntity.Selector)
+		if err != nil {
+			return
+		}
+		if len(s.matchedHEPs(policy, sel)) > 0 {
+			return
+		}
+		for _, set := range s.sets {
+			inScope := set.Metadata.TenantID == 0 || set.Metadata.TenantID == policy.Metadata.TenantID
+			if inScope && sel.EvaluateLabels(selector.WithTenant(set.Metadata.Labels, set.Metadata.TenantID)) {
+				return
+			}
+		}
+		unmatched = append(unmatched, &dto.SelectorReference{
+			GNPName:  policy.Metadata.Name,
+			Field:    field,
+			Selector: ruleEntity.Selector,
+		})
+	}
+	var checkRules = func(direction string, rules []entity.GNPSpecRule) {
+		for i, rule := range rules {
+			check(fmt.Sprintf("spec.%s[%d].source.selector", direction, i), rule.Source)
+			check(fmt.Sprintf("spec.%s[%d].destination.selector", direction, i), rule.Destination)
+		}
+	}
+	checkRules("ingress", policy.Spec.Ingress)
+	checkRules("egress", policy.Spec.Egress)
+	return unmatched
+}
+
+// validateResourcesOffline validates resources with the validators of the api server, and resolves the selectors of
+// policies against the snapshot if set. It does not check the resources against the stored ones.
+func validateResourcesOffline(resourceType resourcemanager.ResourceType, resources []*common.ResourceFile) error {
+	validator.Init()
+
+	var snapshot *offlineSnapshot
+	if validateSnapshot != "" {
+		var err error
+		if snapshot, err = loadOfflineSnapshot(validateSnapshot); err != nil {
+			return err
+		}
+	}
+
+	invalid := 0
+	for _, r := range resources {
+		fmt.Printf("Validate for resource %s\n", r.Name)
+		if ierr := httpbase.ValidateStruct(context.Background(), r.Content); ierr != nil {
+			printInvalidDetail(ierr.Detail)
+			invalid++
+			continue
+		}
+
+		var policy *entity.GlobalNetworkPolicy
+		var warnings []string
+		switch resourceType {
+		case resourcemanager.ResourceTypeGNS:
+			warnings = validator.NetOverlapWarnings(r.Content.(*dto.CreateGlobalNetworkSetInput).Spec.Nets)
+		case resourcemanager.ResourceTypeNS:
+			warnings = validator.NetOverlapWarnings(r.Content.(*dto.CreateNetworkSetInput).Spec.Nets)
+		case resourcemanager.ResourceTypeGNP:
+			policy = mapper.ToLintPolicyEntity(mapper.ToCreateGlobalNetworkPolicyInput(r.Content.(*dto.CreateGlobalNetworkPolicyInput)))
+		case resourcemanager.ResourceTypeNP:
+			input := mapper.ToCreateNetworkPolicyInput(r.Content.(*dto.CreateNetworkPolicyInput))
+			if input.Metadata.TenantID == 0 {
+				input.Metadata.TenantID = entity.DefaultTenantID
+			}
+			policy = mapper.ToLintPolicyEntity(input)
+		}
+		fmt.Printf("Resource is valid.\n")
+		for _, warning := range warnings {
+			fmt.Printf("Warning: %s\n", warning)
+		}
+		if policy == nil || snapshot == nil {
+			continue
+		}
+
+		// the spec selector is checked by the validators
+		sel, _ := selector.Parse(policy.Spec.Selector)
+		if parsedHEPs := snapshot.matchedHEPs(policy, sel); len(parsedHEPs) > 0 {
+			fmt.Printf("Resource will match %d host endpoints:\n", len(parsedHEPs))
+			if err := printParsedHEPs(parsedHEPs); err != nil {
+				fmt.Printf("Fail to print related host endpoint. Error: %v\n", err)
+			}
+		} else {
+			fmt.Printf("Resource will not match any host endpoint.\n")
+		}
+		if unmatched := snapshot.unmatchedRuleSelectors(policy); len(unmatched) > 0 {
+			fmt.Printf("Warning: %d rule selectors match no host endpoint or set of the snapshot:\n", len(unmatched))
+			for _, ref := range unmatched {
+				fmt.Printf("  %s: %s\n", ref.Field, ref.Selector)
+			}
+		}
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d resources are invalid", invalid, len(resources))
+	}
+	return nil
+}
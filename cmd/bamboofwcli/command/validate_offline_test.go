@@ -0,0 +1,337 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/cmd/bamboofwcli/command/common"
+	"github.com/bamboo-firewall/be/cmd/bamboofwcli/command/resourcemanager"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/selector"
+)
+
+// writeSnapshotFile writes content to name under dir, creating its directories.
+func writeSnapshotFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	fileName := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(fileName), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fileName, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return fileName
+}
+
+// snapshotDir returns a snapshot directory with the host endpoint web of the default tenant which has no ip, the host
+// endpoint db of the tenant 2, the global set lb and the set cache of the tenant 2.
+func snapshotDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeSnapshotFile(t, dir, "hep/web.yaml", `
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  ips: ["fd00::1", "10.0.0.1"]
+`)
+	writeSnapshotFile(t, dir, "hep/db.json", `{
+  "metadata": {"name": "db", "labels": {"app": "db"}},
+  "spec": {"tenantID": 2, "ip": "10.0.0.2", "ips": ["10.0.0.2"]}
+}`)
+	writeSnapshotFile(t, dir, "hep/README.md", "not a resource")
+	writeSnapshotFile(t, dir, "gns/lb.yaml", `
+metadata:
+  name: lb
+  labels:
+    role: lb
+spec:
+  nets: ["192.168.0.0/24"]
+`)
+	writeSnapshotFile(t, dir, "ns/cache.yaml", `
+metadata:
+  name: cache
+  tenantID: 2
+  labels:
+    role: cache
+spec:
+  nets: ["192.168.1.0/24"]
+`)
+	return dir
+}
+
+// snapshotBundle returns a backup bundle holding the resources of snapshotDir as they are stored.
+func snapshotBundle(t *testing.T) string {
+	t.Helper()
+	return writeSnapshotFile(t, t.TempDir(), "bundle.json", `{
+  "schemaVersion": 1,
+  "hostEndpoints": [
+    {"metadata": {"name": "db", "labels": {"app": "db"}}, "spec": {"tenantID": 2, "ip": "10.0.0.2", "ips": ["10.0.0.2"]}},
+    {"metadata": {"name": "web", "labels": {"app": "web"}}, "spec": {"tenantID": 1, "ips": ["fd00::1", "10.0.0.1"]}}
+  ],
+  "globalNetworkSets": [{"metadata": {"name": "lb", "labels": {"role": "lb"}}, "spec": {"nets": ["192.168.0.0/24"]}}],
+  "networkSets": [
+    {"metadata": {"name": "cache", "tenantID": 2, "labels": {"role": "cache"}}, "spec": {"nets": ["192.168.1.0/24"]}}
+  ]
+}`)
+}
+
+func TestLoadOfflineSnapshot(t *testing.T) {
+	wantHEPs := []*dto.ParsedHEP{
+		{TenantID: 2, Name: "db", IP: "10.0.0.2"},
+		{TenantID: entity.DefaultTenantID, Name: "web", IP: "10.0.0.1"},
+	}
+	// wantSets are the tenant and name of the sets
+	wantSets := []string{"0/lb", "2/cache"}
+	tests := []struct {
+		name     string
+		snapshot func(t *testing.T) string
+		wantErr  string
+	}{
+		{name: "directory", snapshot: snapshotDir},
+		{name: "bundle", snapshot: snapshotBundle},
+		{
+			name: "unsupported directory",
+			snapshot: func(t *testing.T) string {
+				dir := snapshotDir(t)
+				writeSnapshotFile(t, dir, "gnp/allow.yaml", "metadata:\n  name: allow\n")
+				return dir
+			},
+			wantErr: "only host endpoints and sets are supported",
+		},
+		{
+			name: "bundle without schema version",
+			snapshot: func(t *testing.T) string {
+				return writeSnapshotFile(t, t.TempDir(), "bundle.json", `{"hostEndpoints": []}`)
+			},
+			wantErr: "bundle has no schema version",
+		},
+		{
+			name: "missing",
+			snapshot: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "missing")
+			},
+			wantErr: "no such file or directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot, err := loadOfflineSnapshot(tt.snapshot(t))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("loadOfflineSnapshot() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadOfflineSnapshot() error = %v", err)
+			}
+			if len(snapshot.hepLabels) != len(snapshot.heps) {
+				t.Fatalf("%d labels for %d host endpoints", len(snapshot.hepLabels), len(snapshot.heps))
+			}
+			if diff := cmp.Diff(wantHEPs, snapshot.heps); diff != "" {
+				t.Errorf("host endpoints mismatch (-want +got):\n%s", diff)
+			}
+			var sets []string
+			for _, set := range snapshot.sets {
+				sets = append(sets, fmt.Sprintf("%d/%s", set.Metadata.TenantID, set.Metadata.Name))
+			}
+			if diff := cmp.Diff(wantSets, sets); diff != "" {
+				t.Errorf("sets mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOfflineSnapshotMatchedHEPs(t *testing.T) {
+	snapshot, err := loadOfflineSnapshot(snapshotDir(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		tenantID uint64
+		selector string
+		want     []string
+	}{
+		{name: "global policy", selector: "app == 'db'", want: []string{"db"}},
+		{name: "global policy of any tenant", selector: "has(app)", want: []string{"db", "web"}},
+		{name: "tenant pseudo-label", selector: "tenant == '2'", want: []string{"db"}},
+		{name: "network policy of the tenant", tenantID: 2, selector: "has(app)", want: []string{"db"}},
+		{name: "network policy of another tenant", tenantID: entity.DefaultTenantID, selector: "app == 'db'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, err := selector.Parse(tt.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			policy := &entity.GlobalNetworkPolicy{Metadata: entity.GNPMetadata{Name: "allow", TenantID: tt.tenantID}}
+			var got []string
+			for _, hep := range snapshot.matchedHEPs(policy, sel) {
+				got = append(got, hep.Name)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("matchedHEPs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOfflineSnapshotUnmatchedRuleSelectors(t *testing.T) {
+	snapshot, err := loadOfflineSnapshot(snapshotBundle(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := func(source, destination string) entity.GNPSpecRule {
+		return entity.GNPSpecRule{
+			Action:      string(entity.RuleActionAllow),
+			Source:      &entity.GNPSpecRuleEntity{Selector: source},
+			Destination: &entity.GNPSpecRuleEntity{Selector: destination},
+		}
+	}
+	tests := []struct {
+		name     string
+		tenantID uint64
+		ingress  []entity.GNPSpecRule
+		egress   []entity.GNPSpecRule
+		// want are the field and the selector of the unmatched rule selectors
+		want [][2]string
+	}{
+		{
+			name:    "host endpoints and global sets matched",
+			ingress: []entity.GNPSpecRule{rule("app == 'web'", "role == 'lb'")},
+			egress:  []entity.GNPSpecRule{rule("", "tenant == '2'")},
+		},
+		{
+			name:    "nothing matched",
+			ingress: []entity.GNPSpecRule{rule("app == 'web'", "app == 'api'")},
+			egress:  []entity.GNPSpecRule{rule("app == 'api' || role == 'api'", "")},
+			want: [][2]string{
+				{"spec.ingress[0].destination.selector", "app == 'api'"},
+				{"spec.egress[0].source.selector", "app == 'api' || role == 'api'"},
+			},
+		},
+		{
+			name:    "set of another tenant",
+			ingress: []entity.GNPSpecRule{rule("role == 'cache'", "")},
+			want:    [][2]string{{"spec.ingress[0].source.selector", "role == 'cache'"}},
+		},
+		{
+			name:     "set of the tenant",
+			tenantID: 2,
+			ingress:  []entity.GNPSpecRule{rule("role == 'cache'", "role == 'lb'")},
+		},
+		{
+			name:     "host endpoint of another tenant",
+			tenantID: 2,
+			ingress:  []entity.GNPSpecRule{rule("app == 'web'", "")},
+			want:     [][2]string{{"spec.ingress[0].source.selector", "app == 'web'"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &entity.GlobalNetworkPolicy{
+				Metadata: entity.GNPMetadata{Name: "allow", TenantID: tt.tenantID},
+				Spec:     entity.GNPSpec{Ingress: tt.ingress, Egress: tt.egress},
+			}
+			var got [][2]string
+			for _, ref := range snapshot.unmatchedRuleSelectors(policy) {
+				if ref.GNPName != "allow" {
+					t.Errorf("reference of the policy %q, want allow", ref.GNPName)
+				}
+				got = append(got, [2]string{ref.Field, ref.Selector})
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmatchedRuleSelectors() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func allowFrom(sourceSelector string) []dto.GNPSpecRuleInput {
+	return []dto.GNPSpecRuleInput{{
+		Action: string(entity.RuleActionAllow),
+		Source: &dto.GNPSpecRuleEntityInput{Selector: sourceSelector},
+	}}
+}
+
+func TestValidateResourcesOffline(t *testing.T) {
+	tests := []struct {
+		name         string
+		snapshot     string
+		resourceType resourcemanager.ResourceType
+		contents     []interface{}
+		wantErr      string
+	}{
+		{
+			name:         "valid policies",
+			snapshot:     "directory",
+			resourceType: resourcemanager.ResourceTypeGNP,
+			contents: []interface{}{
+				&dto.CreateGlobalNetworkPolicyInput{
+					Metadata: dto.GNPMetadataInput{Name: "allow-web"},
+					Spec:     dto.GNPSpecInput{Selector: "app == 'db'", Ingress: allowFrom("app == 'api'")},
+				},
+			},
+		},
+		{
+			name:         "invalid policy",
+			snapshot:     "bundle",
+			resourceType: resourcemanager.ResourceTypeNP,
+			contents: []interface{}{
+				&dto.CreateNetworkPolicyInput{
+					Metadata: dto.NPMetadataInput{Name: "allow-web", TenantID: 2},
+					Spec:     dto.GNPSpecInput{Selector: "app == 'db'", Ingress: allowFrom("app == 'web'")},
+				},
+				&dto.CreateNetworkPolicyInput{
+					Metadata: dto.NPMetadataInput{Name: "allow-api"},
+					Spec:     dto.GNPSpecInput{Selector: "app ==", Ingress: allowFrom("app == 'web'")},
+				},
+			},
+			wantErr: "1 of 2 resources are invalid",
+		},
+		{
+			name:         "invalid sets without snapshot",
+			resourceType: resourcemanager.ResourceTypeNS,
+			contents: []interface{}{
+				&dto.CreateNetworkSetInput{Metadata: dto.NSMetadataInput{Name: "cache"}},
+				&dto.CreateNetworkSetInput{
+					Metadata: dto.NSMetadataInput{Name: "lb"},
+					Spec:     dto.NSSpecInput{Nets: []string{"192.168.0.0/24", "192.168.0.10/32"}},
+				},
+				&dto.CreateNetworkSetInput{Metadata: dto.NSMetadataInput{Name: "Invalid Name"}},
+			},
+			wantErr: "2 of 3 resources are invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			switch tt.snapshot {
+			case "directory":
+				validateSnapshot = snapshotDir(t)
+			case "bundle":
+				validateSnapshot = snapshotBundle(t)
+			default:
+				validateSnapshot = ""
+			}
+			t.Cleanup(func() { validateSnapshot = "" })
+			var resources []*common.ResourceFile
+			for i, content := range tt.contents {
+				resources = append(resources, &common.ResourceFile{Name: fmt.Sprintf("resource-%d", i), Content: content})
+			}
+
+			err := validateResourcesOffline(tt.resourceType, resources)
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("validateResourcesOffline() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
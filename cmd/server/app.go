@@ -4,10 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/bamboo-firewall/be/cmd/server/route"
 	"github.com/bamboo-firewall/be/config"
+	"github.com/bamboo-firewall/be/domain/service"
 	"github.com/bamboo-firewall/be/pkg/httpbase"
 	"github.com/bamboo-firewall/be/pkg/repository"
 	"github.com/bamboo-firewall/be/pkg/storage"
@@ -20,8 +26,17 @@ type App interface {
 }
 
 type app struct {
-	httpServer *httpbase.Server
-	policyDB   *storage.PolicyDB
+	httpServer     *httpbase.Server
+	grpcServer     *grpc.Server
+	grpcAddr       string
+	policyDB       *storage.PolicyDB
+	policySnapshot *service.PolicySnapshot
+	snapshotResync time.Duration
+	gnsSyncer      *service.GNSSyncer
+	gnsCheck       time.Duration
+	dispatcher     *service.WebhookDispatcher
+	deliveryCheck  time.Duration
+	cancel         context.CancelFunc
 }
 
 func NewApp(cfg config.Config) (App, error) {
@@ -32,7 +47,34 @@ func NewApp(cfg config.Config) (App, error) {
 
 	validator.Init()
 
-	router := route.RegisterHandler(repository.NewPolicy(policy))
+	repo := repository.NewPolicy(policy)
+	policySnapshot := service.NewPolicySnapshot(repo)
+	if err = policySnapshot.Load(context.Background()); err != nil {
+		return nil, fmt.Errorf("load policy snapshot: %w", err)
+	}
+
+	lintSeverities, err := validator.ParseLintSeverities(cfg.LintSeverities)
+	if err != nil {
+		return nil, fmt.Errorf("parse lint severities: %w", err)
+	}
+
+	router := route.RegisterHandler(repo, policySnapshot, validator.NewLinter(lintSeverities))
+	var (
+		grpcServer *grpc.Server
+		grpcAddr   string
+	)
+	if cfg.GRPCServerPort != "" {
+		var opts []grpc.ServerOption
+		if cfg.GRPCTLSCertFile != "" {
+			creds, err := credentials.NewServerTLSFromFile(cfg.GRPCTLSCertFile, cfg.GRPCTLSKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("load grpc tls: %w", err)
+			}
+			opts = append(opts, grpc.Creds(creds))
+		}
+		grpcServer = route.RegisterGRPCHandler(repo, policySnapshot, opts...)
+		grpcAddr = fmt.Sprintf("%s:%s", cfg.GRPCServerHost, cfg.GRPCServerPort)
+	}
 	return &app{
 		httpServer: httpbase.NewServer(
 			fmt.Sprintf("%s:%s", cfg.HTTPServerHost, cfg.HTTPServerPort),
@@ -44,18 +86,56 @@ func NewApp(cfg config.Config) (App, error) {
 				IdleTimeout:       cfg.HTTPServerIdleTimeout,
 			},
 		),
-		policyDB: policy,
+		grpcServer:     grpcServer,
+		grpcAddr:       grpcAddr,
+		policyDB:       policy,
+		policySnapshot: policySnapshot,
+		snapshotResync: cfg.PolicySnapshotResync,
+		gnsSyncer:      service.NewGNSSyncer(repo, policySnapshot, service.NewGNSSourceFetcher(cfg.GNSSourceFileDir, cfg.GNSSourceAllowPrivateURLs), net.DefaultResolver, cfg.GNSDomainResolveInterval, cfg.GNSSourceAllowPrivateURLs),
+		gnsCheck:       cfg.GNSSourceCheckInterval,
+		dispatcher:     service.NewWebhookDispatcher(repo, cfg.GNSSourceAllowPrivateURLs),
+		deliveryCheck:  cfg.WebhookDeliveryInterval,
 	}, nil
 }
 
 func (a *app) Start() error {
-	if err := a.httpServer.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		return err
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+	go a.policySnapshot.Run(ctx, a.snapshotResync)
+	go a.policySnapshot.RunScheduler(ctx)
+	go a.gnsSyncer.Run(ctx, a.gnsCheck)
+	go a.dispatcher.Run(ctx, a.deliveryCheck)
+
+	// the first server failing stops the app, Start returns nil once the http server is stopped
+	errCh := make(chan error, 2)
+	if a.grpcServer != nil {
+		listener, err := net.Listen("tcp", a.grpcAddr)
+		if err != nil {
+			return fmt.Errorf("listen grpc: %w", err)
+		}
+		go func() {
+			if err := a.grpcServer.Serve(listener); err != nil {
+				errCh <- fmt.Errorf("serve grpc: %w", err)
+			}
+		}()
 	}
-	return nil
+	go func() {
+		if err := a.httpServer.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+			return
+		}
+		errCh <- nil
+	}()
+	return <-errCh
 }
 
 func (a *app) Stop(ctx context.Context) error {
+	if a.cancel != nil {
+		a.cancel()
+	}
+	if a.grpcServer != nil {
+		stopGRPC(ctx, a.grpcServer)
+	}
 	if err := a.httpServer.Stop(ctx); err != nil {
 		return err
 	}
@@ -64,3 +144,18 @@ func (a *app) Stop(ctx context.Context) error {
 	}
 	return nil
 }
+
+// stopGRPC stops the grpc server gracefully, then forcibly once ctx is done since watch streams only end when the
+// agents disconnect.
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
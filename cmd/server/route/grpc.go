@@ -0,0 +1,21 @@
+package route
+
+import (
+	"google.golang.org/grpc"
+
+	"github.com/bamboo-firewall/be/api/v1/pb"
+	"github.com/bamboo-firewall/be/api/v1/rpc"
+	"github.com/bamboo-firewall/be/domain/service"
+	"github.com/bamboo-firewall/be/pkg/repository"
+)
+
+// RegisterGRPCHandler returns the grpc server of the agent api. Agents authenticate with an enrollment token, opts
+// may set the transport credentials.
+func RegisterGRPCHandler(repo *repository.PolicyDB, snapshot *service.PolicySnapshot, opts ...grpc.ServerOption) *grpc.Server {
+	hepService := service.NewHEP(repo, snapshot)
+	auth := rpc.NewAgentAuth(hepService)
+	opts = append(opts, grpc.UnaryInterceptor(auth.Unary), grpc.StreamInterceptor(auth.Stream))
+	server := grpc.NewServer(opts...)
+	pb.RegisterAgentPolicyServer(server, rpc.NewAgentPolicy(hepService))
+	return server
+}
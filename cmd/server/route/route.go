@@ -9,9 +9,10 @@ import (
 	"github.com/bamboo-firewall/be/cmd/server/middleware"
 	"github.com/bamboo-firewall/be/domain/service"
 	"github.com/bamboo-firewall/be/pkg/repository"
+	"github.com/bamboo-firewall/be/pkg/validator"
 )
 
-func RegisterHandler(repo *repository.PolicyDB) http.Handler {
+func RegisterHandler(repo *repository.PolicyDB, snapshot *service.PolicySnapshot, linter *validator.Linter) http.Handler {
 	router := gin.New()
 
 	router.Use(gin.Recovery())
@@ -20,7 +21,7 @@ func RegisterHandler(repo *repository.PolicyDB) http.Handler {
 	router.GET("/api/v1/ping", handler.Ping)
 
 	{
-		hepHandler := handler.NewHEP(service.NewHEP(repo))
+		hepHandler := handler.NewHEP(service.NewHEP(repo, snapshot))
 		router.POST("/api/v1/hostEndpoints", hepHandler.Create)
 		router.GET("/api/v1/hostEndpoints", hepHandler.List)
 		router.GET("/api/v1/hostEndpoints/byTenantID/:tenantID/byIP/:ip", hepHandler.Get)
@@ -28,19 +29,26 @@ func RegisterHandler(repo *repository.PolicyDB) http.Handler {
 		router.POST("/api/v1/hostEndpoints/validate", hepHandler.Validate)
 
 		router.GET("/api/internal/v1/hostEndpoints/fetchPolicies", hepHandler.FetchPolicies)
+		router.POST("/api/internal/v1/hostEndpoints/fetchPolicies", hepHandler.FetchPolicyDelta)
+		router.POST("/api/internal/v1/hostEndpoints/register", hepHandler.Register)
 	}
 
 	{
-		gnpHandler := handler.NewGNP(service.NewGNP(repo))
+		gnpHandler := handler.NewGNP(service.NewGNP(repo, snapshot, linter))
 		router.POST("/api/v1/globalNetworkPolicies", gnpHandler.Create)
 		router.GET("/api/v1/globalNetworkPolicies", gnpHandler.List)
 		router.GET("/api/v1/globalNetworkPolicies/byName/:name", gnpHandler.Get)
 		router.DELETE("/api/v1/globalNetworkPolicies", gnpHandler.Delete)
 		router.POST("/api/v1/globalNetworkPolicies/validate", gnpHandler.Validate)
+		router.POST("/api/v1/globalNetworkPolicies/promote", gnpHandler.Promote)
+		router.GET("/api/v1/globalNetworkPolicies/byName/:name/stagedReport", gnpHandler.GetStagedReport)
+
+		router.POST("/api/internal/v1/globalNetworkPolicies/stagedReports", gnpHandler.ReportStaged)
+		router.GET("/api/internal/v1/globalNetworkPolicies/watchSchedule", gnpHandler.WatchSchedule)
 	}
 
 	{
-		gnsHandler := handler.NewGNS(service.NewGNS(repo))
+		gnsHandler := handler.NewGNS(service.NewGNS(repo, snapshot, linter))
 		router.POST("/api/v1/globalNetworkSets", gnsHandler.Create)
 		router.GET("/api/v1/globalNetworkSets", gnsHandler.List)
 		router.GET("/api/v1/globalNetworkSets/byName/:name", gnsHandler.Get)
@@ -48,5 +56,93 @@ func RegisterHandler(repo *repository.PolicyDB) http.Handler {
 		router.POST("/api/v1/globalNetworkSets/validate", gnsHandler.Validate)
 	}
 
+	{
+		npHandler := handler.NewNP(service.NewNetworkPolicy(repo, snapshot, linter))
+		router.POST("/api/v1/networkPolicies", npHandler.Create)
+		router.GET("/api/v1/networkPolicies", npHandler.List)
+		router.GET("/api/v1/networkPolicies/byTenantID/:tenantID/byName/:name", npHandler.Get)
+		router.DELETE("/api/v1/networkPolicies", npHandler.Delete)
+		router.POST("/api/v1/networkPolicies/validate", npHandler.Validate)
+	}
+
+	{
+		nsHandler := handler.NewNS(service.NewNetworkSet(repo, snapshot, linter))
+		router.POST("/api/v1/networkSets", nsHandler.Create)
+		router.GET("/api/v1/networkSets", nsHandler.List)
+		router.GET("/api/v1/networkSets/byTenantID/:tenantID/byName/:name", nsHandler.Get)
+		router.DELETE("/api/v1/networkSets", nsHandler.Delete)
+		router.POST("/api/v1/networkSets/validate", nsHandler.Validate)
+	}
+
+	{
+		tierHandler := handler.NewTier(service.NewTier(repo, snapshot))
+		router.POST("/api/v1/tiers", tierHandler.Create)
+		router.GET("/api/v1/tiers", tierHandler.List)
+		router.GET("/api/v1/tiers/byName/:name", tierHandler.Get)
+		router.DELETE("/api/v1/tiers", tierHandler.Delete)
+		router.POST("/api/v1/tiers/validate", tierHandler.Validate)
+	}
+
+	{
+		tenantHandler := handler.NewTenant(service.NewTenant(repo))
+		router.POST("/api/v1/tenants", tenantHandler.Create)
+		router.GET("/api/v1/tenants", tenantHandler.List)
+		router.GET("/api/v1/tenants/byTenantID/:tenantID", tenantHandler.Get)
+		router.DELETE("/api/v1/tenants", tenantHandler.Delete)
+		router.POST("/api/v1/tenants/validate", tenantHandler.Validate)
+	}
+
+	{
+		enrollmentTokenHandler := handler.NewEnrollmentToken(service.NewEnrollmentToken(repo))
+		router.POST("/api/v1/enrollmentTokens", enrollmentTokenHandler.Create)
+		router.GET("/api/v1/enrollmentTokens", enrollmentTokenHandler.List)
+		router.GET("/api/v1/enrollmentTokens/byName/:name", enrollmentTokenHandler.Get)
+		router.DELETE("/api/v1/enrollmentTokens", enrollmentTokenHandler.Delete)
+		router.POST("/api/v1/enrollmentTokens/validate", enrollmentTokenHandler.Validate)
+	}
+
+	{
+		webhookHandler := handler.NewWebhook(service.NewWebhook(repo))
+		router.POST("/api/v1/webhooks", webhookHandler.Create)
+		router.GET("/api/v1/webhooks", webhookHandler.List)
+		router.GET("/api/v1/webhooks/byName/:name", webhookHandler.Get)
+		router.DELETE("/api/v1/webhooks", webhookHandler.Delete)
+		router.POST("/api/v1/webhooks/validate", webhookHandler.Validate)
+		router.GET("/api/v1/webhookDeliveries", webhookHandler.ListDeliveries)
+	}
+
+	{
+		admissionHookHandler := handler.NewAdmissionHook(service.NewAdmissionHook(repo))
+		router.POST("/api/v1/admissionHooks", admissionHookHandler.Create)
+		router.GET("/api/v1/admissionHooks", admissionHookHandler.List)
+		router.GET("/api/v1/admissionHooks/byName/:name", admissionHookHandler.Get)
+		router.DELETE("/api/v1/admissionHooks", admissionHookHandler.Delete)
+		router.POST("/api/v1/admissionHooks/validate", admissionHookHandler.Validate)
+	}
+
+	{
+		agentHandler := handler.NewAgent(service.NewAgent(repo, snapshot))
+		router.GET("/api/v1/agents", agentHandler.List)
+
+		router.POST("/api/internal/v1/agents/register", agentHandler.Register)
+		router.POST("/api/internal/v1/agents/heartbeat", agentHandler.Heartbeat)
+	}
+
+	{
+		selectorHandler := handler.NewSelector(service.NewSelector(repo))
+		router.POST("/api/v1/selectors/explain", selectorHandler.Explain)
+	}
+
+	{
+		bulkHandler := handler.NewBulk(service.NewBulk(repo, snapshot))
+		router.POST("/api/v1/bulk", bulkHandler.Apply)
+	}
+
+	{
+		backupHandler := handler.NewBackup(service.NewBackup(repo, snapshot))
+		router.GET("/api/v1/backup", backupHandler.Backup)
+		router.POST("/api/v1/restore", backupHandler.Restore)
+	}
+
 	return router
 }
@@ -13,12 +13,33 @@ type Config struct {
 	HTTPServerReadHeaderTimeout time.Duration
 	HTTPServerWriteTimeout      time.Duration
 	HTTPServerIdleTimeout       time.Duration
+	GRPCServerHost              string
+	GRPCServerPort              string
+	GRPCTLSCertFile             string
+	GRPCTLSKeyFile              string
 	DBURI                       string
 	Logging                     bool
+	PolicySnapshotResync        time.Duration
+	GNSSourceCheckInterval      time.Duration
+	GNSSourceFileDir            string
+	GNSSourceAllowPrivateURLs   bool
+	GNSDomainResolveInterval    time.Duration
+	WebhookDeliveryInterval     time.Duration
+	LintSeverities              string
 }
 
+// defaultPolicySnapshotResync keeps instances consistent when POLICY_SNAPSHOT_RESYNC is not set, set it to 0 to
+// disable the resync of a single instance.
+const defaultPolicySnapshotResync = 5 * time.Minute
+
+// defaultGNSDomainResolveInterval re-resolves the allowed egress domains of sets when GNS_DOMAIN_RESOLVE_INTERVAL is
+// not set, set it to 0 to disable the resolution.
+const defaultGNSDomainResolveInterval = time.Minute
+
 func New(path string) (Config, error) {
 	viper.AutomaticEnv()
+	viper.SetDefault("POLICY_SNAPSHOT_RESYNC", defaultPolicySnapshotResync)
+	viper.SetDefault("GNS_DOMAIN_RESOLVE_INTERVAL", defaultGNSDomainResolveInterval)
 	if path != "" {
 		viper.SetConfigFile(path)
 		if err := viper.ReadInConfig(); err != nil {
@@ -32,7 +53,18 @@ func New(path string) (Config, error) {
 		HTTPServerReadHeaderTimeout: viper.GetDuration("HTTP_SERVER_READ_HEADER_TIMEOUT"),
 		HTTPServerWriteTimeout:      viper.GetDuration("HTTP_SERVER_WRITE_TIMEOUT"),
 		HTTPServerIdleTimeout:       viper.GetDuration("HTTP_SERVER_IDLE_TIMEOUT"),
+		GRPCServerHost:              viper.GetString("GRPC_SERVER_HOST"),
+		GRPCServerPort:              viper.GetString("GRPC_SERVER_PORT"),
+		GRPCTLSCertFile:             viper.GetString("GRPC_TLS_CERT_FILE"),
+		GRPCTLSKeyFile:              viper.GetString("GRPC_TLS_KEY_FILE"),
 		DBURI:                       viper.GetString("DB_URI"),
 		Logging:                     viper.GetBool("LOGGING"),
+		PolicySnapshotResync:        viper.GetDuration("POLICY_SNAPSHOT_RESYNC"),
+		GNSSourceCheckInterval:      viper.GetDuration("GNS_SOURCE_CHECK_INTERVAL"),
+		GNSSourceFileDir:            viper.GetString("GNS_SOURCE_FILE_DIR"),
+		GNSSourceAllowPrivateURLs:   viper.GetBool("GNS_SOURCE_ALLOW_PRIVATE_URLS"),
+		GNSDomainResolveInterval:    viper.GetDuration("GNS_DOMAIN_RESOLVE_INTERVAL"),
+		WebhookDeliveryInterval:     viper.GetDuration("WEBHOOK_DELIVERY_INTERVAL"),
+		LintSeverities:              viper.GetString("LINT_SEVERITIES"),
 	}, nil
 }
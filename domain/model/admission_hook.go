@@ -0,0 +1,50 @@
+package model
+
+import (
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+type CreateAdmissionHookInput struct {
+	Metadata    AdmissionHookMetadataInput
+	Spec        AdmissionHookSpecInput
+	Description string
+	FilePath    string
+}
+
+type AdmissionHookMetadataInput struct {
+	Name   string
+	Labels map[string]string
+}
+
+type AdmissionHookSpecInput struct {
+	URL           string
+	Secret        string
+	Kinds         []string
+	Operations    []string
+	FailurePolicy string
+	Timeout       string
+}
+
+type ValidateAdmissionHookOutput struct {
+	AdmissionHook        *entity.AdmissionHook
+	AdmissionHookExisted *entity.AdmissionHook
+}
+
+// AdmissionReviewInput is a change of a resource to review. Object is nil on delete, OldObject is nil when the
+// resource does not exist yet.
+type AdmissionReviewInput struct {
+	Kind      string
+	Operation string
+	// DryRun is set when the change is only validated
+	DryRun    bool
+	Object    interface{}
+	OldObject interface{}
+}
+
+// AdmissionVerdict is the verdict of an admission hook on a change, the verdicts denying a change are the detail of
+// its error.
+type AdmissionVerdict struct {
+	AdmissionHook string `json:"admissionHook"`
+	Allowed       bool   `json:"allowed"`
+	Message       string `json:"message,omitempty"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+type RegisterAgentInput struct {
+	TenantID     uint64
+	IP           string
+	Hostname     string
+	AgentVersion string
+}
+
+type AgentHeartbeatInput struct {
+	TenantID    uint64
+	IP          string
+	Applied     HostEndpointPolicyMetadata
+	ApplyErrors []string
+}
+
+type ListAgentsInput struct {
+	TenantID uint64
+	// StaleAfter is the duration without heartbeat after which an agent is stale
+	StaleAfter time.Duration
+}
+
+type AgentStatus struct {
+	Agent *entity.Agent
+	// ExpectedRevision is the revision of the policy fetchPolicies returns now for the host endpoint of the agent
+	ExpectedRevision uint64
+	Stale            bool
+	OutOfSync        bool
+	Failing          bool
+}
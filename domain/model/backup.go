@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+// Bundle is a consistent snapshot of the policy database.
+type Bundle struct {
+	CreatedAt             time.Time
+	Tenants               []*entity.Tenant
+	Tiers                 []*entity.Tier
+	HostEndpoints         []*entity.HostEndpoint
+	GlobalNetworkSets     []*entity.GlobalNetworkSet
+	NetworkSets           []*entity.GlobalNetworkSet
+	GlobalNetworkPolicies []*entity.GlobalNetworkPolicy
+	NetworkPolicies       []*entity.GlobalNetworkPolicy
+}
+
+const (
+	// RestoreModeSkip keeps the stored resources conflicting with the ones of the bundle
+	RestoreModeSkip = "skip"
+	// RestoreModeOverwrite replaces the stored resources conflicting with the ones of the bundle
+	RestoreModeOverwrite = "overwrite"
+	// RestoreModeFail restores nothing if a stored resource conflicts with one of the bundle
+	RestoreModeFail = "fail"
+)
+
+type RestoreInput struct {
+	Mode   string
+	Bundle *Bundle
+}
+
+// RestoreItemError is the error of an invalid resource of a bundle, the Index-th resource of its kind. It is the
+// detail of the error of an invalid restore, no resource is restored if one is invalid.
+type RestoreItemError struct {
+	Kind   string      `json:"kind"`
+	Index  int         `json:"index"`
+	Name   string      `json:"name"`
+	Error  string      `json:"error"`
+	Detail interface{} `json:"detail,omitempty"`
+}
+
+// RestoreResult counts the restored resources of a kind. A resource conflicts with a stored one of the same uuid or
+// the same unique key, e.g. the name of a global network policy.
+type RestoreResult struct {
+	Kind        string
+	Created     int
+	Overwritten int
+	Skipped     int
+}
+
+type RestoreOutput struct {
+	Results []*RestoreResult
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+const (
+	BulkOperationUpsert = "upsert"
+	BulkOperationDelete = "delete"
+)
+
+const (
+	BulkActionCreated = "created"
+	BulkActionUpdated = "updated"
+	BulkActionDeleted = "deleted"
+	// BulkActionNotFound is the action of the delete of a resource which does not exist
+	BulkActionNotFound = "notFound"
+)
+
+type BulkApplyInput struct {
+	Items []*BulkItemInput
+	// Force deletes host endpoints and sets even if policy selectors lose their last match
+	Force bool
+}
+
+// BulkItemInput is the upsert or the delete of a host endpoint, a global network set or a global network policy. The
+// field of the kind and operation is set.
+type BulkItemInput struct {
+	Operation           string
+	Kind                string
+	HostEndpoint        *CreateHostEndpointInput
+	GlobalNetworkSet    *CreateGlobalNetworkSetInput
+	GlobalNetworkPolicy *CreateGlobalNetworkPolicyInput
+	DeleteHostEndpoint  *DeleteHostEndpointInput
+	// DeleteName is the name of the global network set or the global network policy to delete
+	DeleteName string
+}
+
+// BulkItemResult is the result of an item of a bulk apply, it is the detail of the error of an invalid bulk apply.
+type BulkItemResult struct {
+	Index     int    `json:"index"`
+	Kind      string `json:"kind"`
+	Operation string `json:"operation"`
+	Name      string `json:"name"`
+	UUID      string `json:"uuid,omitempty"`
+	Version   uint   `json:"version,omitempty"`
+	Action    string `json:"action,omitempty"`
+	// Error is the reason the item is invalid, no item is applied if one is invalid
+	Error  string      `json:"error,omitempty"`
+	Detail interface{} `json:"detail,omitempty"`
+}
+
+type BulkApplyOutput struct {
+	Items []*BulkItemResult
+}
+
+// BulkWriteItem is a write of a bulk apply. The entity of the kind is set: the resource to upsert, or the stored
+// resource to delete.
+type BulkWriteItem struct {
+	Operation string
+	Kind      string
+	// StoredVersion is the version of the stored resource as read when the item was validated, 0 if there was none.
+	// The write fails with ErrVersionConflict if the stored resource changed since.
+	StoredVersion       uint
+	HostEndpoint        *entity.HostEndpoint
+	GlobalNetworkSet    *entity.GlobalNetworkSet
+	GlobalNetworkPolicy *entity.GlobalNetworkPolicy
+}
@@ -0,0 +1,37 @@
+package model
+
+import "github.com/bamboo-firewall/be/pkg/entity"
+
+type CreateEnrollmentTokenInput struct {
+	Metadata    EnrollmentTokenMetadataInput
+	Spec        EnrollmentTokenSpecInput
+	Description string
+	FilePath    string
+}
+
+type EnrollmentTokenMetadataInput struct {
+	Name   string
+	Labels map[string]string
+}
+
+type EnrollmentTokenSpecInput struct {
+	TenantID           uint64
+	AllowedCIDRs       []string
+	LabelTemplates     map[string]string
+	AllowedAgentLabels []string
+}
+
+type ValidateEnrollmentTokenOutput struct {
+	EnrollmentToken        *entity.EnrollmentToken
+	EnrollmentTokenExisted *entity.EnrollmentToken
+}
+
+type RegisterHostEndpointInput struct {
+	Token         string
+	Name          string
+	Hostname      string
+	OS            string
+	InterfaceName string
+	IPs           []string
+	Labels        map[string]string
+}
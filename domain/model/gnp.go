@@ -1,6 +1,10 @@
 package model
 
-import "github.com/bamboo-firewall/be/pkg/entity"
+import (
+	"time"
+
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
 
 type CreateGlobalNetworkPolicyInput struct {
 	Metadata    GNPMetadataInput
@@ -10,12 +14,17 @@ type CreateGlobalNetworkPolicyInput struct {
 }
 
 type GNPMetadataInput struct {
-	Name   string
-	Labels map[string]string
+	Name string
+	// TenantID is the tenant of a network policy, zero for a global network policy
+	TenantID uint64
+	Labels   map[string]string
 }
 
 type GNPSpecInput struct {
-	Order    *uint32
+	Tier   string
+	Order  *uint32
+	Staged bool
+	ScheduleInput
 	Selector string
 	Ingress  []GNPSpecRuleInput
 	Egress   []GNPSpecRuleInput
@@ -27,10 +36,18 @@ type GNPSpecRuleInput struct {
 	Protocol    interface{}
 	NotProtocol interface{}
 	IPVersion   *int
+	ICMP        *GNPSpecRuleICMPInput
+	NotICMP     *GNPSpecRuleICMPInput
+	ScheduleInput
 	Source      *GNPSpecRuleEntityInput
 	Destination *GNPSpecRuleEntityInput
 }
 
+type GNPSpecRuleICMPInput struct {
+	Type *int
+	Code *int
+}
+
 type GNPSpecRuleEntityInput struct {
 	Selector string
 	Nets     []string
@@ -41,9 +58,31 @@ type GNPSpecRuleEntityInput struct {
 
 type ListGNPsInput struct {
 	IsOrder bool
+	// ExpiringWithin lists only policies with the policy or a rule reaching its activeUntil, or stopping being active as
+	// its schedule windows close, within the duration
+	ExpiringWithin time.Duration
 }
 
 type PolicyWithRelatedHostEndpoint struct {
 	GNP        *entity.GlobalNetworkPolicy
 	ParsedHEPs []*ParsedHEP
 }
+
+type ReportStagedPoliciesInput struct {
+	TenantID uint64
+	IP       string
+	Reports  []StagedPolicyReportInput
+}
+
+type StagedPolicyReportInput struct {
+	GNPUUID       string
+	DeniedPackets uint64
+	DeniedBytes   uint64
+}
+
+type StagedPolicyReportSummary struct {
+	GNP           *entity.GlobalNetworkPolicy
+	DeniedPackets uint64
+	DeniedBytes   uint64
+	Reports       []*entity.StagedPolicyReport
+}
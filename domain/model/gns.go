@@ -8,10 +8,22 @@ type CreateGlobalNetworkSetInput struct {
 }
 
 type GNSMetadataInput struct {
-	Name   string            `json:"name" validate:"required"`
-	Labels map[string]string `json:"labels"`
+	Name string `json:"name" validate:"required"`
+	// TenantID is the tenant of a network set, zero for a global network set
+	TenantID uint64            `json:"tenantID"`
+	Labels   map[string]string `json:"labels"`
 }
 
 type GNSSpecInput struct {
-	Nets []string `json:"nets"`
+	Nets                 []string        `json:"nets"`
+	Source               *GNSSourceInput `json:"source"`
+	AllowedEgressDomains []string        `json:"allowedEgressDomains"`
+}
+
+type GNSSourceInput struct {
+	URL             string `json:"url"`
+	File            string `json:"file"`
+	Format          string `json:"format"`
+	JSONPath        string `json:"jsonPath"`
+	RefreshInterval string `json:"refreshInterval"`
 }
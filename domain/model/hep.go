@@ -2,6 +2,7 @@ package model
 
 import (
 	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/validator"
 )
 
 type CreateHostEndpointInput struct {
@@ -44,26 +45,64 @@ type DeleteHostEndpointInput struct {
 	TenantID uint64
 	IP       string
 	IPs      []string
+	Force    bool
 }
 
 type HostEndpointPolicy struct {
-	MetaData   HostEndpointPolicyMetadata
-	HEP        *entity.HostEndpoint
-	ParsedGNPs []*ParsedGNP
-	ParsedHEPs []*ParsedHEP
-	ParsedGNSs []*ParsedGNS
+	MetaData    HostEndpointPolicyMetadata
+	HEP         *entity.HostEndpoint
+	ParsedTiers []*ParsedTier
+	ParsedGNPs  []*ParsedGNP
+	ParsedHEPs  []*ParsedHEP
+	ParsedGNSs  []*ParsedGNS
 }
 
 type HostEndpointPolicyMetadata struct {
+	Revision    uint64
 	GNPVersions map[string]uint
 	HEPVersions map[string]uint
 	GNSVersions map[string]uint
+	// GNPDigests by policy uuid is a digest of the parsed policy, which also changes when the matched endpoints and
+	// sets or the active rules change while the policy version does not
+	GNPDigests map[string]string
+}
+
+type FetchHostEndpointPolicyDeltaInput struct {
+	TenantID uint64
+	IP       uint32
+	// Known is the metadata of the policy the agent applied
+	Known HostEndpointPolicyMetadata
+}
+
+// HostEndpointPolicyDelta is the change of a HostEndpointPolicy since the one an agent knows. Tiers are always
+// sent in full, policies, endpoints and sets only when added or changed, the removed ones by uuid.
+type HostEndpointPolicyDelta struct {
+	MetaData    HostEndpointPolicyMetadata
+	HEP         *entity.HostEndpoint
+	ParsedTiers []*ParsedTier
+	// GNPUUIDs is the uuids of all the policies applied, in evaluation order
+	GNPUUIDs    []string
+	ParsedGNPs  []*ParsedGNP
+	ParsedHEPs  []*ParsedHEP
+	ParsedGNSs  []*ParsedGNS
+	RemovedGNPs []string
+	RemovedHEPs []string
+	RemovedGNSs []string
+}
+
+type ParsedTier struct {
+	Name          string
+	Order         uint32
+	DefaultAction string
 }
 
 type ParsedGNP struct {
 	UUID          string
 	Version       uint
 	Name          string
+	TenantID      uint64
+	Tier          string
+	IsStaged      bool
 	InboundRules  []*ParsedRule
 	OutboundRules []*ParsedRule
 }
@@ -73,6 +112,10 @@ type ParsedRule struct {
 	IPVersion          *int
 	Protocol           interface{}
 	IsProtocolNegative bool
+	ICMPType           *int
+	ICMPCode           *int
+	NotICMPType        *int
+	NotICMPCode        *int
 	SrcNets            []string
 	IsSrcNetNegative   bool
 	SrcGNSUUIDs        []string
@@ -97,25 +140,68 @@ type ParsedHEP struct {
 }
 
 type ParsedGNS struct {
-	UUID   string
-	Name   string
-	NetsV4 []string
-	NetsV6 []string
+	UUID     string
+	Name     string
+	TenantID uint64
+	NetsV4   []string
+	NetsV6   []string
+	Domains  []string
 }
 
 type ValidateHostEndpointOutput struct {
 	HEP        *entity.HostEndpoint
 	HEPExisted *entity.HostEndpoint
 	ParsedGNPs []*ParsedGNP
+	// AdmissionVerdicts are the verdicts of the admission hooks on the change
+	AdmissionVerdicts []AdmissionVerdict
 }
 
 type ValidateGlobalNetworkSetOutput struct {
 	GNS        *entity.GlobalNetworkSet
 	GNSExisted *entity.GlobalNetworkSet
+	Warnings   []string
+	// LintFindings are the lint rules broken by the set with the warn severity
+	LintFindings []*validator.LintFinding
+	// AdmissionVerdicts are the verdicts of the admission hooks on the change
+	AdmissionVerdicts []AdmissionVerdict
 }
 
 type ValidateGlobalNetworkPolicyOutput struct {
 	GNP        *entity.GlobalNetworkPolicy
 	GNPExisted *entity.GlobalNetworkPolicy
 	ParsedHEPs []*ParsedHEP
+	// UnmatchedSelectors are the rule selectors matching no host endpoint and no global network set
+	UnmatchedSelectors []*SelectorReference
+	// SelectorChange is how the spec selector relates to the one of the existing policy when it was rewritten
+	SelectorChange string
+	// RelatedSelectors are the other policies whose spec selector is identical to, narrower or broader than the
+	// spec selector
+	RelatedSelectors []*SelectorRelation
+	// RelatedSelectorsTruncated is set when there were too many policies to compare them all
+	RelatedSelectorsTruncated bool
+	// LintFindings are the lint rules broken by the policy with the warn severity
+	LintFindings []*validator.LintFinding
+	// AdmissionVerdicts are the verdicts of the admission hooks on the change
+	AdmissionVerdicts []AdmissionVerdict
+}
+
+const (
+	SelectorRelationIdentical = "identical"
+	SelectorRelationNarrower  = "narrower"
+	SelectorRelationBroader   = "broader"
+	SelectorRelationDifferent = "different"
+)
+
+// SelectorRelation tells that a selector is identical to, narrower or broader than the selector of policy GNPName.
+type SelectorRelation struct {
+	GNPName  string
+	Selector string
+	Relation string
+}
+
+// SelectorReference is a selector of a global network policy, Field is its path in the policy.
+type SelectorReference struct {
+	GNPName  string `json:"gnpName"`
+	Field    string `json:"field"`
+	Selector string `json:"selector"`
 }
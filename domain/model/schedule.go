@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+type ScheduleInput struct {
+	ActiveFrom  *time.Time
+	ActiveUntil *time.Time
+	Windows     []ScheduleWindowInput
+}
+
+type ScheduleWindowInput struct {
+	Cron     string
+	Duration string
+}
+
+// ScheduleEvent is emitted when a window of a policy or of one of its rules opens or closes.
+type ScheduleEvent struct {
+	GNPUUID  string
+	GNPName  string
+	Active   bool
+	Revision uint64
+	At       time.Time
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/selector"
+)
+
+// ExplainSelectorInput evaluates Selector against the labels of the host endpoint identified by TenantID and IP,
+// or against Labels when IP is empty.
+type ExplainSelectorInput struct {
+	Selector string
+	TenantID uint64
+	IP       string
+	Labels   map[string]string
+}
+
+type ExplainSelectorOutput struct {
+	Selector string
+	HEP      *entity.HostEndpoint
+	Labels   map[string]string
+	Trace    *selector.Trace
+}
@@ -0,0 +1,30 @@
+package model
+
+import "github.com/bamboo-firewall/be/pkg/entity"
+
+type CreateTenantInput struct {
+	Metadata    TenantMetadataInput
+	Spec        TenantSpecInput
+	Description string
+	FilePath    string
+}
+
+type TenantMetadataInput struct {
+	Name   string
+	Labels map[string]string
+}
+
+type TenantSpecInput struct {
+	TenantID uint64
+	Quota    TenantQuotaInput
+}
+
+type TenantQuotaInput struct {
+	MaxHostEndpoints   uint32
+	MaxNetworkPolicies uint32
+}
+
+type ValidateTenantOutput struct {
+	Tenant        *entity.Tenant
+	TenantExisted *entity.Tenant
+}
@@ -0,0 +1,25 @@
+package model
+
+import "github.com/bamboo-firewall/be/pkg/entity"
+
+type CreateTierInput struct {
+	Metadata    TierMetadataInput
+	Spec        TierSpecInput
+	Description string
+	FilePath    string
+}
+
+type TierMetadataInput struct {
+	Name   string
+	Labels map[string]string
+}
+
+type TierSpecInput struct {
+	Order         *uint32
+	DefaultAction string
+}
+
+type ValidateTierOutput struct {
+	Tier        *entity.Tier
+	TierExisted *entity.Tier
+}
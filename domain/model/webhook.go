@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+type CreateWebhookInput struct {
+	Metadata    WebhookMetadataInput
+	Spec        WebhookSpecInput
+	Description string
+	FilePath    string
+}
+
+type WebhookMetadataInput struct {
+	Name   string
+	Labels map[string]string
+}
+
+type WebhookSpecInput struct {
+	URL      string
+	Secret   string
+	Kinds    []string
+	Actions  []string
+	Selector string
+}
+
+type ValidateWebhookOutput struct {
+	Webhook        *entity.Webhook
+	WebhookExisted *entity.Webhook
+}
+
+type ListWebhookDeliveriesInput struct {
+	WebhookName string
+	Status      string
+	Limit       int64
+}
+
+// WebhookEventPayload is the body posted to webhooks.
+type WebhookEventPayload struct {
+	ID         string            `json:"id"`
+	Webhook    string            `json:"webhook"`
+	Kind       string            `json:"kind"`
+	Action     string            `json:"action"`
+	Name       string            `json:"name"`
+	TenantID   uint64            `json:"tenantID,omitempty"`
+	UUID       string            `json:"uuid"`
+	Version    uint              `json:"version"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	OccurredAt time.Time         `json:"occurredAt"`
+}
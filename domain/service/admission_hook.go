@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/bamboo-firewall/be"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	"github.com/bamboo-firewall/be/pkg/repository"
+	"github.com/bamboo-firewall/be/pkg/webhook"
+)
+
+const (
+	defaultAdmissionTimeout = 5 * time.Second
+	// maxAdmissionDuration bounds the review of a change by all the admission hooks, or of all the changes of a batch
+	maxAdmissionDuration = 10 * time.Second
+	// maxConcurrentAdmissionReviews bounds the changes of a batch reviewed at once
+	maxConcurrentAdmissionReviews = 8
+)
+
+// admissionSender reviews the changes, the timeout of each review is the one of its hook.
+var admissionSender = webhook.NewSender(&http.Client{})
+
+func NewAdmissionHook(policyMongo *repository.PolicyDB) *admissionHook {
+	return &admissionHook{
+		storage: policyMongo,
+	}
+}
+
+type admissionHook struct {
+	storage be.Storage
+}
+
+func (ds *admissionHook) Create(ctx context.Context, input *model.CreateAdmissionHookInput) (*entity.AdmissionHook, *ierror.Error) {
+	admissionHookEntity := createModelToAdmissionHookEntity(input)
+
+	if coreErr := ds.storage.UpsertAdmissionHook(ctx, admissionHookEntity); coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrDuplicateAdmissionHook) {
+			return nil, httpbase.ErrBadRequest(ctx, "duplicate admission hook").SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "create admission hook failed").SetSubError(coreErr)
+	}
+	return admissionHookEntity, nil
+}
+
+func (ds *admissionHook) Get(ctx context.Context, name string) (*entity.AdmissionHook, *ierror.Error) {
+	admissionHookEntity, coreErr := ds.storage.GetAdmissionHookByName(ctx, name)
+	if coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrNotFoundAdmissionHook) {
+			return nil, httpbase.ErrNotFound(ctx, "not found").SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "get admission hook failed").SetSubError(coreErr)
+	}
+	return admissionHookEntity, nil
+}
+
+func (ds *admissionHook) List(ctx context.Context) ([]*entity.AdmissionHook, *ierror.Error) {
+	admissionHooksEntity, coreErr := ds.storage.ListAdmissionHooks(ctx)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list admission hooks failed").SetSubError(coreErr)
+	}
+	return admissionHooksEntity, nil
+}
+
+func (ds *admissionHook) Delete(ctx context.Context, name string) *ierror.Error {
+	if coreErr := ds.storage.DeleteAdmissionHookByName(ctx, name); coreErr != nil {
+		return httpbase.ErrDatabase(ctx, "delete admission hook failed").SetSubError(coreErr)
+	}
+	return nil
+}
+
+func (ds *admissionHook) Validate(ctx context.Context, input *model.CreateAdmissionHookInput) (*model.ValidateAdmissionHookOutput, *ierror.Error) {
+	admissionHookEntity := createModelToAdmissionHookEntity(input)
+
+	admissionHookExisted, coreErr := ds.storage.GetAdmissionHookByName(ctx, input.Metadata.Name)
+	if coreErr != nil {
+		if !errors.Is(coreErr, errlist.ErrNotFoundAdmissionHook) {
+			return nil, httpbase.ErrDatabase(ctx, "get admission hook failed").SetSubError(coreErr)
+		}
+	}
+	if admissionHookExisted != nil && admissionHookEntity.Spec.Secret == "" {
+		admissionHookEntity.Spec.Secret = admissionHookExisted.Spec.Secret
+	}
+
+	return &model.ValidateAdmissionHookOutput{
+		AdmissionHook:        admissionHookEntity,
+		AdmissionHookExisted: admissionHookExisted,
+	}, nil
+}
+
+func createModelToAdmissionHookEntity(input *model.CreateAdmissionHookInput) *entity.AdmissionHook {
+	failurePolicy := input.Spec.FailurePolicy
+	if failurePolicy == "" {
+		failurePolicy = entity.AdmissionFailurePolicyFail
+	}
+	timeout := input.Spec.Timeout
+	if timeout == "" {
+		timeout = defaultAdmissionTimeout.String()
+	}
+	return &entity.AdmissionHook{
+		ID:   primitive.NewObjectID(),
+		UUID: entity.NewMinifyUUID(),
+		Metadata: entity.AdmissionHookMetadata{
+			Name:   input.Metadata.Name,
+			Labels: input.Metadata.Labels,
+		},
+		Spec: entity.AdmissionHookSpec{
+			URL:           input.Spec.URL,
+			Secret:        input.Spec.Secret,
+			Kinds:         input.Spec.Kinds,
+			Operations:    input.Spec.Operations,
+			FailurePolicy: failurePolicy,
+			Timeout:       timeout,
+		},
+		Description: input.Description,
+		FilePath:    input.FilePath,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// admit asks every admission hook matching the change of review whether it is allowed. A hook which cannot be
+// reached or answers no verdict denies the change unless its failure policy is ignore. The verdicts of the hooks
+// denying the change are the detail of the returned error.
+func admit(ctx context.Context, storage be.Storage, review *model.AdmissionReviewInput) *ierror.Error {
+	verdicts, ierr := reviewAdmissions(ctx, storage, review)
+	if ierr != nil {
+		return ierr
+	}
+	return admissionDenied(ctx, verdicts)
+}
+
+// reviewAdmissions returns the verdicts of the admission hooks matching the change of review, see
+// reviewAdmissionHooks.
+func reviewAdmissions(ctx context.Context, storage be.Storage, review *model.AdmissionReviewInput) ([]model.AdmissionVerdict, *ierror.Error) {
+	admissionHooks, coreErr := storage.ListAdmissionHooks(ctx)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list admission hooks failed").SetSubError(coreErr)
+	}
+	return reviewAdmissionHooks(ctx, admissionHooks, review)
+}
+
+// reviewAdmissionHooks returns the verdicts of the admission hooks of admissionHooks matching the change of review.
+// The hooks review the change concurrently, a hook which did not answer within maxAdmissionDuration failed.
+func reviewAdmissionHooks(ctx context.Context, admissionHooks []*entity.AdmissionHook, review *model.AdmissionReviewInput) ([]model.AdmissionVerdict, *ierror.Error) {
+	var matched []*entity.AdmissionHook
+	for _, admissionHookEntity := range admissionHooks {
+		if admissionHookMatches(admissionHookEntity, review) {
+			matched = append(matched, admissionHookEntity)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, nil
+	}
+
+	body, err := marshalAdmissionReview(review)
+	if err != nil {
+		return nil, httpbase.ErrInternal(ctx, fmt.Sprintf("marshal admission review failed: %v", err))
+	}
+
+	reviewCtx, cancel := context.WithTimeout(ctx, maxAdmissionDuration)
+	defer cancel()
+	verdicts := make([]model.AdmissionVerdict, len(matched))
+	var wg sync.WaitGroup
+	for i, admissionHookEntity := range matched {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			verdicts[i] = reviewAdmission(reviewCtx, admissionHookEntity, body)
+		}()
+	}
+	wg.Wait()
+	return verdicts, nil
+}
+
+// admitAll returns the error of each change of reviews denied by the admission hooks, nil for the allowed ones. The
+// hooks are listed once, the changes are reviewed concurrently and all of them within maxAdmissionDuration.
+func admitAll(ctx context.Context, storage be.Storage, reviews []*model.AdmissionReviewInput) ([]*ierror.Error, *ierror.Error) {
+	admissionHooks, coreErr := storage.ListAdmissionHooks(ctx)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list admission hooks failed").SetSubError(coreErr)
+	}
+
+	reviewCtx, cancel := context.WithTimeout(ctx, maxAdmissionDuration)
+	defer cancel()
+	denied := make([]*ierror.Error, len(reviews))
+	failed := make([]*ierror.Error, len(reviews))
+	sem := make(chan struct{}, maxConcurrentAdmissionReviews)
+	var wg sync.WaitGroup
+	for i, review := range reviews {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			verdicts, ierr := reviewAdmissionHooks(reviewCtx, admissionHooks, review)
+			if ierr != nil {
+				failed[i] = ierr
+				return
+			}
+			denied[i] = admissionDenied(ctx, verdicts)
+		}()
+	}
+	wg.Wait()
+	for _, ierr := range failed {
+		if ierr != nil {
+			return nil, ierr
+		}
+	}
+	return denied, nil
+}
+
+// admissionDenied returns the error of a change denied by any of verdicts, nil when they all allow it.
+func admissionDenied(ctx context.Context, verdicts []model.AdmissionVerdict) *ierror.Error {
+	var denied []model.AdmissionVerdict
+	for _, verdict := range verdicts {
+		if !verdict.Allowed {
+			denied = append(denied, verdict)
+		}
+	}
+	if len(denied) > 0 {
+		return httpbase.ErrBadRequest(ctx, "denied by admission hooks").SetDetail(denied)
+	}
+	return nil
+}
+
+func reviewAdmission(ctx context.Context, admissionHookEntity *entity.AdmissionHook, body []byte) model.AdmissionVerdict {
+	timeout, err := time.ParseDuration(admissionHookEntity.Spec.Timeout)
+	if err != nil || timeout <= 0 {
+		timeout = defaultAdmissionTimeout
+	}
+	reviewCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	response, err := admissionSender.Review(reviewCtx, webhook.Request{
+		URL:        admissionHookEntity.Spec.URL,
+		Secret:     admissionHookEntity.Spec.Secret,
+		DeliveryID: entity.NewMinifyUUID(),
+		Body:       body,
+	})
+	if err != nil {
+		if admissionHookEntity.Spec.FailurePolicy == entity.AdmissionFailurePolicyIgnore {
+			slog.Warn("admission hook failed, change allowed by failure policy", "admission_hook",
+				admissionHookEntity.Metadata.Name, "err", err)
+			return model.AdmissionVerdict{AdmissionHook: admissionHookEntity.Metadata.Name, Allowed: true}
+		}
+		return model.AdmissionVerdict{
+			AdmissionHook: admissionHookEntity.Metadata.Name,
+			Message:       fmt.Sprintf("admission hook failed: %v", err),
+		}
+	}
+	return model.AdmissionVerdict{
+		AdmissionHook: admissionHookEntity.Metadata.Name,
+		Allowed:       response.Allowed,
+		Message:       response.Message,
+	}
+}
+
+func admissionHookMatches(admissionHookEntity *entity.AdmissionHook, review *model.AdmissionReviewInput) bool {
+	if len(admissionHookEntity.Spec.Kinds) > 0 && !slices.Contains(admissionHookEntity.Spec.Kinds, review.Kind) {
+		return false
+	}
+	if len(admissionHookEntity.Spec.Operations) > 0 && !slices.Contains(admissionHookEntity.Spec.Operations, review.Operation) {
+		return false
+	}
+	return true
+}
+
+func marshalAdmissionReview(review *model.AdmissionReviewInput) ([]byte, error) {
+	object, err := json.Marshal(review.Object)
+	if err != nil {
+		return nil, err
+	}
+	oldObject, err := json.Marshal(review.OldObject)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&webhook.AdmissionReview{
+		UID:       entity.NewMinifyUUID(),
+		Kind:      review.Kind,
+		Operation: review.Operation,
+		DryRun:    review.DryRun,
+		Object:    object,
+		OldObject: oldObject,
+	})
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/validator"
+	"github.com/bamboo-firewall/be/pkg/webhook"
+)
+
+// admissionServer answers every review with response once ready is closed, or fails the review when its request is
+// done first.
+func admissionServer(t *testing.T, response webhook.AdmissionResponse, ready <-chan struct{}) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the request is only done on disconnect once its body is read
+		io.Copy(io.Discard, r.Body)
+		select {
+		case <-ready:
+		case <-r.Context().Done():
+			return
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func testAdmissionHook(name, url string, kinds ...string) *entity.AdmissionHook {
+	return &entity.AdmissionHook{
+		Metadata: entity.AdmissionHookMetadata{Name: name},
+		Spec: entity.AdmissionHookSpec{
+			URL:           url,
+			Kinds:         kinds,
+			FailurePolicy: entity.AdmissionFailurePolicyFail,
+			Timeout:       "1m",
+		},
+	}
+}
+
+func closedChan() <-chan struct{} {
+	ready := make(chan struct{})
+	close(ready)
+	return ready
+}
+
+func TestReviewAdmissionHooks(t *testing.T) {
+	allowURL := admissionServer(t, webhook.AdmissionResponse{Allowed: true}, closedChan())
+	denyURL := admissionServer(t, webhook.AdmissionResponse{Message: "frozen"}, closedChan())
+	unreachable := testAdmissionHook("unreachable", "http://127.0.0.1:1")
+	unreachable.Spec.FailurePolicy = entity.AdmissionFailurePolicyIgnore
+
+	verdicts, ierr := reviewAdmissionHooks(context.Background(), []*entity.AdmissionHook{
+		testAdmissionHook("allow", allowURL),
+		testAdmissionHook("deny", denyURL, entity.WebhookKindGlobalNetworkSet),
+		testAdmissionHook("other-kind", denyURL, entity.WebhookKindHostEndpoint),
+		unreachable,
+	}, &model.AdmissionReviewInput{
+		Kind:      entity.WebhookKindGlobalNetworkSet,
+		Operation: entity.AdmissionOperationCreate,
+		Object:    testGNS("lb", nil, "192.168.0.0/24"),
+	})
+	if ierr != nil {
+		t.Fatalf("reviewAdmissionHooks() error: %v", ierr)
+	}
+	want := []model.AdmissionVerdict{
+		{AdmissionHook: "allow", Allowed: true},
+		{AdmissionHook: "deny", Message: "frozen"},
+		{AdmissionHook: "unreachable", Allowed: true},
+	}
+	if diff := cmp.Diff(want, verdicts); diff != "" {
+		t.Errorf("reviewAdmissionHooks() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReviewAdmissionHooksConcurrently(t *testing.T) {
+	// each hook only answers once both were asked, hooks asked one after the other time out
+	ready := make(chan struct{})
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	go func() {
+		arrived.Wait()
+		close(ready)
+	}()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		arrived.Done()
+		select {
+		case <-ready:
+		case <-r.Context().Done():
+			return
+		}
+		json.NewEncoder(w).Encode(webhook.AdmissionResponse{Allowed: true})
+	})
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	verdicts, ierr := reviewAdmissionHooks(ctx, []*entity.AdmissionHook{
+		testAdmissionHook("first", server.URL),
+		testAdmissionHook("second", server.URL),
+	}, &model.AdmissionReviewInput{Kind: entity.WebhookKindGlobalNetworkSet, Operation: entity.AdmissionOperationCreate})
+	if ierr != nil {
+		t.Fatalf("reviewAdmissionHooks() error: %v", ierr)
+	}
+	for _, verdict := range verdicts {
+		if !verdict.Allowed {
+			t.Errorf("hook %s denied: %s", verdict.AdmissionHook, verdict.Message)
+		}
+	}
+}
+
+func TestReviewAdmissionHooksDeadline(t *testing.T) {
+	// the hooks never answer, the review is bounded by the deadline of ctx rather than by the timeout of each hook
+	never := make(chan struct{})
+	url := admissionServer(t, webhook.AdmissionResponse{Allowed: true}, never)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	verdicts, ierr := reviewAdmissionHooks(ctx, []*entity.AdmissionHook{
+		testAdmissionHook("first", url),
+		testAdmissionHook("second", url),
+	}, &model.AdmissionReviewInput{Kind: entity.WebhookKindGlobalNetworkSet, Operation: entity.AdmissionOperationCreate})
+	if ierr != nil {
+		t.Fatalf("reviewAdmissionHooks() error: %v", ierr)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("reviewAdmissionHooks() took %v", elapsed)
+	}
+	if len(verdicts) != 2 || verdicts[0].Allowed || verdicts[1].Allowed {
+		t.Errorf("reviewAdmissionHooks() = %+v, want both hooks failed", verdicts)
+	}
+}
+
+func TestGNSValidateAdmissionVerdicts(t *testing.T) {
+	storage := newFakeStorage()
+	storage.admissionHooks = []*entity.AdmissionHook{
+		testAdmissionHook("allow", admissionServer(t, webhook.AdmissionResponse{Allowed: true}, closedChan())),
+		testAdmissionHook("deny", admissionServer(t, webhook.AdmissionResponse{Message: "frozen"}, closedChan())),
+	}
+	ds := &gns{storage: storage, linter: validator.NewLinter(nil)}
+
+	output, ierr := ds.Validate(context.Background(), &model.CreateGlobalNetworkSetInput{
+		Metadata: model.GNSMetadataInput{Name: "lb"},
+		Spec:     model.GNSSpecInput{Nets: []string{"192.168.0.0/24"}},
+	})
+	if ierr != nil {
+		t.Fatalf("Validate() error: %v", ierr)
+	}
+	want := []model.AdmissionVerdict{
+		{AdmissionHook: "allow", Allowed: true},
+		{AdmissionHook: "deny", Message: "frozen"},
+	}
+	if diff := cmp.Diff(want, output.AdmissionVerdicts); diff != "" {
+		t.Errorf("Validate() admission verdicts mismatch (-want +got):\n%s", diff)
+	}
+	if output.GNS == nil || output.GNS.Metadata.Name != "lb" {
+		t.Errorf("Validate() set = %+v, want lb", output.GNS)
+	}
+}
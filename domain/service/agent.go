@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"time"
+
+	"github.com/bamboo-firewall/be"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	"github.com/bamboo-firewall/be/pkg/net"
+	"github.com/bamboo-firewall/be/pkg/repository"
+)
+
+const (
+	defaultAgentStaleAfter = 3 * time.Minute
+)
+
+func NewAgent(policyMongo *repository.PolicyDB, snapshot *PolicySnapshot) *agent {
+	return &agent{
+		storage:  policyMongo,
+		snapshot: snapshot,
+	}
+}
+
+type agent struct {
+	storage  be.Storage
+	snapshot *PolicySnapshot
+}
+
+func (ds *agent) Register(ctx context.Context, input *model.RegisterAgentInput) *ierror.Error {
+	if input.TenantID == 0 {
+		input.TenantID = entity.DefaultTenantID
+	}
+	ip := net.ParseIP(input.IP)
+	if ip == nil {
+		return httpbase.ErrBadRequest(ctx, "malformed ip")
+	}
+	if ierr := checkTenantExists(ctx, ds.storage, input.TenantID); ierr != nil {
+		return ierr
+	}
+
+	coreErr := ds.storage.RegisterAgent(ctx, &entity.Agent{
+		TenantID:     input.TenantID,
+		IP:           net.IPToInt(*ip),
+		Hostname:     input.Hostname,
+		AgentVersion: input.AgentVersion,
+		RegisteredAt: time.Now(),
+	})
+	if coreErr != nil {
+		return httpbase.ErrDatabase(ctx, "register agent failed").SetSubError(coreErr)
+	}
+	return nil
+}
+
+// Heartbeat records the policy versions an agent enforces. An agent which is not registered must register first.
+func (ds *agent) Heartbeat(ctx context.Context, input *model.AgentHeartbeatInput) *ierror.Error {
+	if input.TenantID == 0 {
+		input.TenantID = entity.DefaultTenantID
+	}
+	ip := net.ParseIP(input.IP)
+	if ip == nil {
+		return httpbase.ErrBadRequest(ctx, "malformed ip")
+	}
+
+	now := time.Now()
+	coreErr := ds.storage.UpdateAgentHeartbeat(ctx, &entity.Agent{
+		TenantID: input.TenantID,
+		IP:       net.IPToInt(*ip),
+		Applied: entity.AgentAppliedPolicy{
+			Revision:    input.Applied.Revision,
+			GNPVersions: input.Applied.GNPVersions,
+			HEPVersions: input.Applied.HEPVersions,
+			GNSVersions: input.Applied.GNSVersions,
+		},
+		ApplyErrors:     input.ApplyErrors,
+		LastHeartbeatAt: &now,
+	})
+	if coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrNotFoundAgent) {
+			return httpbase.ErrNotFound(ctx, "agent is not registered").SetSubError(coreErr)
+		}
+		return httpbase.ErrDatabase(ctx, "update agent heartbeat failed").SetSubError(coreErr)
+	}
+	return nil
+}
+
+// List returns the agents with their state compared to the policy fetchPolicies returns now.
+func (ds *agent) List(ctx context.Context, input *model.ListAgentsInput) ([]*model.AgentStatus, *ierror.Error) {
+	agents, coreErr := ds.storage.ListAgents(ctx, input.TenantID)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list agents failed").SetSubError(coreErr)
+	}
+	staleAfter := input.StaleAfter
+	if staleAfter == 0 {
+		staleAfter = defaultAgentStaleAfter
+	}
+
+	now := time.Now()
+	statuses := make([]*model.AgentStatus, 0, len(agents))
+	for _, agentEntity := range agents {
+		var expected model.HostEndpointPolicyMetadata
+		if hepPolicy, ok := ds.snapshot.Get(agentEntity.TenantID, agentEntity.IP); ok {
+			expected = hepPolicy.MetaData
+		}
+		statuses = append(statuses, &model.AgentStatus{
+			Agent:            agentEntity,
+			ExpectedRevision: expected.Revision,
+			Stale:            isAgentStale(agentEntity, staleAfter, now),
+			OutOfSync:        !isAgentInSync(agentEntity, expected),
+			Failing:          len(agentEntity.ApplyErrors) > 0,
+		})
+	}
+	return statuses, nil
+}
+
+// isAgentStale reports whether the agent sent no heartbeat, or did not register, for longer than staleAfter.
+func isAgentStale(agentEntity *entity.Agent, staleAfter time.Duration, now time.Time) bool {
+	lastSeen := agentEntity.RegisteredAt
+	if agentEntity.LastHeartbeatAt != nil && agentEntity.LastHeartbeatAt.After(lastSeen) {
+		lastSeen = *agentEntity.LastHeartbeatAt
+	}
+	return now.Sub(lastSeen) > staleAfter
+}
+
+// isAgentInSync reports whether the agent enforces the versions of expected. Revisions are not compared, a host
+// endpoint policy is recomputed with a new revision even when none of its versions changed.
+func isAgentInSync(agentEntity *entity.Agent, expected model.HostEndpointPolicyMetadata) bool {
+	return maps.Equal(agentEntity.Applied.GNPVersions, expected.GNPVersions) &&
+		maps.Equal(agentEntity.Applied.HEPVersions, expected.HEPVersions) &&
+		maps.Equal(agentEntity.Applied.GNSVersions, expected.GNSVersions)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+func TestIsAgentInSync(t *testing.T) {
+	expected := model.HostEndpointPolicyMetadata{
+		Revision:    7,
+		GNPVersions: map[string]uint{"gnp-a": 2, "gnp-b": 1},
+		HEPVersions: map[string]uint{"hep-a": 3},
+		GNSVersions: map[string]uint{"gns-a": 1},
+	}
+	var applied = func(revision uint64, gnpVersions map[string]uint) entity.AgentAppliedPolicy {
+		return entity.AgentAppliedPolicy{
+			Revision:    revision,
+			GNPVersions: gnpVersions,
+			HEPVersions: map[string]uint{"hep-a": 3},
+			GNSVersions: map[string]uint{"gns-a": 1},
+		}
+	}
+	tests := []struct {
+		name     string
+		applied  entity.AgentAppliedPolicy
+		expected model.HostEndpointPolicyMetadata
+		want     bool
+	}{
+		{name: "same versions", applied: applied(7, map[string]uint{"gnp-a": 2, "gnp-b": 1}), expected: expected, want: true},
+		{name: "older revision with the same versions", applied: applied(5, map[string]uint{"gnp-a": 2, "gnp-b": 1}), expected: expected, want: true},
+		{name: "older policy version", applied: applied(7, map[string]uint{"gnp-a": 1, "gnp-b": 1}), expected: expected},
+		{name: "missing policy", applied: applied(7, map[string]uint{"gnp-a": 2}), expected: expected},
+		{name: "deleted policy still applied", applied: applied(7, map[string]uint{"gnp-a": 2, "gnp-b": 1, "gnp-c": 1}), expected: expected},
+		{name: "nothing applied", expected: expected},
+		{name: "nothing expected nor applied", want: true},
+		{name: "host endpoint deleted", applied: applied(7, map[string]uint{"gnp-a": 2})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAgentInSync(&entity.Agent{Applied: tt.applied}, tt.expected); got != tt.want {
+				t.Errorf("isAgentInSync() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsAgentStale(t *testing.T) {
+	now := time.Now()
+	at := func(ago time.Duration) *time.Time {
+		t := now.Add(-ago)
+		return &t
+	}
+	tests := []struct {
+		name          string
+		registeredAgo time.Duration
+		heartbeat     *time.Time
+		want          bool
+	}{
+		{name: "registered recently", registeredAgo: time.Minute},
+		{name: "registered long ago without heartbeat", registeredAgo: time.Hour, want: true},
+		{name: "recent heartbeat", registeredAgo: time.Hour, heartbeat: at(time.Minute)},
+		{name: "old heartbeat", registeredAgo: time.Hour, heartbeat: at(10 * time.Minute), want: true},
+		{name: "registered again after the last heartbeat", registeredAgo: time.Minute, heartbeat: at(time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agentEntity := &entity.Agent{RegisteredAt: now.Add(-tt.registeredAgo), LastHeartbeatAt: tt.heartbeat}
+			if got := isAgentStale(agentEntity, defaultAgentStaleAfter, now); got != tt.want {
+				t.Errorf("isAgentStale() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgentList(t *testing.T) {
+	ctx := context.Background()
+	storage := newFakeStorage()
+	web := testHEP("web", 1, map[string]string{"role": "web"})
+	_ = storage.UpsertHostEndpoint(ctx, web)
+	_ = storage.UpsertGroupPolicy(ctx, testGNP("allow-web", "role == 'web'", 10, ""))
+	snapshot := loadedSnapshot(t, storage)
+	expected, ok := snapshot.Get(web.Spec.TenantID, web.Spec.IP)
+	if !ok {
+		t.Fatal("no policy for the host endpoint")
+	}
+
+	now := time.Now()
+	storage.agents = []*entity.Agent{
+		{
+			Hostname: "in-sync", TenantID: web.Spec.TenantID, IP: web.Spec.IP, RegisteredAt: now,
+			Applied: entity.AgentAppliedPolicy{
+				GNPVersions: expected.MetaData.GNPVersions,
+				HEPVersions: expected.MetaData.HEPVersions,
+				GNSVersions: expected.MetaData.GNSVersions,
+			},
+		},
+		{Hostname: "out-of-sync", TenantID: web.Spec.TenantID, IP: web.Spec.IP, RegisteredAt: now},
+		{Hostname: "failing", TenantID: 2, IP: 9, RegisteredAt: now, ApplyErrors: []string{"iptables-restore failed"}},
+		{Hostname: "stale", TenantID: 2, IP: 10, RegisteredAt: now.Add(-time.Hour)},
+	}
+
+	statuses, ierr := (&agent{storage: storage, snapshot: snapshot}).List(ctx, &model.ListAgentsInput{})
+	if ierr != nil {
+		t.Fatal(ierr)
+	}
+	want := map[string]model.AgentStatus{
+		"in-sync":     {ExpectedRevision: expected.MetaData.Revision},
+		"out-of-sync": {ExpectedRevision: expected.MetaData.Revision, OutOfSync: true},
+		"failing":     {Failing: true},
+		"stale":       {Stale: true},
+	}
+	if len(statuses) != len(want) {
+		t.Fatalf("got %d statuses, want %d", len(statuses), len(want))
+	}
+	for _, status := range statuses {
+		w := want[status.Agent.Hostname]
+		if status.ExpectedRevision != w.ExpectedRevision || status.OutOfSync != w.OutOfSync || status.Failing != w.Failing ||
+			status.Stale != w.Stale {
+			t.Errorf("status of %s = %+v, want %+v", status.Agent.Hostname, *status, w)
+		}
+	}
+}
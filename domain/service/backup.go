@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/bamboo-firewall/be"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	"github.com/bamboo-firewall/be/pkg/repository"
+	"github.com/bamboo-firewall/be/pkg/selector"
+)
+
+func NewBackup(policyMongo *repository.PolicyDB, snapshot *PolicySnapshot) *backup {
+	return &backup{
+		storage:  policyMongo,
+		snapshot: snapshot,
+	}
+}
+
+type backup struct {
+	storage  be.Storage
+	snapshot *PolicySnapshot
+}
+
+func (ds *backup) Backup(ctx context.Context) (*model.Bundle, *ierror.Error) {
+	bundle, coreErr := ds.storage.GetBundle(ctx)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "get backup bundle failed").SetSubError(coreErr)
+	}
+	return bundle, nil
+}
+
+// Restore checks the resources of a bundle as they are checked when created, writes them and reloads the policy
+// snapshot. The fields derived from the spec of host endpoints and sets are recomputed, they are not part of bundles.
+func (ds *backup) Restore(ctx context.Context, input *model.RestoreInput) (*model.RestoreOutput, *ierror.Error) {
+	for _, hepEntity := range input.Bundle.HostEndpoints {
+		if hepEntity.Spec.TenantID == 0 {
+			hepEntity.Spec.TenantID = entity.DefaultTenantID
+		}
+		hepEntity.Spec.IPsV4, hepEntity.Spec.IPsV6 = exactIPs(hepEntity.Spec.IPs)
+	}
+	for _, gnsEntity := range append(input.Bundle.GlobalNetworkSets, input.Bundle.NetworkSets...) {
+		gnsEntity.Spec.NetsV4, gnsEntity.Spec.NetsV6, _ = effectiveNets(gnsEntity.Spec.Nets, gnsEntity.Resolved)
+	}
+	for _, nsEntity := range input.Bundle.NetworkSets {
+		if nsEntity.Metadata.TenantID == 0 {
+			nsEntity.Metadata.TenantID = entity.DefaultTenantID
+		}
+	}
+	for _, npEntity := range input.Bundle.NetworkPolicies {
+		if npEntity.Metadata.TenantID == 0 {
+			npEntity.Metadata.TenantID = entity.DefaultTenantID
+		}
+	}
+	if ierr := ds.checkBundle(ctx, input); ierr != nil {
+		return nil, ierr
+	}
+
+	output, coreErr := ds.storage.RestoreBundle(ctx, input)
+	if coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrRestoreConflict) {
+			return nil, httpbase.ErrConflict(ctx, "restore conflict").SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "restore backup bundle failed").SetSubError(coreErr)
+	}
+	if err := ds.snapshot.Load(ctx); err != nil {
+		return nil, httpbase.ErrInternal(ctx, fmt.Sprintf("reload policy snapshot failed: %v", err))
+	}
+	return output, nil
+}
+
+// bundleResource is a resource of a bundle along with the error of its checks, nil if it is valid.
+type bundleResource struct {
+	kind   string
+	index  int
+	name   string
+	object interface{}
+	ierr   *ierror.Error
+}
+
+// checkBundle checks each resource of the bundle against the stored resources as they are once the bundle is
+// restored, then has the admission hooks review the valid ones. No resource is restored if one is invalid, the error
+// then details the error of each invalid resource.
+func (ds *backup) checkBundle(ctx context.Context, input *model.RestoreInput) *ierror.Error {
+	state, ierr := ds.loadRestoreState(ctx, input)
+	if ierr != nil {
+		return ierr
+	}
+
+	bundle := input.Bundle
+	var resources []*bundleResource
+	for i, tenantEntity := range bundle.Tenants {
+		resources = append(resources, &bundleResource{
+			kind:   entity.WebhookKindTenant,
+			index:  i,
+			name:   tenantEntity.Metadata.Name,
+			object: tenantEntity,
+			ierr:   checkTenantName(ctx, tenantEntity.Metadata.Name, tenantEntity.Spec.TenantID),
+		})
+	}
+	for i, tierEntity := range bundle.Tiers {
+		resources = append(resources, &bundleResource{
+			kind:   entity.WebhookKindTier,
+			index:  i,
+			name:   tierEntity.Metadata.Name,
+			object: tierEntity,
+		})
+	}
+	for i, hepEntity := range bundle.HostEndpoints {
+		resources = append(resources, &bundleResource{
+			kind:   entity.WebhookKindHostEndpoint,
+			index:  i,
+			name:   hepEntity.Metadata.Name,
+			object: hepEntity,
+			ierr:   state.checkHostEndpoint(ctx, hepEntity),
+		})
+	}
+	for i, gnsEntity := range bundle.GlobalNetworkSets {
+		resources = append(resources, &bundleResource{
+			kind:   entity.WebhookKindGlobalNetworkSet,
+			index:  i,
+			name:   gnsEntity.Metadata.Name,
+			object: gnsEntity,
+		})
+	}
+	for i, nsEntity := range bundle.NetworkSets {
+		resources = append(resources, &bundleResource{
+			kind:   entity.WebhookKindNetworkSet,
+			index:  i,
+			name:   nsEntity.Metadata.Name,
+			object: nsEntity,
+			ierr:   state.checkNetworkSet(ctx, nsEntity),
+		})
+	}
+	for i, gnpEntity := range bundle.GlobalNetworkPolicies {
+		resources = append(resources, &bundleResource{
+			kind:   entity.WebhookKindGlobalNetworkPolicy,
+			index:  i,
+			name:   gnpEntity.Metadata.Name,
+			object: gnpEntity,
+			ierr:   state.checkTier(ctx, gnpEntity.Spec.Tier),
+		})
+	}
+	for i, npEntity := range bundle.NetworkPolicies {
+		resources = append(resources, &bundleResource{
+			kind:   entity.WebhookKindNetworkPolicy,
+			index:  i,
+			name:   npEntity.Metadata.Name,
+			object: npEntity,
+			ierr:   state.checkNetworkPolicy(ctx, npEntity),
+		})
+	}
+
+	var itemErrors []*model.RestoreItemError
+	for _, resource := range resources {
+		if resource.ierr == nil {
+			continue
+		}
+		if resource.ierr.HTTPStatusCode >= http.StatusInternalServerError {
+			return resource.ierr
+		}
+		itemErrors = append(itemErrors, restoreItemError(resource, resource.ierr))
+	}
+	if len(itemErrors) > 0 {
+		return httpbase.ErrBadRequest(ctx, "bundle is invalid").SetDetail(itemErrors)
+	}
+
+	reviews := make([]*model.AdmissionReviewInput, 0, len(resources))
+	for _, resource := range resources {
+		reviews = append(reviews, &model.AdmissionReviewInput{
+			Kind:      resource.kind,
+			Operation: entity.AdmissionOperationCreate,
+			Object:    resource.object,
+		})
+	}
+	denied, ierr := admitAll(ctx, ds.storage, reviews)
+	if ierr != nil {
+		return ierr
+	}
+	for i, deniedErr := range denied {
+		if deniedErr != nil {
+			itemErrors = append(itemErrors, restoreItemError(resources[i], deniedErr))
+		}
+	}
+	if len(itemErrors) > 0 {
+		return httpbase.ErrBadRequest(ctx, "bundle is denied by admission hooks").SetDetail(itemErrors)
+	}
+	return nil
+}
+
+func restoreItemError(resource *bundleResource, ierr *ierror.Error) *model.RestoreItemError {
+	return &model.RestoreItemError{
+		Kind:   resource.kind,
+		Index:  resource.index,
+		Name:   resource.name,
+		Error:  ierr.Message,
+		Detail: ierr.Detail,
+	}
+}
+
+// restoreState holds the tenants, tiers, host endpoints and network policies as they are once a bundle is restored.
+type restoreState struct {
+	tenants map[uint64]*entity.Tenant
+	tiers   map[string]*entity.Tier
+	heps    []*entity.HostEndpoint
+	nps     []*entity.GlobalNetworkPolicy
+}
+
+// loadRestoreState returns the stored resources the resources of the bundle of input refer to, as they are once the
+// bundle is restored.
+func (ds *backup) loadRestoreState(ctx context.Context, input *model.RestoreInput) (*restoreState, *ierror.Error) {
+	tenants, coreErr := ds.storage.ListTenants(ctx)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list tenants failed").SetSubError(coreErr)
+	}
+	tiers, coreErr := ds.storage.ListTiers(ctx)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list tiers failed").SetSubError(coreErr)
+	}
+	heps, coreErr := ds.storage.ListHostEndpoints(ctx, nil)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list host endpoints failed").SetSubError(coreErr)
+	}
+	nps, coreErr := ds.storage.ListNetworkPolicies(ctx, 0)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list network policies failed").SetSubError(coreErr)
+	}
+
+	state := &restoreState{
+		tenants: make(map[uint64]*entity.Tenant),
+		tiers:   make(map[string]*entity.Tier),
+		heps: restoredResources(input.Mode, heps, input.Bundle.HostEndpoints, func(hepEntity *entity.HostEndpoint) []string {
+			return []string{"uuid/" + hepEntity.UUID, fmt.Sprintf("ip/%d/%d", hepEntity.Spec.TenantID, hepEntity.Spec.IP)}
+		}),
+		nps: restoredResources(input.Mode, nps, input.Bundle.NetworkPolicies, func(npEntity *entity.GlobalNetworkPolicy) []string {
+			return []string{"uuid/" + npEntity.UUID, fmt.Sprintf("name/%d/%s", npEntity.Metadata.TenantID, npEntity.Metadata.Name)}
+		}),
+	}
+	for _, tenantEntity := range restoredResources(input.Mode, tenants, input.Bundle.Tenants, func(tenantEntity *entity.Tenant) []string {
+		return []string{"uuid/" + tenantEntity.UUID, "name/" + tenantEntity.Metadata.Name, fmt.Sprintf("id/%d", tenantEntity.Spec.TenantID)}
+	}) {
+		state.tenants[tenantEntity.Spec.TenantID] = tenantEntity
+	}
+	for _, tierEntity := range restoredResources(input.Mode, tiers, input.Bundle.Tiers, func(tierEntity *entity.Tier) []string {
+		return []string{"uuid/" + tierEntity.UUID, "name/" + tierEntity.Metadata.Name}
+	}) {
+		state.tiers[tierEntity.Metadata.Name] = tierEntity
+	}
+	return state, nil
+}
+
+// restoredResources returns the stored resources once the ones of the bundle are restored with mode. keys returns the
+// uuid and the unique keys of a resource, a resource of the bundle conflicts with the stored ones sharing one of them:
+// it replaces them in the overwrite mode and is skipped in the skip mode.
+func restoredResources[T any](mode string, stored []T, bundle []T, keys func(T) []string) []T {
+	indexes := make(map[string]int, 2*len(stored))
+	for i, resource := range stored {
+		for _, key := range keys(resource) {
+			indexes[key] = i
+		}
+	}
+	replaced := make(map[int]bool)
+	var added []T
+	for _, resource := range bundle {
+		var conflicting []int
+		for _, key := range keys(resource) {
+			if i, ok := indexes[key]; ok {
+				conflicting = append(conflicting, i)
+			}
+		}
+		if len(conflicting) > 0 && mode == model.RestoreModeSkip {
+			continue
+		}
+		if mode == model.RestoreModeOverwrite {
+			for _, i := range conflicting {
+				replaced[i] = true
+			}
+		}
+		added = append(added, resource)
+	}
+
+	resources := make([]T, 0, len(stored)+len(added))
+	for i, resource := range stored {
+		if !replaced[i] {
+			resources = append(resources, resource)
+		}
+	}
+	return append(resources, added...)
+}
+
+// checkTenant returns the tenant of tenantID, or a bad request error if a resource refers to a tenant which is neither
+// stored nor restored.
+func (s *restoreState) checkTenant(ctx context.Context, tenantID uint64) (*entity.Tenant, *ierror.Error) {
+	if tenantEntity, ok := s.tenants[tenantID]; ok {
+		return tenantEntity, nil
+	}
+	if tenantID == entity.DefaultTenantID {
+		defaultTenant := entity.TenantDefault
+		return &defaultTenant, nil
+	}
+	return nil, httpbase.ErrBadRequest(ctx, fmt.Sprintf("tenant %d not found", tenantID))
+}
+
+// checkTier returns a bad request error if a policy refers to a tier which is neither stored nor restored.
+func (s *restoreState) checkTier(ctx context.Context, name string) *ierror.Error {
+	if name == "" || name == entity.DefaultTierName {
+		return nil
+	}
+	if _, ok := s.tiers[name]; !ok {
+		return httpbase.ErrBadRequest(ctx, fmt.Sprintf("tier %s not found", name))
+	}
+	return nil
+}
+
+func (s *restoreState) checkHostEndpoint(ctx context.Context, hepEntity *entity.HostEndpoint) *ierror.Error {
+	if len(hepEntity.Spec.IPsV4) == 0 {
+		return httpbase.ErrBadRequest(ctx, "required at least one ip version 4")
+	}
+	if _, ok := hepEntity.Metadata.Labels[selector.TenantLabel]; ok {
+		return httpbase.ErrBadRequest(ctx, fmt.Sprintf("label %q is reserved", selector.TenantLabel))
+	}
+	tenantID := hepEntity.Spec.TenantID
+	tenantEntity, ierr := s.checkTenant(ctx, tenantID)
+	if ierr != nil {
+		return ierr
+	}
+	maxHEPs := tenantEntity.Spec.Quota.MaxHostEndpoints
+	if maxHEPs == 0 {
+		return nil
+	}
+	var count uint32
+	for _, restored := range s.heps {
+		if restored.Spec.TenantID == tenantID {
+			count++
+		}
+	}
+	if count > maxHEPs {
+		return httpbase.ErrBadRequest(ctx, fmt.Sprintf("tenant %d exceeds its quota of %d host endpoints", tenantID, maxHEPs))
+	}
+	return nil
+}
+
+func (s *restoreState) checkNetworkSet(ctx context.Context, nsEntity *entity.GlobalNetworkSet) *ierror.Error {
+	if _, ok := nsEntity.Metadata.Labels[selector.TenantLabel]; ok {
+		return httpbase.ErrBadRequest(ctx, fmt.Sprintf("label %q is reserved", selector.TenantLabel))
+	}
+	_, ierr := s.checkTenant(ctx, nsEntity.Metadata.TenantID)
+	return ierr
+}
+
+func (s *restoreState) checkNetworkPolicy(ctx context.Context, npEntity *entity.GlobalNetworkPolicy) *ierror.Error {
+	if ierr := s.checkTier(ctx, npEntity.Spec.Tier); ierr != nil {
+		return ierr
+	}
+	tenantID := npEntity.Metadata.TenantID
+	tenantEntity, ierr := s.checkTenant(ctx, tenantID)
+	if ierr != nil {
+		return ierr
+	}
+	maxNPs := tenantEntity.Spec.Quota.MaxNetworkPolicies
+	if maxNPs == 0 {
+		return nil
+	}
+	var count uint32
+	for _, restored := range s.nps {
+		if restored.Metadata.TenantID == tenantID {
+			count++
+		}
+	}
+	if count > maxNPs {
+		return httpbase.ErrBadRequest(ctx, fmt.Sprintf("tenant %d exceeds its quota of %d network policies", tenantID, maxNPs))
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	"github.com/bamboo-firewall/be/pkg/selector"
+	"github.com/bamboo-firewall/be/pkg/webhook"
+)
+
+func restoredHEP(uuid string, tenantID uint64, ip uint32, labels map[string]string) *entity.HostEndpoint {
+	hepEntity := testHEP(uuid, ip, labels)
+	hepEntity.UUID = uuid
+	hepEntity.Spec.TenantID = tenantID
+	return hepEntity
+}
+
+func restoredNP(uuid string, tenantID uint64, tier string) *entity.GlobalNetworkPolicy {
+	npEntity := testGNP(uuid, "app == 'web'", 10, "app == 'lb'")
+	npEntity.UUID = uuid
+	npEntity.Metadata.TenantID = tenantID
+	npEntity.Spec.Tier = tier
+	return npEntity
+}
+
+// restoreItemErrors returns the kind and index of each invalid resource detailed by ierr.
+func restoreItemErrors(t *testing.T, ierr *ierror.Error) [][2]interface{} {
+	t.Helper()
+	if ierr == nil {
+		return nil
+	}
+	itemErrors, ok := ierr.Detail.([]*model.RestoreItemError)
+	if !ok {
+		t.Fatalf("Restore() error: %v", ierr)
+	}
+	got := make([][2]interface{}, 0, len(itemErrors))
+	for _, itemError := range itemErrors {
+		got = append(got, [2]interface{}{itemError.Kind, itemError.Index})
+	}
+	return got
+}
+
+func TestRestoreChecksBundle(t *testing.T) {
+	ctx := context.Background()
+	// storage holds the tenant 2 whose quota is 2 host endpoints, and its host endpoint stored
+	newStorage := func() *fakeStorage {
+		storage := newFakeStorage()
+		storage.UpsertTenant(ctx, testTenant(2, 2, 0))
+		storage.UpsertHostEndpoint(ctx, restoredHEP("stored", 2, 1, nil))
+		return storage
+	}
+	stored := func(storage *fakeStorage) *entity.HostEndpoint {
+		hepEntity, _ := storage.GetHostEndpoint(ctx, &model.GetHostEndpointInput{TenantID: 2, IP: 1})
+		return hepEntity
+	}
+	tests := []struct {
+		name   string
+		mode   string
+		bundle func(storage *fakeStorage) *model.Bundle
+		want   [][2]interface{}
+	}{
+		{
+			name: "valid",
+			mode: model.RestoreModeFail,
+			bundle: func(*fakeStorage) *model.Bundle {
+				tenant := testTenant(3, 0, 1)
+				tenant.UUID = "tenant-3"
+				return &model.Bundle{
+					Tenants:               []*entity.Tenant{tenant},
+					Tiers:                 []*entity.Tier{{UUID: "platform", Metadata: entity.TierMetadata{Name: "platform"}}},
+					HostEndpoints:         []*entity.HostEndpoint{restoredHEP("web", 3, 2, nil)},
+					NetworkSets:           []*entity.GlobalNetworkSet{{UUID: "lb", Metadata: entity.GNSMetadata{Name: "lb", TenantID: 3}}},
+					GlobalNetworkPolicies: []*entity.GlobalNetworkPolicy{restoredNP("allow", 0, "platform")},
+					NetworkPolicies:       []*entity.GlobalNetworkPolicy{restoredNP("allow-web", 3, "")},
+				}
+			},
+		},
+		{
+			name: "reserved default tenant name",
+			mode: model.RestoreModeFail,
+			bundle: func(*fakeStorage) *model.Bundle {
+				tenant := testTenant(3, 0, 0)
+				tenant.Metadata.Name = entity.DefaultTenantName
+				return &model.Bundle{Tenants: []*entity.Tenant{tenant}}
+			},
+			want: [][2]interface{}{{entity.WebhookKindTenant, 0}},
+		},
+		{
+			name: "reserved tenant label",
+			mode: model.RestoreModeFail,
+			bundle: func(*fakeStorage) *model.Bundle {
+				return &model.Bundle{
+					HostEndpoints: []*entity.HostEndpoint{restoredHEP("web", 2, 2, map[string]string{selector.TenantLabel: "3"})},
+					NetworkSets: []*entity.GlobalNetworkSet{{
+						Metadata: entity.GNSMetadata{Name: "lb", TenantID: 2, Labels: map[string]string{selector.TenantLabel: "3"}},
+					}},
+				}
+			},
+			want: [][2]interface{}{{entity.WebhookKindHostEndpoint, 0}, {entity.WebhookKindNetworkSet, 0}},
+		},
+		{
+			name: "tenant not found",
+			mode: model.RestoreModeFail,
+			bundle: func(*fakeStorage) *model.Bundle {
+				return &model.Bundle{
+					HostEndpoints:   []*entity.HostEndpoint{restoredHEP("web", 3, 2, nil)},
+					NetworkPolicies: []*entity.GlobalNetworkPolicy{restoredNP("allow-web", 3, "")},
+				}
+			},
+			want: [][2]interface{}{{entity.WebhookKindHostEndpoint, 0}, {entity.WebhookKindNetworkPolicy, 0}},
+		},
+		{
+			name: "tier not found",
+			mode: model.RestoreModeFail,
+			bundle: func(*fakeStorage) *model.Bundle {
+				return &model.Bundle{
+					GlobalNetworkPolicies: []*entity.GlobalNetworkPolicy{restoredNP("allow", 0, "platform")},
+				}
+			},
+			want: [][2]interface{}{{entity.WebhookKindGlobalNetworkPolicy, 0}},
+		},
+		{
+			name: "without ip version 4",
+			mode: model.RestoreModeFail,
+			bundle: func(*fakeStorage) *model.Bundle {
+				hepEntity := restoredHEP("web", 2, 2, nil)
+				hepEntity.Spec.IPs = []string{"2001:db8::1"}
+				return &model.Bundle{HostEndpoints: []*entity.HostEndpoint{hepEntity}}
+			},
+			want: [][2]interface{}{{entity.WebhookKindHostEndpoint, 0}},
+		},
+		{
+			name: "host endpoint quota exceeded",
+			mode: model.RestoreModeFail,
+			bundle: func(*fakeStorage) *model.Bundle {
+				return &model.Bundle{
+					HostEndpoints: []*entity.HostEndpoint{restoredHEP("web", 2, 2, nil), restoredHEP("db", 2, 3, nil)},
+				}
+			},
+			want: [][2]interface{}{{entity.WebhookKindHostEndpoint, 0}, {entity.WebhookKindHostEndpoint, 1}},
+		},
+		{
+			name: "host endpoint quota with the stored one overwritten",
+			mode: model.RestoreModeOverwrite,
+			bundle: func(storage *fakeStorage) *model.Bundle {
+				return &model.Bundle{
+					HostEndpoints: []*entity.HostEndpoint{restoredHEP(stored(storage).UUID, 2, 4, nil), restoredHEP("db", 2, 3, nil)},
+				}
+			},
+		},
+		{
+			name: "host endpoint quota with the stored one skipped",
+			mode: model.RestoreModeSkip,
+			bundle: func(*fakeStorage) *model.Bundle {
+				return &model.Bundle{
+					HostEndpoints: []*entity.HostEndpoint{restoredHEP("web", 2, 1, nil), restoredHEP("db", 2, 3, nil)},
+				}
+			},
+		},
+		{
+			name: "network policy quota of a restored tenant",
+			mode: model.RestoreModeOverwrite,
+			bundle: func(*fakeStorage) *model.Bundle {
+				return &model.Bundle{
+					Tenants:         []*entity.Tenant{testTenant(3, 0, 1)},
+					NetworkPolicies: []*entity.GlobalNetworkPolicy{restoredNP("allow-web", 3, ""), restoredNP("allow-db", 3, "")},
+				}
+			},
+			want: [][2]interface{}{{entity.WebhookKindNetworkPolicy, 0}, {entity.WebhookKindNetworkPolicy, 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newStorage()
+			ds := &backup{storage: storage, snapshot: newPolicySnapshot(storage)}
+
+			_, ierr := ds.Restore(ctx, &model.RestoreInput{Mode: tt.mode, Bundle: tt.bundle(storage)})
+			if diff := cmp.Diff(tt.want, restoreItemErrors(t, ierr)); diff != "" {
+				t.Errorf("Restore() invalid resources mismatch (-want +got):\n%s", diff)
+			}
+			if restored := storage.restored != nil; restored != (tt.want == nil) {
+				t.Errorf("bundle restored = %v, want %v", restored, tt.want == nil)
+			}
+		})
+	}
+}
+
+func TestRestoreAdmission(t *testing.T) {
+	storage := newFakeStorage()
+	storage.admissionHooks = []*entity.AdmissionHook{
+		testAdmissionHook("frozen", admissionServer(t, webhook.AdmissionResponse{Message: "frozen"}, closedChan()),
+			entity.WebhookKindHostEndpoint),
+	}
+	ds := &backup{storage: storage, snapshot: newPolicySnapshot(storage)}
+
+	_, ierr := ds.Restore(context.Background(), &model.RestoreInput{
+		Mode: model.RestoreModeFail,
+		Bundle: &model.Bundle{
+			HostEndpoints:     []*entity.HostEndpoint{restoredHEP("web", entity.DefaultTenantID, 1, nil)},
+			GlobalNetworkSets: []*entity.GlobalNetworkSet{testGNS("lb", nil, "192.168.0.0/24")},
+		},
+	})
+	want := [][2]interface{}{{entity.WebhookKindHostEndpoint, 0}}
+	if diff := cmp.Diff(want, restoreItemErrors(t, ierr)); diff != "" {
+		t.Errorf("Restore() denied resources mismatch (-want +got):\n%s", diff)
+	}
+	if storage.restored != nil {
+		t.Error("bundle restored while denied")
+	}
+}
@@ -0,0 +1,486 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/bamboo-firewall/be"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	"github.com/bamboo-firewall/be/pkg/net"
+	"github.com/bamboo-firewall/be/pkg/repository"
+)
+
+func NewBulk(policyMongo *repository.PolicyDB, snapshot *PolicySnapshot) *bulk {
+	return &bulk{
+		storage:  policyMongo,
+		snapshot: snapshot,
+	}
+}
+
+type bulk struct {
+	storage  be.Storage
+	snapshot *PolicySnapshot
+}
+
+// bulkState holds the host endpoints, global network sets and global network policies as they are once the items
+// staged so far are applied.
+type bulkState struct {
+	heps map[hepKey]*entity.HostEndpoint
+	gnss map[string]*entity.GlobalNetworkSet
+	gnps map[string]*entity.GlobalNetworkPolicy
+	// nps and nss are not changed by bulk applies
+	nps []*entity.GlobalNetworkPolicy
+	nss []*entity.GlobalNetworkSet
+	// changedBy holds the index of the item changing a resource, by kind and key of the resource
+	changedBy map[string]int
+}
+
+// bulkItem is a staged item of a bulk apply.
+type bulkItem struct {
+	result *model.BulkItemResult
+	write  *model.BulkWriteItem
+	// old is the stored resource the item replaces or deletes, nil if there is none
+	old interface{}
+}
+
+// Apply validates the items against the state once all of them are applied, and writes them in a single
+// transaction. No item is written if one of them is invalid, the error then details the result of each item. The
+// items are written only if the resources they change are still as they were read when validated.
+func (ds *bulk) Apply(ctx context.Context, input *model.BulkApplyInput) (*model.BulkApplyOutput, *ierror.Error) {
+	state, ierr := ds.loadState(ctx)
+	if ierr != nil {
+		return nil, ierr
+	}
+
+	output := &model.BulkApplyOutput{Items: make([]*model.BulkItemResult, 0, len(input.Items))}
+	items := make([]*bulkItem, 0, len(input.Items))
+	for i, itemInput := range input.Items {
+		result := &model.BulkItemResult{Index: i, Kind: itemInput.Kind, Operation: itemInput.Operation}
+		output.Items = append(output.Items, result)
+		item, ierr := ds.stage(ctx, state, itemInput, result)
+		if ierr != nil {
+			if ierr = setBulkItemError(result, ierr); ierr != nil {
+				return nil, ierr
+			}
+			continue
+		}
+		items = append(items, item)
+	}
+
+	for _, item := range items {
+		if ierr = ds.checkPostChange(ctx, state, item, input.Force); ierr != nil {
+			if ierr = setBulkItemError(item.result, ierr); ierr != nil {
+				return nil, ierr
+			}
+		}
+	}
+	if hasBulkItemError(output) {
+		return nil, httpbase.ErrBadRequest(ctx, "bulk apply is invalid").SetDetail(output.Items)
+	}
+
+	var reviewed []*bulkItem
+	var reviews []*model.AdmissionReviewInput
+	for _, item := range items {
+		if item.write != nil {
+			reviewed = append(reviewed, item)
+			reviews = append(reviews, bulkAdmissionReview(item))
+		}
+	}
+	denied, ierr := admitAll(ctx, ds.storage, reviews)
+	if ierr != nil {
+		return nil, ierr
+	}
+	for i, deniedErr := range denied {
+		if deniedErr != nil {
+			if ierr = setBulkItemError(reviewed[i].result, deniedErr); ierr != nil {
+				return nil, ierr
+			}
+		}
+	}
+	if hasBulkItemError(output) {
+		return nil, httpbase.ErrBadRequest(ctx, "bulk apply is denied by admission hooks").SetDetail(output.Items)
+	}
+
+	writes := make([]*model.BulkWriteItem, 0, len(items))
+	for _, item := range items {
+		if item.write != nil {
+			writes = append(writes, item.write)
+		}
+	}
+	if coreErr := ds.storage.ApplyBulk(ctx, writes); coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrDuplicateHostEndpoint) || errors.Is(coreErr, errlist.ErrDuplicateGlobalNetworkSet) ||
+			errors.Is(coreErr, errlist.ErrDuplicateGlobalNetworkPolicy) {
+			return nil, httpbase.ErrBadRequest(ctx, "duplicate resource").SetSubError(coreErr)
+		}
+		if errors.Is(coreErr, errlist.ErrQuotaExceeded) {
+			return nil, httpbase.ErrBadRequest(ctx, "tenant quota exceeded").SetSubError(coreErr)
+		}
+		if errors.Is(coreErr, errlist.ErrVersionConflict) {
+			return nil, httpbase.ErrConflict(ctx, "resources changed while applying bulk").SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "apply bulk failed").SetSubError(coreErr)
+	}
+
+	for _, item := range items {
+		ds.applied(ctx, item)
+	}
+	return output, nil
+}
+
+// loadState returns the stored host endpoints, sets and policies.
+func (ds *bulk) loadState(ctx context.Context) (*bulkState, *ierror.Error) {
+	heps, coreErr := ds.storage.ListHostEndpoints(ctx, nil)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list host endpoint failed").SetSubError(coreErr)
+	}
+	gnss, coreErr := ds.storage.ListGNSs(ctx)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list global network sets failed").SetSubError(coreErr)
+	}
+	gnps, coreErr := ds.storage.ListGNPs(ctx, nil)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list global network policies failed").SetSubError(coreErr)
+	}
+	nps, coreErr := ds.storage.ListNetworkPolicies(ctx, 0)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list network policies failed").SetSubError(coreErr)
+	}
+	nss, coreErr := ds.storage.ListNetworkSets(ctx, 0)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list network sets failed").SetSubError(coreErr)
+	}
+
+	state := &bulkState{
+		heps:      make(map[hepKey]*entity.HostEndpoint, len(heps)),
+		gnss:      make(map[string]*entity.GlobalNetworkSet, len(gnss)),
+		gnps:      make(map[string]*entity.GlobalNetworkPolicy, len(gnps)),
+		nps:       nps,
+		nss:       nss,
+		changedBy: make(map[string]int),
+	}
+	for _, hepEntity := range heps {
+		state.heps[keyOfHEP(hepEntity)] = hepEntity
+	}
+	for _, gnsEntity := range gnss {
+		state.gnss[gnsEntity.Metadata.Name] = gnsEntity
+	}
+	for _, gnpEntity := range gnps {
+		state.gnps[gnpEntity.Metadata.Name] = gnpEntity
+	}
+	return state, nil
+}
+
+// stage checks an item on its own and applies it to state.
+func (ds *bulk) stage(ctx context.Context, state *bulkState, input *model.BulkItemInput, result *model.BulkItemResult) (*bulkItem, *ierror.Error) {
+	item := &bulkItem{
+		result: result,
+		write:  &model.BulkWriteItem{Operation: input.Operation, Kind: input.Kind},
+	}
+	switch {
+	case input.Kind == entity.WebhookKindHostEndpoint && input.Operation == model.BulkOperationUpsert:
+		hepEntity, ierr := createModelToHEPEntity(ctx, input.HostEndpoint)
+		if ierr != nil {
+			return nil, ierr
+		}
+		key := keyOfHEP(hepEntity)
+		result.Name = hepEntity.Metadata.Name
+		if ierr = state.claim(ctx, input.Kind, fmt.Sprintf("%d/%d", key.tenantID, key.ip), result.Index); ierr != nil {
+			return nil, ierr
+		}
+		if old, ok := state.heps[key]; ok {
+			item.old = old
+			item.write.StoredVersion = old.Version
+		}
+		state.heps[key] = hepEntity
+		item.write.HostEndpoint = hepEntity
+	case input.Kind == entity.WebhookKindHostEndpoint && input.Operation == model.BulkOperationDelete:
+		key, ierr := deleteHEPKey(ctx, input.DeleteHostEndpoint)
+		if ierr != nil {
+			return nil, ierr
+		}
+		result.Name = net.IntToIP(key.ip).String()
+		if ierr = state.claim(ctx, input.Kind, fmt.Sprintf("%d/%d", key.tenantID, key.ip), result.Index); ierr != nil {
+			return nil, ierr
+		}
+		old, ok := state.heps[key]
+		if !ok {
+			item.write = nil
+			result.Action = model.BulkActionNotFound
+			return item, nil
+		}
+		result.Name = old.Metadata.Name
+		item.old = old
+		item.write.StoredVersion = old.Version
+		delete(state.heps, key)
+		item.write.HostEndpoint = old
+	case input.Kind == entity.WebhookKindGlobalNetworkSet && input.Operation == model.BulkOperationUpsert:
+		gnsEntity := createModelToGNSEntity(input.GlobalNetworkSet)
+		result.Name = gnsEntity.Metadata.Name
+		if ierr := state.claim(ctx, input.Kind, gnsEntity.Metadata.Name, result.Index); ierr != nil {
+			return nil, ierr
+		}
+		old, ok := state.gnss[gnsEntity.Metadata.Name]
+		if ok {
+			item.old = old
+			item.write.StoredVersion = old.Version
+		}
+		keepExternalNets(gnsEntity, old)
+		state.gnss[gnsEntity.Metadata.Name] = gnsEntity
+		item.write.GlobalNetworkSet = gnsEntity
+	case input.Kind == entity.WebhookKindGlobalNetworkSet && input.Operation == model.BulkOperationDelete:
+		result.Name = input.DeleteName
+		if ierr := state.claim(ctx, input.Kind, input.DeleteName, result.Index); ierr != nil {
+			return nil, ierr
+		}
+		old, ok := state.gnss[input.DeleteName]
+		if !ok {
+			item.write = nil
+			result.Action = model.BulkActionNotFound
+			return item, nil
+		}
+		item.old = old
+		item.write.StoredVersion = old.Version
+		delete(state.gnss, input.DeleteName)
+		item.write.GlobalNetworkSet = old
+	case input.Kind == entity.WebhookKindGlobalNetworkPolicy && input.Operation == model.BulkOperationUpsert:
+		gnpEntity := createModelToPolicyEntity(input.GlobalNetworkPolicy)
+		result.Name = gnpEntity.Metadata.Name
+		if ierr := state.claim(ctx, input.Kind, gnpEntity.Metadata.Name, result.Index); ierr != nil {
+			return nil, ierr
+		}
+		if ierr := checkTierExists(ctx, ds.storage, gnpEntity.Spec.Tier); ierr != nil {
+			return nil, ierr
+		}
+		if old, ok := state.gnps[gnpEntity.Metadata.Name]; ok {
+			item.old = old
+			item.write.StoredVersion = old.Version
+		}
+		state.gnps[gnpEntity.Metadata.Name] = gnpEntity
+		item.write.GlobalNetworkPolicy = gnpEntity
+	case input.Kind == entity.WebhookKindGlobalNetworkPolicy && input.Operation == model.BulkOperationDelete:
+		result.Name = input.DeleteName
+		if ierr := state.claim(ctx, input.Kind, input.DeleteName, result.Index); ierr != nil {
+			return nil, ierr
+		}
+		old, ok := state.gnps[input.DeleteName]
+		if !ok {
+			item.write = nil
+			result.Action = model.BulkActionNotFound
+			return item, nil
+		}
+		item.old = old
+		item.write.StoredVersion = old.Version
+		delete(state.gnps, input.DeleteName)
+		item.write.GlobalNetworkPolicy = old
+	default:
+		return nil, httpbase.ErrBadRequest(ctx, fmt.Sprintf("unsupported %s of %s", input.Operation, input.Kind))
+	}
+	return item, nil
+}
+
+// claim returns a bad request error if the resource key of kind is already changed by another item.
+func (s *bulkState) claim(ctx context.Context, kind, key string, index int) *ierror.Error {
+	key = kind + "/" + key
+	if other, ok := s.changedBy[key]; ok {
+		return httpbase.ErrBadRequest(ctx, fmt.Sprintf("resource is already changed by item %d", other))
+	}
+	s.changedBy[key] = index
+	return nil
+}
+
+func (s *bulkState) listHEPs() []*entity.HostEndpoint {
+	heps := make([]*entity.HostEndpoint, 0, len(s.heps))
+	for _, hepEntity := range s.heps {
+		heps = append(heps, hepEntity)
+	}
+	return heps
+}
+
+// listSets returns the global network sets and the network sets.
+func (s *bulkState) listSets() []*entity.GlobalNetworkSet {
+	sets := make([]*entity.GlobalNetworkSet, 0, len(s.gnss)+len(s.nss))
+	for _, gnsEntity := range s.gnss {
+		sets = append(sets, gnsEntity)
+	}
+	return append(sets, s.nss...)
+}
+
+// listPolicies returns the global network policies and the network policies.
+func (s *bulkState) listPolicies() []*entity.GlobalNetworkPolicy {
+	policies := make([]*entity.GlobalNetworkPolicy, 0, len(s.gnps)+len(s.nps))
+	for _, gnpEntity := range s.gnps {
+		policies = append(policies, gnpEntity)
+	}
+	return append(policies, s.nps...)
+}
+
+// checkPostChange checks an item against the state once all the items are applied.
+func (ds *bulk) checkPostChange(ctx context.Context, state *bulkState, item *bulkItem, force bool) *ierror.Error {
+	if item.write == nil {
+		return nil
+	}
+	switch {
+	case item.write.Kind == entity.WebhookKindHostEndpoint && item.write.Operation == model.BulkOperationUpsert:
+		if item.old != nil {
+			return nil
+		}
+		return checkBulkHostEndpointQuota(ctx, ds.storage, state, item.write.HostEndpoint.Spec.TenantID)
+	case item.write.Kind == entity.WebhookKindHostEndpoint && item.write.Operation == model.BulkOperationDelete && !force:
+		hepEntity := item.write.HostEndpoint
+		orphaned := orphanedReferences(state.listPolicies(), hepEntity.Metadata.Labels, hepEntity.Spec.TenantID, true,
+			state.listHEPs(), state.listSets())
+		return orphanedReferencesError(ctx, orphaned)
+	case item.write.Kind == entity.WebhookKindGlobalNetworkSet && item.write.Operation == model.BulkOperationDelete && !force:
+		gnsEntity := item.write.GlobalNetworkSet
+		orphaned := orphanedReferences(state.listPolicies(), gnsEntity.Metadata.Labels, gnsEntity.Metadata.TenantID, false,
+			state.listHEPs(), state.listSets())
+		return orphanedReferencesError(ctx, orphaned)
+	}
+	return nil
+}
+
+// checkBulkHostEndpointQuota returns a bad request error if the tenant does not exist, or if it has more host
+// endpoints than its quota once the items are applied.
+func checkBulkHostEndpointQuota(ctx context.Context, storage be.Storage, state *bulkState, tenantID uint64) *ierror.Error {
+	tenantEntity, ierr := getExistingTenant(ctx, storage, tenantID)
+	if ierr != nil {
+		return ierr
+	}
+	maxHEPs := tenantEntity.Spec.Quota.MaxHostEndpoints
+	if maxHEPs == 0 {
+		return nil
+	}
+	var count uint32
+	for key := range state.heps {
+		if key.tenantID == tenantID {
+			count++
+		}
+	}
+	if count > maxHEPs {
+		return httpbase.ErrBadRequest(ctx, fmt.Sprintf("tenant %d exceeds its quota of %d host endpoints", tenantID, maxHEPs))
+	}
+	return nil
+}
+
+func deleteHEPKey(ctx context.Context, input *model.DeleteHostEndpointInput) (hepKey, *ierror.Error) {
+	tenantID := input.TenantID
+	if tenantID == 0 {
+		tenantID = entity.DefaultTenantID
+	}
+	ipString := input.IP
+	if ipString == "" {
+		ipsV4, _ := exactIPs(input.IPs)
+		if len(ipsV4) == 0 {
+			return hepKey{}, httpbase.ErrBadRequest(ctx, "required at least one ip version 4")
+		}
+		ipString = ipsV4[0]
+	}
+	ip := net.ParseIP(ipString)
+	if ip == nil {
+		return hepKey{}, httpbase.ErrBadRequest(ctx, "malformed ip")
+	}
+	return hepKey{tenantID: tenantID, ip: net.IPToInt(*ip)}, nil
+}
+
+func bulkAdmissionReview(item *bulkItem) *model.AdmissionReviewInput {
+	review := &model.AdmissionReviewInput{
+		Kind:      item.write.Kind,
+		Operation: entity.AdmissionOperationCreate,
+	}
+	var object interface{}
+	switch item.write.Kind {
+	case entity.WebhookKindHostEndpoint:
+		object = item.write.HostEndpoint
+	case entity.WebhookKindGlobalNetworkSet:
+		object = item.write.GlobalNetworkSet
+	case entity.WebhookKindGlobalNetworkPolicy:
+		object = item.write.GlobalNetworkPolicy
+	}
+	if item.write.Operation == model.BulkOperationDelete {
+		review.Operation = entity.AdmissionOperationDelete
+		review.OldObject = object
+		return review
+	}
+	review.Object = object
+	review.OldObject = item.old
+	return review
+}
+
+// setBulkItemError sets the error of an invalid item. Server errors are not about the item, they are returned.
+func setBulkItemError(result *model.BulkItemResult, ierr *ierror.Error) *ierror.Error {
+	if ierr.HTTPStatusCode >= http.StatusInternalServerError {
+		return ierr
+	}
+	result.Error = ierr.Message
+	result.Detail = ierr.Detail
+	return nil
+}
+
+func hasBulkItemError(output *model.BulkApplyOutput) bool {
+	for _, result := range output.Items {
+		if result.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// applied updates the policy snapshot and notifies webhooks of a written item.
+func (ds *bulk) applied(ctx context.Context, item *bulkItem) {
+	write := item.write
+	if write == nil {
+		return
+	}
+	if write.Operation == model.BulkOperationDelete {
+		item.result.Action = model.BulkActionDeleted
+		switch write.Kind {
+		case entity.WebhookKindHostEndpoint:
+			item.result.UUID, item.result.Version = write.HostEndpoint.UUID, write.HostEndpoint.Version
+			ds.snapshot.DeleteHEP(write.HostEndpoint.Spec.TenantID, write.HostEndpoint.Spec.IP)
+			notifyWebhooks(ctx, ds.storage, hepWebhookEvent(entity.WebhookActionDelete, write.HostEndpoint))
+		case entity.WebhookKindGlobalNetworkSet:
+			item.result.UUID, item.result.Version = write.GlobalNetworkSet.UUID, write.GlobalNetworkSet.Version
+			ds.snapshot.DeleteGNS(write.GlobalNetworkSet.Metadata.Name)
+			notifyWebhooks(ctx, ds.storage, setWebhookEvent(entity.WebhookKindGlobalNetworkSet, entity.WebhookActionDelete, write.GlobalNetworkSet))
+		case entity.WebhookKindGlobalNetworkPolicy:
+			gnpEntity := write.GlobalNetworkPolicy
+			item.result.UUID, item.result.Version = gnpEntity.UUID, gnpEntity.Version
+			ds.snapshot.DeleteGNP(gnpEntity.Metadata.Name)
+			notifyWebhooks(ctx, ds.storage, policyWebhookEvent(entity.WebhookKindGlobalNetworkPolicy, entity.WebhookActionDelete, gnpEntity))
+			if gnpEntity.Spec.Staged {
+				if coreErr := ds.storage.DeleteStagedPolicyReports(ctx, gnpEntity.UUID); coreErr != nil {
+					slog.Warn("delete staged policy reports failed", "policy_uuid", gnpEntity.UUID, "err", coreErr)
+				}
+			}
+		}
+		return
+	}
+
+	var version uint
+	switch write.Kind {
+	case entity.WebhookKindHostEndpoint:
+		item.result.UUID, version = write.HostEndpoint.UUID, write.HostEndpoint.Version
+		ds.snapshot.UpsertHEP(write.HostEndpoint)
+		notifyWebhooks(ctx, ds.storage, hepWebhookEvent(upsertAction(version), write.HostEndpoint))
+	case entity.WebhookKindGlobalNetworkSet:
+		item.result.UUID, version = write.GlobalNetworkSet.UUID, write.GlobalNetworkSet.Version
+		ds.snapshot.UpsertGNS(write.GlobalNetworkSet)
+		notifyWebhooks(ctx, ds.storage, setWebhookEvent(entity.WebhookKindGlobalNetworkSet, upsertAction(version), write.GlobalNetworkSet))
+	case entity.WebhookKindGlobalNetworkPolicy:
+		item.result.UUID, version = write.GlobalNetworkPolicy.UUID, write.GlobalNetworkPolicy.Version
+		ds.snapshot.UpsertGNP(write.GlobalNetworkPolicy)
+		notifyWebhooks(ctx, ds.storage, policyWebhookEvent(entity.WebhookKindGlobalNetworkPolicy, upsertAction(version), write.GlobalNetworkPolicy))
+	}
+	item.result.Version = version
+	item.result.Action = model.BulkActionUpdated
+	if version <= 1 {
+		item.result.Action = model.BulkActionCreated
+	}
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	"github.com/bamboo-firewall/be/pkg/net"
+)
+
+const bulkOrphanedError = "policy selectors would lose their last match, use force to delete anyway"
+
+func bulkIP(ip string) uint32 {
+	return net.IPToInt(*net.ParseIP(ip))
+}
+
+func upsertHEPItem(name string, tenantID uint64, ip string, labels map[string]string) *model.BulkItemInput {
+	return &model.BulkItemInput{
+		Operation: model.BulkOperationUpsert,
+		Kind:      entity.WebhookKindHostEndpoint,
+		HostEndpoint: &model.CreateHostEndpointInput{
+			Metadata: model.HostEndpointMetadataInput{Name: name, Labels: labels},
+			Spec:     model.HostEndpointSpecInput{TenantID: tenantID, IPs: []string{ip}},
+		},
+	}
+}
+
+func deleteHEPItem(ip string) *model.BulkItemInput {
+	return &model.BulkItemInput{
+		Operation:          model.BulkOperationDelete,
+		Kind:               entity.WebhookKindHostEndpoint,
+		DeleteHostEndpoint: &model.DeleteHostEndpointInput{IP: ip},
+	}
+}
+
+func upsertGNSItem(name string, nets ...string) *model.BulkItemInput {
+	return &model.BulkItemInput{
+		Operation: model.BulkOperationUpsert,
+		Kind:      entity.WebhookKindGlobalNetworkSet,
+		GlobalNetworkSet: &model.CreateGlobalNetworkSetInput{
+			Metadata: model.GNSMetadataInput{Name: name},
+			Spec:     model.GNSSpecInput{Nets: nets},
+		},
+	}
+}
+
+func upsertGNPItem(name, sel, sourceSelector string) *model.BulkItemInput {
+	return &model.BulkItemInput{
+		Operation: model.BulkOperationUpsert,
+		Kind:      entity.WebhookKindGlobalNetworkPolicy,
+		GlobalNetworkPolicy: &model.CreateGlobalNetworkPolicyInput{
+			Metadata: model.GNPMetadataInput{Name: name},
+			Spec: model.GNPSpecInput{
+				Selector: sel,
+				Ingress: []model.GNPSpecRuleInput{{
+					Action: string(entity.RuleActionAllow),
+					Source: &model.GNPSpecRuleEntityInput{Selector: sourceSelector},
+				}},
+			},
+		},
+	}
+}
+
+func deleteNamedItem(kind, name string) *model.BulkItemInput {
+	return &model.BulkItemInput{Operation: model.BulkOperationDelete, Kind: kind, DeleteName: name}
+}
+
+// newBulkStorage returns a storage with the tenant 2 whose quota is 1 host endpoint, the host endpoints web and db of
+// the default tenant, the policy allow-web from web to db and the set lb selected by no policy.
+func newBulkStorage(t *testing.T) *fakeStorage {
+	t.Helper()
+	ctx := context.Background()
+	storage := newFakeStorage()
+	storage.UpsertTenant(ctx, testTenant(2, 1, 0))
+	web := testHEP("web", bulkIP("10.0.0.1"), map[string]string{"app": "web"})
+	db := testHEP("db", bulkIP("10.0.0.2"), map[string]string{"app": "db"})
+	db.Spec.IPs = []string{"10.0.0.2"}
+	storage.UpsertHostEndpoint(ctx, web)
+	storage.UpsertHostEndpoint(ctx, db)
+	storage.UpsertGroupPolicy(ctx, testGNP("allow-web", "app == 'db'", 10, "app == 'web'"))
+	storage.UpsertGNS(ctx, testGNS("lb", map[string]string{"role": "lb"}, "192.168.0.0/24"))
+	return storage
+}
+
+// storedNames returns the kind and name of the stored host endpoints, global network sets and policies.
+func storedNames(storage *fakeStorage) []string {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+	var names []string
+	for _, hepEntity := range storage.heps {
+		names = append(names, entity.WebhookKindHostEndpoint+"/"+hepEntity.Metadata.Name)
+	}
+	for _, gnsEntity := range storage.gnss {
+		names = append(names, entity.WebhookKindGlobalNetworkSet+"/"+gnsEntity.Metadata.Name)
+	}
+	for _, gnpEntity := range storage.gnps {
+		names = append(names, entity.WebhookKindGlobalNetworkPolicy+"/"+gnpEntity.Metadata.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// bulkItemResults returns the action and the error of each item of an apply, detailed by ierr if it is invalid.
+func bulkItemResults(t *testing.T, output *model.BulkApplyOutput, ierr *ierror.Error) [][2]string {
+	t.Helper()
+	var items []*model.BulkItemResult
+	if ierr != nil {
+		detailed, ok := ierr.Detail.([]*model.BulkItemResult)
+		if !ok {
+			t.Fatalf("Apply() error: %v", ierr)
+		}
+		items = detailed
+	} else {
+		items = output.Items
+	}
+	got := make([][2]string, 0, len(items))
+	for _, result := range items {
+		got = append(got, [2]string{result.Action, result.Error})
+	}
+	return got
+}
+
+func TestBulkApply(t *testing.T) {
+	hepKind, gnsKind, gnpKind := entity.WebhookKindHostEndpoint, entity.WebhookKindGlobalNetworkSet,
+		entity.WebhookKindGlobalNetworkPolicy
+	stored := []string{gnpKind + "/allow-web", gnsKind + "/lb", hepKind + "/db", hepKind + "/web"}
+	tests := []struct {
+		name  string
+		input *model.BulkApplyInput
+		// want are the action and the error of each item
+		want       [][2]string
+		wantStored []string
+	}{
+		{
+			name: "mixed",
+			input: &model.BulkApplyInput{Items: []*model.BulkItemInput{
+				upsertHEPItem("api", 0, "10.0.0.3", map[string]string{"app": "web"}),
+				upsertHEPItem("db", 0, "10.0.0.2", map[string]string{"app": "db"}),
+				deleteNamedItem(gnsKind, "lb"),
+				upsertGNPItem("allow-api", "app == 'web'", "app == 'db'"),
+				// api still matches the source selector of allow-web
+				deleteHEPItem("10.0.0.1"),
+			}},
+			want: [][2]string{
+				{model.BulkActionCreated, ""},
+				{model.BulkActionUpdated, ""},
+				{model.BulkActionDeleted, ""},
+				{model.BulkActionCreated, ""},
+				{model.BulkActionDeleted, ""},
+			},
+			wantStored: []string{gnpKind + "/allow-api", gnpKind + "/allow-web", hepKind + "/api", hepKind + "/db"},
+		},
+		{
+			name: "duplicate claim",
+			input: &model.BulkApplyInput{Items: []*model.BulkItemInput{
+				upsertHEPItem("api", 0, "10.0.0.3", nil),
+				upsertGNSItem("lb", "192.168.1.0/24"),
+				deleteHEPItem("10.0.0.3"),
+			}},
+			want: [][2]string{
+				{"", ""},
+				{"", ""},
+				{"", "resource is already changed by item 0"},
+			},
+			wantStored: stored,
+		},
+		{
+			name: "quota overflow",
+			input: &model.BulkApplyInput{Items: []*model.BulkItemInput{
+				upsertHEPItem("api", 2, "10.0.0.3", nil),
+				deleteNamedItem(gnsKind, "lb"),
+				upsertHEPItem("worker", 2, "10.0.0.4", nil),
+			}},
+			want: [][2]string{
+				{"", "tenant 2 exceeds its quota of 1 host endpoints"},
+				{"", ""},
+				{"", "tenant 2 exceeds its quota of 1 host endpoints"},
+			},
+			wantStored: stored,
+		},
+		{
+			name: "orphaned delete",
+			input: &model.BulkApplyInput{Items: []*model.BulkItemInput{
+				upsertGNSItem("lb", "192.168.1.0/24"),
+				deleteHEPItem("10.0.0.1"),
+			}},
+			want: [][2]string{
+				{"", ""},
+				{"", bulkOrphanedError},
+			},
+			wantStored: stored,
+		},
+		{
+			name: "orphaned delete forced",
+			input: &model.BulkApplyInput{
+				Items: []*model.BulkItemInput{deleteHEPItem("10.0.0.1")},
+				Force: true,
+			},
+			want:       [][2]string{{model.BulkActionDeleted, ""}},
+			wantStored: []string{gnpKind + "/allow-web", gnsKind + "/lb", hepKind + "/db"},
+		},
+		{
+			name: "orphaned delete with the selector deleted",
+			input: &model.BulkApplyInput{Items: []*model.BulkItemInput{
+				deleteHEPItem("10.0.0.1"),
+				deleteNamedItem(gnpKind, "allow-web"),
+			}},
+			want: [][2]string{
+				{model.BulkActionDeleted, ""},
+				{model.BulkActionDeleted, ""},
+			},
+			wantStored: []string{gnsKind + "/lb", hepKind + "/db"},
+		},
+		{
+			name: "delete not found",
+			input: &model.BulkApplyInput{Items: []*model.BulkItemInput{
+				deleteHEPItem("10.0.0.9"),
+				deleteNamedItem(gnsKind, "dns"),
+				deleteNamedItem(gnpKind, "allow-dns"),
+				upsertGNSItem("lb", "192.168.1.0/24"),
+			}},
+			want: [][2]string{
+				{model.BulkActionNotFound, ""},
+				{model.BulkActionNotFound, ""},
+				{model.BulkActionNotFound, ""},
+				{model.BulkActionUpdated, ""},
+			},
+			wantStored: stored,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newBulkStorage(t)
+			ds := &bulk{storage: storage, snapshot: loadedSnapshot(t, storage)}
+
+			output, ierr := ds.Apply(context.Background(), tt.input)
+			if diff := cmp.Diff(tt.want, bulkItemResults(t, output, ierr)); diff != "" {
+				t.Errorf("Apply() item results mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantStored, storedNames(storage)); diff != "" {
+				t.Errorf("stored resources mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBulkApplyVersionConflict(t *testing.T) {
+	ctx := context.Background()
+	storage := newBulkStorage(t)
+	ds := &bulk{storage: storage, snapshot: loadedSnapshot(t, storage)}
+	// db is updated by another request once the bulk apply is validated
+	storage.beforeApplyBulk = func() {
+		db := testHEP("db", bulkIP("10.0.0.2"), map[string]string{"app": "db", "env": "prod"})
+		db.Spec.IPs = []string{"10.0.0.2"}
+		storage.UpsertHostEndpoint(ctx, db)
+	}
+
+	_, ierr := ds.Apply(ctx, &model.BulkApplyInput{Items: []*model.BulkItemInput{
+		upsertHEPItem("api", 0, "10.0.0.3", nil),
+		upsertHEPItem("db", 0, "10.0.0.2", map[string]string{"app": "db"}),
+	}})
+	if ierr == nil || ierr.HTTPStatusCode != http.StatusConflict {
+		t.Fatalf("Apply() error = %v, want a conflict", ierr)
+	}
+	if _, coreErr := storage.GetHostEndpoint(ctx, &model.GetHostEndpointInput{TenantID: entity.DefaultTenantID,
+		IP: bulkIP("10.0.0.3")}); coreErr == nil {
+		t.Error("api written while db changed")
+	}
+}
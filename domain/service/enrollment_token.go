@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/bamboo-firewall/be"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	"github.com/bamboo-firewall/be/pkg/repository"
+	"github.com/bamboo-firewall/be/pkg/selector"
+)
+
+func NewEnrollmentToken(policyMongo *repository.PolicyDB) *enrollmentToken {
+	return &enrollmentToken{
+		storage: policyMongo,
+	}
+}
+
+type enrollmentToken struct {
+	storage be.Storage
+}
+
+func (ds *enrollmentToken) Create(ctx context.Context, input *model.CreateEnrollmentTokenInput) (*entity.EnrollmentToken, *ierror.Error) {
+	tokenEntity, ierr := createModelToEnrollmentTokenEntity(ctx, input)
+	if ierr != nil {
+		return nil, ierr
+	}
+	if ierr = checkTenantExists(ctx, ds.storage, tokenEntity.Spec.TenantID); ierr != nil {
+		return nil, ierr
+	}
+
+	if coreErr := ds.storage.UpsertEnrollmentToken(ctx, tokenEntity); coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrDuplicateEnrollmentToken) {
+			return nil, httpbase.ErrBadRequest(ctx, "duplicate enrollment token").SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "create enrollment token failed").SetSubError(coreErr)
+	}
+	notifyWebhooks(ctx, ds.storage, enrollmentTokenWebhookEvent(upsertAction(tokenEntity.Version), tokenEntity))
+	return tokenEntity, nil
+}
+
+func (ds *enrollmentToken) Get(ctx context.Context, name string) (*entity.EnrollmentToken, *ierror.Error) {
+	tokenEntity, coreErr := ds.storage.GetEnrollmentTokenByName(ctx, name)
+	if coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrNotFoundEnrollmentToken) {
+			return nil, httpbase.ErrNotFound(ctx, "not found").SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "get enrollment token failed").SetSubError(coreErr)
+	}
+	return tokenEntity, nil
+}
+
+func (ds *enrollmentToken) List(ctx context.Context) ([]*entity.EnrollmentToken, *ierror.Error) {
+	tokensEntity, coreErr := ds.storage.ListEnrollmentTokens(ctx)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list enrollment tokens failed").SetSubError(coreErr)
+	}
+	return tokensEntity, nil
+}
+
+// Delete revokes the enrollment token, the host endpoints registered with it are kept.
+func (ds *enrollmentToken) Delete(ctx context.Context, name string) *ierror.Error {
+	tokenEntity, coreErr := ds.storage.GetEnrollmentTokenByName(ctx, name)
+	if coreErr != nil && !errors.Is(coreErr, errlist.ErrNotFoundEnrollmentToken) {
+		return httpbase.ErrDatabase(ctx, "get enrollment token failed").SetSubError(coreErr)
+	}
+	if coreErr = ds.storage.DeleteEnrollmentTokenByName(ctx, name); coreErr != nil {
+		return httpbase.ErrDatabase(ctx, "delete enrollment token failed").SetSubError(coreErr)
+	}
+	if tokenEntity != nil {
+		notifyWebhooks(ctx, ds.storage, enrollmentTokenWebhookEvent(entity.WebhookActionDelete, tokenEntity))
+	}
+	return nil
+}
+
+func (ds *enrollmentToken) Validate(ctx context.Context, input *model.CreateEnrollmentTokenInput) (*model.ValidateEnrollmentTokenOutput, *ierror.Error) {
+	tokenEntity, ierr := createModelToEnrollmentTokenEntity(ctx, input)
+	if ierr != nil {
+		return nil, ierr
+	}
+	if ierr = checkTenantExists(ctx, ds.storage, tokenEntity.Spec.TenantID); ierr != nil {
+		return nil, ierr
+	}
+
+	tokenExisted, coreErr := ds.storage.GetEnrollmentTokenByName(ctx, input.Metadata.Name)
+	if coreErr != nil {
+		if !errors.Is(coreErr, errlist.ErrNotFoundEnrollmentToken) {
+			return nil, httpbase.ErrDatabase(ctx, "get enrollment token failed").SetSubError(coreErr)
+		}
+	}
+	if tokenExisted != nil {
+		tokenEntity.Spec.Token = tokenExisted.Spec.Token
+	}
+
+	return &model.ValidateEnrollmentTokenOutput{
+		EnrollmentToken:        tokenEntity,
+		EnrollmentTokenExisted: tokenExisted,
+	}, nil
+}
+
+func createModelToEnrollmentTokenEntity(ctx context.Context, input *model.CreateEnrollmentTokenInput) (*entity.EnrollmentToken, *ierror.Error) {
+	if input.Spec.TenantID == 0 {
+		input.Spec.TenantID = entity.DefaultTenantID
+	}
+	if _, ok := input.Spec.LabelTemplates[selector.TenantLabel]; ok {
+		return nil, httpbase.ErrBadRequest(ctx, fmt.Sprintf("label %q is reserved", selector.TenantLabel))
+	}
+	if slices.Contains(input.Spec.AllowedAgentLabels, selector.TenantLabel) {
+		return nil, httpbase.ErrBadRequest(ctx, fmt.Sprintf("label %q is reserved", selector.TenantLabel))
+	}
+	if _, err := executeLabelTemplates(input.Spec.LabelTemplates, labelTemplateData{}); err != nil {
+		return nil, httpbase.ErrBadRequest(ctx, fmt.Sprintf("malformed label templates: %v", err))
+	}
+	token, err := newEnrollmentTokenValue()
+	if err != nil {
+		return nil, httpbase.ErrInternal(ctx, "generate enrollment token failed")
+	}
+
+	return &entity.EnrollmentToken{
+		ID:   primitive.NewObjectID(),
+		UUID: entity.NewMinifyUUID(),
+		Metadata: entity.EnrollmentTokenMetadata{
+			Name:   input.Metadata.Name,
+			Labels: input.Metadata.Labels,
+		},
+		Spec: entity.EnrollmentTokenSpec{
+			TenantID:           input.Spec.TenantID,
+			Token:              token,
+			AllowedCIDRs:       input.Spec.AllowedCIDRs,
+			LabelTemplates:     input.Spec.LabelTemplates,
+			AllowedAgentLabels: input.Spec.AllowedAgentLabels,
+		},
+		Description: input.Description,
+		FilePath:    input.FilePath,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
+func newEnrollmentTokenValue() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// labelTemplateData is the data label templates of an enrollment token are executed with.
+type labelTemplateData struct {
+	Hostname      string
+	OS            string
+	InterfaceName string
+	IP            string
+	TenantID      uint64
+}
+
+// executeLabelTemplates returns the labels of templates executed with data.
+func executeLabelTemplates(templates map[string]string, data labelTemplateData) (map[string]string, error) {
+	labels := make(map[string]string, len(templates))
+	for key, value := range templates {
+		tmpl, err := template.New(key).Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("parse template of label %q: %w", key, err)
+		}
+		var sb strings.Builder
+		if err = tmpl.Execute(&sb, data); err != nil {
+			return nil, fmt.Errorf("execute template of label %q: %w", key, err)
+		}
+		labels[key] = sb.String()
+	}
+	return labels, nil
+}
+
+// registrationLabels returns the labels of a host endpoint registered with the enrollment token. The agent only sets
+// the labels the token allows, the labels of the token take precedence over them.
+func registrationLabels(spec entity.EnrollmentTokenSpec, templateLabels map[string]string, input *model.RegisterHostEndpointInput) map[string]string {
+	labels := make(map[string]string, len(templateLabels)+len(input.Labels)+2)
+	if input.Hostname != "" {
+		labels[labelHostname] = input.Hostname
+	}
+	if input.OS != "" {
+		labels[labelOS] = input.OS
+	}
+	for key, value := range input.Labels {
+		if slices.Contains(spec.AllowedAgentLabels, key) {
+			labels[key] = value
+		}
+	}
+	maps.Copy(labels, templateLabels)
+	return labels
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+func TestRegistrationLabels(t *testing.T) {
+	tests := []struct {
+		name           string
+		spec           entity.EnrollmentTokenSpec
+		templateLabels map[string]string
+		input          *model.RegisterHostEndpointInput
+		want           map[string]string
+	}{
+		{
+			name:  "facts",
+			input: &model.RegisterHostEndpointInput{Hostname: "web-1", OS: "linux"},
+			want:  map[string]string{labelHostname: "web-1", labelOS: "linux"},
+		},
+		{
+			name:  "agent labels not allowed",
+			input: &model.RegisterHostEndpointInput{Labels: map[string]string{"role": "db"}},
+			want:  map[string]string{},
+		},
+		{
+			name:  "allowed agent labels",
+			spec:  entity.EnrollmentTokenSpec{AllowedAgentLabels: []string{"role"}},
+			input: &model.RegisterHostEndpointInput{Labels: map[string]string{"role": "db", "zone": "a"}},
+			want:  map[string]string{"role": "db"},
+		},
+		{
+			name:           "token labels take precedence",
+			spec:           entity.EnrollmentTokenSpec{AllowedAgentLabels: []string{"role", "env"}},
+			templateLabels: map[string]string{"env": "prod", labelHostname: "pinned"},
+			input: &model.RegisterHostEndpointInput{
+				Hostname: "web-1",
+				Labels:   map[string]string{"role": "db", "env": "dev"},
+			},
+			want: map[string]string{"role": "db", "env": "prod", labelHostname: "pinned"},
+		},
+		{
+			name:  "tenant label of the agent",
+			spec:  entity.EnrollmentTokenSpec{AllowedAgentLabels: []string{"role"}},
+			input: &model.RegisterHostEndpointInput{Labels: map[string]string{"tenant": "2"}},
+			want:  map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := registrationLabels(tt.spec, tt.templateLabels, tt.input)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("registrationLabels() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
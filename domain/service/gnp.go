@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -16,27 +17,49 @@ import (
 	"github.com/bamboo-firewall/be/pkg/net"
 	"github.com/bamboo-firewall/be/pkg/repository"
 	"github.com/bamboo-firewall/be/pkg/selector"
+	"github.com/bamboo-firewall/be/pkg/validator"
 )
 
-func NewGNP(policyMongo *repository.PolicyDB) *gnp {
+func NewGNP(policyMongo *repository.PolicyDB, snapshot *PolicySnapshot, linter *validator.Linter) *gnp {
 	return &gnp{
-		storage: policyMongo,
+		storage:  policyMongo,
+		snapshot: snapshot,
+		linter:   linter,
 	}
 }
 
 type gnp struct {
-	storage be.Storage
+	storage  be.Storage
+	snapshot *PolicySnapshot
+	linter   *validator.Linter
 }
 
 func (ds *gnp) Create(ctx context.Context, input *model.CreateGlobalNetworkPolicyInput) (*entity.GlobalNetworkPolicy, *ierror.Error) {
 	gnpEntity := createModelToPolicyEntity(input)
+	if ierr := checkTierExists(ctx, ds.storage, gnpEntity.Spec.Tier); ierr != nil {
+		return nil, ierr
+	}
+	gnpExisted, coreErr := ds.storage.GetGNPByName(ctx, gnpEntity.Metadata.Name)
+	if coreErr != nil && !errors.Is(coreErr, errlist.ErrNotFoundGlobalNetworkPolicy) {
+		return nil, httpbase.ErrDatabase(ctx, "get global network policy failed").SetSubError(coreErr)
+	}
+	if ierr := admit(ctx, ds.storage, &model.AdmissionReviewInput{
+		Kind:      entity.WebhookKindGlobalNetworkPolicy,
+		Operation: entity.AdmissionOperationCreate,
+		Object:    gnpEntity,
+		OldObject: gnpExisted,
+	}); ierr != nil {
+		return nil, ierr
+	}
 
-	if coreErr := ds.storage.UpsertGroupPolicy(ctx, gnpEntity); coreErr != nil {
+	if coreErr = ds.storage.UpsertGroupPolicy(ctx, gnpEntity); coreErr != nil {
 		if errors.Is(coreErr, errlist.ErrDuplicateGlobalNetworkPolicy) {
 			return nil, httpbase.ErrBadRequest(ctx, "duplicate global network policy").SetSubError(coreErr)
 		}
 		return nil, httpbase.ErrDatabase(ctx, "create global network policy failed").SetSubError(coreErr)
 	}
+	ds.snapshot.UpsertGNP(gnpEntity)
+	notifyWebhooks(ctx, ds.storage, policyWebhookEvent(entity.WebhookKindGlobalNetworkPolicy, upsertAction(gnpEntity.Version), gnpEntity))
 	return gnpEntity, nil
 }
 
@@ -52,22 +75,171 @@ func (ds *gnp) Get(ctx context.Context, name string) (*entity.GlobalNetworkPolic
 }
 
 func (ds *gnp) Delete(ctx context.Context, name string) *ierror.Error {
-	if coreErr := ds.storage.DeleteGNPByName(ctx, name); coreErr != nil {
+	gnpEntity, coreErr := ds.storage.GetGNPByName(ctx, name)
+	if coreErr != nil && !errors.Is(coreErr, errlist.ErrNotFoundGlobalNetworkPolicy) {
+		return httpbase.ErrDatabase(ctx, "get global network policy failed").SetSubError(coreErr)
+	}
+	if gnpEntity != nil {
+		if ierr := admit(ctx, ds.storage, &model.AdmissionReviewInput{
+			Kind:      entity.WebhookKindGlobalNetworkPolicy,
+			Operation: entity.AdmissionOperationDelete,
+			OldObject: gnpEntity,
+		}); ierr != nil {
+			return ierr
+		}
+	}
+	if coreErr = ds.storage.DeleteGNPByName(ctx, name); coreErr != nil {
 		return httpbase.ErrDatabase(ctx, "delete global network policy failed").SetSubError(coreErr)
 	}
+	ds.snapshot.DeleteGNP(name)
+	if gnpEntity != nil {
+		notifyWebhooks(ctx, ds.storage, policyWebhookEvent(entity.WebhookKindGlobalNetworkPolicy, entity.WebhookActionDelete, gnpEntity))
+	}
+	if gnpEntity != nil && gnpEntity.Spec.Staged {
+		if coreErr = ds.storage.DeleteStagedPolicyReports(ctx, gnpEntity.UUID); coreErr != nil {
+			slog.Warn("delete staged policy reports failed", "policy_uuid", gnpEntity.UUID, "err", coreErr)
+		}
+	}
+	return nil
+}
+
+// SubscribeSchedule returns the events of schedules opening or closing, see PolicySnapshot.Subscribe.
+func (ds *gnp) SubscribeSchedule() (<-chan *model.ScheduleEvent, func()) {
+	return ds.snapshot.Subscribe()
+}
+
+// Promote enforces a staged policy. Reports collected while the policy was staged are dropped.
+func (ds *gnp) Promote(ctx context.Context, name string) (*entity.GlobalNetworkPolicy, *ierror.Error) {
+	gnpEntity, ierr := ds.Get(ctx, name)
+	if ierr != nil {
+		return nil, ierr
+	}
+	if !gnpEntity.Spec.Staged {
+		return nil, httpbase.ErrBadRequest(ctx, "global network policy is not staged")
+	}
+
+	stagedEntity := *gnpEntity
+	gnpEntity.Spec.Staged = false
+	gnpEntity.UpdatedAt = time.Now()
+	if ierr := admit(ctx, ds.storage, &model.AdmissionReviewInput{
+		Kind:      entity.WebhookKindGlobalNetworkPolicy,
+		Operation: entity.AdmissionOperationCreate,
+		Object:    gnpEntity,
+		OldObject: &stagedEntity,
+	}); ierr != nil {
+		return nil, ierr
+	}
+
+	if coreErr := ds.storage.UpsertGroupPolicy(ctx, gnpEntity); coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "promote global network policy failed").SetSubError(coreErr)
+	}
+	ds.snapshot.UpsertGNP(gnpEntity)
+	notifyWebhooks(ctx, ds.storage, policyWebhookEvent(entity.WebhookKindGlobalNetworkPolicy, entity.WebhookActionUpdate, gnpEntity))
+
+	if coreErr := ds.storage.DeleteStagedPolicyReports(ctx, gnpEntity.UUID); coreErr != nil {
+		slog.Warn("delete staged policy reports failed", "policy_uuid", gnpEntity.UUID, "err", coreErr)
+	}
+	return gnpEntity, nil
+}
+
+// ReportStaged adds the traffic a host endpoint reports as would-be denied by staged policies. The host endpoint is
+// identified by its ip version 4 as it is stored. Reports of policies which are unknown, not staged anymore or not
+// applied to the host endpoint are ignored, an agent may not have fetched the latest policies yet.
+func (ds *gnp) ReportStaged(ctx context.Context, input *model.ReportStagedPoliciesInput) *ierror.Error {
+	if input.TenantID == 0 {
+		input.TenantID = entity.DefaultTenantID
+	}
+	ip := net.ParseIP(input.IP)
+	if ip == nil {
+		return httpbase.ErrBadRequest(ctx, "malformed ip")
+	}
+	if ip.Version() != 4 {
+		return httpbase.ErrBadRequest(ctx, "required the ip version 4 of the host endpoint")
+	}
+	hepPolicy, ok := ds.snapshot.Get(input.TenantID, net.IPToInt(*ip))
+	if !ok {
+		return httpbase.ErrNotFound(ctx, "host endpoint not found")
+	}
+
+	for _, report := range input.Reports {
+		gnpEntity, ok := ds.snapshot.GetGNP(report.GNPUUID)
+		if !ok || !gnpEntity.Spec.Staged {
+			slog.Debug("ignore report of policy not staged", "policy_uuid", report.GNPUUID)
+			continue
+		}
+		if _, applied := hepPolicy.MetaData.GNPVersions[gnpEntity.UUID]; !applied {
+			slog.Debug("ignore report of policy not applied to the host endpoint", "policy_uuid", report.GNPUUID,
+				"tenant_id", input.TenantID, "ip", input.IP)
+			continue
+		}
+		coreErr := ds.storage.IncStagedPolicyReport(ctx, &entity.StagedPolicyReport{
+			GNPUUID:       gnpEntity.UUID,
+			GNPName:       gnpEntity.Metadata.Name,
+			TenantID:      input.TenantID,
+			IP:            net.IPToInt(*ip),
+			DeniedPackets: report.DeniedPackets,
+			DeniedBytes:   report.DeniedBytes,
+		})
+		if coreErr != nil {
+			return httpbase.ErrDatabase(ctx, "report staged policy failed").SetSubError(coreErr)
+		}
+	}
 	return nil
 }
 
+func (ds *gnp) GetStagedReport(ctx context.Context, name string) (*model.StagedPolicyReportSummary, *ierror.Error) {
+	gnpEntity, ierr := ds.Get(ctx, name)
+	if ierr != nil {
+		return nil, ierr
+	}
+
+	reports, coreErr := ds.storage.ListStagedPolicyReports(ctx, gnpEntity.UUID)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list staged policy reports failed").SetSubError(coreErr)
+	}
+
+	summary := &model.StagedPolicyReportSummary{
+		GNP:     gnpEntity,
+		Reports: reports,
+	}
+	for _, report := range reports {
+		summary.DeniedPackets += report.DeniedPackets
+		summary.DeniedBytes += report.DeniedBytes
+	}
+	return summary, nil
+}
+
 func (ds *gnp) List(ctx context.Context, input *model.ListGNPsInput) ([]*entity.GlobalNetworkPolicy, *ierror.Error) {
 	gnpsEntity, coreErr := ds.storage.ListGNPs(ctx, input)
 	if coreErr != nil {
 		return nil, httpbase.ErrDatabase(ctx, "list global network policies failed").SetSubError(coreErr)
 	}
+	if input != nil && input.IsOrder {
+		// sorted as evaluated, the order of a policy only applies within its tier
+		tiers, ierr := listTiersByName(ctx, ds.storage)
+		if ierr != nil {
+			return nil, ierr
+		}
+		sortGNPsByTier(gnpsEntity, tiers)
+	}
+	if input != nil && input.ExpiringWithin > 0 {
+		now := time.Now()
+		expiringGNPs := make([]*entity.GlobalNetworkPolicy, 0)
+		for _, gnpEntity := range gnpsEntity {
+			if expiresWithin(gnpEntity, now, input.ExpiringWithin) {
+				expiringGNPs = append(expiringGNPs, gnpEntity)
+			}
+		}
+		return expiringGNPs, nil
+	}
 	return gnpsEntity, nil
 }
 
 func (ds *gnp) Validate(ctx context.Context, input *model.CreateGlobalNetworkPolicyInput) (*model.ValidateGlobalNetworkPolicyOutput, *ierror.Error) {
 	gnpEntity := createModelToPolicyEntity(input)
+	if ierr := checkTierExists(ctx, ds.storage, gnpEntity.Spec.Tier); ierr != nil {
+		return nil, ierr
+	}
 
 	policyWithRelatedHostEndpoint, ierr := ds.ListRelatedHostEndPoints(ctx, gnpEntity, input.Metadata.Name)
 	if ierr != nil {
@@ -80,11 +252,54 @@ func (ds *gnp) Validate(ctx context.Context, input *model.CreateGlobalNetworkPol
 			return nil, httpbase.ErrDatabase(ctx, "get global network policy failed").SetSubError(coreErr)
 		}
 	}
+	admissionVerdicts, ierr := reviewAdmissions(ctx, ds.storage, &model.AdmissionReviewInput{
+		Kind:      entity.WebhookKindGlobalNetworkPolicy,
+		Operation: entity.AdmissionOperationCreate,
+		DryRun:    true,
+		Object:    gnpEntity,
+		OldObject: gnpEntityExisted,
+	})
+	if ierr != nil {
+		return nil, ierr
+	}
+
+	heps, coreErr := ds.storage.ListHostEndpoints(ctx, nil)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list host endpoint failed").SetSubError(coreErr)
+	}
+	gnss, coreErr := ds.storage.ListGNSs(ctx)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list global network sets failed").SetSubError(coreErr)
+	}
+	gnps, coreErr := ds.storage.ListGNPs(ctx, nil)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list global network policies failed").SetSubError(coreErr)
+	}
+	lintFindings, ierr := lintResult(ctx, ds.linter.LintPolicy(gnpEntity))
+	if ierr != nil {
+		return nil, ierr
+	}
+
+	relatedSelectors, relatedSelectorsTruncated := relatedPolicySelectors(gnpEntity, gnps)
+
+	var selectorChange string
+	if gnpEntityExisted != nil && gnpEntityExisted.Spec.Selector != gnpEntity.Spec.Selector {
+		selectorChange = compareSelectors(gnpEntity.Spec.Selector, gnpEntityExisted.Spec.Selector)
+		if selectorChange == "" {
+			selectorChange = model.SelectorRelationDifferent
+		}
+	}
 
 	return &model.ValidateGlobalNetworkPolicyOutput{
-		GNP:        gnpEntity,
-		GNPExisted: gnpEntityExisted,
-		ParsedHEPs: policyWithRelatedHostEndpoint.ParsedHEPs,
+		GNP:                       gnpEntity,
+		GNPExisted:                gnpEntityExisted,
+		ParsedHEPs:                policyWithRelatedHostEndpoint.ParsedHEPs,
+		UnmatchedSelectors:        unmatchedRuleSelectors(gnpEntity, heps, gnss),
+		SelectorChange:            selectorChange,
+		RelatedSelectors:          relatedSelectors,
+		RelatedSelectorsTruncated: relatedSelectorsTruncated,
+		LintFindings:              lintFindings,
+		AdmissionVerdicts:         admissionVerdicts,
 	}, nil
 }
 
@@ -114,7 +329,7 @@ func (ds *gnp) ListRelatedHostEndPoints(ctx context.Context, targetGNPEntity *en
 	var parsedHEPs []*model.ParsedHEP
 
 	for _, hepEntity := range heps {
-		if !sel.Evaluate(hepEntity.Metadata.Labels) {
+		if !appliesToTenant(targetGNPEntity, hepEntity.Spec.TenantID) || !sel.EvaluateLabels(hepLabels(hepEntity)) {
 			continue
 		}
 		parsedHEPs = append(parsedHEPs, &model.ParsedHEP{
@@ -150,11 +365,15 @@ func createModelToPolicyEntity(input *model.CreateGlobalNetworkPolicyInput) *ent
 		ID:   primitive.NewObjectID(),
 		UUID: entity.NewMinifyUUID(),
 		Metadata: entity.GNPMetadata{
-			Name:   input.Metadata.Name,
-			Labels: input.Metadata.Labels,
+			Name:     input.Metadata.Name,
+			TenantID: input.Metadata.TenantID,
+			Labels:   input.Metadata.Labels,
 		},
 		Spec: entity.GNPSpec{
+			Tier:     input.Spec.Tier,
 			Order:    order,
+			Staged:   input.Spec.Staged,
+			Schedule: modelToSchedule(input.Spec.ScheduleInput),
 			Selector: input.Spec.Selector,
 			Ingress:  specIngress,
 			Egress:   specEgress,
@@ -173,11 +392,24 @@ func modelToRule(rule model.GNPSpecRuleInput) entity.GNPSpecRule {
 		Protocol:    rule.Protocol,
 		NotProtocol: rule.NotProtocol,
 		IPVersion:   rule.IPVersion,
+		ICMP:        modelToRuleICMP(rule.ICMP),
+		NotICMP:     modelToRuleICMP(rule.NotICMP),
+		Schedule:    modelToSchedule(rule.ScheduleInput),
 		Source:      modelToRuleEntity(rule.Source),
 		Destination: modelToRuleEntity(rule.Destination),
 	}
 }
 
+func modelToRuleICMP(icmp *model.GNPSpecRuleICMPInput) *entity.GNPSpecRuleICMP {
+	if icmp == nil {
+		return nil
+	}
+	return &entity.GNPSpecRuleICMP{
+		Type: icmp.Type,
+		Code: icmp.Code,
+	}
+}
+
 func modelToRuleEntity(ruleEntity *model.GNPSpecRuleEntityInput) *entity.GNPSpecRuleEntity {
 	if ruleEntity == nil {
 		return nil
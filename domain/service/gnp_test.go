@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/net"
+)
+
+func TestGNPListOrderedByTier(t *testing.T) {
+	ctx := context.Background()
+	storage := newFakeStorage()
+	storage.UpsertTier(ctx, &entity.Tier{Metadata: entity.TierMetadata{Name: "security"}, Spec: entity.TierSpec{Order: 10}})
+	late := testGNP("security-late", "all()", 900, "")
+	late.Spec.Tier = "security"
+	storage.UpsertGroupPolicy(ctx, testGNP("untiered-early", "all()", 1, ""))
+	storage.UpsertGroupPolicy(ctx, late)
+	ds := &gnp{storage: storage}
+
+	gnps, ierr := ds.List(ctx, &model.ListGNPsInput{IsOrder: true})
+	if ierr != nil {
+		t.Fatal(ierr)
+	}
+	if len(gnps) != 2 || gnps[0].Metadata.Name != "security-late" {
+		t.Errorf("first policy = %s, want security-late of the first tier", gnps[0].Metadata.Name)
+	}
+}
+
+// newStagedStorage returns a storage with the host endpoints web and db, the staged policy canary applied to web, the
+// staged policy canary-db applied to db and the enforced policy allow applied to web.
+func newStagedStorage(t *testing.T) (*fakeStorage, *gnp) {
+	t.Helper()
+	ctx := context.Background()
+	storage := newFakeStorage()
+	web := testHEP("web", net.IPToInt(*net.ParseIP("10.0.0.1")), map[string]string{"app": "web"})
+	db := testHEP("db", net.IPToInt(*net.ParseIP("10.0.0.2")), map[string]string{"app": "db"})
+	db.Spec.IPs = []string{"10.0.0.2"}
+	storage.UpsertHostEndpoint(ctx, web)
+	storage.UpsertHostEndpoint(ctx, db)
+	for _, policy := range []*entity.GlobalNetworkPolicy{
+		testGNP("canary", "app == 'web'", 10, "app == 'lb'"),
+		testGNP("canary-db", "app == 'db'", 10, "app == 'web'"),
+		testGNP("allow", "app == 'web'", 20, "app == 'lb'"),
+	} {
+		policy.Spec.Staged = policy.Metadata.Name != "allow"
+		storage.UpsertGroupPolicy(ctx, policy)
+	}
+	return storage, &gnp{storage: storage, snapshot: loadedSnapshot(t, storage)}
+}
+
+func storedGNP(t *testing.T, storage *fakeStorage, name string) *entity.GlobalNetworkPolicy {
+	t.Helper()
+	gnpEntity, coreErr := storage.GetGNPByName(context.Background(), name)
+	if coreErr != nil {
+		t.Fatalf("get policy %s: %v", name, coreErr)
+	}
+	return gnpEntity
+}
+
+func TestGNPPromote(t *testing.T) {
+	ctx := context.Background()
+	storage, ds := newStagedStorage(t)
+	canary := storedGNP(t, storage, "canary")
+	storage.IncStagedPolicyReport(ctx, &entity.StagedPolicyReport{GNPUUID: canary.UUID, IP: 1, DeniedPackets: 1})
+
+	if _, ierr := ds.Promote(ctx, "allow"); ierr == nil || ierr.HTTPStatusCode != http.StatusBadRequest {
+		t.Errorf("Promote() of a policy not staged error = %v, want a bad request", ierr)
+	}
+	if _, ierr := ds.Promote(ctx, "unknown"); ierr == nil || ierr.HTTPStatusCode != http.StatusNotFound {
+		t.Errorf("Promote() of an unknown policy error = %v, want not found", ierr)
+	}
+
+	promoted, ierr := ds.Promote(ctx, "canary")
+	if ierr != nil {
+		t.Fatalf("Promote() error: %v", ierr)
+	}
+	stored := storedGNP(t, storage, "canary")
+	if promoted.Spec.Staged || stored.Spec.Staged {
+		t.Error("promoted policy is still staged")
+	}
+	if stored.Version != canary.Version+1 {
+		t.Errorf("version = %d, want %d", stored.Version, canary.Version+1)
+	}
+	if snapshotted, _ := ds.snapshot.GetGNP(canary.UUID); snapshotted.Spec.Staged {
+		t.Error("promoted policy is still staged in the policy snapshot")
+	}
+	if reports, _ := storage.ListStagedPolicyReports(ctx, canary.UUID); len(reports) != 0 {
+		t.Errorf("reports = %d, want them dropped", len(reports))
+	}
+}
+
+func TestGNPReportStaged(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		ip       string
+		tenantID uint64
+		wantCode int
+		// wantReports are the denied packets and bytes reported by policy name
+		wantReports map[string][2]uint64
+	}{
+		{
+			name:        "reported",
+			ip:          "10.0.0.1",
+			wantReports: map[string][2]uint64{"canary": {15, 1500}},
+		},
+		{
+			name:        "reported by the default tenant",
+			ip:          "10.0.0.1",
+			tenantID:    entity.DefaultTenantID,
+			wantReports: map[string][2]uint64{"canary": {15, 1500}},
+		},
+		{
+			name:     "malformed ip",
+			ip:       "web",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "ip version 6",
+			ip:       "2001:db8::a00:1",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unknown host endpoint",
+			ip:       "10.0.0.9",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "host endpoint of another tenant",
+			ip:       "10.0.0.1",
+			tenantID: 2,
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage, ds := newStagedStorage(t)
+			input := &model.ReportStagedPoliciesInput{TenantID: tt.tenantID, IP: tt.ip, Reports: []model.StagedPolicyReportInput{
+				{GNPUUID: storedGNP(t, storage, "canary").UUID, DeniedPackets: 10, DeniedBytes: 1000},
+				{GNPUUID: storedGNP(t, storage, "canary").UUID, DeniedPackets: 5, DeniedBytes: 500},
+				// not staged
+				{GNPUUID: storedGNP(t, storage, "allow").UUID, DeniedPackets: 1, DeniedBytes: 100},
+				// not applied to web
+				{GNPUUID: storedGNP(t, storage, "canary-db").UUID, DeniedPackets: 1, DeniedBytes: 100},
+				{GNPUUID: "unknown", DeniedPackets: 1, DeniedBytes: 100},
+			}}
+
+			ierr := ds.ReportStaged(ctx, input)
+			if tt.wantCode != 0 {
+				if ierr == nil || ierr.HTTPStatusCode != tt.wantCode {
+					t.Fatalf("ReportStaged() error = %v, want status %d", ierr, tt.wantCode)
+				}
+			} else if ierr != nil {
+				t.Fatalf("ReportStaged() error: %v", ierr)
+			}
+			got := make(map[string][2]uint64)
+			for _, report := range storage.stagedReports {
+				if report.TenantID != entity.DefaultTenantID || report.IP != net.IPToInt(*net.ParseIP("10.0.0.1")) {
+					t.Errorf("report of tenant %d and ip %d, want the ones of web", report.TenantID, report.IP)
+				}
+				got[report.GNPName] = [2]uint64{report.DeniedPackets, report.DeniedBytes}
+			}
+			if tt.wantReports == nil {
+				tt.wantReports = map[string][2]uint64{}
+			}
+			if diff := cmp.Diff(tt.wantReports, got); diff != "" {
+				t.Errorf("reports mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGNPGetStagedReport(t *testing.T) {
+	ctx := context.Background()
+	storage, ds := newStagedStorage(t)
+	allWeb := testGNP("all-web", "all()", 10, "app == 'lb'")
+	allWeb.Spec.Staged = true
+	storage.UpsertGroupPolicy(ctx, allWeb)
+	ds.snapshot.UpsertGNP(allWeb)
+	for _, report := range []struct {
+		ip             string
+		packets, bytes uint64
+	}{
+		{ip: "10.0.0.1", packets: 10, bytes: 1000},
+		{ip: "10.0.0.2", packets: 3, bytes: 300},
+		{ip: "10.0.0.1", packets: 2, bytes: 200},
+	} {
+		ierr := ds.ReportStaged(ctx, &model.ReportStagedPoliciesInput{
+			IP:      report.ip,
+			Reports: []model.StagedPolicyReportInput{{GNPUUID: allWeb.UUID, DeniedPackets: report.packets, DeniedBytes: report.bytes}},
+		})
+		if ierr != nil {
+			t.Fatalf("ReportStaged() error: %v", ierr)
+		}
+	}
+
+	summary, ierr := ds.GetStagedReport(ctx, "all-web")
+	if ierr != nil {
+		t.Fatalf("GetStagedReport() error: %v", ierr)
+	}
+	if summary.DeniedPackets != 15 || summary.DeniedBytes != 1500 {
+		t.Errorf("denied = %d packets and %d bytes, want 15 and 1500", summary.DeniedPackets, summary.DeniedBytes)
+	}
+	if len(summary.Reports) != 2 || summary.Reports[0].DeniedPackets != 12 || summary.Reports[1].DeniedPackets != 3 {
+		t.Errorf("reports = %+v, want 12 packets from web and 3 from db", summary.Reports)
+	}
+}
+
+func TestGNPListExpiringWithin(t *testing.T) {
+	ctx := context.Background()
+	storage := newFakeStorage()
+	until := time.Now().Add(time.Hour)
+	expiring := testGNP("expiring", "all()", 10, "")
+	expiring.Spec.Schedule.ActiveUntil = &until
+	// the rule window opens every hour for half an hour, it closes within any two hours
+	windowed := testGNP("windowed", "all()", 20, "")
+	windowed.Spec.Ingress[0].Schedule.Windows = []entity.ScheduleWindow{{Cron: "0 * * * *", Duration: "30m"}}
+	// the windows abut, the rule stays active
+	abutting := testGNP("abutting", "all()", 30, "")
+	abutting.Spec.Ingress[0].Schedule.Windows = []entity.ScheduleWindow{{Cron: "* * * * *", Duration: "1m"}}
+	for _, policy := range []*entity.GlobalNetworkPolicy{expiring, windowed, abutting, testGNP("always", "all()", 40, "")} {
+		storage.UpsertGroupPolicy(ctx, policy)
+	}
+	ds := &gnp{storage: storage}
+
+	gnps, ierr := ds.List(ctx, &model.ListGNPsInput{IsOrder: true, ExpiringWithin: 2 * time.Hour})
+	if ierr != nil {
+		t.Fatal(ierr)
+	}
+	var names []string
+	for _, gnpEntity := range gnps {
+		names = append(names, gnpEntity.Metadata.Name)
+	}
+	if diff := cmp.Diff([]string{"expiring", "windowed"}, names); diff != "" {
+		t.Errorf("List() expiring policies mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"net/netip"
+	"slices"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -16,27 +19,47 @@ import (
 	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
 	"github.com/bamboo-firewall/be/pkg/net"
 	"github.com/bamboo-firewall/be/pkg/repository"
+	"github.com/bamboo-firewall/be/pkg/validator"
 )
 
-func NewGNS(policyMongo *repository.PolicyDB) *gns {
+func NewGNS(policyMongo *repository.PolicyDB, snapshot *PolicySnapshot, linter *validator.Linter) *gns {
 	return &gns{
-		storage: policyMongo,
+		storage:  policyMongo,
+		snapshot: snapshot,
+		linter:   linter,
 	}
 }
 
 type gns struct {
-	storage be.Storage
+	storage  be.Storage
+	snapshot *PolicySnapshot
+	linter   *validator.Linter
 }
 
 func (ds *gns) Create(ctx context.Context, input *model.CreateGlobalNetworkSetInput) (*entity.GlobalNetworkSet, *ierror.Error) {
 	gnsEntity := createModelToGNSEntity(input)
+	gnsExisted, coreErr := ds.storage.GetGNSByName(ctx, gnsEntity.Metadata.Name)
+	if coreErr != nil && !errors.Is(coreErr, errlist.ErrNotFoundGlobalNetworkSet) {
+		return nil, httpbase.ErrDatabase(ctx, "get global network set failed").SetSubError(coreErr)
+	}
+	keepExternalNets(gnsEntity, gnsExisted)
+	if ierr := admit(ctx, ds.storage, &model.AdmissionReviewInput{
+		Kind:      entity.WebhookKindGlobalNetworkSet,
+		Operation: entity.AdmissionOperationCreate,
+		Object:    gnsEntity,
+		OldObject: gnsExisted,
+	}); ierr != nil {
+		return nil, ierr
+	}
 
-	if coreErr := ds.storage.UpsertGNS(ctx, gnsEntity); coreErr != nil {
+	if coreErr = ds.storage.UpsertGNS(ctx, gnsEntity); coreErr != nil {
 		if errors.Is(coreErr, errlist.ErrDuplicateGlobalNetworkSet) {
 			return nil, httpbase.ErrBadRequest(ctx, "duplicate global network set").SetSubError(coreErr)
 		}
 		return nil, httpbase.ErrDatabase(ctx, "create global network set failed").SetSubError(coreErr)
 	}
+	ds.snapshot.UpsertGNS(gnsEntity)
+	notifyWebhooks(ctx, ds.storage, setWebhookEvent(entity.WebhookKindGlobalNetworkSet, upsertAction(gnsEntity.Version), gnsEntity))
 	return gnsEntity, nil
 }
 
@@ -59,10 +82,33 @@ func (ds *gns) List(ctx context.Context) ([]*entity.GlobalNetworkSet, *ierror.Er
 	return gnssEntity, nil
 }
 
-func (ds *gns) Delete(ctx context.Context, name string) *ierror.Error {
-	if coreErr := ds.storage.DeleteGNSByName(ctx, name); coreErr != nil {
+func (ds *gns) Delete(ctx context.Context, name string, force bool) *ierror.Error {
+	gnsEntity, coreErr := ds.storage.GetGNSByName(ctx, name)
+	if coreErr != nil && !errors.Is(coreErr, errlist.ErrNotFoundGlobalNetworkSet) {
+		return httpbase.ErrDatabase(ctx, "get global network set failed").SetSubError(coreErr)
+	}
+	if gnsEntity != nil {
+		if ierr := admit(ctx, ds.storage, &model.AdmissionReviewInput{
+			Kind:      entity.WebhookKindGlobalNetworkSet,
+			Operation: entity.AdmissionOperationDelete,
+			OldObject: gnsEntity,
+		}); ierr != nil {
+			return ierr
+		}
+	}
+	if !force && gnsEntity != nil {
+		if ierr := checkOrphanedReferences(ctx, ds.storage, gnsEntity.UUID, gnsEntity.Metadata.Labels, gnsEntity.Metadata.TenantID, false); ierr != nil {
+			return ierr
+		}
+	}
+
+	if coreErr = ds.storage.DeleteGNSByName(ctx, name); coreErr != nil {
 		return httpbase.ErrDatabase(ctx, "delete global network set failed").SetSubError(coreErr)
 	}
+	ds.snapshot.DeleteGNS(name)
+	if gnsEntity != nil {
+		notifyWebhooks(ctx, ds.storage, setWebhookEvent(entity.WebhookKindGlobalNetworkSet, entity.WebhookActionDelete, gnsEntity))
+	}
 	return nil
 }
 
@@ -76,25 +122,116 @@ func (ds *gns) Validate(ctx context.Context, input *model.CreateGlobalNetworkSet
 		}
 	}
 
+	keepExternalNets(gnsEntity, gnsExisted)
+	admissionVerdicts, ierr := reviewAdmissions(ctx, ds.storage, &model.AdmissionReviewInput{
+		Kind:      entity.WebhookKindGlobalNetworkSet,
+		Operation: entity.AdmissionOperationCreate,
+		DryRun:    true,
+		Object:    gnsEntity,
+		OldObject: gnsExisted,
+	})
+	if ierr != nil {
+		return nil, ierr
+	}
+
+	policies, coreErr := ds.storage.ListGNPs(ctx, nil)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list global network policies failed").SetSubError(coreErr)
+	}
+	nps, coreErr := ds.storage.ListNetworkPolicies(ctx, 0)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list network policies failed").SetSubError(coreErr)
+	}
+	lintFindings, ierr := lintResult(ctx, ds.linter.LintSet(gnsEntity, append(policies, nps...)))
+	if ierr != nil {
+		return nil, ierr
+	}
+
 	return &model.ValidateGlobalNetworkSetOutput{
-		GNS:        gnsEntity,
-		GNSExisted: gnsExisted,
+		GNS:               gnsEntity,
+		GNSExisted:        gnsExisted,
+		Warnings:          validator.NetOverlapWarnings(input.Spec.Nets),
+		LintFindings:      lintFindings,
+		AdmissionVerdicts: admissionVerdicts,
 	}, nil
 }
 
+// keepExternalNets keeps the nets synced from the source and the addresses resolved from the domains of the existing
+// set, so that re-creating a set does not drop them until the next sync. The status is reset when the source
+// changes, so that the set is synced on the next check.
+func keepExternalNets(gnsEntity *entity.GlobalNetworkSet, gnsExisted *entity.GlobalNetworkSet) {
+	if gnsEntity.Spec.Source != nil {
+		if gnsExisted != nil && gnsExisted.Spec.Source != nil && *gnsExisted.Spec.Source == *gnsEntity.Spec.Source {
+			gnsEntity.Spec.Nets = gnsExisted.Spec.Nets
+			gnsEntity.Status = gnsExisted.Status
+		} else {
+			gnsEntity.Status = &entity.GNSStatus{}
+		}
+	}
+	if len(gnsEntity.Spec.AllowedEgressDomains) > 0 {
+		resolved := &entity.GNSResolved{}
+		if gnsExisted != nil && gnsExisted.Resolved != nil {
+			resolved.Version = gnsExisted.Resolved.Version
+			if slices.Equal(gnsExisted.Spec.AllowedEgressDomains, gnsEntity.Spec.AllowedEgressDomains) {
+				resolved.ResolvedAt = gnsExisted.Resolved.ResolvedAt
+			}
+			for _, domain := range gnsExisted.Resolved.Domains {
+				if slices.Contains(gnsEntity.Spec.AllowedEgressDomains, domain.Name) {
+					resolved.Domains = append(resolved.Domains, domain)
+				}
+			}
+		}
+		gnsEntity.Resolved = resolved
+	}
+	gnsEntity.Spec.NetsV4, gnsEntity.Spec.NetsV6, _ = effectiveNets(gnsEntity.Spec.Nets, gnsEntity.Resolved)
+}
+
+// effectiveNets returns the nets delivered to agents, nets and the resolved addresses.
+func effectiveNets(nets []string, resolved *entity.GNSResolved) (netsV4 []string, netsV6 []string, malformed int) {
+	if resolved == nil {
+		return exactNets(nets)
+	}
+	allNets := slices.Clone(nets)
+	for _, domain := range resolved.Domains {
+		allNets = append(allNets, domain.Addresses...)
+	}
+	return exactNets(allNets)
+}
+
 func createModelToGNSEntity(input *model.CreateGlobalNetworkSetInput) *entity.GlobalNetworkSet {
-	netsV4, netsV6 := exactNets(input.Spec.Nets)
+	netsV4, netsV6, _ := exactNets(input.Spec.Nets)
+	var source *entity.GNSSource
+	if input.Spec.Source != nil {
+		format := input.Spec.Source.Format
+		if format == "" {
+			format = entity.GNSSourceFormatPlain
+		}
+		source = &entity.GNSSource{
+			URL:             input.Spec.Source.URL,
+			File:            input.Spec.Source.File,
+			Format:          format,
+			JSONPath:        input.Spec.Source.JSONPath,
+			RefreshInterval: input.Spec.Source.RefreshInterval,
+		}
+	}
+	domains := make([]string, 0, len(input.Spec.AllowedEgressDomains))
+	for _, domain := range input.Spec.AllowedEgressDomains {
+		domains = append(domains, strings.TrimSuffix(strings.ToLower(domain), "."))
+	}
 	return &entity.GlobalNetworkSet{
 		ID:   primitive.NewObjectID(),
 		UUID: entity.NewMinifyUUID(),
 		Metadata: entity.GNSMetadata{
-			Name:   input.Metadata.Name,
-			Labels: input.Metadata.Labels,
+			Name:     input.Metadata.Name,
+			TenantID: input.Metadata.TenantID,
+			Labels:   input.Metadata.Labels,
 		},
 		Spec: entity.GNSSpec{
-			Nets:   input.Spec.Nets,
-			NetsV4: netsV4,
-			NetsV6: netsV6,
+			Nets:                 input.Spec.Nets,
+			NetsV4:               netsV4,
+			NetsV6:               netsV6,
+			Source:               source,
+			AllowedEgressDomains: domains,
 		},
 		Description: input.Description,
 		FilePath:    input.FilePath,
@@ -103,11 +240,14 @@ func createModelToGNSEntity(input *model.CreateGlobalNetworkSetInput) *entity.Gl
 	}
 }
 
-func exactNets(nets []string) (netsV4 []string, netsV6 []string) {
+// exactNets splits nets by ip version into the minimal list of CIDRs covering them, malformed nets are skipped.
+func exactNets(nets []string) (netsV4 []string, netsV6 []string, malformed int) {
+	prefixes := make([]netip.Prefix, 0, len(nets))
 	for _, netString := range nets {
 		ip, ipnet, err := net.ParseCIDROrIP(netString)
 		if err != nil {
 			slog.Warn("malformed net", "net", netString)
+			malformed++
 			continue
 		}
 		var netV4V6 string
@@ -116,11 +256,14 @@ func exactNets(nets []string) (netsV4 []string, netsV6 []string) {
 		} else {
 			netV4V6 = ip.Network().String()
 		}
-		if ip.Version() == entity.IPVersion4 {
-			netsV4 = append(netsV4, netV4V6)
-		} else if ip.Version() == entity.IPVersion6 {
-			netsV6 = append(netsV6, netV4V6)
+		prefix, err := net.ParsePrefix(netV4V6)
+		if err != nil {
+			slog.Warn("malformed net", "net", netString)
+			malformed++
+			continue
 		}
+		prefixes = append(prefixes, prefix)
 	}
+	netsV4, netsV6 = net.NewCIDRSet(prefixes...).Strings()
 	return
 }
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/bamboo-firewall/be"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/dnsresolver"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/netsource"
+	"github.com/bamboo-firewall/be/pkg/repository"
+)
+
+const (
+	gnsSyncTimeout    = 30 * time.Second
+	gnsResolveTimeout = 10 * time.Second
+)
+
+// NewGNSSourceFetcher reads the sources of sets. URLs are limited to public addresses unless allowPrivateURLs is set,
+// files to fileDir and refused when it is empty.
+func NewGNSSourceFetcher(fileDir string, allowPrivateURLs bool) *netsource.Fetcher {
+	client := netsource.NewPublicClient(gnsSyncTimeout)
+	if allowPrivateURLs {
+		client = &http.Client{Timeout: gnsSyncTimeout}
+	}
+	return netsource.NewFetcher(client, fileDir)
+}
+
+// NewGNSSyncer resolves domains every resolveInterval, a resolveInterval <= 0 disables the resolution. Resolved
+// addresses are limited to public addresses unless allowPrivateAddrs is set.
+func NewGNSSyncer(policyMongo *repository.PolicyDB, snapshot *PolicySnapshot, fetcher *netsource.Fetcher, resolver dnsresolver.Resolver, resolveInterval time.Duration, allowPrivateAddrs bool) *GNSSyncer {
+	return &GNSSyncer{
+		storage:           policyMongo,
+		snapshot:          snapshot,
+		fetcher:           fetcher,
+		resolver:          resolver,
+		resolveInterval:   resolveInterval,
+		allowPrivateAddrs: allowPrivateAddrs,
+	}
+}
+
+// GNSSyncer keeps the global network sets up to date with their external nets: it replaces the nets of sets with
+// a source by the networks fetched from the source and resolves the allowed egress domains of sets.
+// A set is written only while it is at the version it was synced from, so that a set edited during a sync keeps the
+// edit and is synced again on the next check.
+type GNSSyncer struct {
+	storage           be.Storage
+	snapshot          *PolicySnapshot
+	fetcher           *netsource.Fetcher
+	resolver          dnsresolver.Resolver
+	resolveInterval   time.Duration
+	allowPrivateAddrs bool
+}
+
+// Run checks every interval for sets whose refresh interval or resolve interval elapsed and syncs them.
+func (s *GNSSyncer) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case tick := <-ticker.C:
+			s.syncDue(ctx, tick)
+		}
+	}
+}
+
+func (s *GNSSyncer) syncDue(ctx context.Context, now time.Time) {
+	gnss, coreErr := s.storage.ListGNSs(ctx)
+	if coreErr != nil {
+		slog.Warn("list global network sets failed", "err", coreErr)
+		return
+	}
+	for _, gnsEntity := range gnss {
+		if isGNSSyncDue(gnsEntity, now) {
+			if err := s.Sync(ctx, gnsEntity, now); err != nil {
+				if errors.Is(err, errlist.ErrVersionConflict) {
+					slog.Info("global network set changed during sync", "name", gnsEntity.Metadata.Name)
+					continue
+				}
+				slog.Warn("sync global network set failed", "name", gnsEntity.Metadata.Name, "err", err)
+			}
+		}
+		if s.isGNSResolveDue(gnsEntity, now) {
+			if err := s.Resolve(ctx, gnsEntity, now); err != nil {
+				if errors.Is(err, errlist.ErrVersionConflict) {
+					slog.Info("global network set changed during resolve", "name", gnsEntity.Metadata.Name)
+					continue
+				}
+				slog.Warn("resolve global network set failed", "name", gnsEntity.Metadata.Name, "err", err)
+			}
+		}
+	}
+}
+
+func isGNSSyncDue(gnsEntity *entity.GlobalNetworkSet, now time.Time) bool {
+	if gnsEntity.Spec.Source == nil {
+		return false
+	}
+	if gnsEntity.Status == nil || gnsEntity.Status.LastSyncAt.IsZero() {
+		return true
+	}
+	interval, err := time.ParseDuration(gnsEntity.Spec.Source.RefreshInterval)
+	if err != nil {
+		return false
+	}
+	return !now.Before(gnsEntity.Status.LastSyncAt.Add(interval))
+}
+
+func (s *GNSSyncer) isGNSResolveDue(gnsEntity *entity.GlobalNetworkSet, now time.Time) bool {
+	if s.resolver == nil || s.resolveInterval <= 0 || len(gnsEntity.Spec.AllowedEgressDomains) == 0 {
+		return false
+	}
+	if gnsEntity.Resolved == nil || gnsEntity.Resolved.ResolvedAt.IsZero() {
+		return true
+	}
+	return !now.Before(gnsEntity.Resolved.ResolvedAt.Add(s.resolveInterval))
+}
+
+// Sync fetches the source of gnsEntity. The set is only upserted, bumping its version, when its nets change,
+// otherwise just the status is recorded. A failed fetch keeps the current nets.
+func (s *GNSSyncer) Sync(ctx context.Context, gnsEntity *entity.GlobalNetworkSet, now time.Time) error {
+	source := gnsEntity.Spec.Source
+	status := &entity.GNSStatus{LastSyncAt: now}
+	if gnsEntity.Status != nil {
+		status.LastSuccessAt = gnsEntity.Status.LastSuccessAt
+		status.NetsCount = gnsEntity.Status.NetsCount
+		status.InvalidNetsCount = gnsEntity.Status.InvalidNetsCount
+	}
+	gnsEntity.Status = status
+
+	fetchCtx, cancel := context.WithTimeout(ctx, gnsSyncTimeout)
+	defer cancel()
+	nets, err := s.fetcher.Fetch(fetchCtx, netsource.Source{
+		URL:      source.URL,
+		File:     source.File,
+		Format:   source.Format,
+		JSONPath: source.JSONPath,
+	})
+	if err == nil && len(nets) == 0 {
+		err = fmt.Errorf("source has no nets")
+	}
+	var (
+		sourceV4, sourceV6 []string
+		invalidCount       int
+	)
+	if err == nil {
+		sourceV4, sourceV6, invalidCount = exactNets(nets)
+		if len(sourceV4) == 0 && len(sourceV6) == 0 {
+			err = fmt.Errorf("source has no valid nets, %d malformed", len(nets))
+		}
+	}
+	if err != nil {
+		status.LastError = err.Error()
+		if coreErr := s.storage.UpdateGNSExternalState(ctx, gnsEntity.Metadata.Name, gnsEntity.Version, status, nil); coreErr != nil {
+			return coreErr
+		}
+		return err
+	}
+
+	status.LastSuccessAt = &now
+	status.NetsCount = len(sourceV4) + len(sourceV6)
+	status.InvalidNetsCount = invalidCount
+	gnsEntity.Spec.Nets = append(slices.Clone(sourceV4), sourceV6...)
+	return s.save(ctx, gnsEntity, now)
+}
+
+// Resolve looks up the allowed egress domains of gnsEntity which are not wildcards. A domain which fails to
+// resolve keeps its previous addresses, addresses which are not public are dropped unless private addresses are
+// allowed. The resolution version and the set version are bumped when addresses change.
+func (s *GNSSyncer) Resolve(ctx context.Context, gnsEntity *entity.GlobalNetworkSet, now time.Time) error {
+	previous := make(map[string]entity.GNSResolvedDomain)
+	resolved := &entity.GNSResolved{ResolvedAt: now}
+	if gnsEntity.Resolved != nil {
+		resolved.Version = gnsEntity.Resolved.Version
+		for _, domain := range gnsEntity.Resolved.Domains {
+			previous[domain.Name] = domain
+		}
+	}
+
+	resolveCtx, cancel := context.WithTimeout(ctx, gnsResolveTimeout)
+	defer cancel()
+	var failed int
+	for _, result := range dnsresolver.ResolveAll(resolveCtx, s.resolver, gnsEntity.Spec.AllowedEgressDomains) {
+		domain := entity.GNSResolvedDomain{Name: result.Domain}
+		if result.Err != nil {
+			failed++
+			domain.Addresses = previous[result.Domain].Addresses
+			domain.Error = result.Err.Error()
+		} else {
+			for _, addr := range result.Addrs {
+				if !s.allowPrivateAddrs && !netsource.IsPublicAddr(addr) {
+					slog.Debug("drop resolved address which is not public", "name", gnsEntity.Metadata.Name,
+						"domain", result.Domain, "addr", addr)
+					continue
+				}
+				domain.Addresses = append(domain.Addresses, addr.String())
+			}
+		}
+		resolved.Domains = append(resolved.Domains, domain)
+	}
+	if !sameResolvedAddresses(gnsEntity.Resolved, resolved) {
+		resolved.Version++
+	}
+	gnsEntity.Resolved = resolved
+
+	if err := s.save(ctx, gnsEntity, now); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d domains failed to resolve", failed)
+	}
+	return nil
+}
+
+// save updates the nets of gnsEntity when its effective nets changed, otherwise it records only the sync status and
+// resolution. Both fail with ErrVersionConflict when the stored set is no longer at the version of gnsEntity.
+func (s *GNSSyncer) save(ctx context.Context, gnsEntity *entity.GlobalNetworkSet, now time.Time) error {
+	netsV4, netsV6, _ := effectiveNets(gnsEntity.Spec.Nets, gnsEntity.Resolved)
+	if slices.Equal(netsV4, gnsEntity.Spec.NetsV4) && slices.Equal(netsV6, gnsEntity.Spec.NetsV6) {
+		if coreErr := s.storage.UpdateGNSExternalState(ctx, gnsEntity.Metadata.Name, gnsEntity.Version, gnsEntity.Status, gnsEntity.Resolved); coreErr != nil {
+			return coreErr
+		}
+		return nil
+	}
+
+	gnsEntity.Spec.NetsV4 = netsV4
+	gnsEntity.Spec.NetsV6 = netsV6
+	gnsEntity.UpdatedAt = now
+	if coreErr := s.storage.UpdateGNSExternalNets(ctx, gnsEntity); coreErr != nil {
+		return coreErr
+	}
+	s.snapshot.UpsertGNS(gnsEntity)
+	slog.Info("synced global network set", "name", gnsEntity.Metadata.Name, "version", gnsEntity.Version,
+		"netsV4", len(netsV4), "netsV6", len(netsV6))
+	return nil
+}
+
+func sameResolvedAddresses(a, b *entity.GNSResolved) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return slices.EqualFunc(a.Domains, b.Domains, func(x, y entity.GNSResolvedDomain) bool {
+		return x.Name == y.Name && slices.Equal(x.Addresses, y.Addresses)
+	})
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/dnsresolver"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/netsource"
+)
+
+func TestGNSSyncerSync(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		edit        bool
+		wantErr     bool
+		wantNets    []string
+		wantVersion uint
+		wantLabel   string
+	}{
+		{name: "nets changed", status: http.StatusOK, wantNets: []string{"1.10.16.0/20", "2.56.192.0/22"}, wantVersion: 2},
+		{name: "fetch failed", status: http.StatusInternalServerError, wantErr: true, wantVersion: 1},
+		{name: "set edited during fetch", status: http.StatusOK, edit: true, wantErr: true, wantVersion: 2, wantLabel: "edited"},
+		{name: "set edited during failed fetch", status: http.StatusInternalServerError, edit: true, wantErr: true, wantVersion: 2, wantLabel: "edited"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			storage := newFakeStorage()
+			var edit func()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if edit != nil {
+					edit()
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte("1.10.16.0/20\n2.56.192.0/22\n"))
+			}))
+			defer server.Close()
+
+			gns := testGNS("blocklist", nil)
+			gns.Spec.Source = &entity.GNSSource{URL: server.URL, Format: entity.GNSSourceFormatPlain, RefreshInterval: "1h"}
+			if coreErr := storage.UpsertGNS(ctx, gns); coreErr != nil {
+				t.Fatal(coreErr)
+			}
+			if tt.edit {
+				edit = func() {
+					edited := storage.listSets(false, 0)[0]
+					edited.Metadata.Labels = map[string]string{"edited": "true"}
+					_ = storage.UpsertGNS(ctx, edited)
+				}
+			}
+			syncer := &GNSSyncer{
+				storage:  storage,
+				snapshot: loadedSnapshot(t, storage),
+				fetcher:  netsource.NewFetcher(server.Client(), ""),
+			}
+
+			err := syncer.Sync(ctx, storage.listSets(false, 0)[0], time.Now())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Sync() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.edit && !errors.Is(err, errlist.ErrVersionConflict) {
+				t.Errorf("Sync() error = %v, want a version conflict", err)
+			}
+
+			stored := storage.listSets(false, 0)[0]
+			if stored.Version != tt.wantVersion {
+				t.Errorf("version = %d, want %d", stored.Version, tt.wantVersion)
+			}
+			if !slices.Equal(stored.Spec.NetsV4, tt.wantNets) {
+				t.Errorf("nets = %v, want %v", stored.Spec.NetsV4, tt.wantNets)
+			}
+			if _, ok := stored.Metadata.Labels[tt.wantLabel]; tt.wantLabel != "" && !ok {
+				t.Errorf("labels = %v, the edit is lost", stored.Metadata.Labels)
+			}
+			if !tt.edit && stored.Status == nil {
+				t.Error("sync status is not recorded")
+			}
+			if tt.edit && stored.Status != nil {
+				t.Errorf("status %+v of the outdated set is recorded", stored.Status)
+			}
+		})
+	}
+}
+
+func TestGNSSyncerResolve(t *testing.T) {
+	const domain = "api.example.com"
+	resolvedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	previous := &entity.GNSResolved{
+		Version:    1,
+		ResolvedAt: resolvedAt,
+		Domains:    []entity.GNSResolvedDomain{{Name: domain, Addresses: []string{"140.82.112.6"}}},
+	}
+	tests := []struct {
+		name         string
+		previous     *entity.GNSResolved
+		addrs        []string
+		allowPrivate bool
+		edit         bool
+		wantErr      bool
+		wantConflict bool
+		wantNets     []string
+		// wantResolvedVersion is 0 when the resolution is not stored
+		wantResolvedVersion uint
+		wantVersion         uint
+		wantDomainError     bool
+	}{
+		{
+			name:                "resolved",
+			addrs:               []string{"140.82.113.6", "140.82.112.6"},
+			wantNets:            []string{"140.82.112.6/32", "140.82.113.6/32"},
+			wantResolvedVersion: 1,
+			wantVersion:         2,
+		},
+		{
+			name:                "addresses unchanged",
+			previous:            previous,
+			addrs:               []string{"140.82.112.6"},
+			wantNets:            []string{"140.82.112.6/32"},
+			wantResolvedVersion: 1,
+			wantVersion:         1,
+		},
+		{
+			name:                "addresses changed",
+			previous:            previous,
+			addrs:               []string{"140.82.113.6"},
+			wantNets:            []string{"140.82.113.6/32"},
+			wantResolvedVersion: 2,
+			wantVersion:         2,
+		},
+		{
+			name:                "lookup failed",
+			previous:            previous,
+			wantErr:             true,
+			wantNets:            []string{"140.82.112.6/32"},
+			wantResolvedVersion: 1,
+			wantVersion:         1,
+			wantDomainError:     true,
+		},
+		{
+			name:                "addresses which are not public dropped",
+			addrs:               []string{"10.0.0.5", "127.0.0.1", "0.0.0.0", "169.254.169.254", "::1", "fe80::1", "140.82.112.6"},
+			wantNets:            []string{"140.82.112.6/32"},
+			wantResolvedVersion: 1,
+			wantVersion:         2,
+		},
+		{
+			name:                "private addresses allowed",
+			addrs:               []string{"10.0.0.5", "140.82.112.6"},
+			allowPrivate:        true,
+			wantNets:            []string{"10.0.0.5/32", "140.82.112.6/32"},
+			wantResolvedVersion: 1,
+			wantVersion:         2,
+		},
+		{
+			name:         "set edited before resolve",
+			addrs:        []string{"140.82.113.6"},
+			edit:         true,
+			wantErr:      true,
+			wantConflict: true,
+			wantVersion:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			storage := newFakeStorage()
+			resolver := dnsresolver.NewFake()
+			if tt.addrs != nil {
+				resolver.Set(domain, tt.addrs...)
+			}
+
+			gns := testGNS("egress", nil)
+			gns.Spec.AllowedEgressDomains = []string{domain, "*.github.com"}
+			gns.Resolved = tt.previous
+			gns.Spec.NetsV4, gns.Spec.NetsV6, _ = effectiveNets(nil, tt.previous)
+			if coreErr := storage.UpsertGNS(ctx, gns); coreErr != nil {
+				t.Fatal(coreErr)
+			}
+			gnsEntity := storage.listSets(false, 0)[0]
+			if tt.edit {
+				edited := storage.listSets(false, 0)[0]
+				edited.Metadata.Labels = map[string]string{"edited": "true"}
+				_ = storage.UpsertGNS(ctx, edited)
+			}
+			syncer := &GNSSyncer{
+				storage:           storage,
+				snapshot:          loadedSnapshot(t, storage),
+				resolver:          resolver,
+				resolveInterval:   time.Minute,
+				allowPrivateAddrs: tt.allowPrivate,
+			}
+
+			now := resolvedAt.Add(time.Hour)
+			err := syncer.Resolve(ctx, gnsEntity, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, errlist.ErrVersionConflict) != tt.wantConflict {
+				t.Errorf("Resolve() error = %v, want a version conflict %v", err, tt.wantConflict)
+			}
+
+			stored := storage.listSets(false, 0)[0]
+			if stored.Version != tt.wantVersion {
+				t.Errorf("version = %d, want %d", stored.Version, tt.wantVersion)
+			}
+			if !slices.Equal(stored.Spec.NetsV4, tt.wantNets) || len(stored.Spec.NetsV6) > 0 {
+				t.Errorf("nets = %v %v, want %v", stored.Spec.NetsV4, stored.Spec.NetsV6, tt.wantNets)
+			}
+			if tt.wantResolvedVersion == 0 {
+				if stored.Resolved != nil {
+					t.Errorf("resolution %+v of the outdated set is stored", stored.Resolved)
+				}
+				return
+			}
+			if stored.Resolved == nil {
+				t.Fatal("resolution is not stored")
+			}
+			if stored.Resolved.Version != tt.wantResolvedVersion {
+				t.Errorf("resolved version = %d, want %d", stored.Resolved.Version, tt.wantResolvedVersion)
+			}
+			if !stored.Resolved.ResolvedAt.Equal(now) {
+				t.Errorf("resolved at = %v, want %v", stored.Resolved.ResolvedAt, now)
+			}
+			if len(stored.Resolved.Domains) != 1 || stored.Resolved.Domains[0].Name != domain {
+				t.Fatalf("domains = %+v, want only %s as the wildcard is not resolved", stored.Resolved.Domains, domain)
+			}
+			if gotErr := stored.Resolved.Domains[0].Error != ""; gotErr != tt.wantDomainError {
+				t.Errorf("domain error = %q, want an error %v", stored.Resolved.Domains[0].Error, tt.wantDomainError)
+			}
+		})
+	}
+}
+
+func TestGNSSyncerIsResolveDue(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		interval   time.Duration
+		domains    []string
+		resolvedAt time.Time
+		want       bool
+	}{
+		{name: "never resolved", interval: time.Minute, domains: []string{"example.com"}, want: true},
+		{name: "interval elapsed", interval: time.Minute, domains: []string{"example.com"}, resolvedAt: now.Add(-time.Minute), want: true},
+		{name: "interval not elapsed", interval: time.Minute, domains: []string{"example.com"}, resolvedAt: now.Add(-time.Second)},
+		{name: "no domains", interval: time.Minute},
+		{name: "resolution disabled", domains: []string{"example.com"}},
+		{name: "negative interval", interval: -time.Minute, domains: []string{"example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncer := &GNSSyncer{resolver: dnsresolver.NewFake(), resolveInterval: tt.interval}
+			gns := testGNS("egress", nil)
+			gns.Spec.AllowedEgressDomains = tt.domains
+			if !tt.resolvedAt.IsZero() {
+				gns.Resolved = &entity.GNSResolved{ResolvedAt: tt.resolvedAt}
+			}
+			if got := syncer.isGNSResolveDue(gns, now); got != tt.want {
+				t.Errorf("isGNSResolveDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
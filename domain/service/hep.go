@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -20,14 +21,16 @@ import (
 	"github.com/bamboo-firewall/be/pkg/selector"
 )
 
-func NewHEP(policyMongo *repository.PolicyDB) *hep {
+func NewHEP(policyMongo *repository.PolicyDB, snapshot *PolicySnapshot) *hep {
 	return &hep{
-		storage: policyMongo,
+		storage:  policyMongo,
+		snapshot: snapshot,
 	}
 }
 
 type hep struct {
-	storage be.Storage
+	storage  be.Storage
+	snapshot *PolicySnapshot
 }
 
 func (ds *hep) Create(ctx context.Context, input *model.CreateHostEndpointInput) (*entity.HostEndpoint, *ierror.Error) {
@@ -35,13 +38,37 @@ func (ds *hep) Create(ctx context.Context, input *model.CreateHostEndpointInput)
 	if ierr != nil {
 		return nil, ierr
 	}
+	if ierr = checkHostEndpointQuota(ctx, ds.storage, hepEntity); ierr != nil {
+		return nil, ierr
+	}
+	hepExisted, coreErr := ds.storage.GetHostEndpoint(ctx, &model.GetHostEndpointInput{
+		TenantID: hepEntity.Spec.TenantID,
+		IP:       hepEntity.Spec.IP,
+	})
+	if coreErr != nil && !errors.Is(coreErr, errlist.ErrNotFoundHostEndpoint) {
+		return nil, httpbase.ErrDatabase(ctx, "get host endpoint failed").SetSubError(coreErr)
+	}
+	if ierr := admit(ctx, ds.storage, &model.AdmissionReviewInput{
+		Kind:      entity.WebhookKindHostEndpoint,
+		Operation: entity.AdmissionOperationCreate,
+		Object:    hepEntity,
+		OldObject: hepExisted,
+	}); ierr != nil {
+		return nil, ierr
+	}
 
-	if coreErr := ds.storage.UpsertHostEndpoint(ctx, hepEntity); coreErr != nil {
+	if coreErr = ds.storage.UpsertHostEndpoint(ctx, hepEntity); coreErr != nil {
 		if errors.Is(coreErr, errlist.ErrDuplicateHostEndpoint) {
 			return nil, httpbase.ErrBadRequest(ctx, "duplicate host endpoint").SetSubError(coreErr)
 		}
+		if errors.Is(coreErr, errlist.ErrQuotaExceeded) {
+			return nil, httpbase.ErrBadRequest(ctx, fmt.Sprintf("tenant %d reached its quota of host endpoints",
+				hepEntity.Spec.TenantID)).SetSubError(coreErr)
+		}
 		return nil, httpbase.ErrDatabase(ctx, "create host endpoint failed").SetSubError(coreErr)
 	}
+	ds.snapshot.UpsertHEP(hepEntity)
+	notifyWebhooks(ctx, ds.storage, hepWebhookEvent(upsertAction(hepEntity.Version), hepEntity))
 	return hepEntity, nil
 }
 
@@ -80,9 +107,31 @@ func (ds *hep) Delete(ctx context.Context, input *model.DeleteHostEndpointInput)
 	}
 
 	ip := net.ParseIP(ipString)
-	if coreErr := ds.storage.DeleteHostEndpoint(ctx, input.TenantID, net.IPToInt(*ip)); coreErr != nil {
+	hepEntity, coreErr := ds.storage.GetHostEndpoint(ctx, &model.GetHostEndpointInput{TenantID: input.TenantID, IP: net.IPToInt(*ip)})
+	if coreErr != nil && !errors.Is(coreErr, errlist.ErrNotFoundHostEndpoint) {
+		return httpbase.ErrDatabase(ctx, "get host endpoint failed").SetSubError(coreErr)
+	}
+	if hepEntity != nil {
+		if ierr := admit(ctx, ds.storage, &model.AdmissionReviewInput{
+			Kind:      entity.WebhookKindHostEndpoint,
+			Operation: entity.AdmissionOperationDelete,
+			OldObject: hepEntity,
+		}); ierr != nil {
+			return ierr
+		}
+	}
+	if !input.Force && hepEntity != nil {
+		if ierr := checkOrphanedReferences(ctx, ds.storage, hepEntity.UUID, hepEntity.Metadata.Labels, hepEntity.Spec.TenantID, true); ierr != nil {
+			return ierr
+		}
+	}
+	if coreErr = ds.storage.DeleteHostEndpoint(ctx, input.TenantID, net.IPToInt(*ip)); coreErr != nil {
 		return httpbase.ErrDatabase(ctx, "delete host endpoint failed").SetSubError(coreErr)
 	}
+	ds.snapshot.DeleteHEP(input.TenantID, net.IPToInt(*ip))
+	if hepEntity != nil {
+		notifyWebhooks(ctx, ds.storage, hepWebhookEvent(entity.WebhookActionDelete, hepEntity))
+	}
 	return nil
 }
 
@@ -91,6 +140,9 @@ func (ds *hep) Validate(ctx context.Context, input *model.CreateHostEndpointInpu
 	if ierr != nil {
 		return nil, ierr
 	}
+	if ierr = checkHostEndpointQuota(ctx, ds.storage, hepEntity); ierr != nil {
+		return nil, ierr
+	}
 
 	hepPolicy, ierr := ds.ListRelatedPolicies(ctx, hepEntity, nil)
 	if ierr != nil {
@@ -106,11 +158,22 @@ func (ds *hep) Validate(ctx context.Context, input *model.CreateHostEndpointInpu
 			return nil, httpbase.ErrDatabase(ctx, "get host endpoint failed").SetSubError(coreErr)
 		}
 	}
+	admissionVerdicts, ierr := reviewAdmissions(ctx, ds.storage, &model.AdmissionReviewInput{
+		Kind:      entity.WebhookKindHostEndpoint,
+		Operation: entity.AdmissionOperationCreate,
+		DryRun:    true,
+		Object:    hepEntity,
+		OldObject: hepExistedEntity,
+	})
+	if ierr != nil {
+		return nil, ierr
+	}
 
 	return &model.ValidateHostEndpointOutput{
-		HEP:        hepEntity,
-		HEPExisted: hepExistedEntity,
-		ParsedGNPs: hepPolicy.ParsedGNPs,
+		HEP:               hepEntity,
+		HEPExisted:        hepExistedEntity,
+		ParsedGNPs:        hepPolicy.ParsedGNPs,
+		AdmissionVerdicts: admissionVerdicts,
 	}, nil
 }
 
@@ -130,6 +193,16 @@ func (ds *hep) ListRelatedPolicies(ctx context.Context, targetHEPEntity *entity.
 	if coreErr != nil {
 		return nil, httpbase.ErrDatabase(ctx, "list global network policy failed").SetSubError(coreErr)
 	}
+	nps, coreErr := ds.storage.ListNetworkPolicies(ctx, targetHEPEntity.Spec.TenantID)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list network policies failed").SetSubError(coreErr)
+	}
+	gnps = append(gnps, nps...)
+	tiersByName, ierr := listTiersByName(ctx, ds.storage)
+	if ierr != nil {
+		return nil, ierr
+	}
+	sortGNPsByTier(gnps, tiersByName)
 
 	var (
 		parsedGNPs []*model.ParsedGNP
@@ -141,11 +214,13 @@ func (ds *hep) ListRelatedPolicies(ctx context.Context, targetHEPEntity *entity.
 			slog.Warn("malformed selector", "policy_uuid", policy.UUID, "selector", policy.Spec.Selector, "err", errParse)
 			continue
 		}
-		if !sel.Evaluate(targetHEPEntity.Metadata.Labels) {
+		if !appliesToTenant(policy, targetHEPEntity.Spec.TenantID) || !sel.EvaluateLabels(hepLabels(targetHEPEntity)) {
 			continue
 		}
 		parsedGNPs = append(parsedGNPs, &model.ParsedGNP{
-			Name: policy.Metadata.Name,
+			Name:     policy.Metadata.Name,
+			TenantID: policy.Metadata.TenantID,
+			Tier:     policy.TierName(),
 		})
 	}
 	return &model.HostEndpointPolicy{
@@ -162,6 +237,9 @@ func createModelToHEPEntity(ctx context.Context, input *model.CreateHostEndpoint
 	if input.Spec.TenantID == 0 {
 		input.Spec.TenantID = entity.DefaultTenantID
 	}
+	if _, ok := input.Metadata.Labels[selector.TenantLabel]; ok {
+		return nil, httpbase.ErrBadRequest(ctx, fmt.Sprintf("label %q is reserved", selector.TenantLabel))
+	}
 	var ipString string
 	if input.Spec.IP == "" {
 		ipString = ipsV4[0]
@@ -210,86 +288,124 @@ func exactIPs(ips []string) (ipsV4, ipsV6 []string) {
 }
 
 func (ds *hep) FetchPolicies(ctx context.Context, input *model.ListHostEndpointsInput) ([]*model.HostEndpointPolicy, *ierror.Error) {
-	heps, coreErr := ds.storage.ListHostEndpoints(ctx, nil)
-	if coreErr != nil {
-		return nil, httpbase.ErrDatabase(ctx, "list host endpoint failed").SetSubError(coreErr)
-	}
-
-	gnps, coreErr := ds.storage.ListGNPs(ctx, &model.ListGNPsInput{IsOrder: true})
-	if coreErr != nil {
-		return nil, httpbase.ErrDatabase(ctx, "list global network policy failed").SetSubError(coreErr)
+	if input != nil && input.TenantID != nil && input.IP != nil {
+		hepPolicy, ok := ds.snapshot.Get(*input.TenantID, *input.IP)
+		if !ok {
+			return []*model.HostEndpointPolicy{}, nil
+		}
+		return []*model.HostEndpointPolicy{hepPolicy}, nil
 	}
+	return ds.snapshot.List(), nil
+}
 
-	gnss, coreErr := ds.storage.ListGNSs(ctx)
-	if coreErr != nil {
-		return nil, httpbase.ErrDatabase(ctx, "list global network set failed").SetSubError(coreErr)
+// buildHostEndpointPolicy computes the policy of one host endpoint from the full set of policies, endpoints and sets.
+// gnps holds both global and network policies and must be sorted by tier order then by order, see sortGNPsByTier.
+// Network policies of other tenants, policies and rules inactive at now are left out.
+func buildHostEndpointPolicy(hepEntity *entity.HostEndpoint, gnps []*entity.GlobalNetworkPolicy, tiers map[string]*entity.Tier,
+	heps []*entity.HostEndpoint, gnss []*entity.GlobalNetworkSet, now time.Time) *model.HostEndpointPolicy {
+	rp := &ruleParser{
+		parsedHEPsMap: make(map[string]struct{}),
+		hepVersions:   make(map[string]uint),
+		parsedGNSsMap: make(map[string]struct{}),
+		gnsVersions:   make(map[string]uint),
 	}
 
 	var (
-		hepPolicies []*model.HostEndpointPolicy
+		parsedGNPs  []*model.ParsedGNP
+		parsedTiers []*model.ParsedTier
+		gnpVersions = make(map[string]uint)
+		gnpDigests  = make(map[string]string)
 	)
-
-	for _, hepEntity := range heps {
-		if input != nil {
-			if input.TenantID != nil && input.IP != nil {
-				if hepEntity.Spec.TenantID != *input.TenantID || hepEntity.Spec.IP != *input.IP {
-					continue
-				}
-			}
+	for _, policy := range gnps {
+		if !isScheduleActive(policy.Spec.Schedule, now) {
+			continue
 		}
-
-		rp := &ruleParser{
-			parsedHEPsMap: make(map[string]struct{}),
-			hepVersions:   make(map[string]uint),
-			parsedGNSsMap: make(map[string]struct{}),
-			gnsVersions:   make(map[string]uint),
+		sel, errParse := selector.Parse(policy.Spec.Selector)
+		if errParse != nil {
+			slog.Warn("malformed selector", "policy_uuid", policy.UUID, "selector", policy.Spec.Selector, "err", errParse)
+			continue
+		}
+		if !appliesToTenant(policy, hepEntity.Spec.TenantID) || !sel.EvaluateLabels(hepLabels(hepEntity)) {
+			continue
+		}
+		gnpVersions[policy.UUID] = policy.Version
+
+		policyTier := tierOfGNP(tiers, policy)
+		if len(parsedTiers) == 0 || parsedTiers[len(parsedTiers)-1].Name != policyTier.Metadata.Name {
+			parsedTiers = append(parsedTiers, &model.ParsedTier{
+				Name:          policyTier.Metadata.Name,
+				Order:         policyTier.Spec.Order,
+				DefaultAction: policyTier.Spec.DefaultAction,
+			})
 		}
 
-		var (
-			parsedGNPs  []*model.ParsedGNP
-			gnpVersions = make(map[string]uint)
-		)
-		for _, policy := range gnps {
-			sel, errParse := selector.Parse(policy.Spec.Selector)
-			if errParse != nil {
-				slog.Warn("malformed selector", "policy_uuid", policy.UUID, "selector", policy.Spec.Selector, "err", errParse)
-				continue
-			}
-			if !sel.Evaluate(hepEntity.Metadata.Labels) {
-				continue
-			}
-			gnpVersions[policy.UUID] = policy.Version
-
-			inboundRules := make([]*model.ParsedRule, 0)
-			outboundRules := make([]*model.ParsedRule, 0)
-			for _, rule := range policy.Spec.Ingress {
+		inboundRules := make([]*model.ParsedRule, 0)
+		outboundRules := make([]*model.ParsedRule, 0)
+		for _, rule := range policy.Spec.Ingress {
+			if isScheduleActive(rule.Schedule, now) {
 				inboundRules = append(inboundRules, rp.parseRule(policy, &rule, heps, gnss))
 			}
-			for _, rule := range policy.Spec.Egress {
+		}
+		for _, rule := range policy.Spec.Egress {
+			if isScheduleActive(rule.Schedule, now) {
 				outboundRules = append(outboundRules, rp.parseRule(policy, &rule, heps, gnss))
 			}
-			parsedGNPs = append(parsedGNPs, &model.ParsedGNP{
-				UUID:          policy.UUID,
-				Version:       policy.Version,
-				Name:          policy.Metadata.Name,
-				InboundRules:  inboundRules,
-				OutboundRules: outboundRules,
-			})
 		}
-		hepPolicies = append(hepPolicies, &model.HostEndpointPolicy{
-			MetaData: model.HostEndpointPolicyMetadata{
-				GNPVersions: gnpVersions,
-				HEPVersions: rp.hepVersions,
-				GNSVersions: rp.gnsVersions,
-			},
-			HEP:        hepEntity,
-			ParsedGNPs: parsedGNPs,
-			ParsedHEPs: rp.parsedHEPs,
-			ParsedGNSs: rp.parsedGNSs,
-		})
+		parsedGNP := &model.ParsedGNP{
+			UUID:          policy.UUID,
+			Version:       policy.Version,
+			Name:          policy.Metadata.Name,
+			TenantID:      policy.Metadata.TenantID,
+			Tier:          policyTier.Metadata.Name,
+			IsStaged:      policy.Spec.Staged,
+			InboundRules:  inboundRules,
+			OutboundRules: outboundRules,
+		}
+		parsedGNPs = append(parsedGNPs, parsedGNP)
+		gnpDigests[policy.UUID] = digestParsedGNP(parsedGNP)
+	}
+	return &model.HostEndpointPolicy{
+		MetaData: model.HostEndpointPolicyMetadata{
+			GNPVersions: gnpVersions,
+			HEPVersions: rp.hepVersions,
+			GNSVersions: rp.gnsVersions,
+			GNPDigests:  gnpDigests,
+		},
+		HEP:         hepEntity,
+		ParsedTiers: parsedTiers,
+		ParsedGNPs:  parsedGNPs,
+		ParsedHEPs:  rp.parsedHEPs,
+		ParsedGNSs:  rp.parsedGNSs,
 	}
+}
 
-	return hepPolicies, nil
+// tierOfGNP returns the tier of a policy. A policy referring to an unknown tier falls back to the default tier.
+func tierOfGNP(tiers map[string]*entity.Tier, gnp *entity.GlobalNetworkPolicy) *entity.Tier {
+	if t, ok := tiers[gnp.TierName()]; ok {
+		return t
+	}
+	return &entity.TierDefault
+}
+
+// sortGNPsByTier sorts policies by tier order, tier name, policy order then policy name. At the same order a global
+// policy comes before a network policy, so a tenant can not take precedence over global policies of the same order.
+func sortGNPsByTier(gnps []*entity.GlobalNetworkPolicy, tiers map[string]*entity.Tier) {
+	sort.SliceStable(gnps, func(i, j int) bool {
+		tierI, tierJ := tierOfGNP(tiers, gnps[i]), tierOfGNP(tiers, gnps[j])
+		if tierI.Spec.Order != tierJ.Spec.Order {
+			return tierI.Spec.Order < tierJ.Spec.Order
+		}
+		if tierI.Metadata.Name != tierJ.Metadata.Name {
+			return tierI.Metadata.Name < tierJ.Metadata.Name
+		}
+		if gnps[i].Spec.Order != gnps[j].Spec.Order {
+			return gnps[i].Spec.Order < gnps[j].Spec.Order
+		}
+		if gnps[i].Metadata.TenantID != gnps[j].Metadata.TenantID {
+			return gnps[i].Metadata.TenantID < gnps[j].Metadata.TenantID
+		}
+		return gnps[i].Metadata.Name < gnps[j].Metadata.Name
+	})
 }
 
 type ruleParser struct {
@@ -327,7 +443,19 @@ func (r *ruleParser) parseRule(policy *entity.GlobalNetworkPolicy, rule *entity.
 		isProtocolNegative = true
 	}
 
-	var ruleIPVersion int
+	var (
+		icmpType, icmpCode       *int
+		notICMPType, notICMPCode *int
+	)
+	if rule.ICMP != nil {
+		icmpType, icmpCode = rule.ICMP.Type, rule.ICMP.Code
+	}
+	if rule.NotICMP != nil {
+		notICMPType, notICMPCode = rule.NotICMP.Type, rule.NotICMP.Code
+	}
+
+	// icmp and icmpv6 only exist in one ip version
+	ruleIPVersion := entity.ICMPVersionOfProtocol(rule.Protocol)
 	if rule.Source != nil {
 		if len(rule.Source.Nets) > 0 {
 			ip, _, err := net.ParseCIDR(rule.Source.Nets[0])
@@ -373,7 +501,7 @@ func (r *ruleParser) parseRule(policy *entity.GlobalNetworkPolicy, rule *entity.
 	// get host endpoint and global network set match if selector is available
 	if rule.Source != nil {
 		if len(rule.Source.Selector) > 0 {
-			hepUUIDs, gnsUUIDs, err := r.handleSelector(rule.Source.Selector, rule.IPVersion, heps, gnss)
+			hepUUIDs, gnsUUIDs, err := r.handleSelector(policy, rule.Source.Selector, rule.IPVersion, heps, gnss)
 			if err != nil {
 				slog.Warn("malformed selector in source", "policy_uuid", policy.UUID, "selector", rule.Source.Selector, "err", err)
 			}
@@ -396,7 +524,7 @@ func (r *ruleParser) parseRule(policy *entity.GlobalNetworkPolicy, rule *entity.
 	// get global network set match if selector is available
 	if rule.Destination != nil {
 		if len(rule.Destination.Selector) > 0 {
-			hepUUIDs, gnsUUIDs, err := r.handleSelector(rule.Destination.Selector, rule.IPVersion, heps, gnss)
+			hepUUIDs, gnsUUIDs, err := r.handleSelector(policy, rule.Destination.Selector, rule.IPVersion, heps, gnss)
 			if err != nil {
 				slog.Warn("malformed selector in destination", "policy_uuid", policy.UUID, "selector", rule.Source.Selector, "err", err)
 			}
@@ -421,6 +549,10 @@ func (r *ruleParser) parseRule(policy *entity.GlobalNetworkPolicy, rule *entity.
 		IPVersion:          rule.IPVersion,
 		Protocol:           protocol,
 		IsProtocolNegative: isProtocolNegative,
+		ICMPType:           icmpType,
+		ICMPCode:           icmpCode,
+		NotICMPType:        notICMPType,
+		NotICMPCode:        notICMPCode,
 		SrcGNSUUIDs:        srcGNSUUIDs,
 		SrcHEPUUIDs:        srcHEPUUIDs,
 		SrcNets:            srcNets,
@@ -436,8 +568,10 @@ func (r *ruleParser) parseRule(policy *entity.GlobalNetworkPolicy, rule *entity.
 	}
 }
 
-func (r *ruleParser) handleSelector(selectorString string, ruleIPVersion *int, heps []*entity.HostEndpoint,
-	gnss []*entity.GlobalNetworkSet) ([]string, []string, error) {
+// handleSelector returns the host endpoints and sets matched by a rule selector of policy. The rule selectors of a
+// network policy only match the host endpoints and network sets of its tenant, and global network sets.
+func (r *ruleParser) handleSelector(policy *entity.GlobalNetworkPolicy, selectorString string, ruleIPVersion *int,
+	heps []*entity.HostEndpoint, gnss []*entity.GlobalNetworkSet) ([]string, []string, error) {
 	var (
 		hepUUIDs []string
 		gnsUUIDs []string
@@ -447,7 +581,7 @@ func (r *ruleParser) handleSelector(selectorString string, ruleIPVersion *int, h
 		return nil, nil, fmt.Errorf("parse selector for rule failed:  %w", errParse)
 	}
 	for _, ep := range heps {
-		if !sel.Evaluate(ep.Metadata.Labels) {
+		if !appliesToTenant(policy, ep.Spec.TenantID) || !sel.EvaluateLabels(hepLabels(ep)) {
 			continue
 		}
 		if ruleIPVersion != nil {
@@ -464,10 +598,10 @@ func (r *ruleParser) handleSelector(selectorString string, ruleIPVersion *int, h
 	}
 
 	for _, set := range gnss {
-		if !sel.Evaluate(set.Metadata.Labels) {
+		if !setInScope(policy, set) || !sel.EvaluateLabels(gnsLabels(set)) {
 			continue
 		}
-		if ruleIPVersion != nil {
+		if ruleIPVersion != nil && len(set.Spec.AllowedEgressDomains) == 0 {
 			if !((*ruleIPVersion == entity.IPVersion4 && len(set.Spec.NetsV4) > 0) || (*ruleIPVersion == entity.IPVersion6 && len(set.Spec.NetsV6) > 0)) {
 				continue
 			}
@@ -507,13 +641,38 @@ func entityToParsedHEP(hep *entity.HostEndpoint) *model.ParsedHEP {
 
 func entityToParsedGNS(set *entity.GlobalNetworkSet) *model.ParsedGNS {
 	return &model.ParsedGNS{
-		UUID:   set.UUID,
-		Name:   set.Metadata.Name,
-		NetsV4: set.Spec.NetsV4,
-		NetsV6: set.Spec.NetsV6,
+		UUID:     set.UUID,
+		Name:     set.Metadata.Name,
+		TenantID: set.Metadata.TenantID,
+		NetsV4:   set.Spec.NetsV4,
+		NetsV6:   set.Spec.NetsV6,
+		Domains:  set.Spec.AllowedEgressDomains,
 	}
 }
 
+// hepLabels returns the labels of a host endpoint selectors are evaluated against, with the tenant pseudo-label.
+func hepLabels(hep *entity.HostEndpoint) selector.Labels {
+	return selector.WithTenant(hep.Metadata.Labels, hep.Spec.TenantID)
+}
+
+// gnsLabels returns the labels of a set selectors are evaluated against, with the tenant pseudo-label for a network
+// set.
+func gnsLabels(set *entity.GlobalNetworkSet) selector.Labels {
+	return selector.WithTenant(set.Metadata.Labels, set.Metadata.TenantID)
+}
+
+// appliesToTenant reports whether policy may select the host endpoints of tenantID: a global policy may select any
+// host endpoint, a network policy only the ones of its tenant.
+func appliesToTenant(policy *entity.GlobalNetworkPolicy, tenantID uint64) bool {
+	return !policy.IsNamespaced() || policy.Metadata.TenantID == tenantID
+}
+
+// setInScope reports whether the rule selectors of policy may match set: global sets are matched by any policy,
+// network sets only by the network policies of their tenant.
+func setInScope(policy *entity.GlobalNetworkPolicy, set *entity.GlobalNetworkSet) bool {
+	return set.Metadata.TenantID == 0 || set.Metadata.TenantID == policy.Metadata.TenantID
+}
+
 func convertPorts(ports []interface{}) []string {
 	var portStrings []string
 	for _, port := range ports {
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+// FetchPolicyDelta returns the policy of a host endpoint as a change of the one the agent knows. Endpoints and sets
+// are compared by version, policies by digest since their parsed rules also depend on the matched endpoints and sets
+// and on the active rule schedules. A policy the agent knows no digest of is always sent.
+func (ds *hep) FetchPolicyDelta(ctx context.Context, input *model.FetchHostEndpointPolicyDeltaInput) (*model.HostEndpointPolicyDelta, *ierror.Error) {
+	hepPolicy, ok := ds.snapshot.Get(input.TenantID, input.IP)
+	if !ok {
+		return nil, httpbase.ErrNotFound(ctx, "not found").SetSubError(errlist.ErrNotFoundHostEndpoint)
+	}
+	return diffHostEndpointPolicy(hepPolicy, &input.Known), nil
+}
+
+// WatchPolicyDelta calls send with the policy of a host endpoint as a change of the one the agent knows, then with the
+// change since the previously sent one whenever the policy is recomputed, until ctx is done. It fails once the host
+// endpoint is deleted.
+func (ds *hep) WatchPolicyDelta(ctx context.Context, input *model.FetchHostEndpointPolicyDeltaInput,
+	send func(*model.HostEndpointPolicyDelta) error) *ierror.Error {
+	known := input.Known
+	sent := false
+	for {
+		changed := ds.snapshot.Changed()
+		hepPolicy, ok := ds.snapshot.Get(input.TenantID, input.IP)
+		if !ok {
+			return httpbase.ErrNotFound(ctx, "not found").SetSubError(errlist.ErrNotFoundHostEndpoint)
+		}
+		if !sent || hepPolicy.MetaData.Revision != known.Revision {
+			if err := send(diffHostEndpointPolicy(hepPolicy, &known)); err != nil {
+				return httpbase.ErrInternal(ctx, fmt.Sprintf("send policy delta failed: %v", err))
+			}
+			known = hepPolicy.MetaData
+			sent = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+		}
+	}
+}
+
+func diffHostEndpointPolicy(hepPolicy *model.HostEndpointPolicy, known *model.HostEndpointPolicyMetadata) *model.HostEndpointPolicyDelta {
+	delta := &model.HostEndpointPolicyDelta{
+		MetaData:    hepPolicy.MetaData,
+		HEP:         hepPolicy.HEP,
+		ParsedTiers: hepPolicy.ParsedTiers,
+		GNPUUIDs:    make([]string, 0, len(hepPolicy.ParsedGNPs)),
+		ParsedGNPs:  make([]*model.ParsedGNP, 0),
+		ParsedHEPs:  make([]*model.ParsedHEP, 0),
+		ParsedGNSs:  make([]*model.ParsedGNS, 0),
+	}
+	for _, policy := range hepPolicy.ParsedGNPs {
+		delta.GNPUUIDs = append(delta.GNPUUIDs, policy.UUID)
+		digest, ok := known.GNPDigests[policy.UUID]
+		if !ok || digest != hepPolicy.MetaData.GNPDigests[policy.UUID] {
+			delta.ParsedGNPs = append(delta.ParsedGNPs, policy)
+		}
+	}
+	for _, endpoint := range hepPolicy.ParsedHEPs {
+		if !isKnownVersion(known.HEPVersions, hepPolicy.MetaData.HEPVersions, endpoint.UUID) {
+			delta.ParsedHEPs = append(delta.ParsedHEPs, endpoint)
+		}
+	}
+	for _, set := range hepPolicy.ParsedGNSs {
+		if !isKnownVersion(known.GNSVersions, hepPolicy.MetaData.GNSVersions, set.UUID) {
+			delta.ParsedGNSs = append(delta.ParsedGNSs, set)
+		}
+	}
+	delta.RemovedGNPs = removedUUIDs(known.GNPVersions, hepPolicy.MetaData.GNPVersions)
+	delta.RemovedHEPs = removedUUIDs(known.HEPVersions, hepPolicy.MetaData.HEPVersions)
+	delta.RemovedGNSs = removedUUIDs(known.GNSVersions, hepPolicy.MetaData.GNSVersions)
+	return delta
+}
+
+// isKnownVersion reports whether the agent knows uuid at its current version.
+func isKnownVersion(known, current map[string]uint, uuid string) bool {
+	version, ok := known[uuid]
+	return ok && version == current[uuid]
+}
+
+// removedUUIDs returns the sorted uuids known by the agent which are no longer part of the policy.
+func removedUUIDs(known, current map[string]uint) []string {
+	removed := make([]string, 0)
+	for uuid := range known {
+		if _, ok := current[uuid]; !ok {
+			removed = append(removed, uuid)
+		}
+	}
+	slices.Sort(removed)
+	return removed
+}
+
+func digestParsedGNP(policy *model.ParsedGNP) string {
+	// a parsed policy only holds strings, numbers and slices of them, it always marshals
+	data, _ := json.Marshal(policy)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16])
+}
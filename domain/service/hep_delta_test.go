@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/bamboo-firewall/be/api/v1/mapper"
+	"github.com/bamboo-firewall/be/api/v1/pb"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/agentclient"
+)
+
+// deltaTestStorage holds web, which allowLB and allowDB apply to. allowLB allows the set lb and allowDB the endpoint
+// db.
+func deltaTestStorage() *fakeStorage {
+	ctx := context.Background()
+	storage := newFakeStorage()
+	storage.UpsertHostEndpoint(ctx, testHEP("web", 1, map[string]string{"app": "web"}))
+	storage.UpsertHostEndpoint(ctx, testHEP("db", 2, map[string]string{"app": "db"}))
+	storage.UpsertGNS(ctx, testGNS("lb", map[string]string{"role": "lb"}, "192.168.0.0/24"))
+	storage.UpsertGNS(ctx, testGNS("monitor", map[string]string{"role": "monitor"}, "192.168.1.0/24"))
+	storage.UpsertGroupPolicy(ctx, testGNP("allow-lb", "app == 'web'", 10, "role == 'lb'"))
+	storage.UpsertGroupPolicy(ctx, testGNP("allow-db", "app == 'web'", 20, "app == 'db'"))
+	storage.UpsertGroupPolicy(ctx, testGNP("allow-monitor", "app == 'web'", 30, "role == 'monitor'"))
+	return storage
+}
+
+func webPolicy(t *testing.T, storage *fakeStorage) *model.HostEndpointPolicy {
+	t.Helper()
+	policy, ok := loadedSnapshot(t, storage).Get(1, 1)
+	if !ok {
+		t.Fatal("no policy of web")
+	}
+	return policy
+}
+
+func TestApplyDeltaMatchesFullFetch(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		change func(storage *fakeStorage)
+	}{
+		{
+			name:   "no change",
+			change: func(storage *fakeStorage) {},
+		},
+		{
+			name: "policy added",
+			change: func(storage *fakeStorage) {
+				storage.UpsertGroupPolicy(ctx, testGNP("allow-any-lb", "app == 'web'", 5, "role == 'lb'"))
+			},
+		},
+		{
+			name: "policy changed",
+			change: func(storage *fakeStorage) {
+				storage.UpsertGroupPolicy(ctx, testGNP("allow-db", "app == 'web'", 1, "app == 'db'"))
+			},
+		},
+		{
+			name: "policy removed",
+			change: func(storage *fakeStorage) {
+				storage.DeleteGNPByName(ctx, "allow-db")
+			},
+		},
+		{
+			name: "policy and its set removed",
+			change: func(storage *fakeStorage) {
+				storage.DeleteGNPByName(ctx, "allow-monitor")
+				storage.DeleteGNSByName(ctx, "monitor")
+			},
+		},
+		{
+			name: "set removed",
+			change: func(storage *fakeStorage) {
+				storage.DeleteGNSByName(ctx, "lb")
+			},
+		},
+		{
+			name: "set changed",
+			change: func(storage *fakeStorage) {
+				storage.UpsertGNS(ctx, testGNS("lb", map[string]string{"role": "lb"}, "192.168.0.0/25"))
+			},
+		},
+		{
+			name: "endpoint removed",
+			change: func(storage *fakeStorage) {
+				storage.DeleteHostEndpoint(ctx, 1, 2)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := deltaTestStorage()
+			known := webPolicy(t, storage)
+			tt.change(storage)
+			current := webPolicy(t, storage)
+
+			delta := diffHostEndpointPolicy(current, &known.MetaData)
+			got := agentclient.ApplyDelta(mapper.ToPBHostEndpointPolicy(known), mapper.ToPBHostEndpointPolicyDelta(delta))
+			want := mapper.ToPBHostEndpointPolicy(current)
+			if diff := cmp.Diff(want, got, protoPolicyCmpOptions()...); diff != "" {
+				t.Errorf("ApplyDelta() mismatch with the full fetch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyDeltaWithoutPolicy(t *testing.T) {
+	current := webPolicy(t, deltaTestStorage())
+
+	delta := diffHostEndpointPolicy(current, &model.HostEndpointPolicyMetadata{})
+	got := agentclient.ApplyDelta(nil, mapper.ToPBHostEndpointPolicyDelta(delta))
+	want := mapper.ToPBHostEndpointPolicy(current)
+	if diff := cmp.Diff(want, got, protoPolicyCmpOptions()...); diff != "" {
+		t.Errorf("ApplyDelta() mismatch with the full fetch (-want +got):\n%s", diff)
+	}
+}
+
+// protoPolicyCmpOptions compares policies regardless of the order of their endpoints and sets, which is only defined
+// for the policies.
+func protoPolicyCmpOptions() []cmp.Option {
+	return []cmp.Option{
+		protocmp.Transform(),
+		protocmp.SortRepeated(func(a, b *pb.ParsedHEP) bool { return a.GetUuid() < b.GetUuid() }),
+		protocmp.SortRepeated(func(a, b *pb.ParsedGNS) bool { return a.GetUuid() < b.GetUuid() }),
+	}
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	"github.com/bamboo-firewall/be/pkg/net"
+)
+
+const (
+	labelHostname = "hostname"
+	labelOS       = "os"
+)
+
+// Register creates or updates the host endpoint an agent runs on. The agent presents an enrollment token which sets
+// the tenant, the allowed ips, the labels and which labels the agent may set itself. Fields of an existing host endpoint changed by hand since the
+// last registration are kept, see mergeRegisteredHEP.
+func (ds *hep) Register(ctx context.Context, input *model.RegisterHostEndpointInput) (*entity.HostEndpoint, *ierror.Error) {
+	tokenEntity, coreErr := ds.storage.GetEnrollmentTokenByToken(ctx, input.Token)
+	if coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrNotFoundEnrollmentToken) {
+			return nil, httpbase.ErrUnauthorized(ctx, "invalid enrollment token").SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "get enrollment token failed").SetSubError(coreErr)
+	}
+	if ierr := checkAllowedIPs(ctx, tokenEntity.Spec.AllowedCIDRs, input.IPs); ierr != nil {
+		return nil, ierr
+	}
+
+	ipsV4, _ := exactIPs(input.IPs)
+	if len(ipsV4) == 0 {
+		return nil, httpbase.ErrBadRequest(ctx, "required at least one ip version 4")
+	}
+	templateLabels, err := executeLabelTemplates(tokenEntity.Spec.LabelTemplates, labelTemplateData{
+		Hostname:      input.Hostname,
+		OS:            input.OS,
+		InterfaceName: input.InterfaceName,
+		IP:            ipsV4[0],
+		TenantID:      tokenEntity.Spec.TenantID,
+	})
+	if err != nil {
+		return nil, httpbase.ErrBadRequest(ctx, fmt.Sprintf("malformed label templates of enrollment token: %v", err))
+	}
+	labels := registrationLabels(tokenEntity.Spec, templateLabels, input)
+
+	name := input.Name
+	if name == "" {
+		name = input.Hostname
+	}
+	registeredHEP, ierr := createModelToHEPEntity(ctx, &model.CreateHostEndpointInput{
+		Metadata: model.HostEndpointMetadataInput{
+			Name:   name,
+			Labels: labels,
+		},
+		Spec: model.HostEndpointSpecInput{
+			InterfaceName: input.InterfaceName,
+			TenantID:      tokenEntity.Spec.TenantID,
+			IPs:           input.IPs,
+		},
+	})
+	if ierr != nil {
+		return nil, ierr
+	}
+	registeredHEP.Registration = &entity.HostEndpointRegistration{
+		TokenName:     tokenEntity.Metadata.Name,
+		InterfaceName: registeredHEP.Spec.InterfaceName,
+		IPs:           registeredHEP.Spec.IPs,
+		Labels:        labels,
+		RegisteredAt:  time.Now(),
+	}
+
+	existedHEP, coreErr := ds.storage.GetHostEndpoint(ctx, &model.GetHostEndpointInput{
+		TenantID: registeredHEP.Spec.TenantID,
+		IP:       registeredHEP.Spec.IP,
+	})
+	if coreErr != nil && !errors.Is(coreErr, errlist.ErrNotFoundHostEndpoint) {
+		return nil, httpbase.ErrDatabase(ctx, "get host endpoint failed").SetSubError(coreErr)
+	}
+	hepEntity := mergeRegisteredHEP(existedHEP, registeredHEP)
+	if ierr = checkHostEndpointQuota(ctx, ds.storage, hepEntity); ierr != nil {
+		return nil, ierr
+	}
+	if ierr := admit(ctx, ds.storage, &model.AdmissionReviewInput{
+		Kind:      entity.WebhookKindHostEndpoint,
+		Operation: entity.AdmissionOperationCreate,
+		Object:    hepEntity,
+		OldObject: existedHEP,
+	}); ierr != nil {
+		return nil, ierr
+	}
+
+	if coreErr = ds.storage.UpsertHostEndpoint(ctx, hepEntity); coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrDuplicateHostEndpoint) {
+			return nil, httpbase.ErrBadRequest(ctx, "duplicate host endpoint").SetSubError(coreErr)
+		}
+		if errors.Is(coreErr, errlist.ErrQuotaExceeded) {
+			return nil, httpbase.ErrBadRequest(ctx, fmt.Sprintf("tenant %d reached its quota of host endpoints",
+				hepEntity.Spec.TenantID)).SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "register host endpoint failed").SetSubError(coreErr)
+	}
+	ds.snapshot.UpsertHEP(hepEntity)
+	notifyWebhooks(ctx, ds.storage, hepWebhookEvent(upsertAction(hepEntity.Version), hepEntity))
+	return hepEntity, nil
+}
+
+// checkAllowedIPs returns a forbidden error if an ip is not in allowedCIDRs. Empty allowedCIDRs allow any ip.
+// AuthorizeAgent checks that an agent presenting the enrollment token may get the policy of the host endpoint of
+// tenantID with ip, the same way the token allows an agent to register it.
+func (ds *hep) AuthorizeAgent(ctx context.Context, token string, tenantID uint64, ip string) *ierror.Error {
+	tokenEntity, coreErr := ds.storage.GetEnrollmentTokenByToken(ctx, token)
+	if coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrNotFoundEnrollmentToken) {
+			return httpbase.ErrUnauthorized(ctx, "invalid enrollment token").SetSubError(coreErr)
+		}
+		return httpbase.ErrDatabase(ctx, "get enrollment token failed").SetSubError(coreErr)
+	}
+	if tokenEntity.Spec.TenantID != tenantID {
+		return httpbase.ErrForbidden(ctx, fmt.Sprintf("tenant %d is not allowed by the enrollment token", tenantID))
+	}
+	return checkAllowedIPs(ctx, tokenEntity.Spec.AllowedCIDRs, []string{ip})
+}
+
+func checkAllowedIPs(ctx context.Context, allowedCIDRs []string, ips []string) *ierror.Error {
+	if len(allowedCIDRs) == 0 {
+		return nil
+	}
+	allowed, err := net.ParseCIDRSet(allowedCIDRs)
+	if err != nil {
+		return httpbase.ErrInternal(ctx, fmt.Sprintf("malformed allowed cidrs of enrollment token: %v", err))
+	}
+	for _, ipString := range ips {
+		addr, err := netip.ParseAddr(ipString)
+		if err != nil {
+			return httpbase.ErrBadRequest(ctx, fmt.Sprintf("malformed ip %s", ipString))
+		}
+		if !allowed.Contains(addr) {
+			return httpbase.ErrForbidden(ctx, fmt.Sprintf("ip %s is not allowed by the enrollment token", ipString))
+		}
+	}
+	return nil
+}
+
+// mergeRegisteredHEP returns the host endpoint to store when an agent registers registered over existed. The name
+// and the description of an existing host endpoint are managed by hand. The interface name, the ips and each label
+// are managed by the agent while they are empty or hold the value of the previous registration, otherwise they were
+// changed by hand and are kept.
+func mergeRegisteredHEP(existed, registered *entity.HostEndpoint) *entity.HostEndpoint {
+	if existed == nil {
+		return registered
+	}
+	previous := existed.Registration
+	if previous == nil {
+		previous = new(entity.HostEndpointRegistration)
+	}
+
+	merged := *registered
+	merged.Metadata.Name = existed.Metadata.Name
+	merged.Description = existed.Description
+	merged.FilePath = existed.FilePath
+
+	if existed.Spec.InterfaceName != "" && existed.Spec.InterfaceName != previous.InterfaceName {
+		merged.Spec.InterfaceName = existed.Spec.InterfaceName
+	}
+	if len(existed.Spec.IPs) > 0 && !slices.Equal(existed.Spec.IPs, previous.IPs) {
+		merged.Spec.IPs = existed.Spec.IPs
+		merged.Spec.IPsV4 = existed.Spec.IPsV4
+		merged.Spec.IPsV6 = existed.Spec.IPsV6
+	}
+
+	labels := make(map[string]string, len(existed.Metadata.Labels)+len(registered.Metadata.Labels))
+	for key, value := range existed.Metadata.Labels {
+		if registeredValue, ok := previous.Labels[key]; !ok || registeredValue != value {
+			labels[key] = value
+		}
+	}
+	for key, value := range registered.Metadata.Labels {
+		if _, ok := labels[key]; !ok {
+			labels[key] = value
+		}
+	}
+	merged.Metadata.Labels = labels
+	return &merged
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+func TestSortGNPsByTier(t *testing.T) {
+	tiers := map[string]*entity.Tier{
+		"security": {Metadata: entity.TierMetadata{Name: "security"}, Spec: entity.TierSpec{Order: 10}},
+		"platform": {Metadata: entity.TierMetadata{Name: "platform"}, Spec: entity.TierSpec{Order: 20}},
+		"app":      {Metadata: entity.TierMetadata{Name: "app"}, Spec: entity.TierSpec{Order: 20}},
+	}
+	var policy = func(name, tier string, order uint32, tenantID uint64) *entity.GlobalNetworkPolicy {
+		return &entity.GlobalNetworkPolicy{
+			Metadata: entity.GNPMetadata{Name: name, TenantID: tenantID},
+			Spec:     entity.GNPSpec{Tier: tier, Order: order},
+		}
+	}
+	gnps := []*entity.GlobalNetworkPolicy{
+		policy("untiered", "", 1, 0),
+		policy("unknown-tier", "missing", 0, 0),
+		policy("platform-late", "platform", 50, 0),
+		policy("app-first", "app", 100, 0),
+		policy("security-late", "security", 900, 0),
+		policy("platform-tenant", "platform", 5, 2),
+		policy("platform-global", "platform", 5, 0),
+		policy("security-early", "security", 1, 0),
+	}
+	sortGNPsByTier(gnps, tiers)
+
+	var names []string
+	for _, gnp := range gnps {
+		names = append(names, gnp.Metadata.Name)
+	}
+	want := []string{
+		// tier order 10
+		"security-early", "security-late",
+		// tier order 20, app before platform by name whatever the policy order
+		"app-first",
+		// global before network policy of the same order
+		"platform-global", "platform-tenant", "platform-late",
+		// default tier, unknown tiers fall back to it
+		"unknown-tier", "untiered",
+	}
+	if !slices.Equal(names, want) {
+		t.Errorf("sorted = %v, want %v", names, want)
+	}
+}
+
+func TestBuildHostEndpointPolicyTenantScope(t *testing.T) {
+	var hostEndpoint = func(name string, ip uint32, tenantID uint64, role string) *entity.HostEndpoint {
+		hep := testHEP(name, ip, map[string]string{"role": role})
+		hep.UUID = name
+		hep.Spec.TenantID = tenantID
+		return hep
+	}
+	var set = func(name string, tenantID uint64) *entity.GlobalNetworkSet {
+		gns := testGNS(name, map[string]string{"role": "feed"}, "1.10.16.0/20")
+		gns.UUID = name
+		gns.Metadata.TenantID = tenantID
+		return gns
+	}
+	var policy = func(name string, tenantID uint64, sel, sourceSelector string) *entity.GlobalNetworkPolicy {
+		gnp := testGNP(name, sel, 10, sourceSelector)
+		gnp.UUID = name
+		gnp.Metadata.TenantID = tenantID
+		return gnp
+	}
+	heps := []*entity.HostEndpoint{
+		hostEndpoint("web-1", 1, 1, "web"),
+		hostEndpoint("web-2", 2, 2, "web"),
+		hostEndpoint("db-2", 3, 2, "db"),
+	}
+	gnss := []*entity.GlobalNetworkSet{set("global-feed", 0), set("feed-2", 2), set("feed-3", 3)}
+	gnps := []*entity.GlobalNetworkPolicy{
+		policy("global-web", 0, "role == 'web'", "role == 'db'"),
+		policy("tenant-one", 0, "tenant == '1'", ""),
+		policy("np-web-2", 2, "role == 'web'", "has(role)"),
+		policy("np-web-3", 3, "role == 'web'", ""),
+	}
+	sortGNPsByTier(gnps, nil)
+
+	tests := []struct {
+		hep          *entity.HostEndpoint
+		wantPolicies []string
+		// wantSources are the host endpoints and sets matched by the source selector of each policy
+		wantSources map[string][]string
+	}{
+		{
+			hep:          heps[0],
+			wantPolicies: []string{"global-web", "tenant-one"},
+			wantSources:  map[string][]string{"global-web": {"db-2"}},
+		},
+		{
+			hep:          heps[1],
+			wantPolicies: []string{"global-web", "np-web-2"},
+			wantSources: map[string][]string{
+				"global-web": {"db-2"},
+				"np-web-2":   {"web-2", "db-2", "global-feed", "feed-2"},
+			},
+		},
+		{
+			hep: heps[2],
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hep.Metadata.Name, func(t *testing.T) {
+			policy := buildHostEndpointPolicy(tt.hep, gnps, nil, heps, gnss, time.Now())
+			var names []string
+			for _, gnp := range policy.ParsedGNPs {
+				names = append(names, gnp.Name)
+				var sources []string
+				for _, rule := range gnp.InboundRules {
+					sources = append(sources, rule.SrcHEPUUIDs...)
+					sources = append(sources, rule.SrcGNSUUIDs...)
+				}
+				if want := tt.wantSources[gnp.Name]; !slices.Equal(sources, want) {
+					t.Errorf("sources of %s = %v, want %v", gnp.Name, sources, want)
+				}
+			}
+			if !slices.Equal(names, tt.wantPolicies) {
+				t.Errorf("policies = %v, want %v", names, tt.wantPolicies)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	"github.com/bamboo-firewall/be/pkg/validator"
+)
+
+// lintResult returns the lint findings of a validated resource, or a bad request holding them when one of them has
+// the error severity.
+func lintResult(ctx context.Context, findings []*validator.LintFinding) ([]*validator.LintFinding, *ierror.Error) {
+	if validator.HasLintError(findings) {
+		return nil, httpbase.ErrBadRequest(ctx, "lint failed").SetDetail(findings)
+	}
+	return findings, nil
+}
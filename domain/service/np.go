@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bamboo-firewall/be"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	"github.com/bamboo-firewall/be/pkg/net"
+	"github.com/bamboo-firewall/be/pkg/repository"
+	"github.com/bamboo-firewall/be/pkg/selector"
+	"github.com/bamboo-firewall/be/pkg/validator"
+)
+
+func NewNetworkPolicy(policyMongo *repository.PolicyDB, snapshot *PolicySnapshot, linter *validator.Linter) *networkPolicy {
+	return &networkPolicy{
+		storage:  policyMongo,
+		snapshot: snapshot,
+		linter:   linter,
+	}
+}
+
+// networkPolicy manages the network policies, which are handled as global network policies belonging to a tenant.
+type networkPolicy struct {
+	storage  be.Storage
+	snapshot *PolicySnapshot
+	linter   *validator.Linter
+}
+
+func (ds *networkPolicy) Create(ctx context.Context, input *model.CreateGlobalNetworkPolicyInput) (*entity.GlobalNetworkPolicy, *ierror.Error) {
+	if input.Metadata.TenantID == 0 {
+		input.Metadata.TenantID = entity.DefaultTenantID
+	}
+	npEntity := createModelToPolicyEntity(input)
+	if ierr := checkTierExists(ctx, ds.storage, npEntity.Spec.Tier); ierr != nil {
+		return nil, ierr
+	}
+	if ierr := checkNetworkPolicyQuota(ctx, ds.storage, npEntity); ierr != nil {
+		return nil, ierr
+	}
+	npExisted, coreErr := ds.storage.GetNetworkPolicy(ctx, npEntity.Metadata.TenantID, npEntity.Metadata.Name)
+	if coreErr != nil && !errors.Is(coreErr, errlist.ErrNotFoundNetworkPolicy) {
+		return nil, httpbase.ErrDatabase(ctx, "get network policy failed").SetSubError(coreErr)
+	}
+	if ierr := admit(ctx, ds.storage, &model.AdmissionReviewInput{
+		Kind:      entity.WebhookKindNetworkPolicy,
+		Operation: entity.AdmissionOperationCreate,
+		Object:    npEntity,
+		OldObject: npExisted,
+	}); ierr != nil {
+		return nil, ierr
+	}
+
+	if coreErr = ds.storage.UpsertNetworkPolicy(ctx, npEntity); coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrDuplicateNetworkPolicy) {
+			return nil, httpbase.ErrBadRequest(ctx, "duplicate network policy").SetSubError(coreErr)
+		}
+		if errors.Is(coreErr, errlist.ErrQuotaExceeded) {
+			return nil, httpbase.ErrBadRequest(ctx, fmt.Sprintf("tenant %d reached its quota of network policies",
+				npEntity.Metadata.TenantID)).SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "create network policy failed").SetSubError(coreErr)
+	}
+	ds.snapshot.UpsertGNP(npEntity)
+	notifyWebhooks(ctx, ds.storage, policyWebhookEvent(entity.WebhookKindNetworkPolicy, upsertAction(npEntity.Version), npEntity))
+	return npEntity, nil
+}
+
+func (ds *networkPolicy) Get(ctx context.Context, tenantID uint64, name string) (*entity.GlobalNetworkPolicy, *ierror.Error) {
+	npEntity, coreErr := ds.storage.GetNetworkPolicy(ctx, tenantID, name)
+	if coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrNotFoundNetworkPolicy) {
+			return nil, httpbase.ErrNotFound(ctx, "not found").SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "get network policy failed").SetSubError(coreErr)
+	}
+	return npEntity, nil
+}
+
+func (ds *networkPolicy) List(ctx context.Context, tenantID uint64) ([]*entity.GlobalNetworkPolicy, *ierror.Error) {
+	npsEntity, coreErr := ds.storage.ListNetworkPolicies(ctx, tenantID)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list network policies failed").SetSubError(coreErr)
+	}
+	return npsEntity, nil
+}
+
+func (ds *networkPolicy) Delete(ctx context.Context, tenantID uint64, name string) *ierror.Error {
+	if tenantID == 0 {
+		tenantID = entity.DefaultTenantID
+	}
+	npEntity, coreErr := ds.storage.GetNetworkPolicy(ctx, tenantID, name)
+	if coreErr != nil && !errors.Is(coreErr, errlist.ErrNotFoundNetworkPolicy) {
+		return httpbase.ErrDatabase(ctx, "get network policy failed").SetSubError(coreErr)
+	}
+	if npEntity != nil {
+		if ierr := admit(ctx, ds.storage, &model.AdmissionReviewInput{
+			Kind:      entity.WebhookKindNetworkPolicy,
+			Operation: entity.AdmissionOperationDelete,
+			OldObject: npEntity,
+		}); ierr != nil {
+			return ierr
+		}
+	}
+	if coreErr = ds.storage.DeleteNetworkPolicy(ctx, tenantID, name); coreErr != nil {
+		return httpbase.ErrDatabase(ctx, "delete network policy failed").SetSubError(coreErr)
+	}
+	ds.snapshot.DeleteNetworkPolicy(tenantID, name)
+	if npEntity != nil {
+		notifyWebhooks(ctx, ds.storage, policyWebhookEvent(entity.WebhookKindNetworkPolicy, entity.WebhookActionDelete, npEntity))
+	}
+	if npEntity != nil && npEntity.Spec.Staged {
+		if coreErr = ds.storage.DeleteStagedPolicyReports(ctx, npEntity.UUID); coreErr != nil {
+			slog.Warn("delete staged policy reports failed", "policy_uuid", npEntity.UUID, "err", coreErr)
+		}
+	}
+	return nil
+}
+
+func (ds *networkPolicy) Validate(ctx context.Context, input *model.CreateGlobalNetworkPolicyInput) (*model.ValidateGlobalNetworkPolicyOutput, *ierror.Error) {
+	if input.Metadata.TenantID == 0 {
+		input.Metadata.TenantID = entity.DefaultTenantID
+	}
+	npEntity := createModelToPolicyEntity(input)
+	if ierr := checkTierExists(ctx, ds.storage, npEntity.Spec.Tier); ierr != nil {
+		return nil, ierr
+	}
+	if ierr := checkNetworkPolicyQuota(ctx, ds.storage, npEntity); ierr != nil {
+		return nil, ierr
+	}
+
+	npEntityExisted, coreErr := ds.storage.GetNetworkPolicy(ctx, input.Metadata.TenantID, input.Metadata.Name)
+	if coreErr != nil {
+		if !errors.Is(coreErr, errlist.ErrNotFoundNetworkPolicy) {
+			return nil, httpbase.ErrDatabase(ctx, "get network policy failed").SetSubError(coreErr)
+		}
+	}
+	admissionVerdicts, ierr := reviewAdmissions(ctx, ds.storage, &model.AdmissionReviewInput{
+		Kind:      entity.WebhookKindNetworkPolicy,
+		Operation: entity.AdmissionOperationCreate,
+		DryRun:    true,
+		Object:    npEntity,
+		OldObject: npEntityExisted,
+	})
+	if ierr != nil {
+		return nil, ierr
+	}
+
+	heps, coreErr := ds.storage.ListHostEndpoints(ctx, &model.ListHostEndpointsInput{TenantID: &input.Metadata.TenantID})
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list host endpoint failed").SetSubError(coreErr)
+	}
+	gnss, coreErr := ds.storage.ListGNSs(ctx)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list global network sets failed").SetSubError(coreErr)
+	}
+	nss, coreErr := ds.storage.ListNetworkSets(ctx, input.Metadata.TenantID)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list network sets failed").SetSubError(coreErr)
+	}
+
+	lintFindings, ierr := lintResult(ctx, ds.linter.LintPolicy(npEntity))
+	if ierr != nil {
+		return nil, ierr
+	}
+
+	sel, errParse := selector.Parse(npEntity.Spec.Selector)
+	if errParse != nil {
+		return nil, httpbase.ErrBadRequest(ctx, "malformed selector").
+			SetSubError(errlist.ErrMalformedSelector.WithChild(errParse))
+	}
+	var parsedHEPs []*model.ParsedHEP
+	for _, hepEntity := range heps {
+		if !sel.EvaluateLabels(hepLabels(hepEntity)) {
+			continue
+		}
+		parsedHEPs = append(parsedHEPs, &model.ParsedHEP{
+			Name:     hepEntity.Metadata.Name,
+			TenantID: hepEntity.Spec.TenantID,
+			IP:       net.IntToIP(hepEntity.Spec.IP).String(),
+		})
+	}
+
+	return &model.ValidateGlobalNetworkPolicyOutput{
+		GNP:                npEntity,
+		GNPExisted:         npEntityExisted,
+		ParsedHEPs:         parsedHEPs,
+		UnmatchedSelectors: unmatchedRuleSelectors(npEntity, heps, append(gnss, nss...)),
+		LintFindings:       lintFindings,
+		AdmissionVerdicts:  admissionVerdicts,
+	}, nil
+}
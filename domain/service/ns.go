@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bamboo-firewall/be"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	"github.com/bamboo-firewall/be/pkg/repository"
+	"github.com/bamboo-firewall/be/pkg/selector"
+	"github.com/bamboo-firewall/be/pkg/validator"
+)
+
+func NewNetworkSet(policyMongo *repository.PolicyDB, snapshot *PolicySnapshot, linter *validator.Linter) *networkSet {
+	return &networkSet{
+		storage:  policyMongo,
+		snapshot: snapshot,
+		linter:   linter,
+	}
+}
+
+// networkSet manages the network sets, which are handled as global network sets belonging to a tenant.
+type networkSet struct {
+	storage  be.Storage
+	snapshot *PolicySnapshot
+	linter   *validator.Linter
+}
+
+func (ds *networkSet) Create(ctx context.Context, input *model.CreateGlobalNetworkSetInput) (*entity.GlobalNetworkSet, *ierror.Error) {
+	nsEntity, ierr := createModelToNetworkSetEntity(ctx, input)
+	if ierr != nil {
+		return nil, ierr
+	}
+	if ierr = checkTenantExists(ctx, ds.storage, nsEntity.Metadata.TenantID); ierr != nil {
+		return nil, ierr
+	}
+	nsExisted, coreErr := ds.storage.GetNetworkSet(ctx, nsEntity.Metadata.TenantID, nsEntity.Metadata.Name)
+	if coreErr != nil && !errors.Is(coreErr, errlist.ErrNotFoundNetworkSet) {
+		return nil, httpbase.ErrDatabase(ctx, "get network set failed").SetSubError(coreErr)
+	}
+	if ierr := admit(ctx, ds.storage, &model.AdmissionReviewInput{
+		Kind:      entity.WebhookKindNetworkSet,
+		Operation: entity.AdmissionOperationCreate,
+		Object:    nsEntity,
+		OldObject: nsExisted,
+	}); ierr != nil {
+		return nil, ierr
+	}
+
+	if coreErr = ds.storage.UpsertNetworkSet(ctx, nsEntity); coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrDuplicateNetworkSet) {
+			return nil, httpbase.ErrBadRequest(ctx, "duplicate network set").SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "create network set failed").SetSubError(coreErr)
+	}
+	ds.snapshot.UpsertGNS(nsEntity)
+	notifyWebhooks(ctx, ds.storage, setWebhookEvent(entity.WebhookKindNetworkSet, upsertAction(nsEntity.Version), nsEntity))
+	return nsEntity, nil
+}
+
+func (ds *networkSet) Get(ctx context.Context, tenantID uint64, name string) (*entity.GlobalNetworkSet, *ierror.Error) {
+	nsEntity, coreErr := ds.storage.GetNetworkSet(ctx, tenantID, name)
+	if coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrNotFoundNetworkSet) {
+			return nil, httpbase.ErrNotFound(ctx, "not found").SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "get network set failed").SetSubError(coreErr)
+	}
+	return nsEntity, nil
+}
+
+func (ds *networkSet) List(ctx context.Context, tenantID uint64) ([]*entity.GlobalNetworkSet, *ierror.Error) {
+	nssEntity, coreErr := ds.storage.ListNetworkSets(ctx, tenantID)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list network sets failed").SetSubError(coreErr)
+	}
+	return nssEntity, nil
+}
+
+func (ds *networkSet) Delete(ctx context.Context, tenantID uint64, name string, force bool) *ierror.Error {
+	if tenantID == 0 {
+		tenantID = entity.DefaultTenantID
+	}
+	nsEntity, coreErr := ds.storage.GetNetworkSet(ctx, tenantID, name)
+	if coreErr != nil && !errors.Is(coreErr, errlist.ErrNotFoundNetworkSet) {
+		return httpbase.ErrDatabase(ctx, "get network set failed").SetSubError(coreErr)
+	}
+	if nsEntity != nil {
+		if ierr := admit(ctx, ds.storage, &model.AdmissionReviewInput{
+			Kind:      entity.WebhookKindNetworkSet,
+			Operation: entity.AdmissionOperationDelete,
+			OldObject: nsEntity,
+		}); ierr != nil {
+			return ierr
+		}
+	}
+	if !force && nsEntity != nil {
+		if ierr := checkOrphanedReferences(ctx, ds.storage, nsEntity.UUID, nsEntity.Metadata.Labels, tenantID, false); ierr != nil {
+			return ierr
+		}
+	}
+
+	if coreErr = ds.storage.DeleteNetworkSet(ctx, tenantID, name); coreErr != nil {
+		return httpbase.ErrDatabase(ctx, "delete network set failed").SetSubError(coreErr)
+	}
+	ds.snapshot.DeleteNetworkSet(tenantID, name)
+	if nsEntity != nil {
+		notifyWebhooks(ctx, ds.storage, setWebhookEvent(entity.WebhookKindNetworkSet, entity.WebhookActionDelete, nsEntity))
+	}
+	return nil
+}
+
+func (ds *networkSet) Validate(ctx context.Context, input *model.CreateGlobalNetworkSetInput) (*model.ValidateGlobalNetworkSetOutput, *ierror.Error) {
+	nsEntity, ierr := createModelToNetworkSetEntity(ctx, input)
+	if ierr != nil {
+		return nil, ierr
+	}
+	if ierr = checkTenantExists(ctx, ds.storage, nsEntity.Metadata.TenantID); ierr != nil {
+		return nil, ierr
+	}
+
+	nsExisted, coreErr := ds.storage.GetNetworkSet(ctx, nsEntity.Metadata.TenantID, nsEntity.Metadata.Name)
+	if coreErr != nil {
+		if !errors.Is(coreErr, errlist.ErrNotFoundNetworkSet) {
+			return nil, httpbase.ErrDatabase(ctx, "get network set failed").SetSubError(coreErr)
+		}
+	}
+	admissionVerdicts, ierr := reviewAdmissions(ctx, ds.storage, &model.AdmissionReviewInput{
+		Kind:      entity.WebhookKindNetworkSet,
+		Operation: entity.AdmissionOperationCreate,
+		DryRun:    true,
+		Object:    nsEntity,
+		OldObject: nsExisted,
+	})
+	if ierr != nil {
+		return nil, ierr
+	}
+
+	// a network set is only selected by the network policies of its tenant
+	nps, coreErr := ds.storage.ListNetworkPolicies(ctx, nsEntity.Metadata.TenantID)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list network policies failed").SetSubError(coreErr)
+	}
+	lintFindings, ierr := lintResult(ctx, ds.linter.LintSet(nsEntity, nps))
+	if ierr != nil {
+		return nil, ierr
+	}
+
+	return &model.ValidateGlobalNetworkSetOutput{
+		GNS:               nsEntity,
+		GNSExisted:        nsExisted,
+		Warnings:          validator.NetOverlapWarnings(input.Spec.Nets),
+		LintFindings:      lintFindings,
+		AdmissionVerdicts: admissionVerdicts,
+	}, nil
+}
+
+// createModelToNetworkSetEntity returns the network set of input, which defaults to the default tenant. Network sets
+// only hold static nets, a source or allowed egress domains are refused, and can not set the tenant pseudo-label.
+func createModelToNetworkSetEntity(ctx context.Context, input *model.CreateGlobalNetworkSetInput) (*entity.GlobalNetworkSet, *ierror.Error) {
+	if input.Metadata.TenantID == 0 {
+		input.Metadata.TenantID = entity.DefaultTenantID
+	}
+	if _, ok := input.Metadata.Labels[selector.TenantLabel]; ok {
+		return nil, httpbase.ErrBadRequest(ctx, fmt.Sprintf("label %q is reserved", selector.TenantLabel))
+	}
+	if input.Spec.Source != nil || len(input.Spec.AllowedEgressDomains) > 0 {
+		return nil, httpbase.ErrBadRequest(ctx, "source/allowedEgressDomains are not supported on tenant network sets")
+	}
+	return createModelToGNSEntity(input), nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/selector"
+)
+
+func TestCreateModelToNetworkSetEntity(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       model.GNSSpecInput
+		labels     map[string]string
+		wantStatus int
+	}{
+		{name: "nets", spec: model.GNSSpecInput{Nets: []string{"192.168.0.0/24"}}},
+		{
+			name:       "source",
+			spec:       model.GNSSpecInput{Source: &model.GNSSourceInput{URL: "https://example.com/nets.txt"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "allowed egress domains",
+			spec:       model.GNSSpecInput{AllowedEgressDomains: []string{"api.example.com"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "tenant label",
+			spec:       model.GNSSpecInput{Nets: []string{"192.168.0.0/24"}},
+			labels:     map[string]string{selector.TenantLabel: "3"},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nsEntity, ierr := createModelToNetworkSetEntity(context.Background(), &model.CreateGlobalNetworkSetInput{
+				Metadata: model.GNSMetadataInput{Name: "lb", Labels: tt.labels},
+				Spec:     tt.spec,
+			})
+			if tt.wantStatus != 0 {
+				if ierr == nil || ierr.HTTPStatusCode != tt.wantStatus {
+					t.Fatalf("createModelToNetworkSetEntity() error = %v, want status %d", ierr, tt.wantStatus)
+				}
+				return
+			}
+			if ierr != nil {
+				t.Fatalf("createModelToNetworkSetEntity() error = %v", ierr)
+			}
+			if nsEntity.Metadata.TenantID != entity.DefaultTenantID {
+				t.Errorf("tenant = %d, want the default tenant %d", nsEntity.Metadata.TenantID, entity.DefaultTenantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,776 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bamboo-firewall/be"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/repository"
+	"github.com/bamboo-firewall/be/pkg/selector"
+)
+
+type hepKey struct {
+	tenantID uint64
+	ip       uint32
+}
+
+func keyOfHEP(hep *entity.HostEndpoint) hepKey {
+	return hepKey{tenantID: hep.Spec.TenantID, ip: hep.Spec.IP}
+}
+
+// gnpSelectors caches the parsed selectors of a policy. spec is nil when the policy selector is malformed.
+type gnpSelectors struct {
+	spec  selector.Selector
+	rules []selector.Selector
+}
+
+func (s *gnpSelectors) rulesMatchAny(labelSets ...selector.Labels) bool {
+	for _, sel := range s.rules {
+		for _, labels := range labelSets {
+			if labels != nil && sel.EvaluateLabels(labels) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PolicySnapshot keeps a materialized HostEndpointPolicy per host endpoint. A change to a host endpoint, a set or
+// a policy only recomputes the host endpoints depending on it, so fetching policies does not depend on the size
+// of the policy set. Network policies and network sets are kept along with the global ones.
+type PolicySnapshot struct {
+	storage be.Storage
+
+	mu       sync.RWMutex
+	revision uint64
+	heps     map[hepKey]*entity.HostEndpoint
+	gnps     map[string]*entity.GlobalNetworkPolicy
+	gnss     map[string]*entity.GlobalNetworkSet
+	// tiers by name
+	tiers map[string]*entity.Tier
+	// gnpSelectors by policy uuid
+	gnpSelectors map[string]*gnpSelectors
+	// policies by host endpoint
+	policies map[hepKey]*model.HostEndpointPolicy
+	// gnpHEPs is the host endpoints a policy uuid is applied to
+	gnpHEPs map[string]map[hepKey]struct{}
+	// scheduleStates by uuid of the policies having a schedule, see scheduleState
+	scheduleStates map[string]string
+	// touched is the revision of the latest change applied by Upsert* and Delete* to a resource, by snapshotKey.
+	// Load does not merge the resources changed after it started reading storage, its read is older than them.
+	touched map[string]uint64
+
+	// sorted views used to compute a host endpoint policy
+	sortedHEPs []*entity.HostEndpoint
+	sortedGNPs []*entity.GlobalNetworkPolicy
+	sortedGNSs []*entity.GlobalNetworkSet
+
+	// changed is closed then replaced on every change, see Changed
+	changed chan struct{}
+
+	subMu       sync.Mutex
+	subscribers map[chan *model.ScheduleEvent]struct{}
+}
+
+func NewPolicySnapshot(policyMongo *repository.PolicyDB) *PolicySnapshot {
+	return newPolicySnapshot(policyMongo)
+}
+
+func newPolicySnapshot(storage be.Storage) *PolicySnapshot {
+	return &PolicySnapshot{
+		storage:     storage,
+		touched:     make(map[string]uint64),
+		changed:     make(chan struct{}),
+		subscribers: make(map[chan *model.ScheduleEvent]struct{}),
+	}
+}
+
+// snapshotKey identifies a resource of the snapshot across Upsert*, Delete* and Load.
+func snapshotKey(kind, id string) string {
+	return kind + "/" + id
+}
+
+func hepSnapshotKey(key hepKey) string {
+	return snapshotKey(entity.WebhookKindHostEndpoint, fmt.Sprintf("%d/%d", key.tenantID, key.ip))
+}
+
+// Load builds the snapshot from storage the first time. Later loads merge the resources whose version differs from
+// storage, so that changes written by other instances are picked up, and only change the revision if one did.
+func (s *PolicySnapshot) Load(ctx context.Context) error {
+	start := s.Revision()
+	heps, coreErr := s.storage.ListHostEndpoints(ctx, nil)
+	if coreErr != nil {
+		return coreErr
+	}
+	gnps, coreErr := s.storage.ListGNPs(ctx, nil)
+	if coreErr != nil {
+		return coreErr
+	}
+	nps, coreErr := s.storage.ListNetworkPolicies(ctx, 0)
+	if coreErr != nil {
+		return coreErr
+	}
+	gnps = append(gnps, nps...)
+	gnss, coreErr := s.storage.ListGNSs(ctx)
+	if coreErr != nil {
+		return coreErr
+	}
+	nss, coreErr := s.storage.ListNetworkSets(ctx, 0)
+	if coreErr != nil {
+		return coreErr
+	}
+	gnss = append(gnss, nss...)
+	tiers, coreErr := s.storage.ListTiers(ctx)
+	if coreErr != nil {
+		return coreErr
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.heps == nil {
+		s.build(heps, gnps, gnss, tiers)
+		return nil
+	}
+	s.merge(start, heps, gnps, gnss, tiers)
+	return nil
+}
+
+// build replaces the whole snapshot.
+func (s *PolicySnapshot) build(heps []*entity.HostEndpoint, gnps []*entity.GlobalNetworkPolicy,
+	gnss []*entity.GlobalNetworkSet, tiers []*entity.Tier) {
+	s.heps = make(map[hepKey]*entity.HostEndpoint, len(heps))
+	for _, hep := range heps {
+		s.heps[keyOfHEP(hep)] = hep
+	}
+	s.gnps = make(map[string]*entity.GlobalNetworkPolicy, len(gnps))
+	s.gnpSelectors = make(map[string]*gnpSelectors, len(gnps))
+	for _, gnp := range gnps {
+		s.gnps[gnp.UUID] = gnp
+		s.gnpSelectors[gnp.UUID] = parseGNPSelectors(gnp)
+	}
+	s.gnss = make(map[string]*entity.GlobalNetworkSet, len(gnss))
+	for _, gns := range gnss {
+		s.gnss[gns.UUID] = gns
+	}
+	s.tiers = make(map[string]*entity.Tier, len(tiers))
+	for _, tier := range tiers {
+		s.tiers[tier.Metadata.Name] = tier
+	}
+	s.refreshSortedHEPs()
+	s.refreshSortedGNPs()
+	s.refreshSortedGNSs()
+
+	now := time.Now()
+	s.scheduleStates = make(map[string]string)
+	for _, gnp := range gnps {
+		s.refreshScheduleState(gnp, now)
+	}
+
+	s.policies = make(map[hepKey]*model.HostEndpointPolicy, len(heps))
+	s.gnpHEPs = make(map[string]map[hepKey]struct{}, len(gnps))
+	s.revision++
+	for key := range s.heps {
+		s.recompute(key, now)
+	}
+	s.notifyChanged()
+	slog.Info("policy snapshot loaded", "revision", s.revision, "heps", len(heps), "gnps", len(gnps), "gnss", len(gnss), "tiers", len(tiers))
+}
+
+// merge applies the resources read from storage whose version differs from the snapshot, and deletes the ones
+// storage no longer has. Resources changed since start, the revision at which the read began, are left as they are.
+func (s *PolicySnapshot) merge(start uint64, heps []*entity.HostEndpoint, gnps []*entity.GlobalNetworkPolicy,
+	gnss []*entity.GlobalNetworkSet, tiers []*entity.Tier) {
+	var changed int
+	affected := make(map[hepKey]struct{})
+	var merge = func(keys map[hepKey]struct{}) {
+		changed++
+		for key := range keys {
+			affected[key] = struct{}{}
+		}
+	}
+	var stale = func(key string) bool {
+		return s.touched[key] <= start
+	}
+
+	loadedTiers := make(map[string]struct{}, len(tiers))
+	for _, tier := range tiers {
+		loadedTiers[tier.Metadata.Name] = struct{}{}
+		if old, ok := s.tiers[tier.Metadata.Name]; ok && old.UUID == tier.UUID && old.Version == tier.Version {
+			continue
+		}
+		if stale(snapshotKey(entity.WebhookKindTier, tier.Metadata.Name)) {
+			merge(s.upsertTier(tier))
+		}
+	}
+	for name := range s.tiers {
+		if _, ok := loadedTiers[name]; !ok && stale(snapshotKey(entity.WebhookKindTier, name)) {
+			merge(s.deleteTier(name))
+		}
+	}
+
+	loadedHEPs := make(map[hepKey]struct{}, len(heps))
+	for _, hep := range heps {
+		key := keyOfHEP(hep)
+		loadedHEPs[key] = struct{}{}
+		if old, ok := s.heps[key]; ok && old.UUID == hep.UUID && old.Version == hep.Version {
+			continue
+		}
+		if stale(hepSnapshotKey(key)) {
+			merge(s.upsertHEP(hep))
+		}
+	}
+	for key := range s.heps {
+		if _, ok := loadedHEPs[key]; !ok && stale(hepSnapshotKey(key)) {
+			merge(s.deleteHEP(key))
+		}
+	}
+
+	loadedGNPs := make(map[string]struct{}, len(gnps))
+	for _, gnp := range gnps {
+		loadedGNPs[gnp.UUID] = struct{}{}
+		if old, ok := s.gnps[gnp.UUID]; ok && old.Version == gnp.Version {
+			continue
+		}
+		if stale(snapshotKey(entity.WebhookKindGlobalNetworkPolicy, gnp.UUID)) {
+			merge(s.upsertGNP(gnp))
+		}
+	}
+	for uuid := range s.gnps {
+		if _, ok := loadedGNPs[uuid]; !ok && stale(snapshotKey(entity.WebhookKindGlobalNetworkPolicy, uuid)) {
+			merge(s.deleteGNPByUUID(uuid))
+		}
+	}
+
+	loadedGNSs := make(map[string]struct{}, len(gnss))
+	for _, gns := range gnss {
+		loadedGNSs[gns.UUID] = struct{}{}
+		if old, ok := s.gnss[gns.UUID]; ok && old.Version == gns.Version {
+			continue
+		}
+		if stale(snapshotKey(entity.WebhookKindGlobalNetworkSet, gns.UUID)) {
+			merge(s.upsertGNS(gns))
+		}
+	}
+	for uuid := range s.gnss {
+		if _, ok := loadedGNSs[uuid]; !ok && stale(snapshotKey(entity.WebhookKindGlobalNetworkSet, uuid)) {
+			merge(s.deleteGNSByUUID(uuid))
+		}
+	}
+
+	for key, revision := range s.touched {
+		if revision <= start {
+			delete(s.touched, key)
+		}
+	}
+	if changed == 0 {
+		return
+	}
+	s.refreshSortedHEPs()
+	s.refreshSortedGNPs()
+	s.refreshSortedGNSs()
+	s.apply(affected)
+	slog.Info("policy snapshot merged", "revision", s.revision, "changed", changed, "recomputed_heps", len(affected))
+}
+
+// Run reloads the snapshot every interval until ctx is done, so changes written by other instances are picked up.
+func (s *PolicySnapshot) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		slog.Warn("policy snapshot resync is disabled, changes written by other instances are not picked up")
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Load(ctx); err != nil {
+				slog.Warn("reload policy snapshot failed", "err", err)
+			}
+		}
+	}
+}
+
+// Revision returns the revision of the latest change applied to the snapshot.
+func (s *PolicySnapshot) Revision() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.revision
+}
+
+// Get returns the policy of the host endpoint identified by tenantID and ip.
+func (s *PolicySnapshot) Get(tenantID uint64, ip uint32) (*model.HostEndpointPolicy, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	hepPolicy, ok := s.policies[hepKey{tenantID: tenantID, ip: ip}]
+	return hepPolicy, ok
+}
+
+// List returns the policies of all host endpoints.
+func (s *PolicySnapshot) List() []*model.HostEndpointPolicy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	hepPolicies := make([]*model.HostEndpointPolicy, 0, len(s.sortedHEPs))
+	for _, hep := range s.sortedHEPs {
+		if hepPolicy, ok := s.policies[keyOfHEP(hep)]; ok {
+			hepPolicies = append(hepPolicies, hepPolicy)
+		}
+	}
+	return hepPolicies
+}
+
+// Changed returns a channel closed by the next change applied to the snapshot. Callers get the channel before
+// reading the snapshot so that no change is missed.
+func (s *PolicySnapshot) Changed() <-chan struct{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.changed
+}
+
+func (s *PolicySnapshot) notifyChanged() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// GetGNP returns the policy identified by uuid.
+func (s *PolicySnapshot) GetGNP(uuid string) (*entity.GlobalNetworkPolicy, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	gnp, ok := s.gnps[uuid]
+	return gnp, ok
+}
+
+func (s *PolicySnapshot) UpsertHEP(hep *entity.HostEndpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	affected := s.upsertHEP(hep)
+	s.refreshSortedHEPs()
+	s.apply(affected)
+	s.touched[hepSnapshotKey(keyOfHEP(hep))] = s.revision
+}
+
+func (s *PolicySnapshot) upsertHEP(hep *entity.HostEndpoint) map[hepKey]struct{} {
+	key := keyOfHEP(hep)
+	labelSets := []selector.Labels{hepLabels(hep)}
+	if old, ok := s.heps[key]; ok {
+		labelSets = append(labelSets, hepLabels(old))
+	}
+	s.heps[key] = hep
+
+	affected := s.hepsOfGNPsWithRulesMatching(labelSets...)
+	affected[key] = struct{}{}
+	return affected
+}
+
+func (s *PolicySnapshot) DeleteHEP(tenantID uint64, ip uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := hepKey{tenantID: tenantID, ip: ip}
+	if _, ok := s.heps[key]; !ok {
+		return
+	}
+	affected := s.deleteHEP(key)
+	s.refreshSortedHEPs()
+	s.apply(affected)
+	s.touched[hepSnapshotKey(key)] = s.revision
+}
+
+func (s *PolicySnapshot) deleteHEP(key hepKey) map[hepKey]struct{} {
+	old := s.heps[key]
+	delete(s.heps, key)
+
+	affected := s.hepsOfGNPsWithRulesMatching(hepLabels(old))
+	affected[key] = struct{}{}
+	return affected
+}
+
+func (s *PolicySnapshot) UpsertGNP(gnp *entity.GlobalNetworkPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	affected := s.upsertGNP(gnp)
+	s.refreshSortedGNPs()
+	s.apply(affected)
+	s.touched[snapshotKey(entity.WebhookKindGlobalNetworkPolicy, gnp.UUID)] = s.revision
+}
+
+func (s *PolicySnapshot) upsertGNP(gnp *entity.GlobalNetworkPolicy) map[hepKey]struct{} {
+	affected := make(map[hepKey]struct{})
+	for key := range s.gnpHEPs[gnp.UUID] {
+		affected[key] = struct{}{}
+	}
+	s.gnps[gnp.UUID] = gnp
+	sels := parseGNPSelectors(gnp)
+	s.gnpSelectors[gnp.UUID] = sels
+	s.refreshScheduleState(gnp, time.Now())
+
+	if sels.spec != nil {
+		for key, hep := range s.heps {
+			if appliesToTenant(gnp, hep.Spec.TenantID) && sels.spec.EvaluateLabels(hepLabels(hep)) {
+				affected[key] = struct{}{}
+			}
+		}
+	}
+	return affected
+}
+
+// DeleteGNP deletes the global network policy name.
+func (s *PolicySnapshot) DeleteGNP(name string) {
+	s.deleteGNP(0, name)
+}
+
+// DeleteNetworkPolicy deletes the network policy name of tenantID.
+func (s *PolicySnapshot) DeleteNetworkPolicy(tenantID uint64, name string) {
+	s.deleteGNP(tenantID, name)
+}
+
+func (s *PolicySnapshot) deleteGNP(tenantID uint64, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for uuid, gnp := range s.gnps {
+		if gnp.Metadata.TenantID != tenantID || gnp.Metadata.Name != name {
+			continue
+		}
+		affected := s.deleteGNPByUUID(uuid)
+		s.refreshSortedGNPs()
+		s.apply(affected)
+		s.touched[snapshotKey(entity.WebhookKindGlobalNetworkPolicy, uuid)] = s.revision
+		return
+	}
+}
+
+func (s *PolicySnapshot) deleteGNPByUUID(uuid string) map[hepKey]struct{} {
+	affected := make(map[hepKey]struct{})
+	for key := range s.gnpHEPs[uuid] {
+		affected[key] = struct{}{}
+	}
+	delete(s.gnps, uuid)
+	delete(s.gnpSelectors, uuid)
+	delete(s.gnpHEPs, uuid)
+	delete(s.scheduleStates, uuid)
+	return affected
+}
+
+func (s *PolicySnapshot) UpsertGNS(gns *entity.GlobalNetworkSet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	affected := s.upsertGNS(gns)
+	s.refreshSortedGNSs()
+	s.apply(affected)
+	s.touched[snapshotKey(entity.WebhookKindGlobalNetworkSet, gns.UUID)] = s.revision
+}
+
+func (s *PolicySnapshot) upsertGNS(gns *entity.GlobalNetworkSet) map[hepKey]struct{} {
+	labelSets := []selector.Labels{gnsLabels(gns)}
+	if old, ok := s.gnss[gns.UUID]; ok {
+		labelSets = append(labelSets, gnsLabels(old))
+	}
+	s.gnss[gns.UUID] = gns
+	return s.hepsOfGNPsWithRulesMatching(labelSets...)
+}
+
+// DeleteGNS deletes the global network set name.
+func (s *PolicySnapshot) DeleteGNS(name string) {
+	s.deleteGNS(0, name)
+}
+
+// DeleteNetworkSet deletes the network set name of tenantID.
+func (s *PolicySnapshot) DeleteNetworkSet(tenantID uint64, name string) {
+	s.deleteGNS(tenantID, name)
+}
+
+func (s *PolicySnapshot) deleteGNS(tenantID uint64, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for uuid, gns := range s.gnss {
+		if gns.Metadata.TenantID != tenantID || gns.Metadata.Name != name {
+			continue
+		}
+		affected := s.deleteGNSByUUID(uuid)
+		s.refreshSortedGNSs()
+		s.apply(affected)
+		s.touched[snapshotKey(entity.WebhookKindGlobalNetworkSet, uuid)] = s.revision
+		return
+	}
+}
+
+func (s *PolicySnapshot) deleteGNSByUUID(uuid string) map[hepKey]struct{} {
+	gns := s.gnss[uuid]
+	delete(s.gnss, uuid)
+	return s.hepsOfGNPsWithRulesMatching(gnsLabels(gns))
+}
+
+func (s *PolicySnapshot) UpsertTier(tier *entity.Tier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	affected := s.upsertTier(tier)
+	s.refreshSortedGNPs()
+	s.apply(affected)
+	s.touched[snapshotKey(entity.WebhookKindTier, tier.Metadata.Name)] = s.revision
+}
+
+func (s *PolicySnapshot) upsertTier(tier *entity.Tier) map[hepKey]struct{} {
+	s.tiers[tier.Metadata.Name] = tier
+	return s.hepsOfTier(tier.Metadata.Name)
+}
+
+func (s *PolicySnapshot) DeleteTier(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tiers[name]; !ok {
+		return
+	}
+	affected := s.deleteTier(name)
+	s.refreshSortedGNPs()
+	s.apply(affected)
+	s.touched[snapshotKey(entity.WebhookKindTier, name)] = s.revision
+}
+
+func (s *PolicySnapshot) deleteTier(name string) map[hepKey]struct{} {
+	delete(s.tiers, name)
+	return s.hepsOfTier(name)
+}
+
+// hepsOfTier returns the host endpoints applying a policy of the tier.
+func (s *PolicySnapshot) hepsOfTier(name string) map[hepKey]struct{} {
+	affected := make(map[hepKey]struct{})
+	for uuid, gnp := range s.gnps {
+		if gnp.TierName() != name {
+			continue
+		}
+		for key := range s.gnpHEPs[uuid] {
+			affected[key] = struct{}{}
+		}
+	}
+	return affected
+}
+
+// hepsOfGNPsWithRulesMatching returns the host endpoints applying a policy which has a rule selector matching
+// any of labelSets.
+func (s *PolicySnapshot) hepsOfGNPsWithRulesMatching(labelSets ...selector.Labels) map[hepKey]struct{} {
+	affected := make(map[hepKey]struct{})
+	for uuid, sels := range s.gnpSelectors {
+		if !sels.rulesMatchAny(labelSets...) {
+			continue
+		}
+		for key := range s.gnpHEPs[uuid] {
+			affected[key] = struct{}{}
+		}
+	}
+	return affected
+}
+
+// apply bumps the revision and recomputes the affected host endpoints.
+func (s *PolicySnapshot) apply(affected map[hepKey]struct{}) {
+	s.applyAt(affected, time.Now())
+}
+
+// applyAt is apply evaluating schedules at now.
+func (s *PolicySnapshot) applyAt(affected map[hepKey]struct{}, now time.Time) {
+	s.revision++
+	for key := range affected {
+		s.recompute(key, now)
+	}
+	s.notifyChanged()
+	slog.Debug("policy snapshot changed", "revision", s.revision, "recomputed_heps", len(affected))
+}
+
+func (s *PolicySnapshot) recompute(key hepKey, now time.Time) {
+	if old, ok := s.policies[key]; ok {
+		for uuid := range old.MetaData.GNPVersions {
+			delete(s.gnpHEPs[uuid], key)
+		}
+	}
+
+	hep, ok := s.heps[key]
+	if !ok {
+		delete(s.policies, key)
+		return
+	}
+
+	hepPolicy := buildHostEndpointPolicy(hep, s.sortedGNPs, s.tiers, s.sortedHEPs, s.sortedGNSs, now)
+	hepPolicy.MetaData.Revision = s.revision
+	s.policies[key] = hepPolicy
+	for uuid := range hepPolicy.MetaData.GNPVersions {
+		if s.gnpHEPs[uuid] == nil {
+			s.gnpHEPs[uuid] = make(map[hepKey]struct{})
+		}
+		s.gnpHEPs[uuid][key] = struct{}{}
+	}
+}
+
+func (s *PolicySnapshot) refreshSortedHEPs() {
+	s.sortedHEPs = make([]*entity.HostEndpoint, 0, len(s.heps))
+	for _, hep := range s.heps {
+		s.sortedHEPs = append(s.sortedHEPs, hep)
+	}
+	sort.Slice(s.sortedHEPs, func(i, j int) bool {
+		return s.sortedHEPs[i].ID.Hex() < s.sortedHEPs[j].ID.Hex()
+	})
+}
+
+func (s *PolicySnapshot) refreshSortedGNPs() {
+	s.sortedGNPs = make([]*entity.GlobalNetworkPolicy, 0, len(s.gnps))
+	for _, gnp := range s.gnps {
+		s.sortedGNPs = append(s.sortedGNPs, gnp)
+	}
+	sortGNPsByTier(s.sortedGNPs, s.tiers)
+}
+
+func (s *PolicySnapshot) refreshSortedGNSs() {
+	s.sortedGNSs = make([]*entity.GlobalNetworkSet, 0, len(s.gnss))
+	for _, gns := range s.gnss {
+		s.sortedGNSs = append(s.sortedGNSs, gns)
+	}
+	sort.Slice(s.sortedGNSs, func(i, j int) bool {
+		return s.sortedGNSs[i].ID.Hex() < s.sortedGNSs[j].ID.Hex()
+	})
+}
+
+func parseGNPSelectors(gnp *entity.GlobalNetworkPolicy) *gnpSelectors {
+	sels := new(gnpSelectors)
+	sel, err := selector.Parse(gnp.Spec.Selector)
+	if err != nil {
+		slog.Warn("malformed selector", "policy_uuid", gnp.UUID, "selector", gnp.Spec.Selector, "err", err)
+	} else {
+		sels.spec = sel
+	}
+
+	var addRuleSelector = func(ruleEntity *entity.GNPSpecRuleEntity) {
+		if ruleEntity == nil || len(ruleEntity.Selector) == 0 {
+			return
+		}
+		ruleSel, errRule := selector.Parse(ruleEntity.Selector)
+		if errRule != nil {
+			return
+		}
+		sels.rules = append(sels.rules, ruleSel)
+	}
+	for _, rule := range gnp.Spec.Ingress {
+		addRuleSelector(rule.Source)
+		addRuleSelector(rule.Destination)
+	}
+	for _, rule := range gnp.Spec.Egress {
+		addRuleSelector(rule.Source)
+		addRuleSelector(rule.Destination)
+	}
+	return sels
+}
+
+func (s *PolicySnapshot) refreshScheduleState(gnp *entity.GlobalNetworkPolicy, now time.Time) {
+	if !hasSchedule(gnp) {
+		delete(s.scheduleStates, gnp.UUID)
+		return
+	}
+	s.scheduleStates[gnp.UUID] = scheduleState(gnp, now)
+}
+
+// RunScheduler recomputes the host endpoints of a policy whenever a schedule of the policy or of one of its rules
+// opens or closes, then notifies subscribers. Schedules have a minute precision so it checks every minute.
+func (s *PolicySnapshot) RunScheduler(ctx context.Context) {
+	for {
+		now := time.Now()
+		timer := time.NewTimer(now.Truncate(time.Minute).Add(time.Minute).Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case tick := <-timer.C:
+			for _, event := range s.checkSchedules(tick) {
+				s.publish(event)
+			}
+		}
+	}
+}
+
+func (s *PolicySnapshot) checkSchedules(now time.Time) []*model.ScheduleEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changedGNPs []*entity.GlobalNetworkPolicy
+	affected := make(map[hepKey]struct{})
+	for uuid, state := range s.scheduleStates {
+		gnp := s.gnps[uuid]
+		newState := scheduleState(gnp, now)
+		if newState == state {
+			continue
+		}
+		s.scheduleStates[uuid] = newState
+		changedGNPs = append(changedGNPs, gnp)
+
+		for key := range s.gnpHEPs[uuid] {
+			affected[key] = struct{}{}
+		}
+		if sels := s.gnpSelectors[uuid]; sels.spec != nil {
+			for key, hep := range s.heps {
+				if appliesToTenant(gnp, hep.Spec.TenantID) && sels.spec.EvaluateLabels(hepLabels(hep)) {
+					affected[key] = struct{}{}
+				}
+			}
+		}
+	}
+	if len(changedGNPs) == 0 {
+		return nil
+	}
+	s.applyAt(affected, now)
+
+	events := make([]*model.ScheduleEvent, 0, len(changedGNPs))
+	for _, gnp := range changedGNPs {
+		slog.Info("policy schedule changed", "policy_uuid", gnp.UUID, "policy_name", gnp.Metadata.Name,
+			"state", s.scheduleStates[gnp.UUID], "revision", s.revision)
+		events = append(events, &model.ScheduleEvent{
+			GNPUUID:  gnp.UUID,
+			GNPName:  gnp.Metadata.Name,
+			Active:   isScheduleActive(gnp.Spec.Schedule, now),
+			Revision: s.revision,
+			At:       now,
+		})
+	}
+	return events
+}
+
+// Subscribe returns a channel receiving schedule events until unsubscribe is called. Events are dropped for a
+// subscriber which does not keep up.
+func (s *PolicySnapshot) Subscribe() (<-chan *model.ScheduleEvent, func()) {
+	ch := make(chan *model.ScheduleEvent, 16)
+	s.subMu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.subMu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.subMu.Lock()
+			delete(s.subscribers, ch)
+			s.subMu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+func (s *PolicySnapshot) publish(event *model.ScheduleEvent) {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+	for ch := range s.subscribers {
+		select {
+		case ch <- event:
+		default:
+			slog.Warn("drop schedule event of slow subscriber", "policy_uuid", event.GNPUUID)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+func testHEP(name string, ip uint32, labels map[string]string) *entity.HostEndpoint {
+	return &entity.HostEndpoint{
+		Metadata: entity.HostEndpointMetadata{Name: name, Labels: labels},
+		Spec:     entity.HostEndpointSpec{IP: ip, TenantID: entity.DefaultTenantID, IPs: []string{"10.0.0.1"}},
+	}
+}
+
+func testGNP(name, sel string, order uint32, sourceSelector string) *entity.GlobalNetworkPolicy {
+	gnp := &entity.GlobalNetworkPolicy{
+		Metadata: entity.GNPMetadata{Name: name},
+		Spec:     entity.GNPSpec{Order: order, Selector: sel},
+	}
+	gnp.Spec.Ingress = []entity.GNPSpecRule{{
+		Action: string(entity.RuleActionAllow),
+		Source: &entity.GNPSpecRuleEntity{Selector: sourceSelector},
+	}}
+	return gnp
+}
+
+func testGNS(name string, labels map[string]string, nets ...string) *entity.GlobalNetworkSet {
+	return &entity.GlobalNetworkSet{
+		Metadata: entity.GNSMetadata{Name: name, Labels: labels},
+		Spec:     entity.GNSSpec{Nets: nets, NetsV4: nets},
+	}
+}
+
+func loadedSnapshot(t *testing.T, storage *fakeStorage) *PolicySnapshot {
+	t.Helper()
+	snapshot := newPolicySnapshot(storage)
+	if err := snapshot.Load(context.Background()); err != nil {
+		t.Fatalf("load snapshot: %v", err)
+	}
+	return snapshot
+}
+
+// snapshotPolicies returns the policies of snapshot without their revision, which depends on the changes applied.
+func snapshotPolicies(snapshot *PolicySnapshot) []*model.HostEndpointPolicy {
+	policies := snapshot.List()
+	for i, policy := range policies {
+		copied := *policy
+		copied.MetaData.Revision = 0
+		policies[i] = &copied
+	}
+	return policies
+}
+
+func TestPolicySnapshotIncrementalMatchesRebuild(t *testing.T) {
+	ctx := context.Background()
+	storage := newFakeStorage()
+	web := testHEP("web", 1, map[string]string{"app": "web"})
+	db := testHEP("db", 2, map[string]string{"app": "db"})
+	lb := testGNS("lb", map[string]string{"role": "lb"}, "192.168.0.0/24")
+	allowLB := testGNP("allow-lb", "app == 'web'", 10, "role == 'lb'")
+	allowWeb := testGNP("allow-web", "app == 'db'", 20, "app == 'web'")
+	storage.UpsertHostEndpoint(ctx, web)
+	storage.UpsertHostEndpoint(ctx, db)
+	storage.UpsertGNS(ctx, lb)
+	storage.UpsertGroupPolicy(ctx, allowLB)
+	storage.UpsertGroupPolicy(ctx, allowWeb)
+	snapshot := loadedSnapshot(t, storage)
+
+	steps := []struct {
+		name  string
+		apply func()
+	}{
+		{name: "add host endpoint matched by rule selector", apply: func() {
+			web2 := testHEP("web2", 3, map[string]string{"app": "web"})
+			storage.UpsertHostEndpoint(ctx, web2)
+			snapshot.UpsertHEP(web2)
+		}},
+		{name: "relabel host endpoint", apply: func() {
+			db2 := testHEP("db", 2, map[string]string{"app": "cache"})
+			storage.UpsertHostEndpoint(ctx, db2)
+			snapshot.UpsertHEP(db2)
+		}},
+		{name: "change set nets", apply: func() {
+			lb2 := testGNS("lb", map[string]string{"role": "lb"}, "192.168.1.0/24")
+			storage.UpsertGNS(ctx, lb2)
+			snapshot.UpsertGNS(lb2)
+		}},
+		{name: "select relabeled host endpoint", apply: func() {
+			allowWeb2 := testGNP("allow-web", "app == 'cache'", 20, "app == 'web'")
+			storage.UpsertGroupPolicy(ctx, allowWeb2)
+			snapshot.UpsertGNP(allowWeb2)
+		}},
+		{name: "delete host endpoint matched by rule selector", apply: func() {
+			storage.DeleteHostEndpoint(ctx, entity.DefaultTenantID, 1)
+			snapshot.DeleteHEP(entity.DefaultTenantID, 1)
+		}},
+		{name: "delete set", apply: func() {
+			storage.DeleteGNSByName(ctx, "lb")
+			snapshot.DeleteGNS("lb")
+		}},
+		{name: "delete policy", apply: func() {
+			storage.DeleteGNPByName(ctx, "allow-lb")
+			snapshot.DeleteGNP("allow-lb")
+		}},
+		{name: "add network policy of another tenant", apply: func() {
+			np := testGNP("np", "all()", 5, "app == 'web'")
+			np.Metadata.TenantID = 2
+			storage.UpsertNetworkPolicy(ctx, np)
+			snapshot.UpsertGNP(np)
+		}},
+	}
+	for _, step := range steps {
+		step.apply()
+		rebuilt := loadedSnapshot(t, storage)
+		if diff := cmp.Diff(snapshotPolicies(rebuilt), snapshotPolicies(snapshot)); diff != "" {
+			t.Fatalf("after %s, incremental snapshot differs from rebuild (-rebuild +incremental):\n%s", step.name, diff)
+		}
+	}
+}
+
+func TestPolicySnapshotLoadMerge(t *testing.T) {
+	ctx := context.Background()
+	storage := newFakeStorage()
+	storage.UpsertHostEndpoint(ctx, testHEP("web", 1, map[string]string{"app": "web"}))
+	storage.UpsertGroupPolicy(ctx, testGNP("allow", "app == 'web'", 10, "app == 'db'"))
+	snapshot := loadedSnapshot(t, storage)
+
+	revision := snapshot.Revision()
+	if err := snapshot.Load(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := snapshot.Revision(); got != revision {
+		t.Errorf("revision after unchanged load = %d, want %d", got, revision)
+	}
+
+	// written by another instance
+	storage.UpsertHostEndpoint(ctx, testHEP("db", 2, map[string]string{"app": "db"}))
+	if err := snapshot.Load(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Revision() == revision {
+		t.Error("revision not changed by a load picking up a new host endpoint")
+	}
+	if diff := cmp.Diff(snapshotPolicies(loadedSnapshot(t, storage)), snapshotPolicies(snapshot)); diff != "" {
+		t.Errorf("merged snapshot differs from rebuild (-rebuild +merged):\n%s", diff)
+	}
+
+	// written and applied locally while the load reads storage
+	cache := testHEP("cache", 3, map[string]string{"app": "db"})
+	storage.afterListTiers = func() {
+		storage.afterListTiers = nil
+		storage.UpsertHostEndpoint(ctx, cache)
+		snapshot.UpsertHEP(cache)
+	}
+	if err := snapshot.Load(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := snapshot.Get(entity.DefaultTenantID, 3); !ok {
+		t.Fatal("load reverted a host endpoint upserted while it read storage")
+	}
+	if diff := cmp.Diff(snapshotPolicies(loadedSnapshot(t, storage)), snapshotPolicies(snapshot)); diff != "" {
+		t.Errorf("snapshot differs from rebuild (-rebuild +snapshot):\n%s", diff)
+	}
+
+	// deleted locally while the load reads storage
+	storage.afterListTiers = func() {
+		storage.afterListTiers = nil
+		storage.DeleteHostEndpoint(ctx, entity.DefaultTenantID, 3)
+		snapshot.DeleteHEP(entity.DefaultTenantID, 3)
+	}
+	if err := snapshot.Load(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := snapshot.Get(entity.DefaultTenantID, 3); ok {
+		t.Fatal("load restored a host endpoint deleted while it read storage")
+	}
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bamboo-firewall/be"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	"github.com/bamboo-firewall/be/pkg/selector"
+)
+
+// selectorReference is a selector of a policy. The spec selector matches host endpoints, rule selectors match host
+// endpoints and network sets. Empty selectors do not refer to anything and are not listed.
+type selectorReference struct {
+	gnp      *entity.GlobalNetworkPolicy
+	gnpName  string
+	field    string
+	selector string
+	isRule   bool
+}
+
+func gnpSelectorReferences(gnp *entity.GlobalNetworkPolicy) []selectorReference {
+	var refs []selectorReference
+	if gnp.Spec.Selector != "" {
+		refs = append(refs, selectorReference{gnp: gnp, gnpName: gnp.Metadata.Name, field: "spec.selector", selector: gnp.Spec.Selector})
+	}
+	var addRules = func(rules []entity.GNPSpecRule, direction string) {
+		for i, rule := range rules {
+			if rule.Source != nil && rule.Source.Selector != "" {
+				refs = append(refs, selectorReference{
+					gnp:      gnp,
+					gnpName:  gnp.Metadata.Name,
+					field:    fmt.Sprintf("spec.%s[%d].source.selector", direction, i),
+					selector: rule.Source.Selector,
+					isRule:   true,
+				})
+			}
+			if rule.Destination != nil && rule.Destination.Selector != "" {
+				refs = append(refs, selectorReference{
+					gnp:      gnp,
+					gnpName:  gnp.Metadata.Name,
+					field:    fmt.Sprintf("spec.%s[%d].destination.selector", direction, i),
+					selector: rule.Destination.Selector,
+					isRule:   true,
+				})
+			}
+		}
+	}
+	addRules(gnp.Spec.Ingress, "ingress")
+	addRules(gnp.Spec.Egress, "egress")
+	return refs
+}
+
+// matchesAny reports whether sel matches a host endpoint, or a network set for a rule selector, in the scope of the
+// policy.
+func (ref selectorReference) matchesAny(sel selector.Selector, heps []*entity.HostEndpoint, gnss []*entity.GlobalNetworkSet) bool {
+	for _, hepEntity := range heps {
+		if appliesToTenant(ref.gnp, hepEntity.Spec.TenantID) && sel.EvaluateLabels(hepLabels(hepEntity)) {
+			return true
+		}
+	}
+	if !ref.isRule {
+		return false
+	}
+	for _, gnsEntity := range gnss {
+		if setInScope(ref.gnp, gnsEntity) && sel.EvaluateLabels(gnsLabels(gnsEntity)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ref selectorReference) toModel() *model.SelectorReference {
+	return &model.SelectorReference{
+		GNPName:  ref.gnpName,
+		Field:    ref.field,
+		Selector: ref.selector,
+	}
+}
+
+// unmatchedRuleSelectors returns the rule selectors of gnp which match no host endpoint and no global network set.
+// Such a rule never matches traffic as the empty set is used in place of the selector.
+func unmatchedRuleSelectors(gnp *entity.GlobalNetworkPolicy, heps []*entity.HostEndpoint, gnss []*entity.GlobalNetworkSet) []*model.SelectorReference {
+	var unmatched []*model.SelectorReference
+	for _, ref := range gnpSelectorReferences(gnp) {
+		if !ref.isRule {
+			continue
+		}
+		sel, err := selector.Parse(ref.selector)
+		if err != nil {
+			continue
+		}
+		if !ref.matchesAny(sel, heps, gnss) {
+			unmatched = append(unmatched, ref.toModel())
+		}
+	}
+	return unmatched
+}
+
+// orphanedReferences returns the selectors of gnps which match a resource of tenantID with labels and nothing among
+// the remaining heps and gnss, i.e. the selectors which lose their last match when the resource is deleted. tenantID
+// is zero for a global network set.
+func orphanedReferences(gnps []*entity.GlobalNetworkPolicy, labels map[string]string, tenantID uint64, isHEP bool,
+	heps []*entity.HostEndpoint, gnss []*entity.GlobalNetworkSet) []*model.SelectorReference {
+	var orphaned []*model.SelectorReference
+	for _, gnp := range gnps {
+		if isHEP && !appliesToTenant(gnp, tenantID) || !isHEP && tenantID != 0 && tenantID != gnp.Metadata.TenantID {
+			continue
+		}
+		for _, ref := range gnpSelectorReferences(gnp) {
+			if !isHEP && !ref.isRule {
+				continue
+			}
+			sel, err := selector.Parse(ref.selector)
+			if err != nil || !sel.EvaluateLabels(selector.WithTenant(labels, tenantID)) {
+				continue
+			}
+			if !ref.matchesAny(sel, heps, gnss) {
+				orphaned = append(orphaned, ref.toModel())
+			}
+		}
+	}
+	return orphaned
+}
+
+// checkOrphanedReferences returns a conflict error, detailing the selectors, if deleting the host endpoint or the
+// network set identified by uuid would make policy selectors lose their last match.
+func checkOrphanedReferences(ctx context.Context, storage be.Storage, uuid string, labels map[string]string, tenantID uint64,
+	isHEP bool) *ierror.Error {
+	gnps, coreErr := storage.ListGNPs(ctx, nil)
+	if coreErr != nil {
+		return httpbase.ErrDatabase(ctx, "list global network policies failed").SetSubError(coreErr)
+	}
+	nps, coreErr := storage.ListNetworkPolicies(ctx, 0)
+	if coreErr != nil {
+		return httpbase.ErrDatabase(ctx, "list network policies failed").SetSubError(coreErr)
+	}
+	gnps = append(gnps, nps...)
+	heps, coreErr := storage.ListHostEndpoints(ctx, nil)
+	if coreErr != nil {
+		return httpbase.ErrDatabase(ctx, "list host endpoint failed").SetSubError(coreErr)
+	}
+	gnss, coreErr := storage.ListGNSs(ctx)
+	if coreErr != nil {
+		return httpbase.ErrDatabase(ctx, "list global network sets failed").SetSubError(coreErr)
+	}
+	nss, coreErr := storage.ListNetworkSets(ctx, 0)
+	if coreErr != nil {
+		return httpbase.ErrDatabase(ctx, "list network sets failed").SetSubError(coreErr)
+	}
+	gnss = append(gnss, nss...)
+
+	remainingHEPs := make([]*entity.HostEndpoint, 0, len(heps))
+	for _, hepEntity := range heps {
+		if hepEntity.UUID != uuid {
+			remainingHEPs = append(remainingHEPs, hepEntity)
+		}
+	}
+	remainingGNSs := make([]*entity.GlobalNetworkSet, 0, len(gnss))
+	for _, gnsEntity := range gnss {
+		if gnsEntity.UUID != uuid {
+			remainingGNSs = append(remainingGNSs, gnsEntity)
+		}
+	}
+
+	return orphanedReferencesError(ctx, orphanedReferences(gnps, labels, tenantID, isHEP, remainingHEPs, remainingGNSs))
+}
+
+// orphanedReferencesError returns a conflict error detailing the orphaned selectors, nil if there is none.
+func orphanedReferencesError(ctx context.Context, orphaned []*model.SelectorReference) *ierror.Error {
+	if len(orphaned) == 0 {
+		return nil
+	}
+	return httpbase.ErrConflict(ctx, "policy selectors would lose their last match, use force to delete anyway").
+		SetDetail(orphaned)
+}
+
+// maxRelatedPolicies bounds the policies whose spec selector is compared with the one of a validated policy, each
+// comparison may check up to a thousand label combinations.
+const maxRelatedPolicies = 200
+
+// compareSelectors returns how the set of labels matched by a relates to the one matched by b, or an empty string
+// when the selectors are unrelated or cannot be parsed.
+func compareSelectors(a, b string) string {
+	selA, err := selector.Parse(a)
+	if err != nil {
+		return ""
+	}
+	selB, err := selector.Parse(b)
+	if err != nil {
+		return ""
+	}
+	return compareParsedSelectors(selA, selB)
+}
+
+func compareParsedSelectors(selA, selB selector.Selector) string {
+	aImpliesB, bImpliesA := selector.Implies(selA, selB), selector.Implies(selB, selA)
+	switch {
+	case aImpliesB && bImpliesA:
+		return model.SelectorRelationIdentical
+	case aImpliesB:
+		return model.SelectorRelationNarrower
+	case bImpliesA:
+		return model.SelectorRelationBroader
+	}
+	return ""
+}
+
+// relatedPolicySelectors returns the policies among gnps, other than gnp, whose spec selector is identical to,
+// narrower than or broader than the spec selector of gnp. Only the first maxRelatedPolicies policies are compared,
+// truncated reports whether some were left out.
+func relatedPolicySelectors(gnp *entity.GlobalNetworkPolicy, gnps []*entity.GlobalNetworkPolicy) (relations []*model.SelectorRelation, truncated bool) {
+	sel, err := selector.Parse(gnp.Spec.Selector)
+	if err != nil {
+		return nil, false
+	}
+	var compared int
+	for _, other := range gnps {
+		if other.Metadata.Name == gnp.Metadata.Name {
+			continue
+		}
+		if compared == maxRelatedPolicies {
+			return relations, true
+		}
+		compared++
+		otherSel, err := selector.Parse(other.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		if relation := compareParsedSelectors(sel, otherSel); relation != "" {
+			relations = append(relations, &model.SelectorRelation{
+				GNPName:  other.Metadata.Name,
+				Selector: other.Spec.Selector,
+				Relation: relation,
+			})
+		}
+	}
+	return relations, false
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+func referenceFields(refs []*model.SelectorReference) []string {
+	var fields []string
+	for _, ref := range refs {
+		fields = append(fields, ref.GNPName+" "+ref.Field)
+	}
+	return fields
+}
+
+func TestUnmatchedRuleSelectors(t *testing.T) {
+	heps := []*entity.HostEndpoint{testHEP("web-1", 1, map[string]string{"role": "web"})}
+	gnss := []*entity.GlobalNetworkSet{testGNS("blocklist", map[string]string{"list": "drop"}, "1.10.16.0/20")}
+	tenantSet := testGNS("tenant-set", map[string]string{"list": "tenant"}, "10.0.0.0/8")
+	tenantSet.Metadata.TenantID = 2
+	gnss = append(gnss, tenantSet)
+
+	tests := []struct {
+		name string
+		gnp  *entity.GlobalNetworkPolicy
+		want []string
+	}{
+		{name: "matches a host endpoint", gnp: testGNP("allow-web", "role == 'db'", 10, "role == 'web'")},
+		{name: "matches a set", gnp: testGNP("deny-drop", "", 10, "list == 'drop'")},
+		{name: "spec selector is not a rule selector", gnp: testGNP("db", "role == 'db'", 10, "")},
+		{name: "matches nothing", gnp: testGNP("allow-api", "", 10, "role == 'api'"), want: []string{"allow-api spec.ingress[0].source.selector"}},
+		{name: "network set of another tenant", gnp: testGNP("tenant", "", 10, "list == 'tenant'"), want: []string{"tenant spec.ingress[0].source.selector"}},
+		{name: "malformed selector", gnp: testGNP("malformed", "", 10, "role ==")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := referenceFields(unmatchedRuleSelectors(tt.gnp, heps, gnss))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmatchedRuleSelectors() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOrphanedReferences(t *testing.T) {
+	webLabels := map[string]string{"role": "web"}
+	dropLabels := map[string]string{"list": "drop"}
+	webPolicy := testGNP("web", "role == 'web'", 10, "role == 'web'")
+	dropPolicy := testGNP("drop", "", 10, "list == 'drop'")
+	tenantPolicy := testGNP("tenant-web", "role == 'web'", 10, "")
+	tenantPolicy.Metadata.TenantID = 2
+	gnps := []*entity.GlobalNetworkPolicy{webPolicy, dropPolicy, tenantPolicy}
+
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		tenantID uint64
+		isHEP    bool
+		heps     []*entity.HostEndpoint
+		gnss     []*entity.GlobalNetworkSet
+		want     []string
+	}{
+		{
+			name:     "last host endpoint",
+			labels:   webLabels,
+			tenantID: entity.DefaultTenantID,
+			isHEP:    true,
+			want:     []string{"web spec.selector", "web spec.ingress[0].source.selector"},
+		},
+		{
+			name:     "another host endpoint remains",
+			labels:   webLabels,
+			tenantID: entity.DefaultTenantID,
+			isHEP:    true,
+			heps:     []*entity.HostEndpoint{testHEP("web-2", 2, webLabels)},
+		},
+		{
+			name:     "a set remains for the rule selector",
+			labels:   webLabels,
+			tenantID: entity.DefaultTenantID,
+			isHEP:    true,
+			gnss:     []*entity.GlobalNetworkSet{testGNS("web-set", webLabels, "10.0.0.0/8")},
+			want:     []string{"web spec.selector"},
+		},
+		{
+			name:     "host endpoint of the tenant of a network policy",
+			labels:   webLabels,
+			tenantID: 2,
+			isHEP:    true,
+			want:     []string{"web spec.selector", "web spec.ingress[0].source.selector", "tenant-web spec.selector"},
+		},
+		{
+			name:   "last set",
+			labels: dropLabels,
+			want:   []string{"drop spec.ingress[0].source.selector"},
+		},
+		{
+			name:   "set does not orphan spec selectors",
+			labels: webLabels,
+			want:   []string{"web spec.ingress[0].source.selector"},
+		},
+		{
+			name:   "unselected set",
+			labels: map[string]string{"list": "allow"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := referenceFields(orphanedReferences(gnps, tt.labels, tt.tenantID, tt.isHEP, tt.heps, tt.gnss))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("orphanedReferences() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckOrphanedReferences(t *testing.T) {
+	ctx := context.Background()
+	storage := newFakeStorage()
+	_ = storage.UpsertGroupPolicy(ctx, testGNP("drop", "", 10, "list == 'drop'"))
+	drop := testGNS("drop", map[string]string{"list": "drop"}, "1.10.16.0/20")
+	_ = storage.UpsertGNS(ctx, drop)
+
+	ierr := checkOrphanedReferences(ctx, storage, drop.UUID, drop.Metadata.Labels, 0, false)
+	if ierr == nil || ierr.HTTPStatusCode != http.StatusConflict {
+		t.Fatalf("checkOrphanedReferences() = %v, want a conflict", ierr)
+	}
+	refs, ok := ierr.Detail.([]*model.SelectorReference)
+	if !ok || len(refs) != 1 || refs[0].GNPName != "drop" {
+		t.Errorf("conflict detail = %#v, want the selector of drop", ierr.Detail)
+	}
+
+	other := testGNS("drop-2", map[string]string{"list": "drop"}, "2.56.192.0/22")
+	_ = storage.UpsertGNS(ctx, other)
+	if ierr = checkOrphanedReferences(ctx, storage, drop.UUID, drop.Metadata.Labels, 0, false); ierr != nil {
+		t.Errorf("checkOrphanedReferences() with another matching set = %v, want nil", ierr)
+	}
+}
+
+func TestRelatedPolicySelectors(t *testing.T) {
+	gnp := testGNP("web", "role == 'web'", 10, "")
+	gnps := []*entity.GlobalNetworkPolicy{
+		gnp,
+		testGNP("web-copy", "role == 'web'", 20, ""),
+		testGNP("web-prod", "role == 'web' && env == 'prod'", 20, ""),
+		testGNP("all-roles", "has(role)", 20, ""),
+		testGNP("db", "role == 'db'", 20, ""),
+	}
+	relations, truncated := relatedPolicySelectors(gnp, gnps)
+	var got []string
+	for _, relation := range relations {
+		got = append(got, relation.GNPName+" "+relation.Relation)
+	}
+	want := []string{
+		"web-copy " + model.SelectorRelationIdentical,
+		"web-prod " + model.SelectorRelationBroader,
+		"all-roles " + model.SelectorRelationNarrower,
+	}
+	if diff := cmp.Diff(want, got); diff != "" || truncated {
+		t.Errorf("relatedPolicySelectors() truncated %t, mismatch (-want +got):\n%s", truncated, diff)
+	}
+
+	many := []*entity.GlobalNetworkPolicy{gnp}
+	for i := 0; i <= maxRelatedPolicies; i++ {
+		many = append(many, testGNP(fmt.Sprintf("web-%d", i), "role == 'web'", 20, ""))
+	}
+	relations, truncated = relatedPolicySelectors(gnp, many)
+	if !truncated || len(relations) != maxRelatedPolicies {
+		t.Errorf("relatedPolicySelectors() of %d policies = %d relations, truncated %t, want %d truncated",
+			len(many), len(relations), truncated, maxRelatedPolicies)
+	}
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/schedule"
+)
+
+func modelToSchedule(input model.ScheduleInput) entity.Schedule {
+	var windows []entity.ScheduleWindow
+	for _, w := range input.Windows {
+		windows = append(windows, entity.ScheduleWindow{
+			Cron:     w.Cron,
+			Duration: w.Duration,
+		})
+	}
+	return entity.Schedule{
+		ActiveFrom:  input.ActiveFrom,
+		ActiveUntil: input.ActiveUntil,
+		Windows:     windows,
+	}
+}
+
+// scheduleWindowKey identifies a window by its stored cron expression and duration.
+type scheduleWindowKey struct {
+	cron     string
+	duration string
+}
+
+type parsedScheduleWindow struct {
+	window schedule.Window
+	err    error
+}
+
+// maxParsedScheduleWindows bounds the windows cached by parseScheduleWindow, the cache is emptied once it is reached.
+// Windows no policy uses anymore are dropped then, the ones still used are parsed again on their next evaluation.
+const maxParsedScheduleWindows = 4096
+
+// parsedScheduleWindows caches the windows by scheduleWindowKey. Schedules are evaluated for every rule on every host
+// endpoint policy build, a window is only parsed the first time its policy is evaluated.
+var parsedScheduleWindows = struct {
+	mu      sync.Mutex
+	windows map[scheduleWindowKey]*parsedScheduleWindow
+}{windows: make(map[scheduleWindowKey]*parsedScheduleWindow)}
+
+func parseScheduleWindow(w entity.ScheduleWindow) (schedule.Window, error) {
+	key := scheduleWindowKey{cron: w.Cron, duration: w.Duration}
+	parsedScheduleWindows.mu.Lock()
+	parsed, ok := parsedScheduleWindows.windows[key]
+	parsedScheduleWindows.mu.Unlock()
+	if ok {
+		return parsed.window, parsed.err
+	}
+	window, err := schedule.ParseWindow(w.Cron, w.Duration)
+	if err != nil {
+		slog.Warn("malformed schedule window", "cron", w.Cron, "duration", w.Duration, "err", err)
+	}
+	parsedScheduleWindows.mu.Lock()
+	if len(parsedScheduleWindows.windows) >= maxParsedScheduleWindows {
+		clear(parsedScheduleWindows.windows)
+	}
+	parsedScheduleWindows.windows[key] = &parsedScheduleWindow{window: window, err: err}
+	parsedScheduleWindows.mu.Unlock()
+	return window, err
+}
+
+// toSchedule returns the parsed schedule of s, ok is false if s has windows and all of them are malformed.
+func toSchedule(s entity.Schedule) (sched schedule.Schedule, ok bool) {
+	sched = schedule.Schedule{
+		From:  s.ActiveFrom,
+		Until: s.ActiveUntil,
+	}
+	for _, w := range s.Windows {
+		window, err := parseScheduleWindow(w)
+		if err != nil {
+			continue
+		}
+		sched.Windows = append(sched.Windows, window)
+	}
+	return sched, len(s.Windows) == 0 || len(sched.Windows) > 0
+}
+
+// isScheduleActive reports whether s is active at t, windows open in UTC. A malformed window never opens, windows
+// are validated on create.
+func isScheduleActive(s entity.Schedule, t time.Time) bool {
+	if s.IsZero() {
+		return true
+	}
+	sched, ok := toSchedule(s)
+	return ok && sched.ActiveAt(t)
+}
+
+func hasSchedule(gnp *entity.GlobalNetworkPolicy) bool {
+	if !gnp.Spec.Schedule.IsZero() {
+		return true
+	}
+	for _, rule := range gnp.Spec.Ingress {
+		if !rule.Schedule.IsZero() {
+			return true
+		}
+	}
+	for _, rule := range gnp.Spec.Egress {
+		if !rule.Schedule.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// scheduleState returns a key which changes whenever the policy or one of its rules becomes active or inactive.
+func scheduleState(gnp *entity.GlobalNetworkPolicy, t time.Time) string {
+	var b strings.Builder
+	var write = func(s entity.Schedule) {
+		if isScheduleActive(s, t) {
+			b.WriteByte('1')
+		} else {
+			b.WriteByte('0')
+		}
+	}
+	write(gnp.Spec.Schedule)
+	for _, rule := range gnp.Spec.Ingress {
+		write(rule.Schedule)
+	}
+	for _, rule := range gnp.Spec.Egress {
+		write(rule.Schedule)
+	}
+	return b.String()
+}
+
+// expiresWithin reports whether the policy or one of its rules reaches its activeUntil, or stops being active as
+// its schedule windows close, in [t, t+d).
+func expiresWithin(gnp *entity.GlobalNetworkPolicy, t time.Time, d time.Duration) bool {
+	var expires = func(s entity.Schedule) bool {
+		if s.ActiveUntil != nil && !s.ActiveUntil.Before(t) && s.ActiveUntil.Before(t.Add(d)) {
+			return true
+		}
+		if len(s.Windows) == 0 {
+			return false
+		}
+		sched, ok := toSchedule(s)
+		return ok && sched.StopsWithin(t, d)
+	}
+	if expires(gnp.Spec.Schedule) {
+		return true
+	}
+	for _, rule := range gnp.Spec.Ingress {
+		if expires(rule.Schedule) {
+			return true
+		}
+	}
+	for _, rule := range gnp.Spec.Egress {
+		if expires(rule.Schedule) {
+			return true
+		}
+	}
+	return false
+}
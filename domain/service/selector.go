@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bamboo-firewall/be"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	"github.com/bamboo-firewall/be/pkg/net"
+	"github.com/bamboo-firewall/be/pkg/repository"
+	"github.com/bamboo-firewall/be/pkg/selector"
+)
+
+func NewSelector(policyMongo *repository.PolicyDB) *selectorService {
+	return &selectorService{
+		storage: policyMongo,
+	}
+}
+
+type selectorService struct {
+	storage be.Storage
+}
+
+// Explain traces the evaluation of a selector against the labels of a host endpoint or against raw labels.
+func (ds *selectorService) Explain(ctx context.Context, input *model.ExplainSelectorInput) (*model.ExplainSelectorOutput, *ierror.Error) {
+	sel, err := selector.Parse(input.Selector)
+	if err != nil {
+		return nil, httpbase.ErrBadRequest(ctx, "malformed selector").
+			SetSubError(errlist.ErrMalformedSelector.WithChild(err))
+	}
+
+	output := &model.ExplainSelectorOutput{
+		Selector: sel.String(),
+		Labels:   input.Labels,
+	}
+	if input.IP != "" {
+		tenantID := input.TenantID
+		if tenantID == 0 {
+			tenantID = entity.DefaultTenantID
+		}
+		ip := net.ParseIP(input.IP)
+		if ip == nil || ip.Version() != entity.IPVersion4 {
+			return nil, httpbase.ErrBadRequest(ctx, fmt.Sprintf("host endpoint ip %s is not an ip version 4", input.IP))
+		}
+		hepEntity, coreErr := ds.storage.GetHostEndpoint(ctx, &model.GetHostEndpointInput{
+			TenantID: tenantID,
+			IP:       net.IPToInt(*ip),
+		})
+		if coreErr != nil {
+			if errors.Is(coreErr, errlist.ErrNotFoundHostEndpoint) {
+				return nil, httpbase.ErrNotFound(ctx, "host endpoint not found").SetSubError(coreErr)
+			}
+			return nil, httpbase.ErrDatabase(ctx, "get host endpoint failed").SetSubError(coreErr)
+		}
+		output.HEP = hepEntity
+		output.Labels = hepEntity.Metadata.Labels
+	}
+	if output.Labels == nil {
+		output.Labels = map[string]string{}
+	}
+	if output.HEP != nil {
+		output.Trace = sel.ExplainLabels(hepLabels(output.HEP))
+	} else {
+		output.Trace = sel.Explain(output.Labels)
+	}
+	return output, nil
+}
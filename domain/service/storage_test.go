@@ -0,0 +1,538 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/bamboo-firewall/be"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+// fakeStorage is an in-memory be.Storage for the resources services read and write in tests. The methods it does not
+// implement panic on the nil embedded be.Storage.
+type fakeStorage struct {
+	be.Storage
+
+	mu    sync.Mutex
+	heps  map[hepKey]*entity.HostEndpoint
+	gnps  map[string]*entity.GlobalNetworkPolicy
+	gnss  map[string]*entity.GlobalNetworkSet
+	tiers map[string]*entity.Tier
+	// tenants by tenant id
+	tenants        map[uint64]*entity.Tenant
+	agents         []*entity.Agent
+	admissionHooks []*entity.AdmissionHook
+	webhooks       []*entity.Webhook
+	// deliveries are the queued webhook deliveries, updated in place
+	deliveries []*entity.WebhookDelivery
+	// stagedReports by policy uuid, tenant id and ip
+	stagedReports map[string]*entity.StagedPolicyReport
+	// restored is the input of the last RestoreBundle, which writes nothing
+	restored *model.RestoreInput
+
+	// afterListTiers is called by ListTiers, the last read of PolicySnapshot.Load
+	afterListTiers func()
+	// beforeApplyBulk is called by ApplyBulk before the versions of the items are checked
+	beforeApplyBulk func()
+}
+
+func newFakeStorage() *fakeStorage {
+	return &fakeStorage{
+		heps:    make(map[hepKey]*entity.HostEndpoint),
+		gnps:    make(map[string]*entity.GlobalNetworkPolicy),
+		gnss:    make(map[string]*entity.GlobalNetworkSet),
+		tiers:   make(map[string]*entity.Tier),
+		tenants: make(map[uint64]*entity.Tenant),
+
+		stagedReports: make(map[string]*entity.StagedPolicyReport),
+	}
+}
+
+// namespacedKey keys network policies and sets by tenant along with the global ones.
+func namespacedKey(tenantID uint64, name string) string {
+	return fmt.Sprintf("%d/%s", tenantID, name)
+}
+
+// stored sets the id, uuid and version of a resource written over old, as the repository does.
+func stored(id *primitive.ObjectID, resourceUUID *string, version *uint, oldID primitive.ObjectID, oldUUID string, oldVersion uint) {
+	if oldUUID != "" {
+		*id, *resourceUUID = oldID, oldUUID
+	}
+	if id.IsZero() {
+		*id = primitive.NewObjectID()
+	}
+	if *resourceUUID == "" {
+		*resourceUUID = uuid.NewString()
+	}
+	*version = oldVersion + 1
+}
+
+func (f *fakeStorage) UpsertHostEndpoint(_ context.Context, hep *entity.HostEndpoint) *ierror.CoreError {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	old := f.heps[keyOfHEP(hep)]
+	if old == nil {
+		old = new(entity.HostEndpoint)
+	}
+	stored(&hep.ID, &hep.UUID, &hep.Version, old.ID, old.UUID, old.Version)
+	stored := *hep
+	f.heps[keyOfHEP(hep)] = &stored
+	return nil
+}
+
+func (f *fakeStorage) DeleteHostEndpoint(_ context.Context, tenantID uint64, ip uint32) *ierror.CoreError {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.heps, hepKey{tenantID: tenantID, ip: ip})
+	return nil
+}
+
+func (f *fakeStorage) ListHostEndpoints(_ context.Context, input *model.ListHostEndpointsInput) ([]*entity.HostEndpoint, *ierror.CoreError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	heps := make([]*entity.HostEndpoint, 0, len(f.heps))
+	for _, hep := range f.heps {
+		if input != nil && input.TenantID != nil && hep.Spec.TenantID != *input.TenantID {
+			continue
+		}
+		copied := *hep
+		heps = append(heps, &copied)
+	}
+	sort.Slice(heps, func(i, j int) bool { return heps[i].ID.Hex() < heps[j].ID.Hex() })
+	return heps, nil
+}
+
+func (f *fakeStorage) upsertPolicy(gnp *entity.GlobalNetworkPolicy) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := namespacedKey(gnp.Metadata.TenantID, gnp.Metadata.Name)
+	old := f.gnps[key]
+	if old == nil {
+		old = new(entity.GlobalNetworkPolicy)
+	}
+	stored(&gnp.ID, &gnp.UUID, &gnp.Version, old.ID, old.UUID, old.Version)
+	copied := *gnp
+	f.gnps[key] = &copied
+}
+
+func (f *fakeStorage) listPolicies(namespaced bool, tenantID uint64) []*entity.GlobalNetworkPolicy {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	gnps := make([]*entity.GlobalNetworkPolicy, 0, len(f.gnps))
+	for _, gnp := range f.gnps {
+		if gnp.IsNamespaced() != namespaced || tenantID != 0 && gnp.Metadata.TenantID != tenantID {
+			continue
+		}
+		copied := *gnp
+		gnps = append(gnps, &copied)
+	}
+	sort.Slice(gnps, func(i, j int) bool { return gnps[i].ID.Hex() < gnps[j].ID.Hex() })
+	return gnps
+}
+
+func (f *fakeStorage) UpsertGroupPolicy(_ context.Context, gnp *entity.GlobalNetworkPolicy) *ierror.CoreError {
+	f.upsertPolicy(gnp)
+	return nil
+}
+
+func (f *fakeStorage) GetGNPByName(_ context.Context, name string) (*entity.GlobalNetworkPolicy, *ierror.CoreError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	gnp, ok := f.gnps[namespacedKey(0, name)]
+	if !ok {
+		return nil, errlist.ErrNotFoundGlobalNetworkPolicy
+	}
+	copied := *gnp
+	return &copied, nil
+}
+
+func (f *fakeStorage) DeleteGNPByName(_ context.Context, name string) *ierror.CoreError {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.gnps, namespacedKey(0, name))
+	return nil
+}
+
+func (f *fakeStorage) ListGNPs(_ context.Context, _ *model.ListGNPsInput) ([]*entity.GlobalNetworkPolicy, *ierror.CoreError) {
+	return f.listPolicies(false, 0), nil
+}
+
+func (f *fakeStorage) UpsertNetworkPolicy(_ context.Context, np *entity.GlobalNetworkPolicy) *ierror.CoreError {
+	f.upsertPolicy(np)
+	return nil
+}
+
+func (f *fakeStorage) ListNetworkPolicies(_ context.Context, tenantID uint64) ([]*entity.GlobalNetworkPolicy, *ierror.CoreError) {
+	return f.listPolicies(true, tenantID), nil
+}
+
+func (f *fakeStorage) upsertSet(gns *entity.GlobalNetworkSet) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := namespacedKey(gns.Metadata.TenantID, gns.Metadata.Name)
+	old := f.gnss[key]
+	if old == nil {
+		old = new(entity.GlobalNetworkSet)
+	}
+	stored(&gns.ID, &gns.UUID, &gns.Version, old.ID, old.UUID, old.Version)
+	copied := *gns
+	f.gnss[key] = &copied
+}
+
+func (f *fakeStorage) listSets(namespaced bool, tenantID uint64) []*entity.GlobalNetworkSet {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	gnss := make([]*entity.GlobalNetworkSet, 0, len(f.gnss))
+	for _, gns := range f.gnss {
+		if (gns.Metadata.TenantID != 0) != namespaced || tenantID != 0 && gns.Metadata.TenantID != tenantID {
+			continue
+		}
+		copied := *gns
+		gnss = append(gnss, &copied)
+	}
+	sort.Slice(gnss, func(i, j int) bool { return gnss[i].ID.Hex() < gnss[j].ID.Hex() })
+	return gnss
+}
+
+func (f *fakeStorage) UpsertGNS(_ context.Context, gns *entity.GlobalNetworkSet) *ierror.CoreError {
+	f.upsertSet(gns)
+	return nil
+}
+
+func (f *fakeStorage) DeleteGNSByName(_ context.Context, name string) *ierror.CoreError {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.gnss, namespacedKey(0, name))
+	return nil
+}
+
+func (f *fakeStorage) GetGNSByName(_ context.Context, name string) (*entity.GlobalNetworkSet, *ierror.CoreError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	gns, ok := f.gnss[namespacedKey(0, name)]
+	if !ok {
+		return nil, errlist.ErrNotFoundGlobalNetworkSet
+	}
+	return gns, nil
+}
+
+func (f *fakeStorage) ListGNSs(_ context.Context) ([]*entity.GlobalNetworkSet, *ierror.CoreError) {
+	return f.listSets(false, 0), nil
+}
+
+func (f *fakeStorage) UpsertNetworkSet(_ context.Context, ns *entity.GlobalNetworkSet) *ierror.CoreError {
+	f.upsertSet(ns)
+	return nil
+}
+
+func (f *fakeStorage) ListNetworkSets(_ context.Context, tenantID uint64) ([]*entity.GlobalNetworkSet, *ierror.CoreError) {
+	return f.listSets(true, tenantID), nil
+}
+
+func (f *fakeStorage) UpsertTier(_ context.Context, tier *entity.Tier) *ierror.CoreError {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	old := f.tiers[tier.Metadata.Name]
+	if old == nil {
+		old = new(entity.Tier)
+	}
+	stored(&tier.ID, &tier.UUID, &tier.Version, old.ID, old.UUID, old.Version)
+	copied := *tier
+	f.tiers[tier.Metadata.Name] = &copied
+	return nil
+}
+
+func (f *fakeStorage) GetTierByName(_ context.Context, name string) (*entity.Tier, *ierror.CoreError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tier, ok := f.tiers[name]
+	if !ok {
+		return nil, errlist.ErrNotFoundTier
+	}
+	copied := *tier
+	return &copied, nil
+}
+
+func (f *fakeStorage) ListTiers(_ context.Context) ([]*entity.Tier, *ierror.CoreError) {
+	f.mu.Lock()
+	tiers := make([]*entity.Tier, 0, len(f.tiers))
+	for _, tier := range f.tiers {
+		copied := *tier
+		tiers = append(tiers, &copied)
+	}
+	afterListTiers := f.afterListTiers
+	f.mu.Unlock()
+	if afterListTiers != nil {
+		afterListTiers()
+	}
+	return tiers, nil
+}
+
+func (f *fakeStorage) UpdateGNSExternalState(_ context.Context, name string, version uint, status *entity.GNSStatus, resolved *entity.GNSResolved) *ierror.CoreError {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	gns, ok := f.gnss[namespacedKey(0, name)]
+	if !ok || gns.Version != version {
+		return errlist.ErrVersionConflict
+	}
+	if status != nil {
+		gns.Status = status
+	}
+	if resolved != nil {
+		gns.Resolved = resolved
+	}
+	return nil
+}
+
+func (f *fakeStorage) UpdateGNSExternalNets(_ context.Context, gns *entity.GlobalNetworkSet) *ierror.CoreError {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	old, ok := f.gnss[namespacedKey(0, gns.Metadata.Name)]
+	if !ok || old.Version != gns.Version {
+		return errlist.ErrVersionConflict
+	}
+	updated := *old
+	updated.Spec.Nets, updated.Spec.NetsV4, updated.Spec.NetsV6 = gns.Spec.Nets, gns.Spec.NetsV4, gns.Spec.NetsV6
+	updated.Status, updated.Resolved, updated.UpdatedAt = gns.Status, gns.Resolved, gns.UpdatedAt
+	updated.Version++
+	f.gnss[namespacedKey(0, gns.Metadata.Name)] = &updated
+	*gns = updated
+	return nil
+}
+
+func (f *fakeStorage) GetHostEndpoint(_ context.Context, input *model.GetHostEndpointInput) (*entity.HostEndpoint, *ierror.CoreError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	hep, ok := f.heps[hepKey{tenantID: input.TenantID, ip: input.IP}]
+	if !ok {
+		return nil, errlist.ErrNotFoundHostEndpoint
+	}
+	copied := *hep
+	return &copied, nil
+}
+
+func (f *fakeStorage) CountHostEndpoints(_ context.Context, tenantID uint64) (int64, *ierror.CoreError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var count int64
+	for key := range f.heps {
+		if key.tenantID == tenantID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeStorage) GetNetworkPolicy(_ context.Context, tenantID uint64, name string) (*entity.GlobalNetworkPolicy, *ierror.CoreError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	np, ok := f.gnps[namespacedKey(tenantID, name)]
+	if !ok || tenantID == 0 {
+		return nil, errlist.ErrNotFoundNetworkPolicy
+	}
+	copied := *np
+	return &copied, nil
+}
+
+func (f *fakeStorage) CountNetworkPolicies(_ context.Context, tenantID uint64) (int64, *ierror.CoreError) {
+	return int64(len(f.listPolicies(true, tenantID))), nil
+}
+
+func (f *fakeStorage) UpsertTenant(_ context.Context, tenant *entity.Tenant) *ierror.CoreError {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	old := f.tenants[tenant.Spec.TenantID]
+	if old == nil {
+		old = new(entity.Tenant)
+	}
+	stored(&tenant.ID, &tenant.UUID, &tenant.Version, old.ID, old.UUID, old.Version)
+	copied := *tenant
+	f.tenants[tenant.Spec.TenantID] = &copied
+	return nil
+}
+
+func (f *fakeStorage) GetTenant(_ context.Context, tenantID uint64) (*entity.Tenant, *ierror.CoreError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tenant, ok := f.tenants[tenantID]
+	if !ok {
+		return nil, errlist.ErrNotFoundTenant
+	}
+	copied := *tenant
+	return &copied, nil
+}
+
+func (f *fakeStorage) ListTenants(_ context.Context) ([]*entity.Tenant, *ierror.CoreError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tenants := make([]*entity.Tenant, 0, len(f.tenants))
+	for _, tenant := range f.tenants {
+		copied := *tenant
+		tenants = append(tenants, &copied)
+	}
+	return tenants, nil
+}
+
+func (f *fakeStorage) RestoreBundle(_ context.Context, input *model.RestoreInput) (*model.RestoreOutput, *ierror.CoreError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.restored = input
+	return &model.RestoreOutput{}, nil
+}
+
+func (f *fakeStorage) IncStagedPolicyReport(_ context.Context, report *entity.StagedPolicyReport) *ierror.CoreError {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := fmt.Sprintf("%s/%d/%d", report.GNPUUID, report.TenantID, report.IP)
+	stored, ok := f.stagedReports[key]
+	if !ok {
+		stored = &entity.StagedPolicyReport{GNPUUID: report.GNPUUID, TenantID: report.TenantID, IP: report.IP}
+		f.stagedReports[key] = stored
+	}
+	stored.GNPName = report.GNPName
+	stored.DeniedPackets += report.DeniedPackets
+	stored.DeniedBytes += report.DeniedBytes
+	return nil
+}
+
+func (f *fakeStorage) ListStagedPolicyReports(_ context.Context, gnpUUID string) ([]*entity.StagedPolicyReport, *ierror.CoreError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	reports := make([]*entity.StagedPolicyReport, 0)
+	for _, report := range f.stagedReports {
+		if report.GNPUUID == gnpUUID {
+			copied := *report
+			reports = append(reports, &copied)
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].TenantID != reports[j].TenantID {
+			return reports[i].TenantID < reports[j].TenantID
+		}
+		return reports[i].IP < reports[j].IP
+	})
+	return reports, nil
+}
+
+func (f *fakeStorage) DeleteStagedPolicyReports(_ context.Context, gnpUUID string) *ierror.CoreError {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for key, report := range f.stagedReports {
+		if report.GNPUUID == gnpUUID {
+			delete(f.stagedReports, key)
+		}
+	}
+	return nil
+}
+
+func (f *fakeStorage) ListAgents(_ context.Context, tenantID uint64) ([]*entity.Agent, *ierror.CoreError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	agents := make([]*entity.Agent, 0, len(f.agents))
+	for _, agentEntity := range f.agents {
+		if tenantID == 0 || agentEntity.TenantID == tenantID {
+			copied := *agentEntity
+			agents = append(agents, &copied)
+		}
+	}
+	return agents, nil
+}
+
+func (f *fakeStorage) ListAdmissionHooks(_ context.Context) ([]*entity.AdmissionHook, *ierror.CoreError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.admissionHooks, nil
+}
+
+func (f *fakeStorage) ListWebhooks(_ context.Context) ([]*entity.Webhook, *ierror.CoreError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.webhooks, nil
+}
+
+func (f *fakeStorage) GetWebhookByName(_ context.Context, name string) (*entity.Webhook, *ierror.CoreError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, webhookEntity := range f.webhooks {
+		if webhookEntity.Metadata.Name == name {
+			return webhookEntity, nil
+		}
+	}
+	return nil, errlist.ErrNotFoundWebhook
+}
+
+func (f *fakeStorage) InsertWebhookDeliveries(_ context.Context, deliveries []*entity.WebhookDelivery) *ierror.CoreError {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deliveries = append(f.deliveries, deliveries...)
+	return nil
+}
+
+func (f *fakeStorage) UpdateWebhookDelivery(_ context.Context, delivery *entity.WebhookDelivery) *ierror.CoreError {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.deliveries {
+		if f.deliveries[i].ID == delivery.ID {
+			copied := *delivery
+			f.deliveries[i] = &copied
+		}
+	}
+	return nil
+}
+
+// ApplyBulk writes items only if every stored resource is still at the version of its item, as the transaction of
+// the repository does.
+func (f *fakeStorage) ApplyBulk(ctx context.Context, items []*model.BulkWriteItem) *ierror.CoreError {
+	if f.beforeApplyBulk != nil {
+		f.beforeApplyBulk()
+	}
+	f.mu.Lock()
+	for _, item := range items {
+		var version uint
+		switch item.Kind {
+		case entity.WebhookKindHostEndpoint:
+			if old, ok := f.heps[keyOfHEP(item.HostEndpoint)]; ok {
+				version = old.Version
+			}
+		case entity.WebhookKindGlobalNetworkSet:
+			if old, ok := f.gnss[namespacedKey(0, item.GlobalNetworkSet.Metadata.Name)]; ok {
+				version = old.Version
+			}
+		case entity.WebhookKindGlobalNetworkPolicy:
+			if old, ok := f.gnps[namespacedKey(0, item.GlobalNetworkPolicy.Metadata.Name)]; ok {
+				version = old.Version
+			}
+		}
+		if version != item.StoredVersion {
+			f.mu.Unlock()
+			return errlist.ErrVersionConflict
+		}
+	}
+	f.mu.Unlock()
+
+	for _, item := range items {
+		switch {
+		case item.Kind == entity.WebhookKindHostEndpoint && item.Operation == model.BulkOperationUpsert:
+			f.UpsertHostEndpoint(ctx, item.HostEndpoint)
+		case item.Kind == entity.WebhookKindHostEndpoint:
+			f.DeleteHostEndpoint(ctx, item.HostEndpoint.Spec.TenantID, item.HostEndpoint.Spec.IP)
+		case item.Kind == entity.WebhookKindGlobalNetworkSet && item.Operation == model.BulkOperationUpsert:
+			f.UpsertGNS(ctx, item.GlobalNetworkSet)
+		case item.Kind == entity.WebhookKindGlobalNetworkSet:
+			f.DeleteGNSByName(ctx, item.GlobalNetworkSet.Metadata.Name)
+		case item.Operation == model.BulkOperationUpsert:
+			f.UpsertGroupPolicy(ctx, item.GlobalNetworkPolicy)
+		default:
+			f.DeleteGNPByName(ctx, item.GlobalNetworkPolicy.Metadata.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/bamboo-firewall/be"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	"github.com/bamboo-firewall/be/pkg/repository"
+)
+
+func NewTenant(policyMongo *repository.PolicyDB) *tenant {
+	return &tenant{
+		storage: policyMongo,
+	}
+}
+
+type tenant struct {
+	storage be.Storage
+}
+
+func (ds *tenant) Create(ctx context.Context, input *model.CreateTenantInput) (*entity.Tenant, *ierror.Error) {
+	tenantEntity, ierr := createModelToTenantEntity(ctx, input)
+	if ierr != nil {
+		return nil, ierr
+	}
+
+	if coreErr := ds.storage.UpsertTenant(ctx, tenantEntity); coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrDuplicateTenant) {
+			return nil, httpbase.ErrBadRequest(ctx, "duplicate tenant").SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "create tenant failed").SetSubError(coreErr)
+	}
+	notifyWebhooks(ctx, ds.storage, tenantWebhookEvent(upsertAction(tenantEntity.Version), tenantEntity))
+	return tenantEntity, nil
+}
+
+func (ds *tenant) Get(ctx context.Context, tenantID uint64) (*entity.Tenant, *ierror.Error) {
+	tenantEntity, coreErr := ds.storage.GetTenant(ctx, tenantID)
+	if coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrNotFoundTenant) {
+			if tenantID == entity.DefaultTenantID {
+				defaultTenant := entity.TenantDefault
+				return &defaultTenant, nil
+			}
+			return nil, httpbase.ErrNotFound(ctx, "not found").SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "get tenant failed").SetSubError(coreErr)
+	}
+	return tenantEntity, nil
+}
+
+func (ds *tenant) List(ctx context.Context) ([]*entity.Tenant, *ierror.Error) {
+	tenantsEntity, coreErr := ds.storage.ListTenants(ctx)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list tenants failed").SetSubError(coreErr)
+	}
+	for _, tenantEntity := range tenantsEntity {
+		if tenantEntity.Spec.TenantID == entity.DefaultTenantID {
+			return tenantsEntity, nil
+		}
+	}
+	defaultTenant := entity.TenantDefault
+	return append([]*entity.Tenant{&defaultTenant}, tenantsEntity...), nil
+}
+
+// Delete deletes the tenant if it has no resource left. Deleting the default tenant restores the implicit one.
+func (ds *tenant) Delete(ctx context.Context, tenantID uint64) *ierror.Error {
+	if tenantID != entity.DefaultTenantID {
+		hepCount, coreErr := ds.storage.CountHostEndpoints(ctx, tenantID)
+		if coreErr != nil {
+			return httpbase.ErrDatabase(ctx, "count host endpoints failed").SetSubError(coreErr)
+		}
+		if hepCount > 0 {
+			return httpbase.ErrBadRequest(ctx, fmt.Sprintf("tenant %d still has %d host endpoints", tenantID, hepCount))
+		}
+		npCount, coreErr := ds.storage.CountNetworkPolicies(ctx, tenantID)
+		if coreErr != nil {
+			return httpbase.ErrDatabase(ctx, "count network policies failed").SetSubError(coreErr)
+		}
+		if npCount > 0 {
+			return httpbase.ErrBadRequest(ctx, fmt.Sprintf("tenant %d still has %d network policies", tenantID, npCount))
+		}
+		nss, coreErr := ds.storage.ListNetworkSets(ctx, tenantID)
+		if coreErr != nil {
+			return httpbase.ErrDatabase(ctx, "list network sets failed").SetSubError(coreErr)
+		}
+		if len(nss) > 0 {
+			return httpbase.ErrBadRequest(ctx, fmt.Sprintf("tenant %d still has %d network sets", tenantID, len(nss)))
+		}
+	}
+
+	tenantEntity, coreErr := ds.storage.GetTenant(ctx, tenantID)
+	if coreErr != nil && !errors.Is(coreErr, errlist.ErrNotFoundTenant) {
+		return httpbase.ErrDatabase(ctx, "get tenant failed").SetSubError(coreErr)
+	}
+	if coreErr = ds.storage.DeleteTenant(ctx, tenantID); coreErr != nil {
+		return httpbase.ErrDatabase(ctx, "delete tenant failed").SetSubError(coreErr)
+	}
+	if tenantEntity != nil {
+		notifyWebhooks(ctx, ds.storage, tenantWebhookEvent(entity.WebhookActionDelete, tenantEntity))
+	}
+	return nil
+}
+
+func (ds *tenant) Validate(ctx context.Context, input *model.CreateTenantInput) (*model.ValidateTenantOutput, *ierror.Error) {
+	tenantEntity, ierr := createModelToTenantEntity(ctx, input)
+	if ierr != nil {
+		return nil, ierr
+	}
+
+	tenantExisted, coreErr := ds.storage.GetTenant(ctx, input.Spec.TenantID)
+	if coreErr != nil {
+		if !errors.Is(coreErr, errlist.ErrNotFoundTenant) {
+			return nil, httpbase.ErrDatabase(ctx, "get tenant failed").SetSubError(coreErr)
+		}
+	}
+
+	return &model.ValidateTenantOutput{
+		Tenant:        tenantEntity,
+		TenantExisted: tenantExisted,
+	}, nil
+}
+
+// getExistingTenant returns the tenant of tenantID, or a bad request error if a resource refers to a tenant which is
+// not created.
+func getExistingTenant(ctx context.Context, storage be.Storage, tenantID uint64) (*entity.Tenant, *ierror.Error) {
+	tenantEntity, coreErr := storage.GetTenant(ctx, tenantID)
+	if coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrNotFoundTenant) {
+			if tenantID == entity.DefaultTenantID {
+				defaultTenant := entity.TenantDefault
+				return &defaultTenant, nil
+			}
+			return nil, httpbase.ErrBadRequest(ctx, fmt.Sprintf("tenant %d not found", tenantID)).SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "get tenant failed").SetSubError(coreErr)
+	}
+	return tenantEntity, nil
+}
+
+// checkTenantExists returns a bad request error if a resource refers to a tenant which is not created.
+func checkTenantExists(ctx context.Context, storage be.Storage, tenantID uint64) *ierror.Error {
+	_, ierr := getExistingTenant(ctx, storage, tenantID)
+	return ierr
+}
+
+// checkHostEndpointQuota returns a bad request error if the tenant of hepEntity does not exist, or if hepEntity is a
+// new host endpoint and the tenant reached its quota of host endpoints. It reports the quota early, the storage
+// enforces it when writing the host endpoint.
+func checkHostEndpointQuota(ctx context.Context, storage be.Storage, hepEntity *entity.HostEndpoint) *ierror.Error {
+	tenantEntity, ierr := getExistingTenant(ctx, storage, hepEntity.Spec.TenantID)
+	if ierr != nil {
+		return ierr
+	}
+	maxHEPs := tenantEntity.Spec.Quota.MaxHostEndpoints
+	if maxHEPs == 0 {
+		return nil
+	}
+
+	_, coreErr := storage.GetHostEndpoint(ctx, &model.GetHostEndpointInput{TenantID: hepEntity.Spec.TenantID, IP: hepEntity.Spec.IP})
+	if coreErr == nil {
+		return nil
+	}
+	if !errors.Is(coreErr, errlist.ErrNotFoundHostEndpoint) {
+		return httpbase.ErrDatabase(ctx, "get host endpoint failed").SetSubError(coreErr)
+	}
+	count, coreErr := storage.CountHostEndpoints(ctx, hepEntity.Spec.TenantID)
+	if coreErr != nil {
+		return httpbase.ErrDatabase(ctx, "count host endpoints failed").SetSubError(coreErr)
+	}
+	if count >= int64(maxHEPs) {
+		return httpbase.ErrBadRequest(ctx, fmt.Sprintf("tenant %d reached its quota of %d host endpoints",
+			hepEntity.Spec.TenantID, maxHEPs))
+	}
+	return nil
+}
+
+// checkNetworkPolicyQuota returns a bad request error if the tenant of npEntity does not exist, or if npEntity is a
+// new network policy and the tenant reached its quota of network policies. It reports the quota early, the storage
+// enforces it when writing the network policy.
+func checkNetworkPolicyQuota(ctx context.Context, storage be.Storage, npEntity *entity.GlobalNetworkPolicy) *ierror.Error {
+	tenantEntity, ierr := getExistingTenant(ctx, storage, npEntity.Metadata.TenantID)
+	if ierr != nil {
+		return ierr
+	}
+	maxNPs := tenantEntity.Spec.Quota.MaxNetworkPolicies
+	if maxNPs == 0 {
+		return nil
+	}
+
+	_, coreErr := storage.GetNetworkPolicy(ctx, npEntity.Metadata.TenantID, npEntity.Metadata.Name)
+	if coreErr == nil {
+		return nil
+	}
+	if !errors.Is(coreErr, errlist.ErrNotFoundNetworkPolicy) {
+		return httpbase.ErrDatabase(ctx, "get network policy failed").SetSubError(coreErr)
+	}
+	count, coreErr := storage.CountNetworkPolicies(ctx, npEntity.Metadata.TenantID)
+	if coreErr != nil {
+		return httpbase.ErrDatabase(ctx, "count network policies failed").SetSubError(coreErr)
+	}
+	if count >= int64(maxNPs) {
+		return httpbase.ErrBadRequest(ctx, fmt.Sprintf("tenant %d reached its quota of %d network policies",
+			npEntity.Metadata.TenantID, maxNPs))
+	}
+	return nil
+}
+
+// checkTenantName returns a bad request error if the name of the default tenant is given to another tenant.
+func checkTenantName(ctx context.Context, name string, tenantID uint64) *ierror.Error {
+	if name == entity.DefaultTenantName && tenantID != entity.DefaultTenantID {
+		return httpbase.ErrBadRequest(ctx, fmt.Sprintf("tenant name %q is reserved for tenant %d",
+			entity.DefaultTenantName, entity.DefaultTenantID))
+	}
+	return nil
+}
+
+func createModelToTenantEntity(ctx context.Context, input *model.CreateTenantInput) (*entity.Tenant, *ierror.Error) {
+	if ierr := checkTenantName(ctx, input.Metadata.Name, input.Spec.TenantID); ierr != nil {
+		return nil, ierr
+	}
+
+	return &entity.Tenant{
+		ID:   primitive.NewObjectID(),
+		UUID: entity.NewMinifyUUID(),
+		Metadata: entity.TenantMetadata{
+			Name:   input.Metadata.Name,
+			Labels: input.Metadata.Labels,
+		},
+		Spec: entity.TenantSpec{
+			TenantID: input.Spec.TenantID,
+			Quota: entity.TenantQuota{
+				MaxHostEndpoints:   input.Spec.Quota.MaxHostEndpoints,
+				MaxNetworkPolicies: input.Spec.Quota.MaxNetworkPolicies,
+			},
+		},
+		Description: input.Description,
+		FilePath:    input.FilePath,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
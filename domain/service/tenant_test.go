@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+func testTenant(tenantID uint64, maxHEPs, maxNPs uint32) *entity.Tenant {
+	return &entity.Tenant{
+		Metadata: entity.TenantMetadata{Name: fmt.Sprintf("tenant-%d", tenantID)},
+		Spec: entity.TenantSpec{
+			TenantID: tenantID,
+			Quota:    entity.TenantQuota{MaxHostEndpoints: maxHEPs, MaxNetworkPolicies: maxNPs},
+		},
+	}
+}
+
+func TestCheckHostEndpointQuota(t *testing.T) {
+	ctx := context.Background()
+	storage := newFakeStorage()
+	_ = storage.UpsertTenant(ctx, testTenant(2, 2, 0))
+	_ = storage.UpsertTenant(ctx, testTenant(3, 0, 0))
+	for ip := uint32(1); ip <= 2; ip++ {
+		hep := testHEP(fmt.Sprintf("hep-%d", ip), ip, nil)
+		hep.Spec.TenantID = 2
+		_ = storage.UpsertHostEndpoint(ctx, hep)
+	}
+
+	tests := []struct {
+		name       string
+		tenantID   uint64
+		ip         uint32
+		wantStatus int
+	}{
+		{name: "default tenant without quota", tenantID: entity.DefaultTenantID, ip: 1},
+		{name: "unlimited tenant", tenantID: 3, ip: 1},
+		{name: "update of an existing host endpoint", tenantID: 2, ip: 2},
+		{name: "new host endpoint over quota", tenantID: 2, ip: 3, wantStatus: http.StatusBadRequest},
+		{name: "tenant not created", tenantID: 4, ip: 1, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hep := testHEP("new", tt.ip, nil)
+			hep.Spec.TenantID = tt.tenantID
+			ierr := checkHostEndpointQuota(ctx, storage, hep)
+			var status int
+			if ierr != nil {
+				status = ierr.HTTPStatusCode
+			}
+			if status != tt.wantStatus {
+				t.Errorf("checkHostEndpointQuota() = %v, want status %d", ierr, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestCheckNetworkPolicyQuota(t *testing.T) {
+	ctx := context.Background()
+	storage := newFakeStorage()
+	_ = storage.UpsertTenant(ctx, testTenant(2, 0, 1))
+	existing := testGNP("existing", "", 10, "")
+	existing.Metadata.TenantID = 2
+	_ = storage.UpsertNetworkPolicy(ctx, existing)
+
+	tests := []struct {
+		name       string
+		tenantID   uint64
+		policy     string
+		wantStatus int
+	}{
+		{name: "update of an existing network policy", tenantID: 2, policy: "existing"},
+		{name: "new network policy over quota", tenantID: 2, policy: "new", wantStatus: http.StatusBadRequest},
+		{name: "default tenant without quota", tenantID: entity.DefaultTenantID, policy: "new"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			np := testGNP(tt.policy, "", 10, "")
+			np.Metadata.TenantID = tt.tenantID
+			ierr := checkNetworkPolicyQuota(ctx, storage, np)
+			var status int
+			if ierr != nil {
+				status = ierr.HTTPStatusCode
+			}
+			if status != tt.wantStatus {
+				t.Errorf("checkNetworkPolicyQuota() = %v, want status %d", ierr, tt.wantStatus)
+			}
+		})
+	}
+}
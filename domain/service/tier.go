@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/bamboo-firewall/be"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	"github.com/bamboo-firewall/be/pkg/repository"
+)
+
+func NewTier(policyMongo *repository.PolicyDB, snapshot *PolicySnapshot) *tier {
+	return &tier{
+		storage:  policyMongo,
+		snapshot: snapshot,
+	}
+}
+
+type tier struct {
+	storage  be.Storage
+	snapshot *PolicySnapshot
+}
+
+func (ds *tier) Create(ctx context.Context, input *model.CreateTierInput) (*entity.Tier, *ierror.Error) {
+	tierEntity := createModelToTierEntity(input)
+
+	if coreErr := ds.storage.UpsertTier(ctx, tierEntity); coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrDuplicateTier) {
+			return nil, httpbase.ErrBadRequest(ctx, "duplicate tier").SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "create tier failed").SetSubError(coreErr)
+	}
+	ds.snapshot.UpsertTier(tierEntity)
+	notifyWebhooks(ctx, ds.storage, tierWebhookEvent(upsertAction(tierEntity.Version), tierEntity))
+	return tierEntity, nil
+}
+
+func (ds *tier) Get(ctx context.Context, name string) (*entity.Tier, *ierror.Error) {
+	tierEntity, coreErr := ds.storage.GetTierByName(ctx, name)
+	if coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrNotFoundTier) {
+			if name == entity.DefaultTierName {
+				defaultTier := entity.TierDefault
+				return &defaultTier, nil
+			}
+			return nil, httpbase.ErrNotFound(ctx, "not found").SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "get tier failed").SetSubError(coreErr)
+	}
+	return tierEntity, nil
+}
+
+func (ds *tier) List(ctx context.Context) ([]*entity.Tier, *ierror.Error) {
+	tiersEntity, coreErr := ds.storage.ListTiers(ctx)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list tiers failed").SetSubError(coreErr)
+	}
+	for _, tierEntity := range tiersEntity {
+		if tierEntity.Metadata.Name == entity.DefaultTierName {
+			return tiersEntity, nil
+		}
+	}
+	defaultTier := entity.TierDefault
+	return append(tiersEntity, &defaultTier), nil
+}
+
+func (ds *tier) Delete(ctx context.Context, name string) *ierror.Error {
+	if name == entity.DefaultTierName {
+		return httpbase.ErrBadRequest(ctx, "default tier can not be deleted, create it to change its order or default action")
+	}
+	gnps, coreErr := ds.storage.ListGNPs(ctx, nil)
+	if coreErr != nil {
+		return httpbase.ErrDatabase(ctx, "list global network policies failed").SetSubError(coreErr)
+	}
+	var gnpNames []string
+	for _, gnpEntity := range gnps {
+		if gnpEntity.TierName() == name {
+			gnpNames = append(gnpNames, gnpEntity.Metadata.Name)
+		}
+	}
+	if len(gnpNames) > 0 {
+		return httpbase.ErrBadRequest(ctx, fmt.Sprintf("tier is used by global network policies: %s", strings.Join(gnpNames, ", ")))
+	}
+	nps, coreErr := ds.storage.ListNetworkPolicies(ctx, 0)
+	if coreErr != nil {
+		return httpbase.ErrDatabase(ctx, "list network policies failed").SetSubError(coreErr)
+	}
+	var npNames []string
+	for _, npEntity := range nps {
+		if npEntity.TierName() == name {
+			npNames = append(npNames, fmt.Sprintf("%d/%s", npEntity.Metadata.TenantID, npEntity.Metadata.Name))
+		}
+	}
+	if len(npNames) > 0 {
+		return httpbase.ErrBadRequest(ctx, fmt.Sprintf("tier is used by network policies: %s", strings.Join(npNames, ", ")))
+	}
+
+	tierEntity, coreErr := ds.storage.GetTierByName(ctx, name)
+	if coreErr != nil && !errors.Is(coreErr, errlist.ErrNotFoundTier) {
+		return httpbase.ErrDatabase(ctx, "get tier failed").SetSubError(coreErr)
+	}
+	if coreErr = ds.storage.DeleteTierByName(ctx, name); coreErr != nil {
+		return httpbase.ErrDatabase(ctx, "delete tier failed").SetSubError(coreErr)
+	}
+	ds.snapshot.DeleteTier(name)
+	if tierEntity != nil {
+		notifyWebhooks(ctx, ds.storage, tierWebhookEvent(entity.WebhookActionDelete, tierEntity))
+	}
+	return nil
+}
+
+func (ds *tier) Validate(ctx context.Context, input *model.CreateTierInput) (*model.ValidateTierOutput, *ierror.Error) {
+	tierEntity := createModelToTierEntity(input)
+
+	tierExisted, coreErr := ds.storage.GetTierByName(ctx, input.Metadata.Name)
+	if coreErr != nil {
+		if !errors.Is(coreErr, errlist.ErrNotFoundTier) {
+			return nil, httpbase.ErrDatabase(ctx, "get tier failed").SetSubError(coreErr)
+		}
+	}
+
+	return &model.ValidateTierOutput{
+		Tier:        tierEntity,
+		TierExisted: tierExisted,
+	}, nil
+}
+
+// checkTierExists returns a bad request error if a policy refers to a tier which is not created.
+// listTiersByName returns the stored tiers by name.
+func listTiersByName(ctx context.Context, storage be.Storage) (map[string]*entity.Tier, *ierror.Error) {
+	tiers, coreErr := storage.ListTiers(ctx)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list tiers failed").SetSubError(coreErr)
+	}
+	tiersByName := make(map[string]*entity.Tier, len(tiers))
+	for _, tierEntity := range tiers {
+		tiersByName[tierEntity.Metadata.Name] = tierEntity
+	}
+	return tiersByName, nil
+}
+
+func checkTierExists(ctx context.Context, storage be.Storage, name string) *ierror.Error {
+	if name == "" || name == entity.DefaultTierName {
+		return nil
+	}
+	if _, coreErr := storage.GetTierByName(ctx, name); coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrNotFoundTier) {
+			return httpbase.ErrBadRequest(ctx, fmt.Sprintf("tier %s not found", name)).SetSubError(coreErr)
+		}
+		return httpbase.ErrDatabase(ctx, "get tier failed").SetSubError(coreErr)
+	}
+	return nil
+}
+
+func createModelToTierEntity(input *model.CreateTierInput) *entity.Tier {
+	var order uint32
+	if input.Spec.Order != nil {
+		order = *input.Spec.Order
+	} else {
+		order = entity.PolicyOrderLowest
+	}
+	defaultAction := strings.ToLower(input.Spec.DefaultAction)
+	if defaultAction == "" {
+		defaultAction = string(entity.RuleActionDeny)
+	}
+
+	return &entity.Tier{
+		ID:   primitive.NewObjectID(),
+		UUID: entity.NewMinifyUUID(),
+		Metadata: entity.TierMetadata{
+			Name:   input.Metadata.Name,
+			Labels: input.Metadata.Labels,
+		},
+		Spec: entity.TierSpec{
+			Order:         order,
+			DefaultAction: defaultAction,
+		},
+		Description: input.Description,
+		FilePath:    input.FilePath,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+func TestTierDeleteDefault(t *testing.T) {
+	storage := newFakeStorage()
+	storage.UpsertTier(context.Background(), &entity.Tier{Metadata: entity.TierMetadata{Name: entity.DefaultTierName}})
+	ds := &tier{storage: storage, snapshot: newPolicySnapshot(storage)}
+
+	ierr := ds.Delete(context.Background(), entity.DefaultTierName)
+	if ierr == nil || ierr.HTTPStatusCode != http.StatusBadRequest {
+		t.Fatalf("Delete(default) error = %v, want bad request", ierr)
+	}
+	if _, ok := storage.tiers[entity.DefaultTierName]; !ok {
+		t.Error("default tier deleted")
+	}
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/bamboo-firewall/be"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+	"github.com/bamboo-firewall/be/pkg/repository"
+	"github.com/bamboo-firewall/be/pkg/selector"
+)
+
+const defaultWebhookDeliveriesLimit = 100
+
+func NewWebhook(policyMongo *repository.PolicyDB) *webhookService {
+	return &webhookService{
+		storage: policyMongo,
+	}
+}
+
+type webhookService struct {
+	storage be.Storage
+}
+
+func (ds *webhookService) Create(ctx context.Context, input *model.CreateWebhookInput) (*entity.Webhook, *ierror.Error) {
+	webhookEntity := createModelToWebhookEntity(input)
+
+	if coreErr := ds.storage.UpsertWebhook(ctx, webhookEntity); coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrDuplicateWebhook) {
+			return nil, httpbase.ErrBadRequest(ctx, "duplicate webhook").SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "create webhook failed").SetSubError(coreErr)
+	}
+	return webhookEntity, nil
+}
+
+func (ds *webhookService) Get(ctx context.Context, name string) (*entity.Webhook, *ierror.Error) {
+	webhookEntity, coreErr := ds.storage.GetWebhookByName(ctx, name)
+	if coreErr != nil {
+		if errors.Is(coreErr, errlist.ErrNotFoundWebhook) {
+			return nil, httpbase.ErrNotFound(ctx, "not found").SetSubError(coreErr)
+		}
+		return nil, httpbase.ErrDatabase(ctx, "get webhook failed").SetSubError(coreErr)
+	}
+	return webhookEntity, nil
+}
+
+func (ds *webhookService) List(ctx context.Context) ([]*entity.Webhook, *ierror.Error) {
+	webhooksEntity, coreErr := ds.storage.ListWebhooks(ctx)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list webhooks failed").SetSubError(coreErr)
+	}
+	return webhooksEntity, nil
+}
+
+// Delete removes the webhook, its pending deliveries fail on their next attempt.
+func (ds *webhookService) Delete(ctx context.Context, name string) *ierror.Error {
+	if coreErr := ds.storage.DeleteWebhookByName(ctx, name); coreErr != nil {
+		return httpbase.ErrDatabase(ctx, "delete webhook failed").SetSubError(coreErr)
+	}
+	return nil
+}
+
+func (ds *webhookService) Validate(ctx context.Context, input *model.CreateWebhookInput) (*model.ValidateWebhookOutput, *ierror.Error) {
+	webhookEntity := createModelToWebhookEntity(input)
+
+	webhookExisted, coreErr := ds.storage.GetWebhookByName(ctx, input.Metadata.Name)
+	if coreErr != nil {
+		if !errors.Is(coreErr, errlist.ErrNotFoundWebhook) {
+			return nil, httpbase.ErrDatabase(ctx, "get webhook failed").SetSubError(coreErr)
+		}
+	}
+	if webhookExisted != nil && webhookEntity.Spec.Secret == "" {
+		webhookEntity.Spec.Secret = webhookExisted.Spec.Secret
+	}
+
+	return &model.ValidateWebhookOutput{
+		Webhook:        webhookEntity,
+		WebhookExisted: webhookExisted,
+	}, nil
+}
+
+func (ds *webhookService) ListDeliveries(ctx context.Context, input *model.ListWebhookDeliveriesInput) ([]*entity.WebhookDelivery, *ierror.Error) {
+	if input.Limit <= 0 {
+		input.Limit = defaultWebhookDeliveriesLimit
+	}
+	deliveries, coreErr := ds.storage.ListWebhookDeliveries(ctx, input)
+	if coreErr != nil {
+		return nil, httpbase.ErrDatabase(ctx, "list webhook deliveries failed").SetSubError(coreErr)
+	}
+	return deliveries, nil
+}
+
+func createModelToWebhookEntity(input *model.CreateWebhookInput) *entity.Webhook {
+	return &entity.Webhook{
+		ID:   primitive.NewObjectID(),
+		UUID: entity.NewMinifyUUID(),
+		Metadata: entity.WebhookMetadata{
+			Name:   input.Metadata.Name,
+			Labels: input.Metadata.Labels,
+		},
+		Spec: entity.WebhookSpec{
+			URL:      input.Spec.URL,
+			Secret:   input.Spec.Secret,
+			Kinds:    input.Spec.Kinds,
+			Actions:  input.Spec.Actions,
+			Selector: input.Spec.Selector,
+		},
+		Description: input.Description,
+		FilePath:    input.FilePath,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// upsertAction returns the webhook action of a resource upserted to version.
+func upsertAction(version uint) string {
+	if version <= 1 {
+		return entity.WebhookActionCreate
+	}
+	return entity.WebhookActionUpdate
+}
+
+// notifyWebhooks queues a delivery of event to every webhook matching it, see WebhookDispatcher. The change is
+// already stored so a failure is only logged.
+func notifyWebhooks(ctx context.Context, storage be.Storage, event entity.WebhookEvent) {
+	webhooks, coreErr := storage.ListWebhooks(ctx)
+	if coreErr != nil {
+		slog.Warn("list webhooks failed", "kind", event.Kind, "name", event.Name, "err", coreErr)
+		return
+	}
+
+	now := time.Now()
+	event.ID = entity.NewMinifyUUID()
+	event.OccurredAt = now
+	var deliveries []*entity.WebhookDelivery
+	for _, webhookEntity := range webhooks {
+		if !webhookMatches(webhookEntity, &event) {
+			continue
+		}
+		deliveries = append(deliveries, &entity.WebhookDelivery{
+			ID:            primitive.NewObjectID(),
+			UUID:          entity.NewMinifyUUID(),
+			WebhookName:   webhookEntity.Metadata.Name,
+			Event:         event,
+			Status:        entity.WebhookDeliveryStatusPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+	}
+	if coreErr = storage.InsertWebhookDeliveries(ctx, deliveries); coreErr != nil {
+		slog.Warn("queue webhook deliveries failed", "kind", event.Kind, "name", event.Name, "err", coreErr)
+	}
+}
+
+func webhookMatches(webhookEntity *entity.Webhook, event *entity.WebhookEvent) bool {
+	if len(webhookEntity.Spec.Kinds) > 0 && !slices.Contains(webhookEntity.Spec.Kinds, event.Kind) {
+		return false
+	}
+	if len(webhookEntity.Spec.Actions) > 0 && !slices.Contains(webhookEntity.Spec.Actions, event.Action) {
+		return false
+	}
+	if webhookEntity.Spec.Selector == "" {
+		return true
+	}
+	sel, err := selector.Parse(webhookEntity.Spec.Selector)
+	if err != nil {
+		slog.Warn("malformed selector", "webhook", webhookEntity.Metadata.Name, "selector", webhookEntity.Spec.Selector, "err", err)
+		return false
+	}
+	return sel.EvaluateLabels(selector.WithTenant(event.Labels, event.TenantID))
+}
+
+func hepWebhookEvent(action string, hepEntity *entity.HostEndpoint) entity.WebhookEvent {
+	return entity.WebhookEvent{
+		Kind:     entity.WebhookKindHostEndpoint,
+		Action:   action,
+		Name:     hepEntity.Metadata.Name,
+		TenantID: hepEntity.Spec.TenantID,
+		UUID:     hepEntity.UUID,
+		Version:  hepEntity.Version,
+		Labels:   hepEntity.Metadata.Labels,
+	}
+}
+
+// policyWebhookEvent returns the event of a global network policy or of a network policy depending on kind.
+func policyWebhookEvent(kind, action string, policy *entity.GlobalNetworkPolicy) entity.WebhookEvent {
+	return entity.WebhookEvent{
+		Kind:     kind,
+		Action:   action,
+		Name:     policy.Metadata.Name,
+		TenantID: policy.Metadata.TenantID,
+		UUID:     policy.UUID,
+		Version:  policy.Version,
+		Labels:   policy.Metadata.Labels,
+	}
+}
+
+// setWebhookEvent returns the event of a global network set or of a network set depending on kind.
+func setWebhookEvent(kind, action string, set *entity.GlobalNetworkSet) entity.WebhookEvent {
+	return entity.WebhookEvent{
+		Kind:     kind,
+		Action:   action,
+		Name:     set.Metadata.Name,
+		TenantID: set.Metadata.TenantID,
+		UUID:     set.UUID,
+		Version:  set.Version,
+		Labels:   set.Metadata.Labels,
+	}
+}
+
+func tierWebhookEvent(action string, tierEntity *entity.Tier) entity.WebhookEvent {
+	return entity.WebhookEvent{
+		Kind:    entity.WebhookKindTier,
+		Action:  action,
+		Name:    tierEntity.Metadata.Name,
+		UUID:    tierEntity.UUID,
+		Version: tierEntity.Version,
+		Labels:  tierEntity.Metadata.Labels,
+	}
+}
+
+func tenantWebhookEvent(action string, tenantEntity *entity.Tenant) entity.WebhookEvent {
+	return entity.WebhookEvent{
+		Kind:     entity.WebhookKindTenant,
+		Action:   action,
+		Name:     tenantEntity.Metadata.Name,
+		TenantID: tenantEntity.Spec.TenantID,
+		UUID:     tenantEntity.UUID,
+		Version:  tenantEntity.Version,
+		Labels:   tenantEntity.Metadata.Labels,
+	}
+}
+
+func enrollmentTokenWebhookEvent(action string, tokenEntity *entity.EnrollmentToken) entity.WebhookEvent {
+	return entity.WebhookEvent{
+		Kind:     entity.WebhookKindEnrollmentToken,
+		Action:   action,
+		Name:     tokenEntity.Metadata.Name,
+		TenantID: tokenEntity.Spec.TenantID,
+		UUID:     tokenEntity.UUID,
+		Version:  tokenEntity.Version,
+		Labels:   tokenEntity.Metadata.Labels,
+	}
+}
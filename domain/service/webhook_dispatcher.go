@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/bamboo-firewall/be"
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/netsource"
+	"github.com/bamboo-firewall/be/pkg/repository"
+	"github.com/bamboo-firewall/be/pkg/webhook"
+)
+
+const (
+	webhookDeliveryTimeout = 10 * time.Second
+	// webhookDeliveryLease postpones a claimed delivery, it must exceed the delivery timeout
+	webhookDeliveryLease       = time.Minute
+	webhookDeliveryMaxAttempts = 8
+	webhookBackoffBase         = 10 * time.Second
+	webhookBackoffMax          = time.Hour
+)
+
+// NewWebhookDispatcher delivers to public addresses only unless allowPrivateURLs is set, as the sources of sets.
+func NewWebhookDispatcher(policyMongo *repository.PolicyDB, allowPrivateURLs bool) *WebhookDispatcher {
+	client := netsource.NewPublicClient(webhookDeliveryTimeout)
+	if allowPrivateURLs {
+		client = &http.Client{Timeout: webhookDeliveryTimeout}
+	}
+	return &WebhookDispatcher{
+		storage: policyMongo,
+		sender:  webhook.NewSender(client),
+	}
+}
+
+// WebhookDispatcher delivers the events queued by notifyWebhooks. A failed delivery is retried with an exponential
+// backoff, up to webhookDeliveryMaxAttempts attempts.
+type WebhookDispatcher struct {
+	storage be.Storage
+	sender  *webhook.Sender
+}
+
+// Run delivers every interval the deliveries which are due.
+func (d *WebhookDispatcher) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.deliverDue(ctx)
+		}
+	}
+}
+
+func (d *WebhookDispatcher) deliverDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		delivery, coreErr := d.storage.ClaimWebhookDelivery(ctx, time.Now(), webhookDeliveryLease)
+		if coreErr != nil {
+			slog.Warn("claim webhook delivery failed", "err", coreErr)
+			return
+		}
+		if delivery == nil {
+			return
+		}
+		d.Deliver(ctx, delivery)
+	}
+}
+
+// Deliver makes an attempt of delivery and records its result.
+func (d *WebhookDispatcher) Deliver(ctx context.Context, delivery *entity.WebhookDelivery) {
+	statusCode, err := d.send(ctx, delivery)
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	delivery.UpdatedAt = now
+	switch {
+	case err == nil:
+		delivery.Status = entity.WebhookDeliveryStatusSucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	case errors.Is(err, errlist.ErrNotFoundWebhook) || delivery.Attempts >= webhookDeliveryMaxAttempts:
+		delivery.Status = entity.WebhookDeliveryStatusFailed
+		delivery.LastError = err.Error()
+	default:
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(webhook.Backoff(delivery.Attempts, webhookBackoffBase, webhookBackoffMax))
+	}
+	if err != nil {
+		slog.Warn("deliver webhook failed", "webhook", delivery.WebhookName, "delivery", delivery.UUID,
+			"attempts", delivery.Attempts, "status", delivery.Status, "err", err)
+	}
+
+	if coreErr := d.storage.UpdateWebhookDelivery(ctx, delivery); coreErr != nil {
+		slog.Warn("update webhook delivery failed", "delivery", delivery.UUID, "err", coreErr)
+	}
+}
+
+func (d *WebhookDispatcher) send(ctx context.Context, delivery *entity.WebhookDelivery) (int, error) {
+	webhookEntity, coreErr := d.storage.GetWebhookByName(ctx, delivery.WebhookName)
+	if coreErr != nil {
+		return 0, coreErr
+	}
+
+	event := delivery.Event
+	body, err := json.Marshal(&model.WebhookEventPayload{
+		ID:         event.ID,
+		Webhook:    delivery.WebhookName,
+		Kind:       event.Kind,
+		Action:     event.Action,
+		Name:       event.Name,
+		TenantID:   event.TenantID,
+		UUID:       event.UUID,
+		Version:    event.Version,
+		Labels:     event.Labels,
+		OccurredAt: event.OccurredAt,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("marshal event: %w", err)
+	}
+	return d.sender.Send(ctx, webhook.Request{
+		URL:        webhookEntity.Spec.URL,
+		Secret:     webhookEntity.Spec.Secret,
+		Event:      event.Kind + "." + event.Action,
+		DeliveryID: delivery.UUID,
+		Body:       body,
+	})
+}
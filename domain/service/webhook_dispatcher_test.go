@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/netsource"
+	"github.com/bamboo-firewall/be/pkg/webhook"
+)
+
+func TestWebhookDispatcherDeliver(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		deleted bool
+		// public limits the deliveries to public addresses, which the receiver is not
+		public         bool
+		attempts       int
+		wantStatus     string
+		wantStatusCode int
+		// wantBackoff is the delay of the next attempt of a rescheduled delivery
+		wantBackoff time.Duration
+		wantError   string
+		wantPosts   int32
+	}{
+		{
+			name:           "delivered",
+			status:         http.StatusOK,
+			wantStatus:     entity.WebhookDeliveryStatusSucceeded,
+			wantStatusCode: http.StatusOK,
+			wantPosts:      1,
+		},
+		{
+			name:           "server error rescheduled",
+			status:         http.StatusBadGateway,
+			attempts:       2,
+			wantStatus:     entity.WebhookDeliveryStatusPending,
+			wantStatusCode: http.StatusBadGateway,
+			wantBackoff:    4 * webhookBackoffBase,
+			wantError:      "unexpected status 502",
+			wantPosts:      1,
+		},
+		{
+			name:           "last attempt failed",
+			status:         http.StatusInternalServerError,
+			attempts:       webhookDeliveryMaxAttempts - 1,
+			wantStatus:     entity.WebhookDeliveryStatusFailed,
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      "unexpected status 500",
+			wantPosts:      1,
+		},
+		{
+			name:       "webhook deleted",
+			status:     http.StatusOK,
+			deleted:    true,
+			wantStatus: entity.WebhookDeliveryStatusFailed,
+			wantError:  "err_not_found_webhook",
+		},
+		{
+			name:        "private address refused",
+			status:      http.StatusOK,
+			public:      true,
+			wantStatus:  entity.WebhookDeliveryStatusPending,
+			wantBackoff: webhookBackoffBase,
+			wantError:   "is not public",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			var posts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				posts.Add(1)
+				if got := r.Header.Get(webhook.HeaderEvent); got != "hostEndpoint.create" {
+					t.Errorf("event header = %q, want hostEndpoint.create", got)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			storage := newFakeStorage()
+			if !tt.deleted {
+				storage.webhooks = []*entity.Webhook{{
+					Metadata: entity.WebhookMetadata{Name: "audit"},
+					Spec:     entity.WebhookSpec{URL: server.URL},
+				}}
+			}
+			delivery := &entity.WebhookDelivery{
+				ID:          primitive.NewObjectID(),
+				UUID:        "delivery",
+				WebhookName: "audit",
+				Event:       entity.WebhookEvent{Kind: entity.WebhookKindHostEndpoint, Action: entity.WebhookActionCreate, Name: "web"},
+				Status:      entity.WebhookDeliveryStatusPending,
+				Attempts:    tt.attempts,
+			}
+			_ = storage.InsertWebhookDeliveries(ctx, []*entity.WebhookDelivery{delivery})
+			client := server.Client()
+			if tt.public {
+				client = netsource.NewPublicClient(webhookDeliveryTimeout)
+			}
+			dispatcher := &WebhookDispatcher{storage: storage, sender: webhook.NewSender(client)}
+
+			before := time.Now()
+			dispatcher.Deliver(ctx, delivery)
+			after := time.Now()
+
+			if got := posts.Load(); got != tt.wantPosts {
+				t.Errorf("posts = %d, want %d", got, tt.wantPosts)
+			}
+			stored := storage.deliveries[0]
+			if stored.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", stored.Status, tt.wantStatus)
+			}
+			if stored.Attempts != tt.attempts+1 {
+				t.Errorf("attempts = %d, want %d", stored.Attempts, tt.attempts+1)
+			}
+			if stored.LastStatusCode != tt.wantStatusCode {
+				t.Errorf("last status code = %d, want %d", stored.LastStatusCode, tt.wantStatusCode)
+			}
+			if tt.wantError == "" && stored.LastError != "" || !strings.Contains(stored.LastError, tt.wantError) {
+				t.Errorf("last error = %q, want %q", stored.LastError, tt.wantError)
+			}
+			if (stored.DeliveredAt != nil) != (tt.wantStatus == entity.WebhookDeliveryStatusSucceeded) {
+				t.Errorf("delivered at = %v with status %q", stored.DeliveredAt, stored.Status)
+			}
+			if tt.wantBackoff > 0 && (stored.NextAttemptAt.Before(before.Add(tt.wantBackoff)) ||
+				stored.NextAttemptAt.After(after.Add(tt.wantBackoff))) {
+				t.Errorf("next attempt at = %v, want %v after %v", stored.NextAttemptAt, tt.wantBackoff, before)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+func TestNotifyWebhooks(t *testing.T) {
+	ctx := context.Background()
+	storage := newFakeStorage()
+	storage.webhooks = []*entity.Webhook{
+		{Metadata: entity.WebhookMetadata{Name: "any"}},
+		{Metadata: entity.WebhookMetadata{Name: "heps"}, Spec: entity.WebhookSpec{Kinds: []string{entity.WebhookKindHostEndpoint}}},
+		{Metadata: entity.WebhookMetadata{Name: "policies"}, Spec: entity.WebhookSpec{Kinds: []string{entity.WebhookKindGlobalNetworkPolicy}}},
+		{Metadata: entity.WebhookMetadata{Name: "creates"}, Spec: entity.WebhookSpec{Actions: []string{entity.WebhookActionCreate}}},
+		{Metadata: entity.WebhookMetadata{Name: "deletes"}, Spec: entity.WebhookSpec{Actions: []string{entity.WebhookActionDelete}}},
+		{Metadata: entity.WebhookMetadata{Name: "web"}, Spec: entity.WebhookSpec{Selector: "app == 'web'"}},
+		{Metadata: entity.WebhookMetadata{Name: "db"}, Spec: entity.WebhookSpec{Selector: "app == 'db'"}},
+		{Metadata: entity.WebhookMetadata{Name: "tenant-2"}, Spec: entity.WebhookSpec{Selector: "tenant == '2'"}},
+		{Metadata: entity.WebhookMetadata{Name: "tenant-3"}, Spec: entity.WebhookSpec{Selector: "tenant == '3'"}},
+		{Metadata: entity.WebhookMetadata{Name: "malformed"}, Spec: entity.WebhookSpec{Selector: "app =="}},
+	}
+	hep := testHEP("web", bulkIP("10.0.0.1"), map[string]string{"app": "web"})
+	hep.Spec.TenantID = 2
+
+	notifyWebhooks(ctx, storage, hepWebhookEvent(entity.WebhookActionCreate, hep))
+
+	var got []string
+	for _, delivery := range storage.deliveries {
+		got = append(got, delivery.WebhookName)
+		if delivery.Status != entity.WebhookDeliveryStatusPending || delivery.Event.ID == "" || delivery.Event.Name != "web" {
+			t.Errorf("delivery %+v is not a pending delivery of the event", delivery)
+		}
+	}
+	if want := []string{"any", "heps", "creates", "web", "tenant-2"}; !slices.Equal(got, want) {
+		t.Errorf("deliveries to %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,84 @@
+// Package agentclient is the grpc client of the agent api of the server, see api/v1/pb/agent.proto.
+package agentclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/bamboo-firewall/be/api/v1/pb"
+)
+
+type Client struct {
+	conn   *grpc.ClientConn
+	policy pb.AgentPolicyClient
+}
+
+// New returns a client of the server at address. The connection is not encrypted unless opts sets the transport
+// credentials.
+func New(address string, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.NewClient(address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create grpc client: %w", err)
+	}
+	return &Client{
+		conn:   conn,
+		policy: pb.NewAgentPolicyClient(conn),
+	}, nil
+}
+
+// WithToken authenticates the client with an enrollment token allowing the host endpoints it gets the policy of. The
+// token is sent on connections which are not encrypted too.
+func WithToken(token string) grpc.DialOption {
+	return grpc.WithPerRPCCredentials(tokenCredentials(token))
+}
+
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Fetch returns the whole policy of the host endpoint of tenantID with ip.
+func (c *Client) Fetch(ctx context.Context, tenantID uint64, ip string) (*pb.HostEndpointPolicy, error) {
+	policy, err := c.policy.Fetch(ctx, &pb.FetchRequest{TenantId: tenantID, Ip: ip})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch policy: %w", err)
+	}
+	return policy, nil
+}
+
+// Watch calls handle with the changes of the policy of the host endpoint of tenantID with ip since known, nil to
+// receive the whole policy, until ctx is done, the stream fails or handle returns an error.
+func (c *Client) Watch(ctx context.Context, tenantID uint64, ip string, known *pb.HostEndpointPolicyMetadata,
+	handle func(*pb.HostEndpointPolicyDelta) error) error {
+	stream, err := c.policy.Watch(ctx, &pb.WatchRequest{TenantId: tenantID, Ip: ip, Known: known})
+	if err != nil {
+		return fmt.Errorf("failed to watch policy: %w", err)
+	}
+	for {
+		delta, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to receive policy: %w", err)
+		}
+		if err = handle(delta); err != nil {
+			return err
+		}
+	}
+}
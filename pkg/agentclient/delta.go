@@ -0,0 +1,70 @@
+package agentclient
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/bamboo-firewall/be/api/v1/pb"
+)
+
+// ApplyDelta returns the policy resulting of a delta received by Watch on policy, nil for an agent starting without
+// policy. The policies are ordered as listed by the delta, the endpoints and the sets by uuid.
+func ApplyDelta(policy *pb.HostEndpointPolicy, delta *pb.HostEndpointPolicyDelta) *pb.HostEndpointPolicy {
+	gnps := make(map[string]*pb.ParsedGNP)
+	heps := make(map[string]*pb.ParsedHEP)
+	gnss := make(map[string]*pb.ParsedGNS)
+	if policy != nil {
+		for _, gnp := range policy.GetParsedGnps() {
+			gnps[gnp.GetUuid()] = gnp
+		}
+		for _, hep := range policy.GetParsedHeps() {
+			heps[hep.GetUuid()] = hep
+		}
+		for _, gns := range policy.GetParsedGnss() {
+			gnss[gns.GetUuid()] = gns
+		}
+	}
+
+	for _, gnp := range delta.GetParsedGnps() {
+		gnps[gnp.GetUuid()] = gnp
+	}
+	for _, hep := range delta.GetParsedHeps() {
+		heps[hep.GetUuid()] = hep
+	}
+	for _, gns := range delta.GetParsedGnss() {
+		gnss[gns.GetUuid()] = gns
+	}
+	for _, uuid := range delta.GetRemovedHeps() {
+		delete(heps, uuid)
+	}
+	for _, uuid := range delta.GetRemovedGnss() {
+		delete(gnss, uuid)
+	}
+
+	result := &pb.HostEndpointPolicy{
+		Metadata:     delta.GetMetadata(),
+		HostEndpoint: delta.GetHostEndpoint(),
+		ParsedTiers:  delta.GetParsedTiers(),
+		ParsedGnps:   make([]*pb.ParsedGNP, 0, len(delta.GetGnpUuids())),
+		ParsedHeps:   make([]*pb.ParsedHEP, 0, len(heps)),
+		ParsedGnss:   make([]*pb.ParsedGNS, 0, len(gnss)),
+	}
+	for _, uuid := range delta.GetGnpUuids() {
+		if gnp, ok := gnps[uuid]; ok {
+			result.ParsedGnps = append(result.ParsedGnps, gnp)
+		}
+	}
+	for uuid := range delta.GetMetadata().GetHepVersions() {
+		if hep, ok := heps[uuid]; ok {
+			result.ParsedHeps = append(result.ParsedHeps, hep)
+		}
+	}
+	for uuid := range delta.GetMetadata().GetGnsVersions() {
+		if gns, ok := gnss[uuid]; ok {
+			result.ParsedGnss = append(result.ParsedGnss, gns)
+		}
+	}
+	slices.SortFunc(result.ParsedHeps, func(a, b *pb.ParsedHEP) int { return strings.Compare(a.GetUuid(), b.GetUuid()) })
+	slices.SortFunc(result.ParsedGnss, func(a, b *pb.ParsedGNS) int { return strings.Compare(a.GetUuid(), b.GetUuid()) })
+	return result
+}
@@ -0,0 +1,51 @@
+// Package backup handles the schema version of the bundles written by the backup of the policy database.
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SchemaVersion is the schema version of the bundles written by the backup.
+const SchemaVersion = 1
+
+const schemaVersionField = "schemaVersion"
+
+// upgrades holds, by schema version, the upgrade of a bundle to the next schema version. A step gets the decoded
+// bundle and updates it in place.
+var upgrades = map[int]func(bundle map[string]interface{}) error{}
+
+// Upgrade returns data, a bundle of any schema version up to SchemaVersion, upgraded to SchemaVersion.
+func Upgrade(data []byte) ([]byte, error) {
+	return upgradeTo(data, SchemaVersion)
+}
+
+func upgradeTo(data []byte, target int) ([]byte, error) {
+	var bundle map[string]interface{}
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("decode bundle: %w", err)
+	}
+	rawVersion, ok := bundle[schemaVersionField].(float64)
+	if !ok {
+		return nil, errors.New("bundle has no schema version")
+	}
+	version := int(rawVersion)
+	if version < 1 || version > target {
+		return nil, fmt.Errorf("unsupported bundle schema version %d, expected 1 to %d", version, target)
+	}
+	if version == target {
+		return data, nil
+	}
+	for ; version < target; version++ {
+		upgrade, ok := upgrades[version]
+		if !ok {
+			return nil, fmt.Errorf("no upgrade of bundle schema version %d", version)
+		}
+		if err := upgrade(bundle); err != nil {
+			return nil, fmt.Errorf("upgrade bundle schema version %d: %w", version, err)
+		}
+	}
+	bundle[schemaVersionField] = target
+	return json.Marshal(bundle)
+}
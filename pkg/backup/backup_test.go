@@ -0,0 +1,56 @@
+package backup
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUpgradeCurrentVersion(t *testing.T) {
+	data := []byte(`{"schemaVersion":1,"hostEndpoints":[]}`)
+	upgraded, err := Upgrade(data)
+	if err != nil {
+		t.Fatalf("Upgrade() error = %v", err)
+	}
+	if string(upgraded) != string(data) {
+		t.Errorf("Upgrade() = %s, want %s", upgraded, data)
+	}
+}
+
+func TestUpgradeUnsupportedVersion(t *testing.T) {
+	for _, data := range []string{`{}`, `{"schemaVersion":0}`, `{"schemaVersion":99}`, `[]`} {
+		if _, err := Upgrade([]byte(data)); err == nil {
+			t.Errorf("Upgrade(%s) error = nil, want an error", data)
+		}
+	}
+}
+
+func TestUpgradeSteps(t *testing.T) {
+	upgrades[1] = func(bundle map[string]interface{}) error {
+		bundle["hostEndpoints"] = bundle["heps"]
+		delete(bundle, "heps")
+		return nil
+	}
+	defer delete(upgrades, 1)
+
+	upgraded, err := upgradeTo([]byte(`{"schemaVersion":1,"heps":["a"]}`), 2)
+	if err != nil {
+		t.Fatalf("upgradeTo() error = %v", err)
+	}
+	var bundle map[string]interface{}
+	if err = json.Unmarshal(upgraded, &bundle); err != nil {
+		t.Fatalf("decode upgraded bundle: %v", err)
+	}
+	if bundle["schemaVersion"] != float64(2) {
+		t.Errorf("schemaVersion = %v, want 2", bundle["schemaVersion"])
+	}
+	if _, ok := bundle["heps"]; ok {
+		t.Errorf("bundle = %v, want heps renamed", bundle)
+	}
+	if _, ok := bundle["hostEndpoints"]; !ok {
+		t.Errorf("bundle = %v, want hostEndpoints", bundle)
+	}
+
+	if _, err = upgradeTo([]byte(`{"schemaVersion":1}`), 3); err == nil {
+		t.Errorf("upgradeTo() error = nil, want an error for the missing upgrade of schema version 2")
+	}
+}
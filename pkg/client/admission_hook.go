@@ -0,0 +1,123 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+)
+
+func (c *apiServer) CreateAdmissionHook(ctx context.Context, input *dto.CreateAdmissionHookInput) error {
+	inputBytes, _ := json.Marshal(input)
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/admissionHooks").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return fmt.Errorf("failed to create admission hook: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return responseBodyToIError(ctx, res)
+	}
+
+	return nil
+}
+
+func (c *apiServer) ListAdmissionHooks(ctx context.Context) ([]*dto.AdmissionHook, error) {
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/admissionHooks").
+		SetMethod(http.MethodGet).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to list admission hooks: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var admissionHooks []*dto.AdmissionHook
+	if err := json.Unmarshal(res.Body, &admissionHooks); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when list admission hooks, response: %s, err: %w", string(res.Body), err)
+	}
+	return admissionHooks, nil
+}
+
+func (c *apiServer) GetAdmissionHook(ctx context.Context, input *dto.GetAdmissionHookInput) (*dto.AdmissionHook, error) {
+	res := c.client.NewRequest().
+		SetSubURL(fmt.Sprintf("/api/v1/admissionHooks/byName/%s", input.Name)).
+		SetMethod(http.MethodGet).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to get admission hook by name: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var admissionHook *dto.AdmissionHook
+	if err := json.Unmarshal(res.Body, &admissionHook); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when get admission hook by name, response: %s, err: %w", string(res.Body), err)
+	}
+	return admissionHook, nil
+}
+
+func (c *apiServer) DeleteAdmissionHook(ctx context.Context, input *dto.DeleteAdmissionHookInput) error {
+	inputBytes, _ := json.Marshal(input)
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/admissionHooks").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodDelete).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return fmt.Errorf("failed to delete admission hook: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return responseBodyToIError(ctx, res)
+	}
+
+	return nil
+}
+
+func (c *apiServer) ValidateAdmissionHook(ctx context.Context, input *dto.CreateAdmissionHookInput) (*dto.ValidateAdmissionHookOutput, error) {
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input to validate admission hook: %w", err)
+	}
+
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/admissionHooks/validate").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to validate admission hook: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var validateAdmissionHookOutput *dto.ValidateAdmissionHookOutput
+	if err = json.Unmarshal(res.Body, &validateAdmissionHookOutput); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when validate admission hook response: %s, err: %w", string(res.Body), err)
+	}
+
+	return validateAdmissionHookOutput, nil
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+)
+
+func (c *apiServer) ListAgents(ctx context.Context, input *dto.ListAgentsInput) ([]*dto.Agent, error) {
+	req := c.client.NewRequest().
+		SetSubURL("/api/v1/agents").
+		SetMethod(http.MethodGet)
+	if input != nil {
+		if input.TenantID != 0 {
+			req.SetParam("tenantID", strconv.FormatUint(input.TenantID, 10))
+		}
+		if input.StaleAfter != "" {
+			req.SetParam("staleAfter", input.StaleAfter)
+		}
+	}
+	res := req.DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to list agents: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var agents []*dto.Agent
+	if err := json.Unmarshal(res.Body, &agents); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when list agents, response: %s, err: %w", string(res.Body), err)
+	}
+	return agents, nil
+}
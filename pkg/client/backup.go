@@ -0,0 +1,53 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+)
+
+// Backup returns the bundle of the policy database as sent by the api server, so that it is written as is.
+func (c *apiServer) Backup(ctx context.Context) ([]byte, error) {
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/backup").
+		SetMethod(http.MethodGet).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to backup: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+	return res.Body, nil
+}
+
+func (c *apiServer) Restore(ctx context.Context, input *dto.RestoreInput) (*dto.RestoreOutput, error) {
+	inputBytes, _ := json.Marshal(input)
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/restore").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to restore: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var output *dto.RestoreOutput
+	if err := json.Unmarshal(res.Body, &output); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when restore, response: %s, err: %w", string(res.Body), err)
+	}
+	return output, nil
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+)
+
+func (c *apiServer) BulkApply(ctx context.Context, input *dto.BulkApplyInput) (*dto.BulkApplyOutput, error) {
+	inputBytes, _ := json.Marshal(input)
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/bulk").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to apply bulk: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var output *dto.BulkApplyOutput
+	if err := json.Unmarshal(res.Body, &output); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when apply bulk, response: %s, err: %w", string(res.Body), err)
+	}
+	return output, nil
+}
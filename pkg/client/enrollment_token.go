@@ -0,0 +1,127 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+)
+
+func (c *apiServer) CreateEnrollmentToken(ctx context.Context, input *dto.CreateEnrollmentTokenInput) (*dto.EnrollmentToken, error) {
+	inputBytes, _ := json.Marshal(input)
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/enrollmentTokens").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to create enrollment token: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var token *dto.EnrollmentToken
+	if err := json.Unmarshal(res.Body, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when create enrollment token, response: %s, err: %w", string(res.Body), err)
+	}
+	return token, nil
+}
+
+func (c *apiServer) ListEnrollmentTokens(ctx context.Context) ([]*dto.EnrollmentToken, error) {
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/enrollmentTokens").
+		SetMethod(http.MethodGet).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to list enrollment tokens: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var tokens []*dto.EnrollmentToken
+	if err := json.Unmarshal(res.Body, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when list enrollment tokens, response: %s, err: %w", string(res.Body), err)
+	}
+	return tokens, nil
+}
+
+func (c *apiServer) GetEnrollmentToken(ctx context.Context, input *dto.GetEnrollmentTokenInput) (*dto.EnrollmentToken, error) {
+	res := c.client.NewRequest().
+		SetSubURL(fmt.Sprintf("/api/v1/enrollmentTokens/byName/%s", input.Name)).
+		SetMethod(http.MethodGet).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to get enrollment token by name: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var token *dto.EnrollmentToken
+	if err := json.Unmarshal(res.Body, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when get enrollment token by name, response: %s, err: %w", string(res.Body), err)
+	}
+	return token, nil
+}
+
+func (c *apiServer) DeleteEnrollmentToken(ctx context.Context, input *dto.DeleteEnrollmentTokenInput) error {
+	inputBytes, _ := json.Marshal(input)
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/enrollmentTokens").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodDelete).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return fmt.Errorf("failed to delete enrollment token: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return responseBodyToIError(ctx, res)
+	}
+
+	return nil
+}
+
+func (c *apiServer) ValidateEnrollmentToken(ctx context.Context, input *dto.CreateEnrollmentTokenInput) (*dto.ValidateEnrollmentTokenOutput, error) {
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input to validate enrollment token: %w", err)
+	}
+
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/enrollmentTokens/validate").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to validate enrollment token: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var validateEnrollmentTokenOutput *dto.ValidateEnrollmentTokenOutput
+	if err = json.Unmarshal(res.Body, &validateEnrollmentTokenOutput); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when validate enrollment token response: %s, err: %w", string(res.Body), err)
+	}
+
+	return validateEnrollmentTokenOutput, nil
+}
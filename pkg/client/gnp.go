@@ -33,11 +33,14 @@ func (c *apiServer) CreateGNP(ctx context.Context, input *dto.CreateGlobalNetwor
 }
 
 func (c *apiServer) ListGNPs(ctx context.Context, input *dto.ListGNPsInput) ([]*dto.GlobalNetworkPolicy, error) {
-	res := c.client.NewRequest().
+	req := c.client.NewRequest().
 		SetSubURL("/api/v1/globalNetworkPolicies").
 		SetParam("isOrder", strconv.FormatBool(input.IsOrder)).
-		SetMethod(http.MethodGet).
-		DoRequest(ctx)
+		SetMethod(http.MethodGet)
+	if input.ExpiringWithin != "" {
+		req.SetParam("expiringWithin", input.ExpiringWithin)
+	}
+	res := req.DoRequest(ctx)
 
 	if res.Err != nil {
 		return nil, fmt.Errorf("failed to list gnp by name: %w", res.Err)
@@ -123,3 +126,48 @@ func (c *apiServer) ValidateGlobalNetworkPolicy(ctx context.Context, input *dto.
 
 	return validateGlobalNetworkPolicyOutput, nil
 }
+
+func (c *apiServer) PromoteGNP(ctx context.Context, input *dto.PromoteGlobalNetworkPolicyInput) (*dto.GlobalNetworkPolicy, error) {
+	inputBytes, _ := json.Marshal(input)
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/globalNetworkPolicies/promote").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to promote globalnetworkpolicy: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var gnp *dto.GlobalNetworkPolicy
+	if err := json.Unmarshal(res.Body, &gnp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when promote globalnetworkpolicy, response: %s, err: %w", string(res.Body), err)
+	}
+	return gnp, nil
+}
+
+func (c *apiServer) GetGNPStagedReport(ctx context.Context, input *dto.GetGNPStagedReportInput) (*dto.StagedPolicyReportSummary, error) {
+	res := c.client.NewRequest().
+		SetSubURL(fmt.Sprintf("/api/v1/globalNetworkPolicies/byName/%s/stagedReport", input.Name)).
+		SetMethod(http.MethodGet).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to get staged report of globalnetworkpolicy: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var summary *dto.StagedPolicyReportSummary
+	if err := json.Unmarshal(res.Body, &summary); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when get staged report, response: %s, err: %w", string(res.Body), err)
+	}
+	return summary, nil
+}
@@ -75,12 +75,15 @@ func (c *apiServer) GetGNS(ctx context.Context, input *dto.GetGNSInput) (*dto.Gl
 
 func (c *apiServer) DeleteGNS(ctx context.Context, input *dto.DeleteGlobalNetworkSetInput) error {
 	inputBytes, _ := json.Marshal(input)
-	res := c.client.NewRequest().
+	req := c.client.NewRequest().
 		SetSubURL("/api/v1/globalNetworkSets").
 		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
 		SetBody(bytes.NewReader(inputBytes)).
-		SetMethod(http.MethodDelete).
-		DoRequest(ctx)
+		SetMethod(http.MethodDelete)
+	if input.Force {
+		req.SetParam("force", "true")
+	}
+	res := req.DoRequest(ctx)
 
 	if res.Err != nil {
 		return fmt.Errorf("failed to delete globalnetworkset: %w", res.Err)
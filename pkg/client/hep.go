@@ -85,12 +85,15 @@ func (c *apiServer) GetHEP(ctx context.Context, input *dto.GetHostEndpointInput)
 
 func (c *apiServer) DeleteHEP(ctx context.Context, input *dto.DeleteHostEndpointInput) error {
 	inputBytes, _ := json.Marshal(input)
-	res := c.client.NewRequest().
+	req := c.client.NewRequest().
 		SetSubURL("/api/v1/hostEndpoints").
 		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
 		SetBody(bytes.NewReader(inputBytes)).
-		SetMethod(http.MethodDelete).
-		DoRequest(ctx)
+		SetMethod(http.MethodDelete)
+	if input.Force {
+		req.SetParam("force", "true")
+	}
+	res := req.DoRequest(ctx)
 
 	if res.Err != nil {
 		return fmt.Errorf("failed to delete hostendpoint: %w", res.Err)
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+)
+
+func (c *apiServer) CreateNetworkPolicy(ctx context.Context, input *dto.CreateNetworkPolicyInput) error {
+	inputBytes, _ := json.Marshal(input)
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/networkPolicies").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return fmt.Errorf("failed to create networkpolicy: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return responseBodyToIError(ctx, res)
+	}
+
+	return nil
+}
+
+func (c *apiServer) ListNetworkPolicies(ctx context.Context, input *dto.ListNetworkPoliciesInput) ([]*dto.GlobalNetworkPolicy, error) {
+	req := c.client.NewRequest().
+		SetSubURL("/api/v1/networkPolicies").
+		SetMethod(http.MethodGet)
+	if input != nil && input.TenantID != 0 {
+		req.SetParam("tenantID", strconv.FormatUint(input.TenantID, 10))
+	}
+	res := req.DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to list networkpolicies: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var nps []*dto.GlobalNetworkPolicy
+	if err := json.Unmarshal(res.Body, &nps); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when list networkpolicies, response: %s, err: %w", string(res.Body), err)
+	}
+	return nps, nil
+}
+
+func (c *apiServer) GetNetworkPolicy(ctx context.Context, input *dto.GetNetworkPolicyInput) (*dto.GlobalNetworkPolicy, error) {
+	res := c.client.NewRequest().
+		SetSubURL(fmt.Sprintf("/api/v1/networkPolicies/byTenantID/%d/byName/%s", input.TenantID, input.Name)).
+		SetMethod(http.MethodGet).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to get networkpolicy by name: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var np *dto.GlobalNetworkPolicy
+	if err := json.Unmarshal(res.Body, &np); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when get networkpolicy by name, response: %s, err: %w", string(res.Body), err)
+	}
+	return np, nil
+}
+
+func (c *apiServer) DeleteNetworkPolicy(ctx context.Context, input *dto.DeleteNetworkPolicyInput) error {
+	inputBytes, _ := json.Marshal(input)
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/networkPolicies").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodDelete).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return fmt.Errorf("failed to delete networkpolicy: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return responseBodyToIError(ctx, res)
+	}
+
+	return nil
+}
+
+func (c *apiServer) ValidateNetworkPolicy(ctx context.Context, input *dto.CreateNetworkPolicyInput) (*dto.ValidateNetworkPolicyOutput, error) {
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input to validate network policy: %w", err)
+	}
+
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/networkPolicies/validate").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to validate network policy: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var validateNetworkPolicyOutput *dto.ValidateNetworkPolicyOutput
+	if err = json.Unmarshal(res.Body, &validateNetworkPolicyOutput); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when validate network policy response: %s, err: %w", string(res.Body), err)
+	}
+
+	return validateNetworkPolicyOutput, nil
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+)
+
+func (c *apiServer) CreateNetworkSet(ctx context.Context, input *dto.CreateNetworkSetInput) error {
+	inputBytes, _ := json.Marshal(input)
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/networkSets").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return fmt.Errorf("failed to create networkset: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return responseBodyToIError(ctx, res)
+	}
+
+	return nil
+}
+
+func (c *apiServer) ListNetworkSets(ctx context.Context, input *dto.ListNetworkSetsInput) ([]*dto.GlobalNetworkSet, error) {
+	req := c.client.NewRequest().
+		SetSubURL("/api/v1/networkSets").
+		SetMethod(http.MethodGet)
+	if input != nil && input.TenantID != 0 {
+		req.SetParam("tenantID", strconv.FormatUint(input.TenantID, 10))
+	}
+	res := req.DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to list networksets: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var nss []*dto.GlobalNetworkSet
+	if err := json.Unmarshal(res.Body, &nss); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when list networksets, response: %s, err: %w", string(res.Body), err)
+	}
+	return nss, nil
+}
+
+func (c *apiServer) GetNetworkSet(ctx context.Context, input *dto.GetNetworkSetInput) (*dto.GlobalNetworkSet, error) {
+	res := c.client.NewRequest().
+		SetSubURL(fmt.Sprintf("/api/v1/networkSets/byTenantID/%d/byName/%s", input.TenantID, input.Name)).
+		SetMethod(http.MethodGet).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to get networkset by name: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var ns *dto.GlobalNetworkSet
+	if err := json.Unmarshal(res.Body, &ns); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when get networkset by name, response: %s, err: %w", string(res.Body), err)
+	}
+	return ns, nil
+}
+
+func (c *apiServer) DeleteNetworkSet(ctx context.Context, input *dto.DeleteNetworkSetInput) error {
+	inputBytes, _ := json.Marshal(input)
+	req := c.client.NewRequest().
+		SetSubURL("/api/v1/networkSets").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodDelete)
+	if input.Force {
+		req.SetParam("force", "true")
+	}
+	res := req.DoRequest(ctx)
+
+	if res.Err != nil {
+		return fmt.Errorf("failed to delete networkset: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return responseBodyToIError(ctx, res)
+	}
+
+	return nil
+}
+
+func (c *apiServer) ValidateNetworkSet(ctx context.Context, input *dto.CreateNetworkSetInput) (*dto.ValidateNetworkSetOutput, error) {
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input to validate network set: %w", err)
+	}
+
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/networkSets/validate").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to validate network set: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var validateNetworkSetOutput *dto.ValidateNetworkSetOutput
+	if err = json.Unmarshal(res.Body, &validateNetworkSetOutput); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when validate network set response: %s, err: %w", string(res.Body), err)
+	}
+
+	return validateNetworkSetOutput, nil
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+)
+
+func (c *apiServer) ExplainSelector(ctx context.Context, input *dto.ExplainSelectorInput) (*dto.ExplainSelectorOutput, error) {
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input to explain selector: %w", err)
+	}
+
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/selectors/explain").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to explain selector: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var output *dto.ExplainSelectorOutput
+	if err = json.Unmarshal(res.Body, &output); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when explain selector, response: %s, err: %w", string(res.Body), err)
+	}
+	return output, nil
+}
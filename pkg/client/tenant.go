@@ -0,0 +1,123 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+)
+
+func (c *apiServer) CreateTenant(ctx context.Context, input *dto.CreateTenantInput) error {
+	inputBytes, _ := json.Marshal(input)
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/tenants").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return fmt.Errorf("failed to create tenant: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return responseBodyToIError(ctx, res)
+	}
+
+	return nil
+}
+
+func (c *apiServer) ListTenants(ctx context.Context) ([]*dto.Tenant, error) {
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/tenants").
+		SetMethod(http.MethodGet).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var tenants []*dto.Tenant
+	if err := json.Unmarshal(res.Body, &tenants); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when list tenants, response: %s, err: %w", string(res.Body), err)
+	}
+	return tenants, nil
+}
+
+func (c *apiServer) GetTenant(ctx context.Context, input *dto.GetTenantInput) (*dto.Tenant, error) {
+	res := c.client.NewRequest().
+		SetSubURL(fmt.Sprintf("/api/v1/tenants/byTenantID/%d", input.TenantID)).
+		SetMethod(http.MethodGet).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to get tenant by tenant id: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var tenant *dto.Tenant
+	if err := json.Unmarshal(res.Body, &tenant); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when get tenant by tenant id, response: %s, err: %w", string(res.Body), err)
+	}
+	return tenant, nil
+}
+
+func (c *apiServer) DeleteTenant(ctx context.Context, input *dto.DeleteTenantInput) error {
+	inputBytes, _ := json.Marshal(input)
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/tenants").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodDelete).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return fmt.Errorf("failed to delete tenant: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return responseBodyToIError(ctx, res)
+	}
+
+	return nil
+}
+
+func (c *apiServer) ValidateTenant(ctx context.Context, input *dto.CreateTenantInput) (*dto.ValidateTenantOutput, error) {
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input to validate tenant: %w", err)
+	}
+
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/tenants/validate").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to validate tenant: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var validateTenantOutput *dto.ValidateTenantOutput
+	if err = json.Unmarshal(res.Body, &validateTenantOutput); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when validate tenant response: %s, err: %w", string(res.Body), err)
+	}
+
+	return validateTenantOutput, nil
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+)
+
+func (c *apiServer) CreateTier(ctx context.Context, input *dto.CreateTierInput) error {
+	inputBytes, _ := json.Marshal(input)
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/tiers").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return fmt.Errorf("failed to create tier: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return responseBodyToIError(ctx, res)
+	}
+
+	return nil
+}
+
+func (c *apiServer) ListTiers(ctx context.Context) ([]*dto.Tier, error) {
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/tiers").
+		SetMethod(http.MethodGet).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to list tiers by name: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var tiers []*dto.Tier
+	if err := json.Unmarshal(res.Body, &tiers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when list tiers, response: %s, err: %w", string(res.Body), err)
+	}
+	return tiers, nil
+}
+
+func (c *apiServer) GetTier(ctx context.Context, input *dto.GetTierInput) (*dto.Tier, error) {
+	res := c.client.NewRequest().
+		SetSubURL(fmt.Sprintf("/api/v1/tiers/byName/%s", input.Name)).
+		SetMethod(http.MethodGet).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to get tier by name: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var tier *dto.Tier
+	if err := json.Unmarshal(res.Body, &tier); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when get tier by name, response: %s, err: %w", string(res.Body), err)
+	}
+	return tier, nil
+}
+
+func (c *apiServer) DeleteTier(ctx context.Context, input *dto.DeleteTierInput) error {
+	inputBytes, _ := json.Marshal(input)
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/tiers").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodDelete).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return fmt.Errorf("failed to delete tier: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return responseBodyToIError(ctx, res)
+	}
+
+	return nil
+}
+
+func (c *apiServer) ValidateTier(ctx context.Context, input *dto.CreateTierInput) (*dto.ValidateTierOutput, error) {
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input to validate tier: %w", err)
+	}
+
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/tiers/validate").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to validate tier: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var validateTierOutput *dto.ValidateTierOutput
+	if err = json.Unmarshal(res.Body, &validateTierOutput); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when validate tier response: %s, err: %w", string(res.Body), err)
+	}
+
+	return validateTierOutput, nil
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+)
+
+func (c *apiServer) CreateWebhook(ctx context.Context, input *dto.CreateWebhookInput) error {
+	inputBytes, _ := json.Marshal(input)
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/webhooks").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return fmt.Errorf("failed to create webhook: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return responseBodyToIError(ctx, res)
+	}
+
+	return nil
+}
+
+func (c *apiServer) ListWebhooks(ctx context.Context) ([]*dto.Webhook, error) {
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/webhooks").
+		SetMethod(http.MethodGet).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var webhooks []*dto.Webhook
+	if err := json.Unmarshal(res.Body, &webhooks); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when list webhooks, response: %s, err: %w", string(res.Body), err)
+	}
+	return webhooks, nil
+}
+
+func (c *apiServer) GetWebhook(ctx context.Context, input *dto.GetWebhookInput) (*dto.Webhook, error) {
+	res := c.client.NewRequest().
+		SetSubURL(fmt.Sprintf("/api/v1/webhooks/byName/%s", input.Name)).
+		SetMethod(http.MethodGet).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to get webhook by name: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var webhook *dto.Webhook
+	if err := json.Unmarshal(res.Body, &webhook); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when get webhook by name, response: %s, err: %w", string(res.Body), err)
+	}
+	return webhook, nil
+}
+
+func (c *apiServer) DeleteWebhook(ctx context.Context, input *dto.DeleteWebhookInput) error {
+	inputBytes, _ := json.Marshal(input)
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/webhooks").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodDelete).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return responseBodyToIError(ctx, res)
+	}
+
+	return nil
+}
+
+func (c *apiServer) ValidateWebhook(ctx context.Context, input *dto.CreateWebhookInput) (*dto.ValidateWebhookOutput, error) {
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input to validate webhook: %w", err)
+	}
+
+	res := c.client.NewRequest().
+		SetSubURL("/api/v1/webhooks/validate").
+		SetHeader(httpbase.HeaderContentType, httpbase.MIMEApplicationJSON).
+		SetBody(bytes.NewReader(inputBytes)).
+		SetMethod(http.MethodPost).
+		DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to validate webhook: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var validateWebhookOutput *dto.ValidateWebhookOutput
+	if err = json.Unmarshal(res.Body, &validateWebhookOutput); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when validate webhook response: %s, err: %w", string(res.Body), err)
+	}
+
+	return validateWebhookOutput, nil
+}
+
+func (c *apiServer) ListWebhookDeliveries(ctx context.Context, input *dto.ListWebhookDeliveriesInput) ([]*dto.WebhookDelivery, error) {
+	req := c.client.NewRequest().
+		SetSubURL("/api/v1/webhookDeliveries").
+		SetMethod(http.MethodGet)
+	if input != nil {
+		if input.WebhookName != "" {
+			req.SetParam("webhookName", input.WebhookName)
+		}
+		if input.Status != "" {
+			req.SetParam("status", input.Status)
+		}
+		if input.Limit != 0 {
+			req.SetParam("limit", strconv.FormatInt(input.Limit, 10))
+		}
+	}
+	res := req.DoRequest(ctx)
+
+	if res.Err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", res.Err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseBodyToIError(ctx, res)
+	}
+
+	var deliveries []*dto.WebhookDelivery
+	if err := json.Unmarshal(res.Body, &deliveries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal when list webhook deliveries, response: %s, err: %w", string(res.Body), err)
+	}
+	return deliveries, nil
+}
@@ -13,6 +13,24 @@ var (
 	ErrDuplicateHostEndpoint        = ierror.NewCoreError("err_duplicate_host_endpoint", "")
 	ErrDuplicateGlobalNetworkPolicy = ierror.NewCoreError("err_duplicate_global_network_policy", "")
 	ErrDuplicateGlobalNetworkSet    = ierror.NewCoreError("err_duplicate_global_network_set", "")
+	ErrNotFoundTier                 = ierror.NewCoreError("err_not_found_tier", "")
+	ErrDuplicateTier                = ierror.NewCoreError("err_duplicate_tier", "")
+	ErrNotFoundNetworkPolicy        = ierror.NewCoreError("err_not_found_network_policy", "")
+	ErrDuplicateNetworkPolicy       = ierror.NewCoreError("err_duplicate_network_policy", "")
+	ErrNotFoundNetworkSet           = ierror.NewCoreError("err_not_found_network_set", "")
+	ErrDuplicateNetworkSet          = ierror.NewCoreError("err_duplicate_network_set", "")
+	ErrNotFoundTenant               = ierror.NewCoreError("err_not_found_tenant", "")
+	ErrDuplicateTenant              = ierror.NewCoreError("err_duplicate_tenant", "")
+	ErrNotFoundAgent                = ierror.NewCoreError("err_not_found_agent", "")
+	ErrNotFoundEnrollmentToken      = ierror.NewCoreError("err_not_found_enrollment_token", "")
+	ErrDuplicateEnrollmentToken     = ierror.NewCoreError("err_duplicate_enrollment_token", "")
+	ErrNotFoundWebhook              = ierror.NewCoreError("err_not_found_webhook", "")
+	ErrDuplicateWebhook             = ierror.NewCoreError("err_duplicate_webhook", "")
+	ErrNotFoundAdmissionHook        = ierror.NewCoreError("err_not_found_admission_hook", "")
+	ErrDuplicateAdmissionHook       = ierror.NewCoreError("err_duplicate_admission_hook", "")
+	ErrRestoreConflict              = ierror.NewCoreError("err_restore_conflict", "")
+	ErrVersionConflict              = ierror.NewCoreError("err_version_conflict", "")
+	ErrQuotaExceeded                = ierror.NewCoreError("err_quota_exceeded", "")
 
 	ErrUnmarshalFailed = ierror.NewCoreError("err_unmarshal_failed", "")
 
@@ -0,0 +1,99 @@
+// Package dnsresolver resolves the domains of global network sets to ip addresses.
+package dnsresolver
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Resolver looks up the addresses of a host, *net.Resolver implements it.
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// IsWildcard reports whether domain matches any subdomain, e.g. *.github.com.
+// A wildcard cannot be resolved, it is only enforced by agents snooping DNS answers.
+func IsWildcard(domain string) bool {
+	return strings.HasPrefix(domain, "*.")
+}
+
+type Result struct {
+	Domain string
+	Addrs  []netip.Addr
+	Err    error
+}
+
+// ResolveAll resolves the domains which are not wildcards, the addresses of each domain are sorted and unique.
+func ResolveAll(ctx context.Context, resolver Resolver, domains []string) []Result {
+	results := make([]Result, 0, len(domains))
+	for _, domain := range domains {
+		if IsWildcard(domain) {
+			continue
+		}
+		addrs, err := resolver.LookupNetIP(ctx, "ip", domain)
+		for i := range addrs {
+			addrs[i] = addrs[i].Unmap()
+		}
+		slices.SortFunc(addrs, func(a, b netip.Addr) int {
+			return a.Compare(b)
+		})
+		results = append(results, Result{
+			Domain: domain,
+			Addrs:  slices.Compact(addrs),
+			Err:    err,
+		})
+	}
+	return results
+}
+
+// Fake resolves hosts from a static table, hosts not in the table are not found.
+type Fake struct {
+	mu    sync.RWMutex
+	hosts map[string][]netip.Addr
+}
+
+func NewFake() *Fake {
+	return &Fake{
+		hosts: make(map[string][]netip.Addr),
+	}
+}
+
+// Set replaces the addresses of host, it panics if an address is malformed.
+func (f *Fake) Set(host string, addrs ...string) {
+	parsed := make([]netip.Addr, 0, len(addrs))
+	for _, addr := range addrs {
+		parsed = append(parsed, netip.MustParseAddr(addr))
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.hosts[host] = parsed
+}
+
+func (f *Fake) Delete(host string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.hosts, host)
+}
+
+func (f *Fake) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	addrs, ok := f.hosts[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	var matched []netip.Addr
+	for _, addr := range addrs {
+		if network == "ip" || network == "ip4" && addr.Is4() || network == "ip6" && addr.Is6() {
+			matched = append(matched, addr)
+		}
+	}
+	return matched, nil
+}
@@ -0,0 +1,48 @@
+package dnsresolver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"slices"
+	"testing"
+)
+
+func TestResolveAll(t *testing.T) {
+	resolver := NewFake()
+	resolver.Set("api.github.com", "140.82.113.6", "140.82.112.6", "140.82.113.6")
+	resolver.Set("example.com", "2606:2800:21f:cb07:6820:80da:af6b:8b2c", "93.184.215.14")
+
+	results := ResolveAll(context.Background(), resolver, []string{"api.github.com", "*.github.com", "example.com", "missing.example.com"})
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3 as the wildcard is skipped", len(results))
+	}
+
+	want := []netip.Addr{netip.MustParseAddr("140.82.112.6"), netip.MustParseAddr("140.82.113.6")}
+	if results[0].Domain != "api.github.com" || results[0].Err != nil || !slices.Equal(results[0].Addrs, want) {
+		t.Errorf("api.github.com = %+v, want sorted unique %v", results[0], want)
+	}
+	want = []netip.Addr{netip.MustParseAddr("93.184.215.14"), netip.MustParseAddr("2606:2800:21f:cb07:6820:80da:af6b:8b2c")}
+	if !slices.Equal(results[1].Addrs, want) {
+		t.Errorf("example.com = %v, want %v", results[1].Addrs, want)
+	}
+	var dnsErr *net.DNSError
+	if !errors.As(results[2].Err, &dnsErr) || !dnsErr.IsNotFound {
+		t.Errorf("missing.example.com err = %v, want not found", results[2].Err)
+	}
+}
+
+func TestFakeNetwork(t *testing.T) {
+	resolver := NewFake()
+	resolver.Set("example.com", "93.184.215.14", "2606:2800:21f:cb07:6820:80da:af6b:8b2c")
+
+	addrs, err := resolver.LookupNetIP(context.Background(), "ip6", "example.com")
+	if err != nil || len(addrs) != 1 || !addrs[0].Is6() {
+		t.Errorf("ip6 lookup = %v, %v", addrs, err)
+	}
+	resolver.Delete("example.com")
+	if _, err = resolver.LookupNetIP(context.Background(), "ip", "example.com"); err == nil {
+		t.Error("deleted host is still resolved")
+	}
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	AdmissionOperationCreate = "create"
+	AdmissionOperationDelete = "delete"
+)
+
+const (
+	// AdmissionFailurePolicyFail denies the changes when the hook cannot be reached or answers no verdict
+	AdmissionFailurePolicyFail = "fail"
+	// AdmissionFailurePolicyIgnore allows the changes when the hook cannot be reached or answers no verdict
+	AdmissionFailurePolicyIgnore = "ignore"
+)
+
+var (
+	// AdmissionKinds are the kinds of the resources admission hooks review the changes of
+	AdmissionKinds = []string{WebhookKindHostEndpoint, WebhookKindGlobalNetworkPolicy, WebhookKindGlobalNetworkSet,
+		WebhookKindNetworkPolicy, WebhookKindNetworkSet}
+	AdmissionOperations = []string{AdmissionOperationCreate, AdmissionOperationDelete}
+)
+
+// AdmissionHook allows or denies the changes of the resources matching its filter before they are stored.
+type AdmissionHook struct {
+	ID          primitive.ObjectID    `bson:"_id"`
+	UUID        string                `bson:"uuid"`
+	Version     uint                  `bson:"version"`
+	Metadata    AdmissionHookMetadata `bson:"metadata"`
+	Spec        AdmissionHookSpec     `bson:"spec"`
+	Description string                `bson:"description"`
+	FilePath    string                `bson:"file_path"`
+	CreatedAt   time.Time             `bson:"created_at"`
+	UpdatedAt   time.Time             `bson:"updated_at"`
+}
+
+type AdmissionHookMetadata struct {
+	Name   string            `bson:"name"`
+	Labels map[string]string `bson:"labels,omitempty"`
+}
+
+type AdmissionHookSpec struct {
+	URL string `bson:"url"`
+	// Secret signs the reviews, it is kept when the hook is updated without secret
+	Secret string `bson:"secret,omitempty"`
+	// Kinds and Operations filter the changes reviewed, empty matches all
+	Kinds         []string `bson:"kinds,omitempty"`
+	Operations    []string `bson:"operations,omitempty"`
+	FailurePolicy string   `bson:"failure_policy"`
+	Timeout       string   `bson:"timeout"`
+}
+
+func (AdmissionHook) CollectionName() string {
+	return "admission_hook"
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Agent is an agent enforcing the policy of the host endpoint identified by TenantID and IP.
+type Agent struct {
+	ID           primitive.ObjectID `bson:"_id"`
+	TenantID     uint64             `bson:"tenant_id"`
+	IP           uint32             `bson:"ip"`
+	Hostname     string             `bson:"hostname"`
+	AgentVersion string             `bson:"agent_version"`
+	// Applied is the policy the agent enforces, as reported by its last heartbeat
+	Applied AgentAppliedPolicy `bson:"applied"`
+	// ApplyErrors are the errors of the last apply reported by the agent
+	ApplyErrors     []string   `bson:"apply_errors,omitempty"`
+	RegisteredAt    time.Time  `bson:"registered_at"`
+	LastHeartbeatAt *time.Time `bson:"last_heartbeat_at,omitempty"`
+	CreatedAt       time.Time  `bson:"created_at"`
+}
+
+type AgentAppliedPolicy struct {
+	Revision    uint64          `bson:"revision"`
+	GNPVersions map[string]uint `bson:"gnp_versions,omitempty"`
+	HEPVersions map[string]uint `bson:"hep_versions,omitempty"`
+	GNSVersions map[string]uint `bson:"gns_versions,omitempty"`
+}
+
+func (Agent) CollectionName() string {
+	return "agent"
+}
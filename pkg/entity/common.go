@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"math"
 	"strings"
 
 	"github.com/google/uuid"
@@ -15,6 +16,8 @@ const (
 	ProtocolTCP  = "tcp"
 	ProtocolUDP  = "udp"
 	ProtocolICMP = "icmp"
+	// ProtocolICMPv6 is ICMP for IPv6, it also carries neighbor discovery.
+	ProtocolICMPv6 = "icmpv6"
 	// ProtocolSCTP Stream Control Transmission Protocol (SCTP) is a network protocol that allows for the reliable transmission of data between two endpoints in a computer network.
 	// The Stream Control Transmission Protocol (SCTP) is a computer networking communications protocol in the transport layer of the Internet protocol suite.
 	// Originally intended for Signaling System 7 (SS7) message transport in telecommunication, the protocol provides the message-oriented feature of the User Datagram Protocol (UDP),
@@ -23,11 +26,48 @@ const (
 	ProtocolSCTP    = "sctp"
 	ProtocolUDPLite = "udplite"
 
-	ProtocolNumTCP  = 6
-	ProtocolNumUDP  = 17
-	ProtocolNumSCTP = 132
+	ProtocolNumICMP   = 1
+	ProtocolNumTCP    = 6
+	ProtocolNumUDP    = 17
+	ProtocolNumICMPv6 = 58
+	ProtocolNumSCTP   = 132
 )
 
+// ICMPVersionOfProtocol returns the ip version of protocol if it is icmp or icmpv6, 0 otherwise.
+// protocol is a name or a number decoded from json.
+func ICMPVersionOfProtocol(protocol interface{}) int {
+	switch p := protocol.(type) {
+	case string:
+		switch strings.ToLower(p) {
+		case ProtocolICMP:
+			return IPVersion4
+		case ProtocolICMPv6:
+			return IPVersion6
+		}
+	case float64:
+		num, ok := ProtocolNumber(p)
+		if !ok {
+			return 0
+		}
+		switch num {
+		case ProtocolNumICMP:
+			return IPVersion4
+		case ProtocolNumICMPv6:
+			return IPVersion6
+		}
+	}
+	return 0
+}
+
+// ProtocolNumber returns protocol as an ip protocol number, false if it is not a whole number in 0-255.
+// protocol is a number decoded from json.
+func ProtocolNumber(protocol float64) (uint8, bool) {
+	if protocol < 0 || protocol > math.MaxUint8 || protocol != math.Trunc(protocol) {
+		return 0, false
+	}
+	return uint8(protocol), true
+}
+
 func NewMinifyUUID() string {
 	return strings.Replace(uuid.New().String(), "-", "", -1)
 }
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EnrollmentToken allows agents presenting its token to register the host endpoint they run on.
+type EnrollmentToken struct {
+	ID          primitive.ObjectID      `bson:"_id"`
+	UUID        string                  `bson:"uuid"`
+	Version     uint                    `bson:"version"`
+	Metadata    EnrollmentTokenMetadata `bson:"metadata"`
+	Spec        EnrollmentTokenSpec     `bson:"spec"`
+	Description string                  `bson:"description"`
+	FilePath    string                  `bson:"file_path"`
+	CreatedAt   time.Time               `bson:"created_at"`
+	UpdatedAt   time.Time               `bson:"updated_at"`
+}
+
+type EnrollmentTokenMetadata struct {
+	Name   string            `bson:"name"`
+	Labels map[string]string `bson:"labels,omitempty"`
+}
+
+type EnrollmentTokenSpec struct {
+	// TenantID is the tenant of the host endpoints registered with the token
+	TenantID uint64 `bson:"tenant_id"`
+	// Token is generated when the enrollment token is created and kept when it is updated
+	Token string `bson:"token"`
+	// AllowedCIDRs restricts the ips of the registered host endpoints, empty allows any ip
+	AllowedCIDRs []string `bson:"allowed_cidrs,omitempty"`
+	// LabelTemplates are the default labels of the registered host endpoints, values are text/template executed with
+	// the facts reported by the agent
+	LabelTemplates map[string]string `bson:"label_templates,omitempty"`
+	// AllowedAgentLabels are the keys of the labels an agent may set itself, labels from LabelTemplates take precedence
+	AllowedAgentLabels []string `bson:"allowed_agent_labels,omitempty"`
+}
+
+func (EnrollmentToken) CollectionName() string {
+	return "enrollment_token"
+}
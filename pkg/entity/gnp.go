@@ -20,47 +20,75 @@ const (
 )
 
 type GlobalNetworkPolicy struct {
-	ID          primitive.ObjectID `bson:"_id"`
-	UUID        string             `bson:"uuid"`
-	Version     uint               `bson:"version"`
-	Metadata    GNPMetadata        `bson:"metadata"`
-	Spec        GNPSpec            `bson:"spec"`
-	Description string             `bson:"description"`
-	FilePath    string             `bson:"file_path"`
-	CreatedAt   time.Time          `bson:"created_at"`
-	UpdatedAt   time.Time          `bson:"updated_at"`
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	UUID        string             `bson:"uuid" json:"uuid"`
+	Version     uint               `bson:"version" json:"version"`
+	Metadata    GNPMetadata        `bson:"metadata" json:"metadata"`
+	Spec        GNPSpec            `bson:"spec" json:"spec"`
+	Description string             `bson:"description" json:"description"`
+	FilePath    string             `bson:"file_path" json:"filePath"`
+	CreatedAt   time.Time          `bson:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updatedAt"`
 }
 
 type GNPMetadata struct {
-	Name   string            `bson:"name"`
-	Labels map[string]string `bson:"labels"`
+	Name string `bson:"name" json:"name"`
+	// TenantID is the tenant of a network policy, zero for a global network policy
+	TenantID uint64            `bson:"tenant_id,omitempty" json:"tenantID,omitempty"`
+	Labels   map[string]string `bson:"labels" json:"labels"`
 }
 
 type GNPSpec struct {
-	Order    uint32        `bson:"order"`
-	Selector string        `bson:"selector,omitempty"`
-	Ingress  []GNPSpecRule `bson:"ingress,omitempty"`
-	Egress   []GNPSpecRule `bson:"egress,omitempty"`
+	Tier  string `bson:"tier,omitempty" json:"tier,omitempty"`
+	Order uint32 `bson:"order" json:"order"`
+	// Staged policies are delivered to agents as observe-only: they are not enforced, agents report what they would deny
+	Staged   bool `bson:"staged,omitempty" json:"staged,omitempty"`
+	Schedule `bson:",inline"`
+	Selector string        `bson:"selector,omitempty" json:"selector,omitempty"`
+	Ingress  []GNPSpecRule `bson:"ingress,omitempty" json:"ingress,omitempty"`
+	Egress   []GNPSpecRule `bson:"egress,omitempty" json:"egress,omitempty"`
 }
 
 type GNPSpecRule struct {
-	Metadata    map[string]string  `bson:"metadata,omitempty"`
-	Action      string             `bson:"action"`
-	IPVersion   *int               `bson:"ip_version,omitempty"`
-	Protocol    interface{}        `bson:"protocol,omitempty"`
-	NotProtocol interface{}        `bson:"not_protocol,omitempty"`
-	Source      *GNPSpecRuleEntity `bson:"source,omitempty"`
-	Destination *GNPSpecRuleEntity `bson:"destination,omitempty"`
+	Metadata    map[string]string `bson:"metadata,omitempty" json:"metadata,omitempty"`
+	Action      string            `bson:"action" json:"action"`
+	IPVersion   *int              `bson:"ip_version,omitempty" json:"ipVersion,omitempty"`
+	Protocol    interface{}       `bson:"protocol,omitempty" json:"protocol,omitempty"`
+	NotProtocol interface{}       `bson:"not_protocol,omitempty" json:"notProtocol,omitempty"`
+	ICMP        *GNPSpecRuleICMP  `bson:"icmp,omitempty" json:"icmp,omitempty"`
+	NotICMP     *GNPSpecRuleICMP  `bson:"not_icmp,omitempty" json:"notICMP,omitempty"`
+	Schedule    `bson:",inline"`
+	Source      *GNPSpecRuleEntity `bson:"source,omitempty" json:"source,omitempty"`
+	Destination *GNPSpecRuleEntity `bson:"destination,omitempty" json:"destination,omitempty"`
+}
+
+// GNPSpecRuleICMP matches icmp or icmpv6 messages by type, and by code if set.
+type GNPSpecRuleICMP struct {
+	Type *int `bson:"type,omitempty" json:"type,omitempty"`
+	Code *int `bson:"code,omitempty" json:"code,omitempty"`
 }
 
 type GNPSpecRuleEntity struct {
-	Selector string        `bson:"selector,omitempty"`
-	Nets     []string      `bson:"nets,omitempty"`
-	NotNets  []string      `bson:"not_nets,omitempty"`
-	Ports    []interface{} `bson:"ports,omitempty"`
-	NotPorts []interface{} `bson:"not_ports,omitempty"`
+	Selector string        `bson:"selector,omitempty" json:"selector,omitempty"`
+	Nets     []string      `bson:"nets,omitempty" json:"nets,omitempty"`
+	NotNets  []string      `bson:"not_nets,omitempty" json:"notNets,omitempty"`
+	Ports    []interface{} `bson:"ports,omitempty" json:"ports,omitempty"`
+	NotPorts []interface{} `bson:"not_ports,omitempty" json:"notPorts,omitempty"`
 }
 
 func (GlobalNetworkPolicy) CollectionName() string {
 	return "global_network_policy"
 }
+
+// IsNamespaced reports whether the policy is a network policy, which belongs to a tenant.
+func (gnp *GlobalNetworkPolicy) IsNamespaced() bool {
+	return gnp.Metadata.TenantID != 0
+}
+
+// TierName returns the tier of the policy, DefaultTierName if not set.
+func (gnp *GlobalNetworkPolicy) TierName() string {
+	if gnp.Spec.Tier == "" {
+		return DefaultTierName
+	}
+	return gnp.Spec.Tier
+}
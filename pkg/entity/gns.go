@@ -20,28 +20,71 @@ var (
 )
 
 type GlobalNetworkSet struct {
-	ID          primitive.ObjectID `bson:"_id"`
-	UUID        string             `bson:"uuid"`
-	Version     uint               `bson:"version"`
-	Metadata    GNSMetadata        `bson:"metadata"`
-	Spec        GNSSpec            `bson:"spec"`
-	Description string             `bson:"description"`
-	FilePath    string             `bson:"file_path"`
-	CreatedAt   time.Time          `bson:"created_at"`
-	UpdatedAt   time.Time          `bson:"updated_at"`
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	UUID        string             `bson:"uuid" json:"uuid"`
+	Version     uint               `bson:"version" json:"version"`
+	Metadata    GNSMetadata        `bson:"metadata" json:"metadata"`
+	Spec        GNSSpec            `bson:"spec" json:"spec"`
+	Description string             `bson:"description" json:"description"`
+	FilePath    string             `bson:"file_path" json:"filePath"`
+	Status      *GNSStatus         `bson:"status,omitempty" json:"status,omitempty"`
+	Resolved    *GNSResolved       `bson:"resolved,omitempty" json:"resolved,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updatedAt"`
 }
 
 type GNSMetadata struct {
-	Name   string            `bson:"name"`
-	Labels map[string]string `bson:"labels,omitempty"`
+	Name string `bson:"name" json:"name"`
+	// TenantID is the tenant of a network set, zero for a global network set
+	TenantID uint64            `bson:"tenant_id,omitempty" json:"tenantID,omitempty"`
+	Labels   map[string]string `bson:"labels,omitempty" json:"labels,omitempty"`
 }
 
 type GNSSpec struct {
-	Nets   []string `bson:"nets"`
-	NetsV4 []string `bson:"nets_v4,omitempty"`
-	NetsV6 []string `bson:"nets_v6,omitempty"`
+	Nets []string `bson:"nets" json:"nets"`
+	// NetsV4 and NetsV6 are the minimal CIDRs covering Nets and the addresses resolved from AllowedEgressDomains.
+	NetsV4               []string   `bson:"nets_v4,omitempty" json:"netsV4,omitempty"`
+	NetsV6               []string   `bson:"nets_v6,omitempty" json:"netsV6,omitempty"`
+	Source               *GNSSource `bson:"source,omitempty" json:"source,omitempty"`
+	AllowedEgressDomains []string   `bson:"allowed_egress_domains,omitempty" json:"allowedEgressDomains,omitempty"`
+}
+
+const (
+	GNSSourceFormatPlain = "plain"
+	GNSSourceFormatJSON  = "json"
+)
+
+// GNSSource is an external list of networks. The nets of the set are replaced by the list every RefreshInterval.
+type GNSSource struct {
+	URL             string `bson:"url,omitempty" json:"url,omitempty"`
+	File            string `bson:"file,omitempty" json:"file,omitempty"`
+	Format          string `bson:"format" json:"format"`
+	JSONPath        string `bson:"json_path,omitempty" json:"jsonPath,omitempty"`
+	RefreshInterval string `bson:"refresh_interval" json:"refreshInterval"`
+}
+
+// GNSStatus is the result of the last sync of a set with a source.
+type GNSStatus struct {
+	LastSyncAt       time.Time  `bson:"last_sync_at" json:"lastSyncAt"`
+	LastSuccessAt    *time.Time `bson:"last_success_at,omitempty" json:"lastSuccessAt,omitempty"`
+	LastError        string     `bson:"last_error,omitempty" json:"lastError,omitempty"`
+	NetsCount        int        `bson:"nets_count" json:"netsCount"`
+	InvalidNetsCount int        `bson:"invalid_nets_count" json:"invalidNetsCount"`
 }
 
 func (GlobalNetworkSet) CollectionName() string {
 	return "global_network_set"
 }
+
+// GNSResolved holds the addresses of the AllowedEgressDomains of a set. Version is increased each time they change.
+type GNSResolved struct {
+	Version    uint                `bson:"version" json:"version"`
+	ResolvedAt time.Time           `bson:"resolved_at" json:"resolvedAt"`
+	Domains    []GNSResolvedDomain `bson:"domains,omitempty" json:"domains,omitempty"`
+}
+
+type GNSResolvedDomain struct {
+	Name      string   `bson:"name" json:"name"`
+	Addresses []string `bson:"addresses,omitempty" json:"addresses,omitempty"`
+	Error     string   `bson:"error,omitempty" json:"error,omitempty"`
+}
@@ -11,29 +11,41 @@ const (
 )
 
 type HostEndpoint struct {
-	ID          primitive.ObjectID   `bson:"_id"`
-	UUID        string               `bson:"uuid"`
-	Version     uint                 `bson:"version"`
-	Metadata    HostEndpointMetadata `bson:"metadata"`
-	Spec        HostEndpointSpec     `bson:"spec"`
-	Description string               `bson:"description"`
-	FilePath    string               `bson:"file_path"`
-	CreatedAt   time.Time            `bson:"created_at"`
-	UpdatedAt   time.Time            `bson:"updated_at"`
+	ID          primitive.ObjectID   `bson:"_id" json:"id"`
+	UUID        string               `bson:"uuid" json:"uuid"`
+	Version     uint                 `bson:"version" json:"version"`
+	Metadata    HostEndpointMetadata `bson:"metadata" json:"metadata"`
+	Spec        HostEndpointSpec     `bson:"spec" json:"spec"`
+	Description string               `bson:"description" json:"description"`
+	FilePath    string               `bson:"file_path" json:"filePath"`
+	CreatedAt   time.Time            `bson:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time            `bson:"updated_at" json:"updatedAt"`
+	// Registration is set when the host endpoint is registered by its agent
+	Registration *HostEndpointRegistration `bson:"registration,omitempty" json:"registration,omitempty"`
 }
 
 type HostEndpointMetadata struct {
-	Name   string            `bson:"name"`
-	Labels map[string]string `bson:"labels"`
+	Name   string            `bson:"name" json:"name"`
+	Labels map[string]string `bson:"labels" json:"labels"`
 }
 
 type HostEndpointSpec struct {
-	InterfaceName string   `bson:"interface_name"`
+	InterfaceName string   `bson:"interface_name" json:"interfaceName"`
 	IP            uint32   `json:"ip"`
-	TenantID      uint64   `bson:"tenant_id"`
-	IPs           []string `bson:"ips"`
-	IPsV4         []string `bson:"ips_v4,omitempty"`
-	IPsV6         []string `bson:"ips_v6,omitempty"`
+	TenantID      uint64   `bson:"tenant_id" json:"tenantID"`
+	IPs           []string `bson:"ips" json:"ips"`
+	IPsV4         []string `bson:"ips_v4,omitempty" json:"ipsV4,omitempty"`
+	IPsV6         []string `bson:"ips_v6,omitempty" json:"ipsV6,omitempty"`
+}
+
+// HostEndpointRegistration holds the values the agent registered last. A field still holding the registered value is
+// managed by the agent, a field changed since is managed by hand and kept on later registrations.
+type HostEndpointRegistration struct {
+	TokenName     string            `bson:"token_name" json:"tokenName"`
+	InterfaceName string            `bson:"interface_name" json:"interfaceName"`
+	IPs           []string          `bson:"ips" json:"ips"`
+	Labels        map[string]string `bson:"labels,omitempty" json:"labels,omitempty"`
+	RegisteredAt  time.Time         `bson:"registered_at" json:"registeredAt"`
 }
 
 func (HostEndpoint) CollectionName() string {
@@ -0,0 +1,11 @@
+package entity
+
+// NetworkPolicy is a GlobalNetworkPolicy namespaced to the tenant Metadata.TenantID: it only applies to the host
+// endpoints of the tenant and its rule selectors only match the host endpoints and network sets of the tenant, and
+// global network sets. Network policies are stored in their own collection and handled as GlobalNetworkPolicy
+// everywhere else.
+type NetworkPolicy GlobalNetworkPolicy
+
+func (NetworkPolicy) CollectionName() string {
+	return "network_policy"
+}
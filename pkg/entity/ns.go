@@ -0,0 +1,10 @@
+package entity
+
+// NetworkSet is a GlobalNetworkSet namespaced to the tenant Metadata.TenantID, it is only matched by the rule
+// selectors of the network policies of the tenant. Network sets are stored in their own collection and handled as
+// GlobalNetworkSet everywhere else.
+type NetworkSet GlobalNetworkSet
+
+func (NetworkSet) CollectionName() string {
+	return "network_set"
+}
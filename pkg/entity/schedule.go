@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+)
+
+// Schedule limits when a policy or a rule is active. Without windows, it is active between ActiveFrom and
+// ActiveUntil. With windows, it is only active while a window is open.
+type Schedule struct {
+	ActiveFrom  *time.Time       `bson:"active_from,omitempty" json:"activeFrom,omitempty"`
+	ActiveUntil *time.Time       `bson:"active_until,omitempty" json:"activeUntil,omitempty"`
+	Windows     []ScheduleWindow `bson:"windows,omitempty" json:"windows,omitempty"`
+}
+
+// ScheduleWindow opens at each time matching the five fields Cron expression and stays open for Duration.
+type ScheduleWindow struct {
+	Cron     string `bson:"cron" json:"cron"`
+	Duration string `bson:"duration" json:"duration"`
+}
+
+// IsZero reports whether the schedule is always active.
+func (s Schedule) IsZero() bool {
+	return s.ActiveFrom == nil && s.ActiveUntil == nil && len(s.Windows) == 0
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// StagedPolicyReport accumulates the traffic a staged policy would have denied on a host endpoint.
+type StagedPolicyReport struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	GNPUUID        string             `bson:"gnp_uuid"`
+	GNPName        string             `bson:"gnp_name"`
+	TenantID       uint64             `bson:"tenant_id"`
+	IP             uint32             `bson:"ip"`
+	DeniedPackets  uint64             `bson:"denied_packets"`
+	DeniedBytes    uint64             `bson:"denied_bytes"`
+	LastReportedAt time.Time          `bson:"last_reported_at"`
+	CreatedAt      time.Time          `bson:"created_at"`
+}
+
+func (StagedPolicyReport) CollectionName() string {
+	return "staged_policy_report"
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DefaultTenantName = "default"
+)
+
+var (
+	// TenantDefault is the tenant of resources created without tenant, unless a tenant with DefaultTenantID is
+	// created. It has no quota.
+	TenantDefault = Tenant{
+		Metadata: TenantMetadata{
+			Name: DefaultTenantName,
+		},
+		Spec: TenantSpec{
+			TenantID: DefaultTenantID,
+		},
+	}
+)
+
+type Tenant struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	UUID        string             `bson:"uuid"`
+	Version     uint               `bson:"version"`
+	Metadata    TenantMetadata     `bson:"metadata"`
+	Spec        TenantSpec         `bson:"spec"`
+	Description string             `bson:"description"`
+	FilePath    string             `bson:"file_path"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+}
+
+type TenantMetadata struct {
+	Name   string            `bson:"name"`
+	Labels map[string]string `bson:"labels,omitempty"`
+}
+
+type TenantSpec struct {
+	TenantID uint64      `bson:"tenant_id"`
+	Quota    TenantQuota `bson:"quota"`
+}
+
+// TenantQuota limits the resources of a tenant, a zero limit is unlimited.
+type TenantQuota struct {
+	MaxHostEndpoints   uint32 `bson:"max_host_endpoints"`
+	MaxNetworkPolicies uint32 `bson:"max_network_policies"`
+}
+
+func (Tenant) CollectionName() string {
+	return "tenant"
+}
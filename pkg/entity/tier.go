@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DefaultTierName = "default"
+)
+
+var (
+	// TierDefault is used for policies without tier, unless a tier named DefaultTierName is created.
+	// It is evaluated last and passes traffic it doesn't decide on, so untiered policies behave as before tiers.
+	TierDefault = Tier{
+		Metadata: TierMetadata{
+			Name: DefaultTierName,
+		},
+		Spec: TierSpec{
+			Order:         PolicyOrderLowest,
+			DefaultAction: string(RuleActionPass),
+		},
+	}
+)
+
+type Tier struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	UUID        string             `bson:"uuid"`
+	Version     uint               `bson:"version"`
+	Metadata    TierMetadata       `bson:"metadata"`
+	Spec        TierSpec           `bson:"spec"`
+	Description string             `bson:"description"`
+	FilePath    string             `bson:"file_path"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+}
+
+type TierMetadata struct {
+	Name   string            `bson:"name"`
+	Labels map[string]string `bson:"labels,omitempty"`
+}
+
+type TierSpec struct {
+	Order uint32 `bson:"order"`
+	// DefaultAction is applied to traffic matched by no rule of the tier policies selecting the host endpoint
+	DefaultAction string `bson:"default_action"`
+}
+
+func (Tier) CollectionName() string {
+	return "tier"
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Kinds of the resources webhook events are sent for
+const (
+	WebhookKindHostEndpoint        = "hostEndpoint"
+	WebhookKindGlobalNetworkPolicy = "globalNetworkPolicy"
+	WebhookKindGlobalNetworkSet    = "globalNetworkSet"
+	WebhookKindNetworkPolicy       = "networkPolicy"
+	WebhookKindNetworkSet          = "networkSet"
+	WebhookKindTier                = "tier"
+	WebhookKindTenant              = "tenant"
+	WebhookKindEnrollmentToken     = "enrollmentToken"
+)
+
+const (
+	WebhookActionCreate = "create"
+	WebhookActionUpdate = "update"
+	WebhookActionDelete = "delete"
+)
+
+var (
+	WebhookKinds = []string{WebhookKindHostEndpoint, WebhookKindGlobalNetworkPolicy, WebhookKindGlobalNetworkSet,
+		WebhookKindNetworkPolicy, WebhookKindNetworkSet, WebhookKindTier, WebhookKindTenant, WebhookKindEnrollmentToken}
+	WebhookActions = []string{WebhookActionCreate, WebhookActionUpdate, WebhookActionDelete}
+)
+
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	WebhookDeliveryStatusFailed    = "failed"
+)
+
+// Webhook receives the changes of the resources matching its filter.
+type Webhook struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	UUID        string             `bson:"uuid"`
+	Version     uint               `bson:"version"`
+	Metadata    WebhookMetadata    `bson:"metadata"`
+	Spec        WebhookSpec        `bson:"spec"`
+	Description string             `bson:"description"`
+	FilePath    string             `bson:"file_path"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+}
+
+type WebhookMetadata struct {
+	Name   string            `bson:"name"`
+	Labels map[string]string `bson:"labels,omitempty"`
+}
+
+type WebhookSpec struct {
+	URL string `bson:"url"`
+	// Secret signs the deliveries, it is kept when the webhook is updated without secret
+	Secret string `bson:"secret,omitempty"`
+	// Kinds, Actions and Selector filter the events, empty matches any event. Selector is evaluated against the labels
+	// of the changed resource.
+	Kinds    []string `bson:"kinds,omitempty"`
+	Actions  []string `bson:"actions,omitempty"`
+	Selector string   `bson:"selector,omitempty"`
+}
+
+func (Webhook) CollectionName() string {
+	return "webhook"
+}
+
+// WebhookEvent is a change of a resource.
+type WebhookEvent struct {
+	ID         string            `bson:"id"`
+	Kind       string            `bson:"kind"`
+	Action     string            `bson:"action"`
+	Name       string            `bson:"name"`
+	TenantID   uint64            `bson:"tenant_id,omitempty"`
+	UUID       string            `bson:"uuid"`
+	Version    uint              `bson:"version"`
+	Labels     map[string]string `bson:"labels,omitempty"`
+	OccurredAt time.Time         `bson:"occurred_at"`
+}
+
+// WebhookDelivery is an event to deliver to a webhook, kept as the delivery log once delivered or failed.
+type WebhookDelivery struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	UUID           string             `bson:"uuid"`
+	WebhookName    string             `bson:"webhook_name"`
+	Event          WebhookEvent       `bson:"event"`
+	Status         string             `bson:"status"`
+	Attempts       int                `bson:"attempts"`
+	LastStatusCode int                `bson:"last_status_code,omitempty"`
+	LastError      string             `bson:"last_error,omitempty"`
+	NextAttemptAt  time.Time          `bson:"next_attempt_at"`
+	DeliveredAt    *time.Time         `bson:"delivered_at,omitempty"`
+	CreatedAt      time.Time          `bson:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at"`
+}
+
+func (WebhookDelivery) CollectionName() string {
+	return "webhook_delivery"
+}
@@ -24,6 +24,7 @@ const (
 	ErrorCodeValidateRequest
 	ErrorCodeForBidden
 	ErrorCodeUnauthorized
+	ErrorCodeConflict
 )
 
 func toName(id ierror.ErrorCode) ierror.ErrorName {
@@ -38,10 +39,14 @@ func toName(id ierror.ErrorCode) ierror.ErrorName {
 		return "err_bad_request"
 	case ErrorCodeUnauthorized:
 		return "err_unauthorized"
+	case ErrorCodeForBidden:
+		return "err_forbidden"
 	case ErrorCodeNotFound:
 		return "err_not_found"
 	case ErrorCodeValidateRequest:
 		return "err_validate_request"
+	case ErrorCodeConflict:
+		return "err_conflict"
 	default:
 		return "err_common"
 	}
@@ -64,6 +69,18 @@ var (
 		return newClientIError(ctx, ErrorCodeBadRequest, msgID).SetHTTPStatus(http.StatusBadRequest)
 	}
 
+	ErrUnauthorized = func(ctx context.Context, msgID string) *ierror.Error {
+		return newClientIError(ctx, ErrorCodeUnauthorized, msgID).SetHTTPStatus(http.StatusUnauthorized)
+	}
+
+	ErrForbidden = func(ctx context.Context, msgID string) *ierror.Error {
+		return newClientIError(ctx, ErrorCodeForBidden, msgID).SetHTTPStatus(http.StatusForbidden)
+	}
+
+	ErrConflict = func(ctx context.Context, msgID string) *ierror.Error {
+		return newClientIError(ctx, ErrorCodeConflict, msgID).SetHTTPStatus(http.StatusConflict)
+	}
+
 	ErrDatabase = func(ctx context.Context, msgID string) *ierror.Error {
 		return newClientIError(ctx, ErrorCodeDatabase, msgID).SetHTTPStatus(http.StatusInternalServerError)
 	}
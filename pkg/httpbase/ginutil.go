@@ -1,9 +1,11 @@
 package httpbase
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -67,6 +69,19 @@ func BindInput(ctx *gin.Context, input interface{}) *ierror.Error {
 	return nil
 }
 
+// BindQueryBool parses the boolean query param key, a missing param is false.
+func BindQueryBool(ctx *gin.Context, key string) (bool, *ierror.Error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, bindError(ctx, err)
+	}
+	return b, nil
+}
+
 func bindError(ctx *gin.Context, err error) *ierror.Error {
 	return ErrBindRequest(ctx, "BindFailed").SetDetail(err.Error())
 }
@@ -78,7 +93,7 @@ type validatorErrorDetail struct {
 	Param         string      `json:"param,omitempty"`
 }
 
-func validateError(ctx *gin.Context, err error) *ierror.Error {
+func validateError(ctx context.Context, err error) *ierror.Error {
 	var errs validator.ValidationErrors
 	ok := errors.As(err, &errs)
 	if !ok {
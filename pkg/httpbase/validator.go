@@ -1,6 +1,12 @@
 package httpbase
 
-import "github.com/go-playground/validator/v10"
+import (
+	"context"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
 
 var (
 	defaultValidator = validator.New()
@@ -13,3 +19,12 @@ func RegisterValidator(tag string, fn validator.Func) error {
 func RegisterStructValidation(fn validator.StructLevelFunc, types ...interface{}) {
 	defaultValidator.RegisterStructValidation(fn, types...)
 }
+
+// ValidateStruct validates input with the registered validators as BindInput does, for inputs not bound from a
+// request.
+func ValidateStruct(ctx context.Context, input interface{}) *ierror.Error {
+	if err := defaultValidator.Struct(input); err != nil {
+		return validateError(ctx, err)
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package net
+
+import (
+	"net/netip"
+	"slices"
+)
+
+// CIDRSet is a set of ip addresses, IPv4 and IPv6 may be mixed. It is stored as sorted, disjoint and
+// non-adjacent ranges so that Prefixes returns the minimal list of CIDRs covering the set.
+// The zero value is an empty set. Operations return new sets and never modify their operands.
+type CIDRSet struct {
+	ranges []ipRange
+}
+
+// ipRange is the inclusive range from..to of addresses of a single family.
+type ipRange struct {
+	from netip.Addr
+	to   netip.Addr
+}
+
+func NewCIDRSet(prefixes ...netip.Prefix) *CIDRSet {
+	ranges := make([]ipRange, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		if !prefix.IsValid() {
+			continue
+		}
+		ranges = append(ranges, prefixRange(prefix))
+	}
+	return &CIDRSet{ranges: mergeRanges(ranges)}
+}
+
+// ParseCIDRSet parses nets written as CIDRs or single ip addresses.
+func ParseCIDRSet(nets []string) (*CIDRSet, error) {
+	prefixes := make([]netip.Prefix, 0, len(nets))
+	for _, n := range nets {
+		prefix, err := ParsePrefix(n)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return NewCIDRSet(prefixes...), nil
+}
+
+// ParsePrefix parses a CIDR or a single ip address, the host bits of a CIDR are cleared.
+func ParsePrefix(s string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(s)
+	if err == nil {
+		if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		}
+		return prefix.Masked(), nil
+	}
+	addr, errAddr := netip.ParseAddr(s)
+	if errAddr != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+func (s *CIDRSet) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+func (s *CIDRSet) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	i, found := slices.BinarySearchFunc(s.ranges, addr, func(r ipRange, a netip.Addr) int {
+		return r.from.Compare(a)
+	})
+	if found {
+		return true
+	}
+	return i > 0 && s.ranges[i-1].to.Compare(addr) >= 0 && s.ranges[i-1].from.BitLen() == addr.BitLen()
+}
+
+// ContainsPrefix reports whether every address of prefix is in the set.
+func (s *CIDRSet) ContainsPrefix(prefix netip.Prefix) bool {
+	return NewCIDRSet(prefix).Difference(s).IsEmpty()
+}
+
+func (s *CIDRSet) Union(other *CIDRSet) *CIDRSet {
+	ranges := make([]ipRange, 0, len(s.ranges)+len(other.ranges))
+	ranges = append(ranges, s.ranges...)
+	ranges = append(ranges, other.ranges...)
+	return &CIDRSet{ranges: mergeRanges(ranges)}
+}
+
+func (s *CIDRSet) Intersection(other *CIDRSet) *CIDRSet {
+	var ranges []ipRange
+	i, j := 0, 0
+	for i < len(s.ranges) && j < len(other.ranges) {
+		a, b := s.ranges[i], other.ranges[j]
+		from, to := maxAddr(a.from, b.from), minAddr(a.to, b.to)
+		if from.BitLen() == to.BitLen() && from.Compare(to) <= 0 {
+			ranges = append(ranges, ipRange{from: from, to: to})
+		}
+		if a.to.Compare(b.to) < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return &CIDRSet{ranges: ranges}
+}
+
+func (s *CIDRSet) Difference(other *CIDRSet) *CIDRSet {
+	var ranges []ipRange
+	j := 0
+	for _, r := range s.ranges {
+		from := r.from
+		for j < len(other.ranges) && other.ranges[j].to.Compare(from) < 0 {
+			j++
+		}
+		done := false
+		for k := j; k < len(other.ranges) && other.ranges[k].from.Compare(r.to) <= 0; k++ {
+			cut := other.ranges[k]
+			if cut.from.Compare(from) > 0 {
+				ranges = append(ranges, ipRange{from: from, to: cut.from.Prev()})
+			}
+			if cut.to.Compare(r.to) >= 0 {
+				done = true
+				break
+			}
+			from = cut.to.Next()
+		}
+		if !done {
+			ranges = append(ranges, ipRange{from: from, to: r.to})
+		}
+	}
+	return &CIDRSet{ranges: ranges}
+}
+
+// Prefixes returns the minimal sorted list of prefixes covering the set, IPv4 first.
+func (s *CIDRSet) Prefixes() []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, r := range s.ranges {
+		prefixes = appendRangePrefixes(prefixes, r)
+	}
+	return prefixes
+}
+
+// Strings returns the minimal prefixes of the set split by ip version.
+func (s *CIDRSet) Strings() (netsV4 []string, netsV6 []string) {
+	for _, prefix := range s.Prefixes() {
+		if prefix.Addr().Is4() {
+			netsV4 = append(netsV4, prefix.String())
+		} else {
+			netsV6 = append(netsV6, prefix.String())
+		}
+	}
+	return
+}
+
+// Overlap is a pair of prefixes sharing addresses, Prefix is the earlier one in the input.
+type Overlap struct {
+	Prefix netip.Prefix
+	Other  netip.Prefix
+}
+
+// FindOverlaps returns the pairs of prefixes that overlap. Equal prefixes are reported too.
+func FindOverlaps(prefixes []netip.Prefix) []Overlap {
+	type indexed struct {
+		prefix netip.Prefix
+		index  int
+	}
+	sorted := make([]indexed, 0, len(prefixes))
+	for i, prefix := range prefixes {
+		if prefix.IsValid() {
+			sorted = append(sorted, indexed{prefix: prefix.Masked(), index: i})
+		}
+	}
+	slices.SortStableFunc(sorted, func(a, b indexed) int {
+		if c := a.prefix.Addr().Compare(b.prefix.Addr()); c != 0 {
+			return c
+		}
+		return a.prefix.Bits() - b.prefix.Bits()
+	})
+
+	var overlaps []Overlap
+	for i := range sorted {
+		last := prefixRange(sorted[i].prefix).to
+		for j := i + 1; j < len(sorted) && sorted[j].prefix.Addr().Compare(last) <= 0; j++ {
+			a, b := sorted[i], sorted[j]
+			if b.index < a.index {
+				a, b = b, a
+			}
+			overlaps = append(overlaps, Overlap{Prefix: prefixes[a.index], Other: prefixes[b.index]})
+		}
+	}
+	return overlaps
+}
+
+func prefixRange(prefix netip.Prefix) ipRange {
+	prefix = prefix.Masked()
+	from := prefix.Addr()
+	to := from.AsSlice()
+	for bit := prefix.Bits(); bit < len(to)*8; bit++ {
+		to[bit/8] |= 1 << (7 - bit%8)
+	}
+	toAddr, _ := netip.AddrFromSlice(to)
+	return ipRange{from: from, to: toAddr}
+}
+
+// mergeRanges sorts ranges and merges the overlapping and adjacent ones.
+func mergeRanges(ranges []ipRange) []ipRange {
+	if len(ranges) == 0 {
+		return nil
+	}
+	ranges = slices.Clone(ranges)
+	slices.SortFunc(ranges, func(a, b ipRange) int {
+		return a.from.Compare(b.from)
+	})
+	merged := ranges[:1]
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		next := last.to.Next()
+		if last.to.BitLen() == r.from.BitLen() && (r.from.Compare(last.to) <= 0 || next == r.from) {
+			if r.to.Compare(last.to) > 0 {
+				last.to = r.to
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// appendRangePrefixes appends the largest aligned prefixes covering r, from low to high addresses.
+func appendRangePrefixes(prefixes []netip.Prefix, r ipRange) []netip.Prefix {
+	from := r.from
+	for {
+		bits := from.BitLen()
+		for bits > 0 {
+			candidate := netip.PrefixFrom(from, bits-1)
+			if candidate.Masked().Addr() != from || prefixRange(candidate).to.Compare(r.to) > 0 {
+				break
+			}
+			bits--
+		}
+		prefix := netip.PrefixFrom(from, bits)
+		prefixes = append(prefixes, prefix)
+		last := prefixRange(prefix).to
+		if last.Compare(r.to) >= 0 {
+			return prefixes
+		}
+		from = last.Next()
+	}
+}
+
+func minAddr(a, b netip.Addr) netip.Addr {
+	if a.Compare(b) <= 0 {
+		return a
+	}
+	return b
+}
+
+func maxAddr(a, b netip.Addr) netip.Addr {
+	if a.Compare(b) >= 0 {
+		return a
+	}
+	return b
+}
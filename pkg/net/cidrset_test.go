@@ -0,0 +1,121 @@
+package net
+
+import (
+	"net/netip"
+	"slices"
+	"testing"
+)
+
+func mustCIDRSet(t *testing.T, nets ...string) *CIDRSet {
+	t.Helper()
+	set, err := ParseCIDRSet(nets)
+	if err != nil {
+		t.Fatalf("parse %v: %v", nets, err)
+	}
+	return set
+}
+
+func prefixStrings(set *CIDRSet) []string {
+	var out []string
+	for _, prefix := range set.Prefixes() {
+		out = append(out, prefix.String())
+	}
+	return out
+}
+
+func TestCIDRSetAggregate(t *testing.T) {
+	tests := []struct {
+		name string
+		nets []string
+		want []string
+	}{
+		{name: "adjacent halves", nets: []string{"10.0.0.0/25", "10.0.0.128/25"}, want: []string{"10.0.0.0/24"}},
+		{name: "host inside network", nets: []string{"10.0.0.0/24", "10.0.0.7"}, want: []string{"10.0.0.0/24"}},
+		{name: "duplicate", nets: []string{"192.168.1.0/24", "192.168.1.0/24"}, want: []string{"192.168.1.0/24"}},
+		{name: "not aligned", nets: []string{"10.0.0.1", "10.0.0.2"}, want: []string{"10.0.0.1/32", "10.0.0.2/32"}},
+		{name: "range split", nets: []string{"10.0.0.1", "10.0.0.2/31", "10.0.0.4/30"}, want: []string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/30"}},
+		{name: "host bits", nets: []string{"10.0.1.5/23"}, want: []string{"10.0.0.0/23"}},
+		{name: "mixed families", nets: []string{"2001:db8::/33", "10.0.0.0/8", "2001:db8:8000::/33"}, want: []string{"10.0.0.0/8", "2001:db8::/32"}},
+		{name: "whole v4", nets: []string{"0.0.0.0/1", "128.0.0.0/1"}, want: []string{"0.0.0.0/0"}},
+		{name: "v4 and v6 edges not merged", nets: []string{"255.255.255.255", "::"}, want: []string{"255.255.255.255/32", "::/128"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prefixStrings(mustCIDRSet(t, tt.nets...)); !slices.Equal(got, tt.want) {
+				t.Errorf("prefixes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCIDRSetOperations(t *testing.T) {
+	a := mustCIDRSet(t, "10.0.0.0/24", "fd00::/64")
+	b := mustCIDRSet(t, "10.0.0.128/25", "10.0.1.0/24")
+
+	if got, want := prefixStrings(a.Union(b)), []string{"10.0.0.0/23", "fd00::/64"}; !slices.Equal(got, want) {
+		t.Errorf("union = %v, want %v", got, want)
+	}
+	if got, want := prefixStrings(a.Intersection(b)), []string{"10.0.0.128/25"}; !slices.Equal(got, want) {
+		t.Errorf("intersection = %v, want %v", got, want)
+	}
+	if got, want := prefixStrings(a.Difference(b)), []string{"10.0.0.0/25", "fd00::/64"}; !slices.Equal(got, want) {
+		t.Errorf("difference = %v, want %v", got, want)
+	}
+	if got, want := prefixStrings(mustCIDRSet(t, "10.0.0.0/24").Difference(mustCIDRSet(t, "10.0.0.5"))),
+		[]string{"10.0.0.0/30", "10.0.0.4/32", "10.0.0.6/31", "10.0.0.8/29", "10.0.0.16/28", "10.0.0.32/27", "10.0.0.64/26", "10.0.0.128/25"}; !slices.Equal(got, want) {
+		t.Errorf("difference of a host = %v, want %v", got, want)
+	}
+	if !a.Difference(a).IsEmpty() {
+		t.Error("a - a is not empty")
+	}
+	if !a.Contains(netip.MustParseAddr("10.0.0.200")) || a.Contains(netip.MustParseAddr("10.0.1.1")) {
+		t.Error("contains is wrong")
+	}
+	if !a.ContainsPrefix(netip.MustParsePrefix("10.0.0.64/26")) || a.ContainsPrefix(netip.MustParsePrefix("10.0.0.0/23")) {
+		t.Error("contains prefix is wrong")
+	}
+}
+
+// TestCIDRSetMatchesAddresses checks the operations address by address on a small space.
+func TestCIDRSetMatchesAddresses(t *testing.T) {
+	sets := [][]string{
+		{"10.0.0.0/28"},
+		{"10.0.0.4/30", "10.0.0.9"},
+		{"10.0.0.0/29", "10.0.0.12/30"},
+		{"10.0.0.3", "10.0.0.5", "10.0.0.15"},
+		{},
+	}
+	for _, left := range sets {
+		for _, right := range sets {
+			a, b := mustCIDRSet(t, left...), mustCIDRSet(t, right...)
+			union, intersection, difference := a.Union(b), a.Intersection(b), a.Difference(b)
+			for i := 0; i < 20; i++ {
+				addr := netip.AddrFrom4([4]byte{10, 0, 0, byte(i)})
+				inA, inB := a.Contains(addr), b.Contains(addr)
+				if union.Contains(addr) != (inA || inB) ||
+					intersection.Contains(addr) != (inA && inB) ||
+					difference.Contains(addr) != (inA && !inB) {
+					t.Fatalf("%v and %v disagree at %s", left, right, addr)
+				}
+			}
+		}
+	}
+}
+
+func TestFindOverlaps(t *testing.T) {
+	prefixes := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/24"),
+		netip.MustParsePrefix("192.168.0.0/16"),
+		netip.MustParsePrefix("10.0.0.7/32"),
+		netip.MustParsePrefix("10.0.1.0/24"),
+		netip.MustParsePrefix("192.168.3.0/24"),
+	}
+	got := FindOverlaps(prefixes)
+	want := []Overlap{
+		{Prefix: prefixes[0], Other: prefixes[2]},
+		{Prefix: prefixes[1], Other: prefixes[4]},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("overlaps = %v, want %v", got, want)
+	}
+}
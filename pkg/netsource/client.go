@@ -0,0 +1,52 @@
+package netsource
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+var (
+	// thisNetwork addresses the local host on most systems.
+	thisNetwork = netip.MustParsePrefix("0.0.0.0/8")
+	// sharedAddressSpace is the carrier-grade NAT range, cloud providers also serve metadata in it.
+	sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+)
+
+// NewPublicClient returns a client which only connects to public addresses, so that a source can not reach the
+// internal services of the server network or the metadata of its cloud instance. The address is checked when
+// connecting, which covers redirects and names resolving to internal addresses. Proxies from the environment are
+// not used since the check would apply to the proxy.
+func NewPublicClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !IsPublicAddr(addrPort.Addr()) {
+				return fmt.Errorf("address %s is not public", addrPort.Addr())
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   timeout,
+			ResponseHeaderTimeout: timeout,
+		},
+	}
+}
+
+// IsPublicAddr reports whether addr is a public unicast address.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !thisNetwork.Contains(addr) &&
+		!sharedAddressSpace.Contains(addr)
+}
@@ -0,0 +1,143 @@
+package netsource
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseJSON returns the strings selected by path in the json document data.
+// path supports the subset of JSONPath needed by public ip lists: the root $, child members .name or ['name'],
+// array indexes [n] and wildcards .* or [*], e.g. $.prefixes[*].ip_prefix.
+// A selected array of strings is flattened, any other non string value is an error.
+func ParseJSON(data []byte, path string) ([]string, error) {
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err = json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode json: %w", err)
+	}
+
+	values := []interface{}{doc}
+	for _, step := range steps {
+		var next []interface{}
+		for _, value := range values {
+			next = append(next, step.apply(value)...)
+		}
+		values = next
+	}
+
+	var nets []string
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			nets = append(nets, v)
+		case []interface{}:
+			for _, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("json path %s selects a non string value %v", path, item)
+				}
+				nets = append(nets, s)
+			}
+		case nil:
+		default:
+			return nil, fmt.Errorf("json path %s selects a non string value %v", path, v)
+		}
+	}
+	return nets, nil
+}
+
+type jsonPathStep struct {
+	member   string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+func (s jsonPathStep) apply(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if s.wildcard {
+			values := make([]interface{}, 0, len(v))
+			for _, item := range v {
+				values = append(values, item)
+			}
+			return values
+		}
+		if s.isIndex {
+			return nil
+		}
+		if item, ok := v[s.member]; ok {
+			return []interface{}{item}
+		}
+	case []interface{}:
+		if s.wildcard {
+			return v
+		}
+		if s.isIndex && s.index >= 0 && s.index < len(v) {
+			return []interface{}{v[s.index]}
+		}
+	}
+	return nil
+}
+
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("json path %q must start with $", path)
+	}
+	var steps []jsonPathStep
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			member := rest[:end]
+			if member == "" {
+				return nil, fmt.Errorf("json path %q has an empty member", path)
+			}
+			if member == "*" {
+				steps = append(steps, jsonPathStep{wildcard: true})
+			} else {
+				steps = append(steps, jsonPathStep{member: member})
+			}
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("json path %q has an unclosed [", path)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			switch {
+			case inner == "*":
+				steps = append(steps, jsonPathStep{wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				steps = append(steps, jsonPathStep{member: inner[1 : len(inner)-1]})
+			default:
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("json path %q has an unsupported selector [%s]", path, inner)
+				}
+				steps = append(steps, jsonPathStep{index: index, isIndex: true})
+			}
+		default:
+			return nil, fmt.Errorf("json path %q is invalid near %q", path, rest)
+		}
+	}
+	return steps, nil
+}
+
+// CheckJSONPath reports whether path is supported by ParseJSON.
+func CheckJSONPath(path string) error {
+	_, err := parseJSONPath(path)
+	return err
+}
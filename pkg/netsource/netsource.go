@@ -0,0 +1,156 @@
+// Package netsource reads lists of networks from an HTTP URL or a file of a local directory.
+package netsource
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	FormatPlain = "plain"
+	FormatJSON  = "json"
+
+	// defaultMaxBytes bounds the size of a list, the largest public blocklists are a few MB.
+	defaultMaxBytes = 32 << 20
+)
+
+// Source locates a list of networks. Exactly one of URL and File is set, File is relative to the file directory of
+// the Fetcher.
+// A plain list has a network per line, text after the network and lines starting with # or ; are ignored.
+// A json list is a document where JSONPath selects the networks, see ParseJSON.
+type Source struct {
+	URL      string
+	File     string
+	Format   string
+	JSONPath string
+}
+
+// Fetcher reads sources with client and below fileDir. File sources are refused when fileDir is empty.
+type Fetcher struct {
+	client   *http.Client
+	fileDir  string
+	maxBytes int64
+}
+
+func NewFetcher(client *http.Client, fileDir string) *Fetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Fetcher{
+		client:   client,
+		fileDir:  fileDir,
+		maxBytes: defaultMaxBytes,
+	}
+}
+
+// Fetch returns the networks of src as written in the list, they are not validated.
+func (f *Fetcher) Fetch(ctx context.Context, src Source) ([]string, error) {
+	data, err := f.read(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(src.Format) {
+	case "", FormatPlain:
+		return ParsePlain(bytes.NewReader(data))
+	case FormatJSON:
+		return ParseJSON(data, src.JSONPath)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", src.Format)
+	}
+}
+
+func (f *Fetcher) read(ctx context.Context, src Source) ([]byte, error) {
+	switch {
+	case src.URL != "" && src.File != "":
+		return nil, fmt.Errorf("source has both url and file")
+	case src.URL != "":
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.URL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("create request: %w", err)
+		}
+		res, err := f.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("fetch %s: %w", src.URL, err)
+		}
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("fetch %s: unexpected status %s", src.URL, res.Status)
+		}
+		return f.readAll(res.Body)
+	case src.File != "":
+		path, err := f.filePath(src.File)
+		if err != nil {
+			return nil, err
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", src.File, err)
+		}
+		defer file.Close()
+		return f.readAll(file)
+	default:
+		return nil, fmt.Errorf("source has neither url nor file")
+	}
+}
+
+// filePath returns the path of name in the file directory, name must not escape it, even through symlinks.
+func (f *Fetcher) filePath(name string) (string, error) {
+	if f.fileDir == "" {
+		return "", fmt.Errorf("file sources are disabled")
+	}
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("file %s is not relative to the file directory", name)
+	}
+	dir, err := filepath.EvalSymlinks(f.fileDir)
+	if err != nil {
+		return "", fmt.Errorf("file directory: %w", err)
+	}
+	path, err := filepath.EvalSymlinks(filepath.Join(dir, name))
+	if err != nil {
+		return "", fmt.Errorf("open %s: %w", name, err)
+	}
+	if rel, err := filepath.Rel(dir, path); err != nil || !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("file %s is outside the file directory", name)
+	}
+	return path, nil
+}
+
+func (f *Fetcher) readAll(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, f.maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read list: %w", err)
+	}
+	if int64(len(data)) > f.maxBytes {
+		return nil, fmt.Errorf("list is larger than %d bytes", f.maxBytes)
+	}
+	return data, nil
+}
+
+// ParsePlain reads a network per line.
+func ParsePlain(r io.Reader) ([]string, error) {
+	var nets []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ' ' || r == '\t' || r == ';' || r == '#' || r == ','
+		})
+		if len(fields) > 0 {
+			nets = append(nets, fields[0])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read list: %w", err)
+	}
+	return nets, nil
+}
@@ -0,0 +1,167 @@
+package netsource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetchPlainURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("; spamhaus drop list\n1.10.16.0/20 ; SBL256894\n\n# comment\n2.56.192.0/22\n10.0.0.1\n"))
+	}))
+	defer server.Close()
+
+	nets, err := NewFetcher(server.Client(), "").Fetch(context.Background(), Source{URL: server.URL, Format: FormatPlain})
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	want := []string{"1.10.16.0/20", "2.56.192.0/22", "10.0.0.1"}
+	if !slices.Equal(nets, want) {
+		t.Fatalf("nets = %v, want %v", nets, want)
+	}
+}
+
+func TestFetchJSONURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"syncToken":"1","prefixes":[{"ip_prefix":"3.5.140.0/22","region":"ap-northeast-2"},{"ip_prefix":"13.34.37.64/27","region":"ap-southeast-4"}]}`))
+	}))
+	defer server.Close()
+
+	nets, err := NewFetcher(server.Client(), "").Fetch(context.Background(), Source{URL: server.URL, Format: FormatJSON, JSONPath: "$.prefixes[*].ip_prefix"})
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	want := []string{"3.5.140.0/22", "13.34.37.64/27"}
+	if !slices.Equal(nets, want) {
+		t.Fatalf("nets = %v, want %v", nets, want)
+	}
+}
+
+func TestFetchURLStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if _, err := NewFetcher(server.Client(), "").Fetch(context.Background(), Source{URL: server.URL}); err == nil {
+		t.Fatal("expected an error for status 500")
+	}
+}
+
+func TestFetchFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "nets.json"), []byte(`{"nets":["192.168.0.0/16","fd00::/8"]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	nets, err := NewFetcher(nil, dir).Fetch(context.Background(), Source{File: "nets.json", Format: FormatJSON, JSONPath: "$['nets']"})
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	want := []string{"192.168.0.0/16", "fd00::/8"}
+	if !slices.Equal(nets, want) {
+		t.Fatalf("nets = %v, want %v", nets, want)
+	}
+}
+
+func TestFetchFileOutsideDir(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "sources")
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	secret := filepath.Join(root, "secret")
+	if err := os.WriteFile(secret, []byte("10.0.0.0/8\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(secret, filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		fileDir string
+		file    string
+	}{
+		{name: "file sources disabled", fileDir: "", file: "secret"},
+		{name: "absolute", fileDir: dir, file: secret},
+		{name: "parent", fileDir: dir, file: "../secret"},
+		{name: "symlink", fileDir: dir, file: "link"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewFetcher(nil, tt.fileDir).Fetch(context.Background(), Source{File: tt.file}); err == nil {
+				t.Fatalf("read %s below %q", tt.file, tt.fileDir)
+			}
+		})
+	}
+}
+
+func TestPublicClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("10.0.0.0/8\n"))
+	}))
+	defer server.Close()
+
+	_, err := NewFetcher(NewPublicClient(time.Second), "").Fetch(context.Background(), Source{URL: server.URL})
+	if err == nil || !strings.Contains(err.Error(), "is not public") {
+		t.Fatalf("fetch from loopback error = %v, want not public", err)
+	}
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "1.1.1.1", want: true},
+		{addr: "2606:4700::1111", want: true},
+		{addr: "127.0.0.1"},
+		{addr: "::1"},
+		{addr: "10.1.2.3"},
+		{addr: "172.16.0.1"},
+		{addr: "192.168.1.1"},
+		{addr: "169.254.169.254"},
+		{addr: "100.100.100.200"},
+		{addr: "0.0.0.0"},
+		{addr: "0.1.2.3"},
+		{addr: "224.0.0.1"},
+		{addr: "fe80::1"},
+		{addr: "fd00::1"},
+		{addr: "::ffff:127.0.0.1"},
+	}
+	for _, tt := range tests {
+		if got := IsPublicAddr(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("IsPublicAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestParseJSONPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{path: "$.a.b"},
+		{path: "$.a[0]"},
+		{path: "$.a[*].b"},
+		{path: "$['a'].*"},
+		{path: "a.b", wantErr: true},
+		{path: "$.a[", wantErr: true},
+		{path: "$.a[x]", wantErr: true},
+		{path: "$..a", wantErr: true},
+	}
+	for _, tt := range tests {
+		if _, err := parseJSONPath(tt.path); (err != nil) != tt.wantErr {
+			t.Errorf("parseJSONPath(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+		}
+	}
+}
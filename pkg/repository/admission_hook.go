@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+func (r *PolicyDB) UpsertAdmissionHook(ctx context.Context, admissionHook *entity.AdmissionHook) *ierror.CoreError {
+	session, err := r.mongo.Database.Client().StartSession()
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(err)
+	}
+	defer session.EndSession(ctx)
+
+	sessionCallback := func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		filter := bson.D{{Key: "metadata.name", Value: admissionHook.Metadata.Name}}
+		existedAdmissionHook := new(entity.AdmissionHook)
+		err = r.mongo.Database.Collection(admissionHook.CollectionName()).FindOne(ctx, filter).Decode(existedAdmissionHook)
+		if err != nil && !errors.Is(mongo.ErrNoDocuments, err) {
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("find admission hook failed: %w", err))
+		}
+
+		// admission hook is existed
+		if !errors.Is(mongo.ErrNoDocuments, err) {
+			admissionHook.ID = existedAdmissionHook.ID
+			admissionHook.UUID = existedAdmissionHook.UUID
+			admissionHook.Version = existedAdmissionHook.Version
+			admissionHook.CreatedAt = existedAdmissionHook.CreatedAt
+			if admissionHook.Spec.Secret == "" {
+				admissionHook.Spec.Secret = existedAdmissionHook.Spec.Secret
+			}
+		}
+
+		filter = bson.D{{Key: "_id", Value: admissionHook.ID}}
+		update := bson.D{{Key: "$set", Value: admissionHook}}
+		opts := options.Update().SetUpsert(true)
+		_, err = r.mongo.Database.Collection(admissionHook.CollectionName()).UpdateOne(ctx, filter, update, opts)
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return nil, errlist.ErrDuplicateAdmissionHook.
+					WithChild(fmt.Errorf("admission hook already exists: %w", err))
+			}
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update admission hook failed: %w", err))
+		}
+
+		updateVersion := bson.M{
+			"$inc": bson.M{
+				"version": 1,
+			},
+		}
+		optUpdateVersions := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		err = r.mongo.Database.Collection(admissionHook.CollectionName()).FindOneAndUpdate(ctx, filter, updateVersion, optUpdateVersions).Decode(admissionHook)
+		if err != nil {
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update version admission hook failed: %w", err))
+		}
+
+		return nil, nil
+	}
+
+	opts := options.Transaction().SetWriteConcern(writeconcern.Majority()).SetReadConcern(readconcern.Snapshot())
+	_, sessionErr := session.WithTransaction(ctx, sessionCallback, opts)
+	if sessionErr != nil {
+		var coreErr *ierror.CoreError
+		if errors.As(sessionErr, &coreErr) {
+			return coreErr
+		}
+		return errlist.ErrDatabase.WithChild(sessionErr)
+	}
+
+	return nil
+}
+
+func (r *PolicyDB) GetAdmissionHookByName(ctx context.Context, name string) (*entity.AdmissionHook, *ierror.CoreError) {
+	filter := bson.D{{Key: "metadata.name", Value: name}}
+
+	admissionHook := new(entity.AdmissionHook)
+	err := r.mongo.Database.Collection(admissionHook.CollectionName()).FindOne(ctx, filter).Decode(admissionHook)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errlist.ErrNotFoundAdmissionHook
+		}
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("find admission hook failed: %w", err))
+	}
+	return admissionHook, nil
+}
+
+func (r *PolicyDB) DeleteAdmissionHookByName(ctx context.Context, name string) *ierror.CoreError {
+	filter := bson.D{{Key: "metadata.name", Value: name}}
+
+	_, err := r.mongo.Database.Collection(entity.AdmissionHook{}.CollectionName()).DeleteOne(ctx, filter)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("delete admission hook failed: %w", err))
+	}
+	return nil
+}
+
+func (r *PolicyDB) ListAdmissionHooks(ctx context.Context) ([]*entity.AdmissionHook, *ierror.CoreError) {
+	admissionHooks := make([]*entity.AdmissionHook, 0)
+	opts := options.Find().SetSort(bson.D{{Key: "metadata.name", Value: 1}})
+	cursor, err := r.mongo.Database.Collection(entity.AdmissionHook{}.CollectionName()).Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("list admission hooks failed: %w", err))
+	}
+	if err = cursor.All(ctx, &admissionHooks); err != nil {
+		return nil, errlist.ErrUnmarshalFailed.WithChild(fmt.Errorf("decode admission hooks failed: %w", err))
+	}
+	return admissionHooks, nil
+}
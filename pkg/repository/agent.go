@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+// RegisterAgent creates the agent of a host endpoint or refreshes its identity if it registers again. The applied
+// policy reported by heartbeats is kept.
+func (r *PolicyDB) RegisterAgent(ctx context.Context, agent *entity.Agent) *ierror.CoreError {
+	filter := bson.D{
+		{Key: "tenant_id", Value: agent.TenantID},
+		{Key: "ip", Value: agent.IP},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"hostname":      agent.Hostname,
+			"agent_version": agent.AgentVersion,
+			"registered_at": agent.RegisteredAt,
+		},
+		"$setOnInsert": bson.M{
+			"_id":        primitive.NewObjectID(),
+			"created_at": agent.RegisteredAt,
+		},
+	}
+	opts := options.Update().SetUpsert(true)
+	_, err := r.mongo.Database.Collection(entity.Agent{}.CollectionName()).UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("register agent failed: %w", err))
+	}
+	return nil
+}
+
+// UpdateAgentHeartbeat records the applied policy and the apply errors of a registered agent.
+func (r *PolicyDB) UpdateAgentHeartbeat(ctx context.Context, agent *entity.Agent) *ierror.CoreError {
+	filter := bson.D{
+		{Key: "tenant_id", Value: agent.TenantID},
+		{Key: "ip", Value: agent.IP},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"applied":           agent.Applied,
+			"apply_errors":      agent.ApplyErrors,
+			"last_heartbeat_at": agent.LastHeartbeatAt,
+		},
+	}
+	result, err := r.mongo.Database.Collection(entity.Agent{}.CollectionName()).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("update agent heartbeat failed: %w", err))
+	}
+	if result.MatchedCount == 0 {
+		return errlist.ErrNotFoundAgent
+	}
+	return nil
+}
+
+func (r *PolicyDB) ListAgents(ctx context.Context, tenantID uint64) ([]*entity.Agent, *ierror.CoreError) {
+	filter := bson.D{}
+	if tenantID != 0 {
+		filter = append(filter, bson.E{Key: "tenant_id", Value: tenantID})
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "tenant_id", Value: 1}, {Key: "ip", Value: 1}})
+
+	agents := make([]*entity.Agent, 0)
+	cursor, err := r.mongo.Database.Collection(entity.Agent{}.CollectionName()).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("list agents failed: %w", err))
+	}
+	if err = cursor.All(ctx, &agents); err != nil {
+		return nil, errlist.ErrUnmarshalFailed.WithChild(fmt.Errorf("decode agents failed: %w", err))
+	}
+	return agents, nil
+}
@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+// GetBundle reads all the resources of the policy database in a snapshot session, so that the bundle is consistent
+// even while resources are written.
+func (r *PolicyDB) GetBundle(ctx context.Context) (*model.Bundle, *ierror.CoreError) {
+	session, err := r.mongo.Database.Client().StartSession(options.Session().SetSnapshot(true))
+	if err != nil {
+		return nil, errlist.ErrDatabase.WithChild(err)
+	}
+	defer session.EndSession(ctx)
+
+	bundle := &model.Bundle{
+		CreatedAt:             time.Now(),
+		Tenants:               make([]*entity.Tenant, 0),
+		Tiers:                 make([]*entity.Tier, 0),
+		HostEndpoints:         make([]*entity.HostEndpoint, 0),
+		GlobalNetworkSets:     make([]*entity.GlobalNetworkSet, 0),
+		NetworkSets:           make([]*entity.GlobalNetworkSet, 0),
+		GlobalNetworkPolicies: make([]*entity.GlobalNetworkPolicy, 0),
+		NetworkPolicies:       make([]*entity.GlobalNetworkPolicy, 0),
+	}
+	err = mongo.WithSession(ctx, session, func(sessionCtx mongo.SessionContext) error {
+		collections := []struct {
+			name    string
+			results interface{}
+		}{
+			{name: entity.Tenant{}.CollectionName(), results: &bundle.Tenants},
+			{name: entity.Tier{}.CollectionName(), results: &bundle.Tiers},
+			{name: entity.HostEndpoint{}.CollectionName(), results: &bundle.HostEndpoints},
+			{name: entity.GlobalNetworkSet{}.CollectionName(), results: &bundle.GlobalNetworkSets},
+			{name: entity.NetworkSet{}.CollectionName(), results: &bundle.NetworkSets},
+			{name: entity.GlobalNetworkPolicy{}.CollectionName(), results: &bundle.GlobalNetworkPolicies},
+			{name: entity.NetworkPolicy{}.CollectionName(), results: &bundle.NetworkPolicies},
+		}
+		for _, collection := range collections {
+			cursor, errFind := r.mongo.Database.Collection(collection.name).Find(sessionCtx, bson.D{},
+				options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+			if errFind != nil {
+				return errlist.ErrDatabase.WithChild(fmt.Errorf("list %s failed: %w", collection.name, errFind))
+			}
+			if errFind = cursor.All(sessionCtx, collection.results); errFind != nil {
+				return errlist.ErrUnmarshalFailed.WithChild(fmt.Errorf("decode %s failed: %w", collection.name, errFind))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		var coreErr *ierror.CoreError
+		if errors.As(err, &coreErr) {
+			return nil, coreErr
+		}
+		return nil, errlist.ErrDatabase.WithChild(err)
+	}
+	return bundle, nil
+}
+
+// restoreItem is a resource of a bundle to restore.
+type restoreItem struct {
+	kind       string
+	collection string
+	name       string
+	uuid       string
+	// key is the filter of the unique key of the resource other than its uuid
+	key bson.D
+	// version points to the version of doc
+	version *uint
+	doc     interface{}
+}
+
+func bundleRestoreItems(bundle *model.Bundle) []*restoreItem {
+	var items []*restoreItem
+	for _, tenant := range bundle.Tenants {
+		items = append(items, &restoreItem{
+			kind:       entity.WebhookKindTenant,
+			collection: tenant.CollectionName(),
+			name:       tenant.Metadata.Name,
+			uuid:       tenant.UUID,
+			key: bson.D{{Key: "$or", Value: bson.A{
+				bson.D{{Key: "metadata.name", Value: tenant.Metadata.Name}},
+				bson.D{{Key: "spec.tenant_id", Value: tenant.Spec.TenantID}},
+			}}},
+			version: &tenant.Version,
+			doc:     tenant,
+		})
+	}
+	for _, tier := range bundle.Tiers {
+		items = append(items, &restoreItem{
+			kind:       entity.WebhookKindTier,
+			collection: tier.CollectionName(),
+			name:       tier.Metadata.Name,
+			uuid:       tier.UUID,
+			key:        bson.D{{Key: "metadata.name", Value: tier.Metadata.Name}},
+			version:    &tier.Version,
+			doc:        tier,
+		})
+	}
+	for _, hep := range bundle.HostEndpoints {
+		items = append(items, &restoreItem{
+			kind:       entity.WebhookKindHostEndpoint,
+			collection: hep.CollectionName(),
+			name:       hep.Metadata.Name,
+			uuid:       hep.UUID,
+			key:        bson.D{{Key: "spec.tenant_id", Value: hep.Spec.TenantID}, {Key: "spec.ip", Value: hep.Spec.IP}},
+			version:    &hep.Version,
+			doc:        hep,
+		})
+	}
+	for _, gns := range bundle.GlobalNetworkSets {
+		items = append(items, &restoreItem{
+			kind:       entity.WebhookKindGlobalNetworkSet,
+			collection: gns.CollectionName(),
+			name:       gns.Metadata.Name,
+			uuid:       gns.UUID,
+			key:        bson.D{{Key: "metadata.name", Value: gns.Metadata.Name}},
+			version:    &gns.Version,
+			doc:        gns,
+		})
+	}
+	for _, ns := range bundle.NetworkSets {
+		items = append(items, &restoreItem{
+			kind:       entity.WebhookKindNetworkSet,
+			collection: entity.NetworkSet{}.CollectionName(),
+			name:       ns.Metadata.Name,
+			uuid:       ns.UUID,
+			key:        bson.D{{Key: "metadata.tenant_id", Value: ns.Metadata.TenantID}, {Key: "metadata.name", Value: ns.Metadata.Name}},
+			version:    &ns.Version,
+			doc:        ns,
+		})
+	}
+	for _, gnp := range bundle.GlobalNetworkPolicies {
+		items = append(items, &restoreItem{
+			kind:       entity.WebhookKindGlobalNetworkPolicy,
+			collection: gnp.CollectionName(),
+			name:       gnp.Metadata.Name,
+			uuid:       gnp.UUID,
+			key:        bson.D{{Key: "metadata.name", Value: gnp.Metadata.Name}},
+			version:    &gnp.Version,
+			doc:        gnp,
+		})
+	}
+	for _, np := range bundle.NetworkPolicies {
+		items = append(items, &restoreItem{
+			kind:       entity.WebhookKindNetworkPolicy,
+			collection: entity.NetworkPolicy{}.CollectionName(),
+			name:       np.Metadata.Name,
+			uuid:       np.UUID,
+			key:        bson.D{{Key: "metadata.tenant_id", Value: np.Metadata.TenantID}, {Key: "metadata.name", Value: np.Metadata.Name}},
+			version:    &np.Version,
+			doc:        np,
+		})
+	}
+	return items
+}
+
+// RestoreBundle writes the resources of the bundle in a transaction. A resource conflicts with the stored ones of the
+// same uuid or unique key, which are kept, replaced or fail the restore depending on the mode. A replacing resource
+// gets a version greater than the replaced one so that agents pick up the change.
+func (r *PolicyDB) RestoreBundle(ctx context.Context, input *model.RestoreInput) (*model.RestoreOutput, *ierror.CoreError) {
+	session, err := r.mongo.Database.Client().StartSession()
+	if err != nil {
+		return nil, errlist.ErrDatabase.WithChild(err)
+	}
+	defer session.EndSession(ctx)
+
+	sessionCallback := func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		output := &model.RestoreOutput{}
+		results := make(map[string]*model.RestoreResult)
+		for _, item := range bundleRestoreItems(input.Bundle) {
+			result, ok := results[item.kind]
+			if !ok {
+				result = &model.RestoreResult{Kind: item.kind}
+				results[item.kind] = result
+				output.Results = append(output.Results, result)
+			}
+			if errRestore := r.restoreBundleItem(sessionCtx, input.Mode, item, result); errRestore != nil {
+				return nil, errRestore
+			}
+		}
+		return output, nil
+	}
+
+	opts := options.Transaction().SetWriteConcern(writeconcern.Majority()).SetReadConcern(readconcern.Snapshot())
+	output, sessionErr := session.WithTransaction(ctx, sessionCallback, opts)
+	if sessionErr != nil {
+		var coreErr *ierror.CoreError
+		if errors.As(sessionErr, &coreErr) {
+			return nil, coreErr
+		}
+		return nil, errlist.ErrDatabase.WithChild(sessionErr)
+	}
+	return output.(*model.RestoreOutput), nil
+}
+
+// restoreBundleItem writes item with mode and counts it in result. In the overwrite mode, item only replaces a single
+// stored resource: it conflicts when its uuid and its key match different ones.
+func (r *PolicyDB) restoreBundleItem(ctx context.Context, mode string, item *restoreItem, result *model.RestoreResult) error {
+	collection := r.mongo.Database.Collection(item.collection)
+	filter := bson.D{{Key: "$or", Value: bson.A{bson.D{{Key: "uuid", Value: item.uuid}}, item.key}}}
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("find %s %s failed: %w", item.kind, item.name, err))
+	}
+	var existed []struct {
+		ID      primitive.ObjectID `bson:"_id"`
+		Version uint               `bson:"version"`
+	}
+	if err = cursor.All(ctx, &existed); err != nil {
+		return errlist.ErrUnmarshalFailed.WithChild(fmt.Errorf("decode %s %s failed: %w", item.kind, item.name, err))
+	}
+
+	switch {
+	case len(existed) == 0:
+		result.Created++
+	case mode == model.RestoreModeSkip:
+		result.Skipped++
+		return nil
+	case mode == model.RestoreModeOverwrite && len(existed) > 1:
+		return errlist.ErrRestoreConflict.WithChild(fmt.Errorf("%s %s conflicts with %d stored ones", item.kind, item.name,
+			len(existed)))
+	case mode == model.RestoreModeOverwrite:
+		if *item.version <= existed[0].Version {
+			*item.version = existed[0].Version + 1
+		}
+		if _, err = collection.DeleteOne(ctx, bson.D{{Key: "_id", Value: existed[0].ID}}); err != nil {
+			return errlist.ErrDatabase.WithChild(fmt.Errorf("delete %s %s failed: %w", item.kind, item.name, err))
+		}
+		result.Overwritten++
+	default:
+		return errlist.ErrRestoreConflict.WithChild(fmt.Errorf("%s %s conflicts with a stored one", item.kind, item.name))
+	}
+
+	if _, err = collection.InsertOne(ctx, item.doc); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errlist.ErrRestoreConflict.WithChild(fmt.Errorf("%s %s is duplicated: %w", item.kind, item.name, err))
+		}
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("insert %s %s failed: %w", item.kind, item.name, err))
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/storage"
+)
+
+func TestRestoreBundleItem(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	var found = func(versions ...int64) bson.D {
+		docs := make([]bson.D, 0, len(versions))
+		for _, version := range versions {
+			docs = append(docs, bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "version", Value: version}})
+		}
+		return mtest.CreateCursorResponse(0, "db.tier", mtest.FirstBatch, docs...)
+	}
+	var written = mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1})
+	tests := []struct {
+		name      string
+		mode      string
+		responses []bson.D
+		// wantCommands are the commands run for the restore of the tier
+		wantCommands []string
+		wantResult   model.RestoreResult
+		wantVersion  uint
+		wantErr      error
+	}{
+		{
+			name:         "created",
+			mode:         model.RestoreModeFail,
+			responses:    []bson.D{found(), written},
+			wantCommands: []string{"find", "insert"},
+			wantResult:   model.RestoreResult{Created: 1},
+			wantVersion:  2,
+		},
+		{
+			name:         "skipped",
+			mode:         model.RestoreModeSkip,
+			responses:    []bson.D{found(5)},
+			wantCommands: []string{"find"},
+			wantResult:   model.RestoreResult{Skipped: 1},
+			wantVersion:  2,
+		},
+		{
+			name:         "skipped conflicting with several",
+			mode:         model.RestoreModeSkip,
+			responses:    []bson.D{found(5, 1)},
+			wantCommands: []string{"find"},
+			wantResult:   model.RestoreResult{Skipped: 1},
+			wantVersion:  2,
+		},
+		{
+			name:         "overwritten",
+			mode:         model.RestoreModeOverwrite,
+			responses:    []bson.D{found(5), written, written},
+			wantCommands: []string{"find", "delete", "insert"},
+			wantResult:   model.RestoreResult{Overwritten: 1},
+			wantVersion:  6,
+		},
+		{
+			name:         "overwritten older",
+			mode:         model.RestoreModeOverwrite,
+			responses:    []bson.D{found(1), written, written},
+			wantCommands: []string{"find", "delete", "insert"},
+			wantResult:   model.RestoreResult{Overwritten: 1},
+			wantVersion:  2,
+		},
+		{
+			name:         "uuid and key of different stored ones",
+			mode:         model.RestoreModeOverwrite,
+			responses:    []bson.D{found(5, 1)},
+			wantCommands: []string{"find"},
+			wantVersion:  2,
+			wantErr:      errlist.ErrRestoreConflict,
+		},
+		{
+			name:         "conflict",
+			mode:         model.RestoreModeFail,
+			responses:    []bson.D{found(5)},
+			wantCommands: []string{"find"},
+			wantVersion:  2,
+			wantErr:      errlist.ErrRestoreConflict,
+		},
+		{
+			name: "duplicate",
+			mode: model.RestoreModeFail,
+			responses: []bson.D{
+				found(),
+				mtest.CreateWriteErrorsResponse(mtest.WriteError{Code: 11000, Message: "duplicate key"}),
+			},
+			wantCommands: []string{"find", "insert"},
+			wantResult:   model.RestoreResult{Created: 1},
+			wantVersion:  2,
+			wantErr:      errlist.ErrRestoreConflict,
+		},
+	}
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			mt.AddMockResponses(tt.responses...)
+			r := NewPolicy(&storage.PolicyDB{Database: mt.DB})
+			tier := &entity.Tier{UUID: "web", Version: 2, Metadata: entity.TierMetadata{Name: "web"}}
+			item := bundleRestoreItems(&model.Bundle{Tiers: []*entity.Tier{tier}})[0]
+
+			result := model.RestoreResult{}
+			err := r.restoreBundleItem(context.Background(), tt.mode, item, &result)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				mt.Fatalf("restoreBundleItem() error = %v, want %v", err, tt.wantErr)
+			}
+			if result != tt.wantResult {
+				mt.Errorf("result = %+v, want %+v", result, tt.wantResult)
+			}
+			if tier.Version != tt.wantVersion {
+				mt.Errorf("version = %d, want %d", tier.Version, tt.wantVersion)
+			}
+			var commands []string
+			for _, started := range mt.GetAllStartedEvents() {
+				commands = append(commands, started.CommandName)
+				if started.CommandName == "delete" && !strings.Contains(started.Command.String(), `"_id"`) {
+					mt.Errorf("delete %s is not by id", started.Command)
+				}
+			}
+			if len(commands) != len(tt.wantCommands) {
+				mt.Fatalf("commands = %v, want %v", commands, tt.wantCommands)
+			}
+			for i := range commands {
+				if commands[i] != tt.wantCommands[i] {
+					mt.Errorf("commands = %v, want %v", commands, tt.wantCommands)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+// ApplyBulk writes items, in order, in a single transaction: either all of them are written or none. Upserted
+// resources get the id, uuid and version they are stored with. It fails with ErrVersionConflict if a resource changed
+// since the items were validated.
+func (r *PolicyDB) ApplyBulk(ctx context.Context, items []*model.BulkWriteItem) *ierror.CoreError {
+	session, err := r.mongo.Database.Client().StartSession()
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(err)
+	}
+	defer session.EndSession(ctx)
+
+	sessionCallback := func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		for _, item := range items {
+			if errWrite := r.writeBulkItem(sessionCtx, item); errWrite != nil {
+				return nil, errWrite
+			}
+		}
+		return nil, nil
+	}
+
+	opts := options.Transaction().SetWriteConcern(writeconcern.Majority()).SetReadConcern(readconcern.Snapshot())
+	_, sessionErr := session.WithTransaction(ctx, sessionCallback, opts)
+	if sessionErr != nil {
+		var coreErr *ierror.CoreError
+		if errors.As(sessionErr, &coreErr) {
+			return coreErr
+		}
+		return errlist.ErrDatabase.WithChild(sessionErr)
+	}
+	return nil
+}
+
+// writeBulkItem writes item if the stored resource it changes is still at the version of item, it returns
+// ErrVersionConflict otherwise so that the transaction is aborted.
+func (r *PolicyDB) writeBulkItem(ctx context.Context, item *model.BulkWriteItem) error {
+	var collection string
+	var filter bson.D
+	switch item.Kind {
+	case entity.WebhookKindHostEndpoint:
+		collection = entity.HostEndpoint{}.CollectionName()
+		filter = bson.D{{Key: "spec.tenant_id", Value: item.HostEndpoint.Spec.TenantID}, {Key: "spec.ip", Value: item.HostEndpoint.Spec.IP}}
+	case entity.WebhookKindGlobalNetworkSet:
+		collection = entity.GlobalNetworkSet{}.CollectionName()
+		filter = bson.D{{Key: "metadata.name", Value: item.GlobalNetworkSet.Metadata.Name}}
+	case entity.WebhookKindGlobalNetworkPolicy:
+		collection = entity.GlobalNetworkPolicy{}.CollectionName()
+		filter = bson.D{{Key: "metadata.name", Value: item.GlobalNetworkPolicy.Metadata.Name}}
+	default:
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("unsupported kind %s", item.Kind))
+	}
+
+	var stored struct {
+		Version uint `bson:"version"`
+	}
+	err := r.mongo.Database.Collection(collection).FindOne(ctx, filter,
+		options.FindOne().SetProjection(bson.D{{Key: "version", Value: 1}})).Decode(&stored)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("find %s failed: %w", item.Kind, err))
+	}
+	if stored.Version != item.StoredVersion {
+		return errlist.ErrVersionConflict.WithChild(fmt.Errorf("%s changed from version %d to %d", item.Kind,
+			item.StoredVersion, stored.Version))
+	}
+
+	if item.Operation == model.BulkOperationUpsert {
+		switch item.Kind {
+		case entity.WebhookKindHostEndpoint:
+			return r.upsertHostEndpoint(ctx, item.HostEndpoint)
+		case entity.WebhookKindGlobalNetworkSet:
+			return r.upsertGNS(ctx, item.GlobalNetworkSet)
+		default:
+			return r.upsertGNP(ctx, item.GlobalNetworkPolicy)
+		}
+	}
+	if _, err = r.mongo.Database.Collection(collection).DeleteOne(ctx, filter); err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("delete %s failed: %w", item.Kind, err))
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/storage"
+)
+
+func TestWriteBulkItem(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	var found = func(versions ...int64) bson.D {
+		docs := make([]bson.D, 0, len(versions))
+		for _, version := range versions {
+			docs = append(docs, bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "version", Value: version}})
+		}
+		return mtest.CreateCursorResponse(0, "db.global_network_set", mtest.FirstBatch, docs...)
+	}
+	var written = mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1})
+	var versioned = func(version int64) bson.D {
+		return mtest.CreateSuccessResponse(bson.E{Key: "value", Value: bson.D{{Key: "version", Value: version}}})
+	}
+	tests := []struct {
+		name          string
+		operation     string
+		storedVersion uint
+		responses     []bson.D
+		// wantCommands are the commands run for the write of the set
+		wantCommands []string
+		wantErr      error
+	}{
+		{
+			name:          "created",
+			operation:     model.BulkOperationUpsert,
+			storedVersion: 0,
+			responses:     []bson.D{found(), found(), written, versioned(1)},
+			wantCommands:  []string{"find", "find", "update", "findAndModify"},
+		},
+		{
+			name:          "created since validated",
+			operation:     model.BulkOperationUpsert,
+			storedVersion: 0,
+			responses:     []bson.D{found(1)},
+			wantCommands:  []string{"find"},
+			wantErr:       errlist.ErrVersionConflict,
+		},
+		{
+			name:          "updated",
+			operation:     model.BulkOperationUpsert,
+			storedVersion: 3,
+			responses:     []bson.D{found(3), found(3), written, versioned(4)},
+			wantCommands:  []string{"find", "find", "update", "findAndModify"},
+		},
+		{
+			name:          "updated since validated",
+			operation:     model.BulkOperationUpsert,
+			storedVersion: 3,
+			responses:     []bson.D{found(4)},
+			wantCommands:  []string{"find"},
+			wantErr:       errlist.ErrVersionConflict,
+		},
+		{
+			name:          "deleted",
+			operation:     model.BulkOperationDelete,
+			storedVersion: 3,
+			responses:     []bson.D{found(3), written},
+			wantCommands:  []string{"find", "delete"},
+		},
+		{
+			name:          "deleted since validated",
+			operation:     model.BulkOperationDelete,
+			storedVersion: 3,
+			responses:     []bson.D{found()},
+			wantCommands:  []string{"find"},
+			wantErr:       errlist.ErrVersionConflict,
+		},
+	}
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			mt.AddMockResponses(tt.responses...)
+			r := NewPolicy(&storage.PolicyDB{Database: mt.DB})
+			item := &model.BulkWriteItem{
+				Operation:        tt.operation,
+				Kind:             entity.WebhookKindGlobalNetworkSet,
+				StoredVersion:    tt.storedVersion,
+				GlobalNetworkSet: &entity.GlobalNetworkSet{Metadata: entity.GNSMetadata{Name: "lb"}},
+			}
+
+			err := r.writeBulkItem(context.Background(), item)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				mt.Fatalf("writeBulkItem() error = %v, want %v", err, tt.wantErr)
+			}
+			var commands []string
+			for _, started := range mt.GetAllStartedEvents() {
+				commands = append(commands, started.CommandName)
+			}
+			if len(commands) != len(tt.wantCommands) {
+				mt.Fatalf("commands = %v, want %v", commands, tt.wantCommands)
+			}
+			for i := range commands {
+				if commands[i] != tt.wantCommands[i] {
+					mt.Errorf("commands = %v, want %v", commands, tt.wantCommands)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+func (r *PolicyDB) UpsertEnrollmentToken(ctx context.Context, token *entity.EnrollmentToken) *ierror.CoreError {
+	session, err := r.mongo.Database.Client().StartSession()
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(err)
+	}
+	defer session.EndSession(ctx)
+
+	sessionCallback := func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		filter := bson.D{{Key: "metadata.name", Value: token.Metadata.Name}}
+		existedToken := new(entity.EnrollmentToken)
+		err = r.mongo.Database.Collection(token.CollectionName()).FindOne(ctx, filter).Decode(existedToken)
+		if err != nil && !errors.Is(mongo.ErrNoDocuments, err) {
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("find enrollment token failed: %w", err))
+		}
+
+		// enrollment token is existed
+		if !errors.Is(mongo.ErrNoDocuments, err) {
+			token.ID = existedToken.ID
+			token.UUID = existedToken.UUID
+			token.Version = existedToken.Version
+			token.CreatedAt = existedToken.CreatedAt
+			token.Spec.Token = existedToken.Spec.Token
+		}
+
+		filter = bson.D{{Key: "_id", Value: token.ID}}
+		update := bson.D{{Key: "$set", Value: token}}
+		opts := options.Update().SetUpsert(true)
+		_, err = r.mongo.Database.Collection(token.CollectionName()).UpdateOne(ctx, filter, update, opts)
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return nil, errlist.ErrDuplicateEnrollmentToken.
+					WithChild(fmt.Errorf("enrollment token already exists: %w", err))
+			}
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update enrollment token failed: %w", err))
+		}
+
+		updateVersion := bson.M{
+			"$inc": bson.M{
+				"version": 1,
+			},
+		}
+		optUpdateVersions := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		err = r.mongo.Database.Collection(token.CollectionName()).FindOneAndUpdate(ctx, filter, updateVersion, optUpdateVersions).Decode(token)
+		if err != nil {
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update version enrollment token failed: %w", err))
+		}
+
+		return nil, nil
+	}
+
+	opts := options.Transaction().SetWriteConcern(writeconcern.Majority()).SetReadConcern(readconcern.Snapshot())
+	_, sessionErr := session.WithTransaction(ctx, sessionCallback, opts)
+	if sessionErr != nil {
+		var coreErr *ierror.CoreError
+		if errors.As(sessionErr, &coreErr) {
+			return coreErr
+		}
+		return errlist.ErrDatabase.WithChild(sessionErr)
+	}
+
+	return nil
+}
+
+func (r *PolicyDB) GetEnrollmentTokenByName(ctx context.Context, name string) (*entity.EnrollmentToken, *ierror.CoreError) {
+	return r.getEnrollmentToken(ctx, bson.D{{Key: "metadata.name", Value: name}})
+}
+
+func (r *PolicyDB) GetEnrollmentTokenByToken(ctx context.Context, token string) (*entity.EnrollmentToken, *ierror.CoreError) {
+	return r.getEnrollmentToken(ctx, bson.D{{Key: "spec.token", Value: token}})
+}
+
+func (r *PolicyDB) getEnrollmentToken(ctx context.Context, filter bson.D) (*entity.EnrollmentToken, *ierror.CoreError) {
+	token := new(entity.EnrollmentToken)
+	err := r.mongo.Database.Collection(token.CollectionName()).FindOne(ctx, filter).Decode(token)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errlist.ErrNotFoundEnrollmentToken
+		}
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("find enrollment token failed: %w", err))
+	}
+	return token, nil
+}
+
+func (r *PolicyDB) DeleteEnrollmentTokenByName(ctx context.Context, name string) *ierror.CoreError {
+	filter := bson.D{{Key: "metadata.name", Value: name}}
+
+	_, err := r.mongo.Database.Collection(entity.EnrollmentToken{}.CollectionName()).DeleteOne(ctx, filter)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("delete enrollment token failed: %w", err))
+	}
+	return nil
+}
+
+func (r *PolicyDB) ListEnrollmentTokens(ctx context.Context) ([]*entity.EnrollmentToken, *ierror.CoreError) {
+	tokens := make([]*entity.EnrollmentToken, 0)
+	opts := options.Find().SetSort(bson.D{{Key: "metadata.name", Value: 1}})
+	cursor, err := r.mongo.Database.Collection(entity.EnrollmentToken{}.CollectionName()).Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("list enrollment tokens failed: %w", err))
+	}
+	if err = cursor.All(ctx, &tokens); err != nil {
+		return nil, errlist.ErrUnmarshalFailed.WithChild(fmt.Errorf("decode enrollment tokens failed: %w", err))
+	}
+	return tokens, nil
+}
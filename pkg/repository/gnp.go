@@ -25,44 +25,7 @@ func (r *PolicyDB) UpsertGroupPolicy(ctx context.Context, gnp *entity.GlobalNetw
 	defer session.EndSession(ctx)
 
 	sessionCallback := func(sessionCtx mongo.SessionContext) (interface{}, error) {
-		filter := bson.D{{Key: "metadata.name", Value: gnp.Metadata.Name}}
-		existedGNP := new(entity.GlobalNetworkPolicy)
-		err = r.mongo.Database.Collection(gnp.CollectionName()).FindOne(ctx, filter).Decode(existedGNP)
-		if err != nil && !errors.Is(mongo.ErrNoDocuments, err) {
-			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("find global network policy failed: %w", err))
-		}
-
-		// gnp is existed
-		if !errors.Is(mongo.ErrNoDocuments, err) {
-			gnp.ID = existedGNP.ID
-			gnp.UUID = existedGNP.UUID
-			gnp.Version = existedGNP.Version
-			gnp.CreatedAt = existedGNP.CreatedAt
-		}
-
-		filter = bson.D{{Key: "_id", Value: gnp.ID}}
-		update := bson.D{{Key: "$set", Value: gnp}}
-		opts := options.Update().SetUpsert(true)
-		_, err = r.mongo.Database.Collection(gnp.CollectionName()).UpdateOne(ctx, filter, update, opts)
-		if err != nil {
-			if mongo.IsDuplicateKeyError(err) {
-				return nil, errlist.ErrDuplicateGlobalNetworkPolicy.WithChild(fmt.Errorf("global network policy already exists: %w", err))
-			}
-			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update gnp failed: %w", err))
-		}
-
-		updateVersion := bson.M{
-			"$inc": bson.M{
-				"version": 1,
-			},
-		}
-		optUpdateVersions := options.FindOneAndUpdate().SetReturnDocument(options.After)
-		err = r.mongo.Database.Collection(gnp.CollectionName()).FindOneAndUpdate(ctx, filter, updateVersion, optUpdateVersions).Decode(gnp)
-		if err != nil {
-			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update version gnp failed: %w", err))
-		}
-
-		return nil, nil
+		return nil, r.upsertGNP(sessionCtx, gnp)
 	}
 
 	opts := options.Transaction().SetWriteConcern(writeconcern.Majority()).SetReadConcern(readconcern.Snapshot())
@@ -78,6 +41,48 @@ func (r *PolicyDB) UpsertGroupPolicy(ctx context.Context, gnp *entity.GlobalNetw
 	return nil
 }
 
+// upsertGNP writes gnp in the transaction of ctx, as a new policy or over the stored one of the same name.
+func (r *PolicyDB) upsertGNP(ctx context.Context, gnp *entity.GlobalNetworkPolicy) error {
+	filter := bson.D{{Key: "metadata.name", Value: gnp.Metadata.Name}}
+	existedGNP := new(entity.GlobalNetworkPolicy)
+	err := r.mongo.Database.Collection(gnp.CollectionName()).FindOne(ctx, filter).Decode(existedGNP)
+	if err != nil && !errors.Is(mongo.ErrNoDocuments, err) {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("find global network policy failed: %w", err))
+	}
+
+	// gnp is existed
+	if !errors.Is(mongo.ErrNoDocuments, err) {
+		gnp.ID = existedGNP.ID
+		gnp.UUID = existedGNP.UUID
+		gnp.Version = existedGNP.Version
+		gnp.CreatedAt = existedGNP.CreatedAt
+	}
+
+	filter = bson.D{{Key: "_id", Value: gnp.ID}}
+	update := bson.D{{Key: "$set", Value: gnp}}
+	opts := options.Update().SetUpsert(true)
+	_, err = r.mongo.Database.Collection(gnp.CollectionName()).UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errlist.ErrDuplicateGlobalNetworkPolicy.WithChild(fmt.Errorf("global network policy already exists: %w", err))
+		}
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("update gnp failed: %w", err))
+	}
+
+	updateVersion := bson.M{
+		"$inc": bson.M{
+			"version": 1,
+		},
+	}
+	optUpdateVersions := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err = r.mongo.Database.Collection(gnp.CollectionName()).FindOneAndUpdate(ctx, filter, updateVersion, optUpdateVersions).Decode(gnp)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("update version gnp failed: %w", err))
+	}
+
+	return nil
+}
+
 func (r *PolicyDB) GetGNPByName(ctx context.Context, name string) (*entity.GlobalNetworkPolicy, *ierror.CoreError) {
 	filter := bson.D{{Key: "metadata.name", Value: name}}
 
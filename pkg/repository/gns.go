@@ -24,45 +24,7 @@ func (r *PolicyDB) UpsertGNS(ctx context.Context, gns *entity.GlobalNetworkSet)
 	defer session.EndSession(ctx)
 
 	sessionCallback := func(sessionCtx mongo.SessionContext) (interface{}, error) {
-		filter := bson.D{{Key: "metadata.name", Value: gns.Metadata.Name}}
-		existedGNS := new(entity.GlobalNetworkSet)
-		err = r.mongo.Database.Collection(gns.CollectionName()).FindOne(ctx, filter).Decode(existedGNS)
-		if err != nil && !errors.Is(mongo.ErrNoDocuments, err) {
-			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("find global network set failed: %w", err))
-		}
-
-		// gns is existed
-		if !errors.Is(mongo.ErrNoDocuments, err) {
-			gns.ID = existedGNS.ID
-			gns.UUID = existedGNS.UUID
-			gns.Version = existedGNS.Version
-			gns.CreatedAt = existedGNS.CreatedAt
-		}
-
-		filter = bson.D{{Key: "_id", Value: gns.ID}}
-		update := bson.D{{Key: "$set", Value: gns}}
-		opts := options.Update().SetUpsert(true)
-		_, err = r.mongo.Database.Collection(gns.CollectionName()).UpdateOne(ctx, filter, update, opts)
-		if err != nil {
-			if mongo.IsDuplicateKeyError(err) {
-				return nil, errlist.ErrDuplicateGlobalNetworkSet.
-					WithChild(fmt.Errorf("global network set already exists: %w", err))
-			}
-			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update gns failed: %w", err))
-		}
-
-		updateVersion := bson.M{
-			"$inc": bson.M{
-				"version": 1,
-			},
-		}
-		optUpdateVersions := options.FindOneAndUpdate().SetReturnDocument(options.After)
-		err = r.mongo.Database.Collection(gns.CollectionName()).FindOneAndUpdate(ctx, filter, updateVersion, optUpdateVersions).Decode(gns)
-		if err != nil {
-			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update version gns failed: %w", err))
-		}
-
-		return nil, nil
+		return nil, r.upsertGNS(sessionCtx, gns)
 	}
 
 	opts := options.Transaction().SetWriteConcern(writeconcern.Majority()).SetReadConcern(readconcern.Snapshot())
@@ -78,6 +40,120 @@ func (r *PolicyDB) UpsertGNS(ctx context.Context, gns *entity.GlobalNetworkSet)
 	return nil
 }
 
+// upsertGNS writes gns in the transaction of ctx, as a new set or over the stored one of the same name.
+func (r *PolicyDB) upsertGNS(ctx context.Context, gns *entity.GlobalNetworkSet) error {
+	filter := bson.D{{Key: "metadata.name", Value: gns.Metadata.Name}}
+	existedGNS := new(entity.GlobalNetworkSet)
+	err := r.mongo.Database.Collection(gns.CollectionName()).FindOne(ctx, filter).Decode(existedGNS)
+	if err != nil && !errors.Is(mongo.ErrNoDocuments, err) {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("find global network set failed: %w", err))
+	}
+
+	// gns is existed
+	if !errors.Is(mongo.ErrNoDocuments, err) {
+		gns.ID = existedGNS.ID
+		gns.UUID = existedGNS.UUID
+		gns.Version = existedGNS.Version
+		gns.CreatedAt = existedGNS.CreatedAt
+	}
+
+	filter = bson.D{{Key: "_id", Value: gns.ID}}
+	update := bson.D{{Key: "$set", Value: gns}}
+	// the status of a set without source and the resolution of a set without domains are meaningless
+	unset := bson.D{}
+	if gns.Spec.Source == nil {
+		unset = append(unset, bson.E{Key: "status", Value: ""})
+	}
+	if len(gns.Spec.AllowedEgressDomains) == 0 {
+		unset = append(unset, bson.E{Key: "resolved", Value: ""})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.E{Key: "$unset", Value: unset})
+	}
+	opts := options.Update().SetUpsert(true)
+	_, err = r.mongo.Database.Collection(gns.CollectionName()).UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errlist.ErrDuplicateGlobalNetworkSet.
+				WithChild(fmt.Errorf("global network set already exists: %w", err))
+		}
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("update gns failed: %w", err))
+	}
+
+	updateVersion := bson.M{
+		"$inc": bson.M{
+			"version": 1,
+		},
+	}
+	optUpdateVersions := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err = r.mongo.Database.Collection(gns.CollectionName()).FindOneAndUpdate(ctx, filter, updateVersion, optUpdateVersions).Decode(gns)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("update version gns failed: %w", err))
+	}
+
+	return nil
+}
+
+// UpdateGNSExternalState sets the sync status and the resolved domains of a set without bumping its version,
+// the nets are unchanged. It returns ErrVersionConflict when the set is no longer at version.
+func (r *PolicyDB) UpdateGNSExternalState(ctx context.Context, name string, version uint, status *entity.GNSStatus, resolved *entity.GNSResolved) *ierror.CoreError {
+	filter := bson.D{{Key: "metadata.name", Value: name}, {Key: "version", Value: version}}
+	set := bson.D{}
+	if status != nil {
+		set = append(set, bson.E{Key: "status", Value: status})
+	}
+	if resolved != nil {
+		set = append(set, bson.E{Key: "resolved", Value: resolved})
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	update := bson.D{{Key: "$set", Value: set}}
+
+	result, err := r.mongo.Database.Collection(entity.GlobalNetworkSet{}.CollectionName()).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("update global network set status failed: %w", err))
+	}
+	if result.MatchedCount == 0 {
+		return errlist.ErrVersionConflict.WithChild(fmt.Errorf("global network set %s changed or was deleted", name))
+	}
+	return nil
+}
+
+// UpdateGNSExternalNets sets the nets, the sync status and the resolved domains of gns and bumps its version,
+// the rest of the set is unchanged. gns is updated only while the stored set is at the version of gns, otherwise it
+// returns ErrVersionConflict so that the nets fetched for an outdated set do not overwrite a newer one.
+func (r *PolicyDB) UpdateGNSExternalNets(ctx context.Context, gns *entity.GlobalNetworkSet) *ierror.CoreError {
+	filter := bson.D{{Key: "_id", Value: gns.ID}, {Key: "version", Value: gns.Version}}
+	set := bson.D{
+		{Key: "spec.nets", Value: gns.Spec.Nets},
+		{Key: "spec.nets_v4", Value: gns.Spec.NetsV4},
+		{Key: "spec.nets_v6", Value: gns.Spec.NetsV6},
+		{Key: "updated_at", Value: gns.UpdatedAt},
+	}
+	if gns.Status != nil {
+		set = append(set, bson.E{Key: "status", Value: gns.Status})
+	}
+	if gns.Resolved != nil {
+		set = append(set, bson.E{Key: "resolved", Value: gns.Resolved})
+	}
+	update := bson.D{
+		{Key: "$set", Value: set},
+		{Key: "$inc", Value: bson.D{{Key: "version", Value: 1}}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	err := r.mongo.Database.Collection(gns.CollectionName()).FindOneAndUpdate(ctx, filter, update, opts).Decode(gns)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return errlist.ErrVersionConflict.
+				WithChild(fmt.Errorf("global network set %s changed or was deleted", gns.Metadata.Name))
+		}
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("update global network set nets failed: %w", err))
+	}
+	return nil
+}
+
 func (r *PolicyDB) GetGNSByName(ctx context.Context, name string) (*entity.GlobalNetworkSet, *ierror.CoreError) {
 	filter := bson.D{{Key: "metadata.name", Value: name}}
 
@@ -25,44 +25,7 @@ func (r *PolicyDB) UpsertHostEndpoint(ctx context.Context, hep *entity.HostEndpo
 	defer session.EndSession(ctx)
 
 	sessionCallback := func(sessionCtx mongo.SessionContext) (interface{}, error) {
-		filter := bson.D{{Key: "spec.tenant_id", Value: hep.Spec.TenantID}, {Key: "spec.ip", Value: hep.Spec.IP}}
-		existedHEP := new(entity.HostEndpoint)
-		err = r.mongo.Database.Collection(hep.CollectionName()).FindOne(ctx, filter).Decode(existedHEP)
-		if err != nil && !errors.Is(mongo.ErrNoDocuments, err) {
-			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("find host endpoint failed: %w", err))
-		}
-
-		// hep is existed
-		if !errors.Is(mongo.ErrNoDocuments, err) {
-			hep.ID = existedHEP.ID
-			hep.UUID = existedHEP.UUID
-			hep.Version = existedHEP.Version
-			hep.CreatedAt = existedHEP.CreatedAt
-		}
-
-		filter = bson.D{{Key: "_id", Value: hep.ID}}
-		update := bson.D{{Key: "$set", Value: hep}}
-		opts := options.Update().SetUpsert(true)
-		_, err = r.mongo.Database.Collection(hep.CollectionName()).UpdateOne(ctx, filter, update, opts)
-		if err != nil {
-			if mongo.IsDuplicateKeyError(err) {
-				return nil, errlist.ErrDuplicateHostEndpoint.WithChild(fmt.Errorf("host endpoint already exists: %w", err))
-			}
-			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update host endpoint failed: %w", err))
-		}
-
-		updateVersion := bson.M{
-			"$inc": bson.M{
-				"version": 1,
-			},
-		}
-		optUpdateVersions := options.FindOneAndUpdate().SetReturnDocument(options.After)
-		err = r.mongo.Database.Collection(hep.CollectionName()).FindOneAndUpdate(ctx, filter, updateVersion, optUpdateVersions).Decode(hep)
-		if err != nil {
-			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update version host endpoint failed: %w", err))
-		}
-
-		return nil, nil
+		return nil, r.upsertHostEndpoint(sessionCtx, hep)
 	}
 
 	opts := options.Transaction().SetWriteConcern(writeconcern.Majority()).SetReadConcern(readconcern.Snapshot())
@@ -78,6 +41,51 @@ func (r *PolicyDB) UpsertHostEndpoint(ctx context.Context, hep *entity.HostEndpo
 	return nil
 }
 
+// upsertHostEndpoint writes hep in the transaction of ctx, as a new host endpoint or over the stored one of the same tenant and ip.
+// A new host endpoint fails with ErrQuotaExceeded when its tenant reached its quota.
+func (r *PolicyDB) upsertHostEndpoint(ctx context.Context, hep *entity.HostEndpoint) error {
+	filter := bson.D{{Key: "spec.tenant_id", Value: hep.Spec.TenantID}, {Key: "spec.ip", Value: hep.Spec.IP}}
+	existedHEP := new(entity.HostEndpoint)
+	err := r.mongo.Database.Collection(hep.CollectionName()).FindOne(ctx, filter).Decode(existedHEP)
+	if err != nil && !errors.Is(mongo.ErrNoDocuments, err) {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("find host endpoint failed: %w", err))
+	}
+
+	// hep is existed
+	if !errors.Is(mongo.ErrNoDocuments, err) {
+		hep.ID = existedHEP.ID
+		hep.UUID = existedHEP.UUID
+		hep.Version = existedHEP.Version
+		hep.CreatedAt = existedHEP.CreatedAt
+	} else if err = r.checkQuota(ctx, hep.Spec.TenantID, hostEndpointQuota); err != nil {
+		return err
+	}
+
+	filter = bson.D{{Key: "_id", Value: hep.ID}}
+	update := bson.D{{Key: "$set", Value: hep}}
+	opts := options.Update().SetUpsert(true)
+	_, err = r.mongo.Database.Collection(hep.CollectionName()).UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errlist.ErrDuplicateHostEndpoint.WithChild(fmt.Errorf("host endpoint already exists: %w", err))
+		}
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("update host endpoint failed: %w", err))
+	}
+
+	updateVersion := bson.M{
+		"$inc": bson.M{
+			"version": 1,
+		},
+	}
+	optUpdateVersions := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err = r.mongo.Database.Collection(hep.CollectionName()).FindOneAndUpdate(ctx, filter, updateVersion, optUpdateVersions).Decode(hep)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("update version host endpoint failed: %w", err))
+	}
+
+	return nil
+}
+
 func (r *PolicyDB) GetHostEndpoint(ctx context.Context, input *model.GetHostEndpointInput) (*entity.HostEndpoint, *ierror.CoreError) {
 	var filter bson.D
 	if input != nil {
@@ -129,3 +137,13 @@ func (r *PolicyDB) ListHostEndpoints(ctx context.Context, input *model.ListHostE
 	}
 	return heps, nil
 }
+
+func (r *PolicyDB) CountHostEndpoints(ctx context.Context, tenantID uint64) (int64, *ierror.CoreError) {
+	filter := bson.D{{Key: "spec.tenant_id", Value: tenantID}}
+
+	count, err := r.mongo.Database.Collection(entity.HostEndpoint{}.CollectionName()).CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, errlist.ErrDatabase.WithChild(fmt.Errorf("count host endpoints failed: %w", err))
+	}
+	return count, nil
+}
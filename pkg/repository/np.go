@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+func (r *PolicyDB) UpsertNetworkPolicy(ctx context.Context, np *entity.GlobalNetworkPolicy) *ierror.CoreError {
+	session, err := r.mongo.Database.Client().StartSession()
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(err)
+	}
+	defer session.EndSession(ctx)
+
+	sessionCallback := func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		return nil, r.upsertNetworkPolicy(sessionCtx, np)
+	}
+
+	opts := options.Transaction().SetWriteConcern(writeconcern.Majority()).SetReadConcern(readconcern.Snapshot())
+	_, sessionErr := session.WithTransaction(ctx, sessionCallback, opts)
+	if sessionErr != nil {
+		var coreErr *ierror.CoreError
+		if errors.As(sessionErr, &coreErr) {
+			return coreErr
+		}
+		return errlist.ErrDatabase.WithChild(sessionErr)
+	}
+
+	return nil
+}
+
+// upsertNetworkPolicy writes np in the transaction of ctx, as a new network policy or over the stored one of the same
+// tenant and name. A new network policy fails with ErrQuotaExceeded when its tenant reached its quota.
+func (r *PolicyDB) upsertNetworkPolicy(ctx context.Context, np *entity.GlobalNetworkPolicy) error {
+	collection := r.mongo.Database.Collection(entity.NetworkPolicy{}.CollectionName())
+	filter := bson.D{{Key: "metadata.tenant_id", Value: np.Metadata.TenantID}, {Key: "metadata.name", Value: np.Metadata.Name}}
+	existedNP := new(entity.GlobalNetworkPolicy)
+	err := collection.FindOne(ctx, filter).Decode(existedNP)
+	if err != nil && !errors.Is(mongo.ErrNoDocuments, err) {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("find network policy failed: %w", err))
+	}
+
+	// np is existed
+	if !errors.Is(mongo.ErrNoDocuments, err) {
+		np.ID = existedNP.ID
+		np.UUID = existedNP.UUID
+		np.Version = existedNP.Version
+		np.CreatedAt = existedNP.CreatedAt
+	} else if err = r.checkQuota(ctx, np.Metadata.TenantID, networkPolicyQuota); err != nil {
+		return err
+	}
+
+	filter = bson.D{{Key: "_id", Value: np.ID}}
+	update := bson.D{{Key: "$set", Value: np}}
+	opts := options.Update().SetUpsert(true)
+	_, err = collection.UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errlist.ErrDuplicateNetworkPolicy.WithChild(fmt.Errorf("network policy already exists: %w", err))
+		}
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("update np failed: %w", err))
+	}
+
+	updateVersion := bson.M{
+		"$inc": bson.M{
+			"version": 1,
+		},
+	}
+	optUpdateVersions := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err = collection.FindOneAndUpdate(ctx, filter, updateVersion, optUpdateVersions).Decode(np)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("update version np failed: %w", err))
+	}
+	return nil
+}
+
+func (r *PolicyDB) GetNetworkPolicy(ctx context.Context, tenantID uint64, name string) (*entity.GlobalNetworkPolicy, *ierror.CoreError) {
+	filter := bson.D{{Key: "metadata.tenant_id", Value: tenantID}, {Key: "metadata.name", Value: name}}
+
+	np := new(entity.GlobalNetworkPolicy)
+	err := r.mongo.Database.Collection(entity.NetworkPolicy{}.CollectionName()).FindOne(ctx, filter).Decode(np)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errlist.ErrNotFoundNetworkPolicy
+		}
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("find network policy failed: %w", err))
+	}
+	return np, nil
+}
+
+func (r *PolicyDB) DeleteNetworkPolicy(ctx context.Context, tenantID uint64, name string) *ierror.CoreError {
+	filter := bson.D{{Key: "metadata.tenant_id", Value: tenantID}, {Key: "metadata.name", Value: name}}
+
+	_, err := r.mongo.Database.Collection(entity.NetworkPolicy{}.CollectionName()).DeleteOne(ctx, filter)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("delete network policy failed: %w", err))
+	}
+	return nil
+}
+
+// ListNetworkPolicies lists the network policies of a tenant, of all tenants if tenantID is zero.
+func (r *PolicyDB) ListNetworkPolicies(ctx context.Context, tenantID uint64) ([]*entity.GlobalNetworkPolicy, *ierror.CoreError) {
+	filter := bson.D{}
+	if tenantID != 0 {
+		filter = append(filter, bson.E{Key: "metadata.tenant_id", Value: tenantID})
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "metadata.tenant_id", Value: 1}, {Key: "metadata.name", Value: 1}})
+
+	policies := make([]*entity.GlobalNetworkPolicy, 0)
+	cursor, err := r.mongo.Database.Collection(entity.NetworkPolicy{}.CollectionName()).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("list network policies failed: %w", err))
+	}
+	if err = cursor.All(ctx, &policies); err != nil {
+		return nil, errlist.ErrUnmarshalFailed.WithChild(fmt.Errorf("decode network policies failed: %w", err))
+	}
+	return policies, nil
+}
+
+func (r *PolicyDB) CountNetworkPolicies(ctx context.Context, tenantID uint64) (int64, *ierror.CoreError) {
+	filter := bson.D{{Key: "metadata.tenant_id", Value: tenantID}}
+
+	count, err := r.mongo.Database.Collection(entity.NetworkPolicy{}.CollectionName()).CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, errlist.ErrDatabase.WithChild(fmt.Errorf("count network policies failed: %w", err))
+	}
+	return count, nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+func (r *PolicyDB) UpsertNetworkSet(ctx context.Context, ns *entity.GlobalNetworkSet) *ierror.CoreError {
+	session, err := r.mongo.Database.Client().StartSession()
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(err)
+	}
+	defer session.EndSession(ctx)
+
+	collection := r.mongo.Database.Collection(entity.NetworkSet{}.CollectionName())
+	sessionCallback := func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		filter := bson.D{{Key: "metadata.tenant_id", Value: ns.Metadata.TenantID}, {Key: "metadata.name", Value: ns.Metadata.Name}}
+		existedNS := new(entity.GlobalNetworkSet)
+		err = collection.FindOne(ctx, filter).Decode(existedNS)
+		if err != nil && !errors.Is(mongo.ErrNoDocuments, err) {
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("find network set failed: %w", err))
+		}
+
+		// ns is existed
+		if !errors.Is(mongo.ErrNoDocuments, err) {
+			ns.ID = existedNS.ID
+			ns.UUID = existedNS.UUID
+			ns.Version = existedNS.Version
+			ns.CreatedAt = existedNS.CreatedAt
+		}
+
+		filter = bson.D{{Key: "_id", Value: ns.ID}}
+		update := bson.D{{Key: "$set", Value: ns}}
+		opts := options.Update().SetUpsert(true)
+		_, err = collection.UpdateOne(ctx, filter, update, opts)
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return nil, errlist.ErrDuplicateNetworkSet.WithChild(fmt.Errorf("network set already exists: %w", err))
+			}
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update ns failed: %w", err))
+		}
+
+		updateVersion := bson.M{
+			"$inc": bson.M{
+				"version": 1,
+			},
+		}
+		optUpdateVersions := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		err = collection.FindOneAndUpdate(ctx, filter, updateVersion, optUpdateVersions).Decode(ns)
+		if err != nil {
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update version ns failed: %w", err))
+		}
+
+		return nil, nil
+	}
+
+	opts := options.Transaction().SetWriteConcern(writeconcern.Majority()).SetReadConcern(readconcern.Snapshot())
+	_, sessionErr := session.WithTransaction(ctx, sessionCallback, opts)
+	if sessionErr != nil {
+		var coreErr *ierror.CoreError
+		if errors.As(sessionErr, &coreErr) {
+			return coreErr
+		}
+		return errlist.ErrDatabase.WithChild(sessionErr)
+	}
+
+	return nil
+}
+
+func (r *PolicyDB) GetNetworkSet(ctx context.Context, tenantID uint64, name string) (*entity.GlobalNetworkSet, *ierror.CoreError) {
+	filter := bson.D{{Key: "metadata.tenant_id", Value: tenantID}, {Key: "metadata.name", Value: name}}
+
+	ns := new(entity.GlobalNetworkSet)
+	err := r.mongo.Database.Collection(entity.NetworkSet{}.CollectionName()).FindOne(ctx, filter).Decode(ns)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errlist.ErrNotFoundNetworkSet
+		}
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("find network set failed: %w", err))
+	}
+	return ns, nil
+}
+
+func (r *PolicyDB) DeleteNetworkSet(ctx context.Context, tenantID uint64, name string) *ierror.CoreError {
+	filter := bson.D{{Key: "metadata.tenant_id", Value: tenantID}, {Key: "metadata.name", Value: name}}
+
+	_, err := r.mongo.Database.Collection(entity.NetworkSet{}.CollectionName()).DeleteOne(ctx, filter)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("delete network set failed: %w", err))
+	}
+	return nil
+}
+
+// ListNetworkSets lists the network sets of a tenant, of all tenants if tenantID is zero.
+func (r *PolicyDB) ListNetworkSets(ctx context.Context, tenantID uint64) ([]*entity.GlobalNetworkSet, *ierror.CoreError) {
+	filter := bson.D{}
+	if tenantID != 0 {
+		filter = append(filter, bson.E{Key: "metadata.tenant_id", Value: tenantID})
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "metadata.tenant_id", Value: 1}, {Key: "metadata.name", Value: 1}})
+
+	sets := make([]*entity.GlobalNetworkSet, 0)
+	cursor, err := r.mongo.Database.Collection(entity.NetworkSet{}.CollectionName()).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("list network sets failed: %w", err))
+	}
+	if err = cursor.All(ctx, &sets); err != nil {
+		return nil, errlist.ErrUnmarshalFailed.WithChild(fmt.Errorf("decode network sets failed: %w", err))
+	}
+	return sets, nil
+}
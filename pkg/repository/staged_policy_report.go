@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+// IncStagedPolicyReport adds the counters of report to the report of the same policy and host endpoint.
+func (r *PolicyDB) IncStagedPolicyReport(ctx context.Context, report *entity.StagedPolicyReport) *ierror.CoreError {
+	filter := bson.D{
+		{Key: "gnp_uuid", Value: report.GNPUUID},
+		{Key: "tenant_id", Value: report.TenantID},
+		{Key: "ip", Value: report.IP},
+	}
+	now := time.Now()
+	update := bson.M{
+		"$inc": bson.M{
+			"denied_packets": report.DeniedPackets,
+			"denied_bytes":   report.DeniedBytes,
+		},
+		"$set": bson.M{
+			"gnp_name":         report.GNPName,
+			"last_reported_at": now,
+		},
+		"$setOnInsert": bson.M{
+			"_id":        primitive.NewObjectID(),
+			"created_at": now,
+		},
+	}
+	opts := options.Update().SetUpsert(true)
+	_, err := r.mongo.Database.Collection(entity.StagedPolicyReport{}.CollectionName()).UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("update staged policy report failed: %w", err))
+	}
+	return nil
+}
+
+func (r *PolicyDB) ListStagedPolicyReports(ctx context.Context, gnpUUID string) ([]*entity.StagedPolicyReport, *ierror.CoreError) {
+	filter := bson.D{{Key: "gnp_uuid", Value: gnpUUID}}
+	opts := options.Find().SetSort(bson.D{{Key: "tenant_id", Value: 1}, {Key: "ip", Value: 1}})
+
+	reports := make([]*entity.StagedPolicyReport, 0)
+	cursor, err := r.mongo.Database.Collection(entity.StagedPolicyReport{}.CollectionName()).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("list staged policy reports failed: %w", err))
+	}
+	if err = cursor.All(ctx, &reports); err != nil {
+		return nil, errlist.ErrUnmarshalFailed.WithChild(fmt.Errorf("decode staged policy reports failed: %w", err))
+	}
+	return reports, nil
+}
+
+func (r *PolicyDB) DeleteStagedPolicyReports(ctx context.Context, gnpUUID string) *ierror.CoreError {
+	filter := bson.D{{Key: "gnp_uuid", Value: gnpUUID}}
+
+	_, err := r.mongo.Database.Collection(entity.StagedPolicyReport{}.CollectionName()).DeleteMany(ctx, filter)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("delete staged policy reports failed: %w", err))
+	}
+	return nil
+}
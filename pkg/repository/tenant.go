@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+func (r *PolicyDB) UpsertTenant(ctx context.Context, tenant *entity.Tenant) *ierror.CoreError {
+	session, err := r.mongo.Database.Client().StartSession()
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(err)
+	}
+	defer session.EndSession(ctx)
+
+	sessionCallback := func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		filter := bson.D{{Key: "spec.tenant_id", Value: tenant.Spec.TenantID}}
+		existedTenant := new(entity.Tenant)
+		err = r.mongo.Database.Collection(tenant.CollectionName()).FindOne(sessionCtx, filter).Decode(existedTenant)
+		if err != nil && !errors.Is(mongo.ErrNoDocuments, err) {
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("find tenant failed: %w", err))
+		}
+
+		// tenant is existed
+		if !errors.Is(mongo.ErrNoDocuments, err) {
+			tenant.ID = existedTenant.ID
+			tenant.UUID = existedTenant.UUID
+			tenant.Version = existedTenant.Version
+			tenant.CreatedAt = existedTenant.CreatedAt
+		}
+
+		filter = bson.D{{Key: "_id", Value: tenant.ID}}
+		update := bson.D{{Key: "$set", Value: tenant}}
+		opts := options.Update().SetUpsert(true)
+		_, err = r.mongo.Database.Collection(tenant.CollectionName()).UpdateOne(sessionCtx, filter, update, opts)
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return nil, errlist.ErrDuplicateTenant.
+					WithChild(fmt.Errorf("tenant already exists: %w", err))
+			}
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update tenant failed: %w", err))
+		}
+
+		updateVersion := bson.M{
+			"$inc": bson.M{
+				"version": 1,
+			},
+		}
+		optUpdateVersions := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		err = r.mongo.Database.Collection(tenant.CollectionName()).FindOneAndUpdate(sessionCtx, filter, updateVersion, optUpdateVersions).Decode(tenant)
+		if err != nil {
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update version tenant failed: %w", err))
+		}
+
+		return nil, nil
+	}
+
+	opts := options.Transaction().SetWriteConcern(writeconcern.Majority()).SetReadConcern(readconcern.Snapshot())
+	_, sessionErr := session.WithTransaction(ctx, sessionCallback, opts)
+	if sessionErr != nil {
+		var coreErr *ierror.CoreError
+		if errors.As(sessionErr, &coreErr) {
+			return coreErr
+		}
+		return errlist.ErrDatabase.WithChild(sessionErr)
+	}
+
+	return nil
+}
+
+func (r *PolicyDB) GetTenant(ctx context.Context, tenantID uint64) (*entity.Tenant, *ierror.CoreError) {
+	filter := bson.D{{Key: "spec.tenant_id", Value: tenantID}}
+
+	tenant := new(entity.Tenant)
+	err := r.mongo.Database.Collection(tenant.CollectionName()).FindOne(ctx, filter).Decode(tenant)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errlist.ErrNotFoundTenant
+		}
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("find tenant failed: %w", err))
+	}
+	return tenant, nil
+}
+
+func (r *PolicyDB) DeleteTenant(ctx context.Context, tenantID uint64) *ierror.CoreError {
+	filter := bson.D{{Key: "spec.tenant_id", Value: tenantID}}
+
+	_, err := r.mongo.Database.Collection(entity.Tenant{}.CollectionName()).DeleteOne(ctx, filter)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("delete tenant failed: %w", err))
+	}
+	return nil
+}
+
+func (r *PolicyDB) ListTenants(ctx context.Context) ([]*entity.Tenant, *ierror.CoreError) {
+	tenants := make([]*entity.Tenant, 0)
+	opts := options.Find().SetSort(bson.D{{Key: "spec.tenant_id", Value: 1}})
+	cursor, err := r.mongo.Database.Collection(entity.Tenant{}.CollectionName()).Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("list tenants failed: %w", err))
+	}
+	if err = cursor.All(ctx, &tenants); err != nil {
+		return nil, errlist.ErrUnmarshalFailed.WithChild(fmt.Errorf("decode tenants failed: %w", err))
+	}
+	return tenants, nil
+}
+
+// quotaRevisionField is incremented in a tenant with a quota by every creation of a resource counted by the quota.
+// Two transactions creating resources of the tenant write the same document and conflict, the one retried counts the
+// resource created by the other, so that concurrent creations can not exceed the quota.
+const quotaRevisionField = "quota_revision"
+
+// tenantQuota is a limit of TenantQuota and the resources it counts.
+type tenantQuota struct {
+	resources  string
+	collection string
+	tenantKey  string
+	limit      func(quota entity.TenantQuota) uint32
+}
+
+var (
+	hostEndpointQuota = tenantQuota{
+		resources:  "host endpoints",
+		collection: entity.HostEndpoint{}.CollectionName(),
+		tenantKey:  "spec.tenant_id",
+		limit:      func(quota entity.TenantQuota) uint32 { return quota.MaxHostEndpoints },
+	}
+	networkPolicyQuota = tenantQuota{
+		resources:  "network policies",
+		collection: entity.NetworkPolicy{}.CollectionName(),
+		tenantKey:  "metadata.tenant_id",
+		limit:      func(quota entity.TenantQuota) uint32 { return quota.MaxNetworkPolicies },
+	}
+)
+
+// checkQuota returns ErrQuotaExceeded if tenantID reached its quota, in the transaction of ctx and before a resource
+// counted by quota is created. A tenant which is not stored has no quota.
+func (r *PolicyDB) checkQuota(ctx context.Context, tenantID uint64, quota tenantQuota) error {
+	filter := bson.D{{Key: "spec.tenant_id", Value: tenantID}}
+	tenant := new(entity.Tenant)
+	err := r.mongo.Database.Collection(tenant.CollectionName()).FindOne(ctx, filter).Decode(tenant)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("find tenant failed: %w", err))
+	}
+	limit := quota.limit(tenant.Spec.Quota)
+	if limit == 0 {
+		return nil
+	}
+
+	update := bson.D{{Key: "$inc", Value: bson.D{{Key: quotaRevisionField, Value: 1}}}}
+	if _, err = r.mongo.Database.Collection(tenant.CollectionName()).UpdateOne(ctx, filter, update); err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("update tenant quota revision failed: %w", err))
+	}
+	count, err := r.mongo.Database.Collection(quota.collection).CountDocuments(ctx, bson.D{{Key: quota.tenantKey, Value: tenantID}})
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("count %s failed: %w", quota.resources, err))
+	}
+	if count >= int64(limit) {
+		return errlist.ErrQuotaExceeded.WithChild(fmt.Errorf("tenant %d reached its quota of %d %s", tenantID, limit, quota.resources))
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/storage"
+)
+
+func TestCheckQuota(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	var tenant = func(maxHEPs int32) bson.D {
+		return bson.D{
+			{Key: "spec", Value: bson.D{
+				{Key: "tenant_id", Value: int64(2)},
+				{Key: "quota", Value: bson.D{{Key: "max_host_endpoints", Value: maxHEPs}}},
+			}},
+		}
+	}
+	var count = func(n int32) bson.D {
+		return mtest.CreateCursorResponse(0, "db.host_endpoint", mtest.FirstBatch, bson.D{{Key: "n", Value: n}})
+	}
+	tests := []struct {
+		name      string
+		responses []bson.D
+		// wantCommands are the commands run for the check, the tenant is written only when it has a quota
+		wantCommands []string
+		wantErr      error
+	}{
+		{
+			name:         "tenant not stored",
+			responses:    []bson.D{mtest.CreateCursorResponse(0, "db.tenant", mtest.FirstBatch)},
+			wantCommands: []string{"find"},
+		},
+		{
+			name:         "no quota",
+			responses:    []bson.D{mtest.CreateCursorResponse(0, "db.tenant", mtest.FirstBatch, tenant(0))},
+			wantCommands: []string{"find"},
+		},
+		{
+			name: "under quota",
+			responses: []bson.D{
+				mtest.CreateCursorResponse(0, "db.tenant", mtest.FirstBatch, tenant(3)),
+				mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+				count(2),
+			},
+			wantCommands: []string{"find", "update", "aggregate"},
+		},
+		{
+			name: "quota reached",
+			responses: []bson.D{
+				mtest.CreateCursorResponse(0, "db.tenant", mtest.FirstBatch, tenant(3)),
+				mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+				count(3),
+			},
+			wantCommands: []string{"find", "update", "aggregate"},
+			wantErr:      errlist.ErrQuotaExceeded,
+		},
+	}
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			mt.AddMockResponses(tt.responses...)
+			r := NewPolicy(&storage.PolicyDB{Database: mt.DB})
+
+			err := r.checkQuota(context.Background(), 2, hostEndpointQuota)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				mt.Fatalf("checkQuota() error = %v, want %v", err, tt.wantErr)
+			}
+			var commands []string
+			for _, started := range mt.GetAllStartedEvents() {
+				commands = append(commands, started.CommandName)
+				if started.CommandName == "update" && !strings.Contains(started.Command.String(), quotaRevisionField) {
+					mt.Errorf("update %s does not write %s", started.Command, quotaRevisionField)
+				}
+			}
+			if len(commands) != len(tt.wantCommands) {
+				mt.Fatalf("commands = %v, want %v", commands, tt.wantCommands)
+			}
+			for i := range commands {
+				if commands[i] != tt.wantCommands[i] {
+					mt.Errorf("commands = %v, want %v", commands, tt.wantCommands)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+func (r *PolicyDB) UpsertTier(ctx context.Context, tier *entity.Tier) *ierror.CoreError {
+	session, err := r.mongo.Database.Client().StartSession()
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(err)
+	}
+	defer session.EndSession(ctx)
+
+	sessionCallback := func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		filter := bson.D{{Key: "metadata.name", Value: tier.Metadata.Name}}
+		existedTier := new(entity.Tier)
+		err = r.mongo.Database.Collection(tier.CollectionName()).FindOne(ctx, filter).Decode(existedTier)
+		if err != nil && !errors.Is(mongo.ErrNoDocuments, err) {
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("find tier failed: %w", err))
+		}
+
+		// tier is existed
+		if !errors.Is(mongo.ErrNoDocuments, err) {
+			tier.ID = existedTier.ID
+			tier.UUID = existedTier.UUID
+			tier.Version = existedTier.Version
+			tier.CreatedAt = existedTier.CreatedAt
+		}
+
+		filter = bson.D{{Key: "_id", Value: tier.ID}}
+		update := bson.D{{Key: "$set", Value: tier}}
+		opts := options.Update().SetUpsert(true)
+		_, err = r.mongo.Database.Collection(tier.CollectionName()).UpdateOne(ctx, filter, update, opts)
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return nil, errlist.ErrDuplicateTier.
+					WithChild(fmt.Errorf("tier already exists: %w", err))
+			}
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update tier failed: %w", err))
+		}
+
+		updateVersion := bson.M{
+			"$inc": bson.M{
+				"version": 1,
+			},
+		}
+		optUpdateVersions := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		err = r.mongo.Database.Collection(tier.CollectionName()).FindOneAndUpdate(ctx, filter, updateVersion, optUpdateVersions).Decode(tier)
+		if err != nil {
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update version tier failed: %w", err))
+		}
+
+		return nil, nil
+	}
+
+	opts := options.Transaction().SetWriteConcern(writeconcern.Majority()).SetReadConcern(readconcern.Snapshot())
+	_, sessionErr := session.WithTransaction(ctx, sessionCallback, opts)
+	if sessionErr != nil {
+		var coreErr *ierror.CoreError
+		if errors.As(sessionErr, &coreErr) {
+			return coreErr
+		}
+		return errlist.ErrDatabase.WithChild(sessionErr)
+	}
+
+	return nil
+}
+
+func (r *PolicyDB) GetTierByName(ctx context.Context, name string) (*entity.Tier, *ierror.CoreError) {
+	filter := bson.D{{Key: "metadata.name", Value: name}}
+
+	tier := new(entity.Tier)
+	err := r.mongo.Database.Collection(tier.CollectionName()).FindOne(ctx, filter).Decode(tier)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errlist.ErrNotFoundTier
+		}
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("find tier failed: %w", err))
+	}
+	return tier, nil
+}
+
+func (r *PolicyDB) DeleteTierByName(ctx context.Context, name string) *ierror.CoreError {
+	filter := bson.D{{Key: "metadata.name", Value: name}}
+
+	_, err := r.mongo.Database.Collection(entity.Tier{}.CollectionName()).DeleteOne(ctx, filter)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("delete tier failed: %w", err))
+	}
+	return nil
+}
+
+func (r *PolicyDB) ListTiers(ctx context.Context) ([]*entity.Tier, *ierror.CoreError) {
+	tiers := make([]*entity.Tier, 0)
+	opts := options.Find().SetSort(bson.D{{Key: "spec.order", Value: 1}, {Key: "metadata.name", Value: 1}})
+	cursor, err := r.mongo.Database.Collection(entity.Tier{}.CollectionName()).Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("list tiers failed: %w", err))
+	}
+	if err = cursor.All(ctx, &tiers); err != nil {
+		return nil, errlist.ErrUnmarshalFailed.WithChild(fmt.Errorf("decode tiers failed: %w", err))
+	}
+	return tiers, nil
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+
+	"github.com/bamboo-firewall/be/domain/model"
+	"github.com/bamboo-firewall/be/pkg/common/errlist"
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/httpbase/ierror"
+)
+
+func (r *PolicyDB) UpsertWebhook(ctx context.Context, webhook *entity.Webhook) *ierror.CoreError {
+	session, err := r.mongo.Database.Client().StartSession()
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(err)
+	}
+	defer session.EndSession(ctx)
+
+	sessionCallback := func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		filter := bson.D{{Key: "metadata.name", Value: webhook.Metadata.Name}}
+		existedWebhook := new(entity.Webhook)
+		err = r.mongo.Database.Collection(webhook.CollectionName()).FindOne(ctx, filter).Decode(existedWebhook)
+		if err != nil && !errors.Is(mongo.ErrNoDocuments, err) {
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("find webhook failed: %w", err))
+		}
+
+		// webhook is existed
+		if !errors.Is(mongo.ErrNoDocuments, err) {
+			webhook.ID = existedWebhook.ID
+			webhook.UUID = existedWebhook.UUID
+			webhook.Version = existedWebhook.Version
+			webhook.CreatedAt = existedWebhook.CreatedAt
+			if webhook.Spec.Secret == "" {
+				webhook.Spec.Secret = existedWebhook.Spec.Secret
+			}
+		}
+
+		filter = bson.D{{Key: "_id", Value: webhook.ID}}
+		update := bson.D{{Key: "$set", Value: webhook}}
+		opts := options.Update().SetUpsert(true)
+		_, err = r.mongo.Database.Collection(webhook.CollectionName()).UpdateOne(ctx, filter, update, opts)
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return nil, errlist.ErrDuplicateWebhook.
+					WithChild(fmt.Errorf("webhook already exists: %w", err))
+			}
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update webhook failed: %w", err))
+		}
+
+		updateVersion := bson.M{
+			"$inc": bson.M{
+				"version": 1,
+			},
+		}
+		optUpdateVersions := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		err = r.mongo.Database.Collection(webhook.CollectionName()).FindOneAndUpdate(ctx, filter, updateVersion, optUpdateVersions).Decode(webhook)
+		if err != nil {
+			return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("update version webhook failed: %w", err))
+		}
+
+		return nil, nil
+	}
+
+	opts := options.Transaction().SetWriteConcern(writeconcern.Majority()).SetReadConcern(readconcern.Snapshot())
+	_, sessionErr := session.WithTransaction(ctx, sessionCallback, opts)
+	if sessionErr != nil {
+		var coreErr *ierror.CoreError
+		if errors.As(sessionErr, &coreErr) {
+			return coreErr
+		}
+		return errlist.ErrDatabase.WithChild(sessionErr)
+	}
+
+	return nil
+}
+
+func (r *PolicyDB) GetWebhookByName(ctx context.Context, name string) (*entity.Webhook, *ierror.CoreError) {
+	filter := bson.D{{Key: "metadata.name", Value: name}}
+
+	webhook := new(entity.Webhook)
+	err := r.mongo.Database.Collection(webhook.CollectionName()).FindOne(ctx, filter).Decode(webhook)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errlist.ErrNotFoundWebhook
+		}
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("find webhook failed: %w", err))
+	}
+	return webhook, nil
+}
+
+func (r *PolicyDB) DeleteWebhookByName(ctx context.Context, name string) *ierror.CoreError {
+	filter := bson.D{{Key: "metadata.name", Value: name}}
+
+	_, err := r.mongo.Database.Collection(entity.Webhook{}.CollectionName()).DeleteOne(ctx, filter)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("delete webhook failed: %w", err))
+	}
+	return nil
+}
+
+func (r *PolicyDB) ListWebhooks(ctx context.Context) ([]*entity.Webhook, *ierror.CoreError) {
+	webhooks := make([]*entity.Webhook, 0)
+	opts := options.Find().SetSort(bson.D{{Key: "metadata.name", Value: 1}})
+	cursor, err := r.mongo.Database.Collection(entity.Webhook{}.CollectionName()).Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("list webhooks failed: %w", err))
+	}
+	if err = cursor.All(ctx, &webhooks); err != nil {
+		return nil, errlist.ErrUnmarshalFailed.WithChild(fmt.Errorf("decode webhooks failed: %w", err))
+	}
+	return webhooks, nil
+}
+
+func (r *PolicyDB) InsertWebhookDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) *ierror.CoreError {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	documents := make([]interface{}, len(deliveries))
+	for i, delivery := range deliveries {
+		documents[i] = delivery
+	}
+	_, err := r.mongo.Database.Collection(entity.WebhookDelivery{}.CollectionName()).InsertMany(ctx, documents)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("insert webhook deliveries failed: %w", err))
+	}
+	return nil
+}
+
+// ClaimWebhookDelivery returns a pending delivery due at now and postpones its next attempt by lease, so that
+// another server does not deliver it meanwhile. It returns nil when no delivery is due.
+func (r *PolicyDB) ClaimWebhookDelivery(ctx context.Context, now time.Time, lease time.Duration) (*entity.WebhookDelivery, *ierror.CoreError) {
+	filter := bson.D{
+		{Key: "status", Value: entity.WebhookDeliveryStatusPending},
+		{Key: "next_attempt_at", Value: bson.D{{Key: "$lte", Value: now}}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "next_attempt_at", Value: now.Add(lease)}}}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	delivery := new(entity.WebhookDelivery)
+	err := r.mongo.Database.Collection(delivery.CollectionName()).FindOneAndUpdate(ctx, filter, update, opts).Decode(delivery)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("claim webhook delivery failed: %w", err))
+	}
+	return delivery, nil
+}
+
+func (r *PolicyDB) UpdateWebhookDelivery(ctx context.Context, delivery *entity.WebhookDelivery) *ierror.CoreError {
+	filter := bson.D{{Key: "_id", Value: delivery.ID}}
+	update := bson.D{{Key: "$set", Value: delivery}}
+	_, err := r.mongo.Database.Collection(delivery.CollectionName()).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errlist.ErrDatabase.WithChild(fmt.Errorf("update webhook delivery failed: %w", err))
+	}
+	return nil
+}
+
+// ListWebhookDeliveries returns the latest deliveries first.
+func (r *PolicyDB) ListWebhookDeliveries(ctx context.Context, input *model.ListWebhookDeliveriesInput) ([]*entity.WebhookDelivery, *ierror.CoreError) {
+	filter := bson.D{}
+	if input.WebhookName != "" {
+		filter = append(filter, bson.E{Key: "webhook_name", Value: input.WebhookName})
+	}
+	if input.Status != "" {
+		filter = append(filter, bson.E{Key: "status", Value: input.Status})
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	if input.Limit > 0 {
+		opts.SetLimit(input.Limit)
+	}
+
+	deliveries := make([]*entity.WebhookDelivery, 0)
+	cursor, err := r.mongo.Database.Collection(entity.WebhookDelivery{}.CollectionName()).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errlist.ErrDatabase.WithChild(fmt.Errorf("list webhook deliveries failed: %w", err))
+	}
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return nil, errlist.ErrUnmarshalFailed.WithChild(fmt.Errorf("decode webhook deliveries failed: %w", err))
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,76 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr bool
+	}{
+		{expr: "* * * * *"},
+		{expr: "*/15 8-18/2 1,15 1-12 1-5"},
+		{expr: "0 0 * * 7"},
+		{expr: "0 0 * *", wantErr: true},
+		{expr: "0 0 * * * *", wantErr: true},
+		{expr: "60 * * * *", wantErr: true},
+		{expr: "* 24 * * *", wantErr: true},
+		{expr: "* * 0 * *", wantErr: true},
+		{expr: "* * * 13 *", wantErr: true},
+		{expr: "* * * * 8", wantErr: true},
+		{expr: "5-1 * * * *", wantErr: true},
+		{expr: "*/0 * * * *", wantErr: true},
+		{expr: "a * * * *", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			if _, err := ParseCron(tt.expr); (err != nil) != tt.wantErr {
+				t.Errorf("ParseCron(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func mustParseCron(t *testing.T, expr string) *Cron {
+	t.Helper()
+	c, err := ParseCron(expr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func date(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestCronNext(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		t    time.Time
+		want time.Time
+	}{
+		{name: "next minute", expr: "* * * * *", t: date(2024, 1, 1, 10, 0).Add(30 * time.Second), want: date(2024, 1, 1, 10, 1)},
+		{name: "strictly after", expr: "0 10 * * *", t: date(2024, 1, 1, 10, 0), want: date(2024, 1, 2, 10, 0)},
+		{name: "step", expr: "*/15 * * * *", t: date(2024, 1, 1, 10, 16), want: date(2024, 1, 1, 10, 30)},
+		{name: "hour range step", expr: "0 8-18/4 * * *", t: date(2024, 1, 1, 13, 0), want: date(2024, 1, 1, 16, 0)},
+		{name: "next month", expr: "0 0 1 * *", t: date(2024, 1, 15, 0, 0), want: date(2024, 2, 1, 0, 0)},
+		{name: "next year", expr: "0 0 1 1 *", t: date(2024, 6, 1, 0, 0), want: date(2025, 1, 1, 0, 0)},
+		// 2024-01-06 is a saturday
+		{name: "weekday", expr: "0 9 * * 1-5", t: date(2024, 1, 6, 0, 0), want: date(2024, 1, 8, 9, 0)},
+		{name: "sunday as 7", expr: "0 9 * * 7", t: date(2024, 1, 6, 0, 0), want: date(2024, 1, 7, 9, 0)},
+		{name: "day of month or day of week", expr: "0 0 15 * 1", t: date(2024, 1, 2, 0, 0), want: date(2024, 1, 8, 0, 0)},
+		{name: "leap day", expr: "0 0 29 2 *", t: date(2024, 3, 1, 0, 0), want: date(2028, 2, 29, 0, 0)},
+		{name: "never", expr: "0 0 30 2 *", t: date(2024, 1, 1, 0, 0), want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mustParseCron(t, tt.expr).Next(tt.t); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+)
+
+// Window is a recurring period opening at each time matching Cron in UTC and lasting Duration.
+type Window struct {
+	Cron     *Cron
+	Duration time.Duration
+}
+
+// ParseWindow parses a window from a cron expression and a duration such as "8h" or "30m".
+func ParseWindow(cronExpr, duration string) (Window, error) {
+	c, err := ParseCron(cronExpr)
+	if err != nil {
+		return Window{}, err
+	}
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return Window{}, fmt.Errorf("invalid duration %q: %w", duration, err)
+	}
+	if d <= 0 {
+		return Window{}, fmt.Errorf("duration %q must be positive", duration)
+	}
+	return Window{Cron: c, Duration: d}, nil
+}
+
+// ActiveAt reports whether a window opened less than Duration before t. Windows open at the times matching Cron in
+// UTC, whatever the location of t.
+func (w Window) ActiveAt(t time.Time) bool {
+	t = t.UTC()
+	start := w.Cron.Next(t.Add(-w.Duration))
+	return !start.IsZero() && !start.After(t)
+}
+
+// Schedule bounds when something is active. A nil From or Until is unbounded and a schedule without windows is
+// active all the time between them.
+type Schedule struct {
+	From    *time.Time
+	Until   *time.Time
+	Windows []Window
+}
+
+// IsZero reports whether the schedule is always active.
+func (s Schedule) IsZero() bool {
+	return s.From == nil && s.Until == nil && len(s.Windows) == 0
+}
+
+func (s Schedule) ActiveAt(t time.Time) bool {
+	if s.From != nil && t.Before(*s.From) {
+		return false
+	}
+	if s.Until != nil && !t.Before(*s.Until) {
+		return false
+	}
+	if len(s.Windows) == 0 {
+		return true
+	}
+	for _, w := range s.Windows {
+		if w.ActiveAt(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// StopsWithin reports whether the schedule goes from active to inactive in [t, t+d), when Until is reached or when
+// a window closes while no other window is open.
+func (s Schedule) StopsWithin(t time.Time, d time.Duration) bool {
+	end := t.Add(d)
+	var stops = func(at time.Time) bool {
+		return !at.Before(t) && at.Before(end) && s.ActiveAt(at.Add(-time.Nanosecond)) && !s.ActiveAt(at)
+	}
+	if s.Until != nil && stops(*s.Until) {
+		return true
+	}
+	for _, w := range s.Windows {
+		// a window closing at or after t opened at or after t-Duration
+		for start := w.Cron.Next(t.UTC().Add(-w.Duration - time.Minute)); !start.IsZero(); start = w.Cron.Next(start) {
+			closing := start.Add(w.Duration)
+			if !closing.Before(end) {
+				break
+			}
+			if stops(closing) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWindow(t *testing.T) {
+	if _, err := ParseWindow("0 9 * * *", "8h"); err != nil {
+		t.Errorf("ParseWindow() error = %v", err)
+	}
+	for _, duration := range []string{"", "8", "0s", "-1h"} {
+		if _, err := ParseWindow("0 9 * * *", duration); err == nil {
+			t.Errorf("ParseWindow() with duration %q succeeded", duration)
+		}
+	}
+	if _, err := ParseWindow("0 9 * *", "8h"); err == nil {
+		t.Error("ParseWindow() with malformed cron succeeded")
+	}
+}
+
+func TestWindowActiveAt(t *testing.T) {
+	window, err := ParseWindow("0 9 * * 1-5", "8h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2024-01-08 is a monday
+	tests := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{name: "before opening", t: date(2024, 1, 8, 8, 59), want: false},
+		{name: "at opening", t: date(2024, 1, 8, 9, 0), want: true},
+		{name: "open", t: date(2024, 1, 8, 16, 59), want: true},
+		{name: "at closing", t: date(2024, 1, 8, 17, 0), want: false},
+		{name: "weekend", t: date(2024, 1, 6, 10, 0), want: false},
+		{name: "utc whatever the location", t: date(2024, 1, 8, 9, 30).In(time.FixedZone("UTC+7", 7*60*60)), want: true},
+		{name: "not local time", t: time.Date(2024, 1, 8, 9, 30, 0, 0, time.FixedZone("UTC+7", 7*60*60)), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := window.ActiveAt(tt.t); got != tt.want {
+				t.Errorf("ActiveAt(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+
+	overnight, err := ParseWindow("0 22 * * *", "4h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !overnight.ActiveAt(date(2024, 1, 9, 1, 0)) {
+		t.Error("window opened the day before is not active after midnight")
+	}
+}
+
+func TestScheduleActiveAt(t *testing.T) {
+	from, until := date(2024, 1, 1, 0, 0), date(2024, 2, 1, 0, 0)
+	window, err := ParseWindow("0 9 * * *", "1h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		schedule Schedule
+		t        time.Time
+		want     bool
+	}{
+		{name: "zero", schedule: Schedule{}, t: from, want: true},
+		{name: "before from", schedule: Schedule{From: &from}, t: from.Add(-time.Minute), want: false},
+		{name: "at from", schedule: Schedule{From: &from}, t: from, want: true},
+		{name: "at until", schedule: Schedule{Until: &until}, t: until, want: false},
+		{name: "in window", schedule: Schedule{From: &from, Until: &until, Windows: []Window{window}}, t: date(2024, 1, 10, 9, 30), want: true},
+		{name: "out of window", schedule: Schedule{From: &from, Until: &until, Windows: []Window{window}}, t: date(2024, 1, 10, 10, 30), want: false},
+		{name: "window after until", schedule: Schedule{Until: &until, Windows: []Window{window}}, t: date(2024, 2, 10, 9, 30), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.ActiveAt(tt.t); got != tt.want {
+				t.Errorf("ActiveAt(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleStopsWithin(t *testing.T) {
+	until := date(2024, 2, 1, 0, 0)
+	mustWindow := func(cronExpr, duration string) Window {
+		window, err := ParseWindow(cronExpr, duration)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return window
+	}
+	// 2024-01-08 is a monday
+	businessHours := mustWindow("0 9 * * 1-5", "8h")
+	tests := []struct {
+		name     string
+		schedule Schedule
+		t        time.Time
+		d        time.Duration
+		want     bool
+	}{
+		{name: "always active", schedule: Schedule{}, t: date(2024, 1, 8, 9, 0), d: 24 * time.Hour, want: false},
+		{name: "until within", schedule: Schedule{Until: &until}, t: date(2024, 1, 31, 12, 0), d: 24 * time.Hour, want: true},
+		{name: "until after", schedule: Schedule{Until: &until}, t: date(2024, 1, 30, 0, 0), d: 24 * time.Hour, want: false},
+		{name: "window closing within", schedule: Schedule{Windows: []Window{businessHours}}, t: date(2024, 1, 8, 12, 0), d: 6 * time.Hour, want: true},
+		{name: "window closing at the end", schedule: Schedule{Windows: []Window{businessHours}}, t: date(2024, 1, 8, 12, 0), d: 5 * time.Hour, want: false},
+		{name: "window closing at the start", schedule: Schedule{Windows: []Window{businessHours}}, t: date(2024, 1, 8, 17, 0), d: time.Minute, want: true},
+		{name: "window opening and closing within", schedule: Schedule{Windows: []Window{businessHours}}, t: date(2024, 1, 8, 0, 0), d: 24 * time.Hour, want: true},
+		{name: "weekend", schedule: Schedule{Windows: []Window{businessHours}}, t: date(2024, 1, 6, 0, 0), d: 48 * time.Hour, want: false},
+		{
+			name:     "window closing while another is open",
+			schedule: Schedule{Windows: []Window{businessHours, mustWindow("0 16 * * *", "4h")}},
+			t:        date(2024, 1, 8, 12, 0),
+			d:        6 * time.Hour,
+			want:     false,
+		},
+		{
+			name:     "window closing after until",
+			schedule: Schedule{Until: &until, Windows: []Window{mustWindow("0 22 31 1 *", "4h")}},
+			t:        date(2024, 2, 1, 0, 1),
+			d:        6 * time.Hour,
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.StopsWithin(tt.t, tt.d); got != tt.want {
+				t.Errorf("StopsWithin(%v, %v) = %v, want %v", tt.t, tt.d, got, tt.want)
+			}
+		})
+	}
+}
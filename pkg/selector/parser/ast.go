@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -20,6 +22,7 @@ func (m MapAsLabels) Get(labelName string) (value string, present bool) {
 type node interface {
 	Evaluate(labels Labels) bool
 	collectFragments(fragments []string) []string
+	explain(labels Labels) *Trace
 }
 
 type selectorRoot struct {
@@ -129,6 +132,78 @@ func (node *LabelNeValueNode) collectFragments(fragments []string) []string {
 	return appendLabelOpAndQuotedString(fragments, node.LabelName, " != ", node.Value)
 }
 
+// LabelRegexMatchNode matches labels whose whole value matches Pattern.
+type LabelRegexMatchNode struct {
+	LabelName string
+	Pattern   string
+	regex     *regexp.Regexp
+}
+
+func (node *LabelRegexMatchNode) Evaluate(labels Labels) bool {
+	val, ok := labels.Get(node.LabelName)
+	if ok {
+		return node.regex.MatchString(val)
+	}
+	return false
+}
+
+func (node *LabelRegexMatchNode) collectFragments(fragments []string) []string {
+	return appendLabelOpAndQuotedString(fragments, node.LabelName, " =~ ", node.Pattern)
+}
+
+// LabelRegexNotMatchNode matches labels which are not set or whose value does not match Pattern.
+type LabelRegexNotMatchNode struct {
+	LabelName string
+	Pattern   string
+	regex     *regexp.Regexp
+}
+
+func (node *LabelRegexNotMatchNode) Evaluate(labels Labels) bool {
+	val, ok := labels.Get(node.LabelName)
+	if ok {
+		return !node.regex.MatchString(val)
+	}
+	return true
+}
+
+func (node *LabelRegexNotMatchNode) collectFragments(fragments []string) []string {
+	return appendLabelOpAndQuotedString(fragments, node.LabelName, " !~ ", node.Pattern)
+}
+
+// LabelCompareNode compares the numeric value of a label with Value. Labels which are not set or not numeric never
+// match, whatever the operator.
+type LabelCompareNode struct {
+	LabelName string
+	Operator  string
+	Value     float64
+}
+
+func (node *LabelCompareNode) Evaluate(labels Labels) bool {
+	val, ok := labels.Get(node.LabelName)
+	if !ok {
+		return false
+	}
+	number, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return false
+	}
+	switch node.Operator {
+	case "<":
+		return number < node.Value
+	case "<=":
+		return number <= node.Value
+	case ">":
+		return number > node.Value
+	case ">=":
+		return number >= node.Value
+	}
+	return false
+}
+
+func (node *LabelCompareNode) collectFragments(fragments []string) []string {
+	return append(fragments, node.LabelName, " ", node.Operator, " ", strconv.FormatFloat(node.Value, 'f', -1, 64))
+}
+
 func appendLabelOpAndQuotedString(fragments []string, label, op, s string) []string {
 	var quote string
 	if strings.Contains(s, `"`) {
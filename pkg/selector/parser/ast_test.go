@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestRegexAndNumericOperators(t *testing.T) {
+	labels := map[string]string{"env": "prod-eu", "tier": "3", "weight": "0.5", "name": "web"}
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{`env =~ "prod-.*"`, true},
+		{`env =~ "prod"`, false},
+		{`env !~ "dev-.*"`, true},
+		{`missing =~ ".*"`, false},
+		{`missing !~ ".*"`, true},
+		{`tier < 5`, true},
+		{`tier <= 3`, true},
+		{`tier > 3`, false},
+		{`tier >= 3.0`, true},
+		{`tier > -1`, true},
+		{`weight < .75`, true},
+		{`tier < "10"`, true},
+		{`name < 10`, false},
+		{`name >= 10`, false},
+		{`missing < 10`, false},
+		{`tier>2&&env=~'prod-(eu|us)'`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			sel, err := Parse(tt.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := sel.Evaluate(labels); got != tt.want {
+				t.Errorf("Evaluate() = %t, want %t", got, tt.want)
+			}
+
+			reparsed, err := Parse(sel.String())
+			if err != nil {
+				t.Fatalf("parse %q: %v", sel.String(), err)
+			}
+			if reparsed.String() != sel.String() {
+				t.Errorf("String() = %q after round-trip, want %q", reparsed.String(), sel.String())
+			}
+			if got := reparsed.Evaluate(labels); got != tt.want {
+				t.Errorf("Evaluate() = %t after round-trip, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegexAndNumericOperatorErrors(t *testing.T) {
+	for _, selector := range []string{
+		`env =~ "prod-("`,
+		`env !~ "[a-"`,
+		`env =~ prod`,
+		`tier < abc`,
+		`tier >= "ten"`,
+		`tier < `,
+		`env = "prod"`,
+	} {
+		if _, err := Parse(selector); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", selector)
+		}
+	}
+}
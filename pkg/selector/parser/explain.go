@@ -0,0 +1,138 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Trace is the evaluation of a selector expression against labels. Children are the traces of the operands of
+// !, && and ||, they are all evaluated so that the trace shows every sub-expression.
+type Trace struct {
+	Expression string
+	Result     bool
+	// Reason describes the label a comparison is evaluated on
+	Reason   string
+	Children []*Trace
+}
+
+func (r *selectorRoot) ExplainLabels(labels Labels) *Trace {
+	return r.root.explain(labels)
+}
+
+// Explain returns the evaluation trace of the selector against labels, its Result is the same as Evaluate.
+func (r *selectorRoot) Explain(labels map[string]string) *Trace {
+	return r.ExplainLabels(MapAsLabels(labels))
+}
+
+func nodeString(n node) string {
+	return strings.Join(n.collectFragments([]string{}), "")
+}
+
+// explainLabel traces a node comparing the value of a single label.
+func explainLabel(n node, labels Labels, labelName string) *Trace {
+	var reason string
+	if val, ok := labels.Get(labelName); ok {
+		reason = fmt.Sprintf("label %s is %q", labelName, val)
+	} else {
+		reason = fmt.Sprintf("label %s is not set", labelName)
+	}
+	return &Trace{
+		Expression: nodeString(n),
+		Result:     n.Evaluate(labels),
+		Reason:     reason,
+	}
+}
+
+func (node *LabelEqValueNode) explain(labels Labels) *Trace {
+	return explainLabel(node, labels, node.LabelName)
+}
+
+func (node *LabelRegexMatchNode) explain(labels Labels) *Trace {
+	return explainLabel(node, labels, node.LabelName)
+}
+
+func (node *LabelRegexNotMatchNode) explain(labels Labels) *Trace {
+	return explainLabel(node, labels, node.LabelName)
+}
+
+func (node *LabelCompareNode) explain(labels Labels) *Trace {
+	return explainLabel(node, labels, node.LabelName)
+}
+
+func (node *LabelContainsValueNode) explain(labels Labels) *Trace {
+	return explainLabel(node, labels, node.LabelName)
+}
+
+func (node *LabelStartsWithValueNode) explain(labels Labels) *Trace {
+	return explainLabel(node, labels, node.LabelName)
+}
+
+func (node *LabelEndsWithValueNode) explain(labels Labels) *Trace {
+	return explainLabel(node, labels, node.LabelName)
+}
+
+func (node *LabelNeValueNode) explain(labels Labels) *Trace {
+	return explainLabel(node, labels, node.LabelName)
+}
+
+func (node *LabelInSetNode) explain(labels Labels) *Trace {
+	return explainLabel(node, labels, node.LabelName)
+}
+
+func (node *LabelNotInSetNode) explain(labels Labels) *Trace {
+	return explainLabel(node, labels, node.LabelName)
+}
+
+func (node *HasNode) explain(labels Labels) *Trace {
+	return explainLabel(node, labels, node.LabelName)
+}
+
+func (node *NotNode) explain(labels Labels) *Trace {
+	operand := node.Operand.explain(labels)
+	return &Trace{
+		Expression: nodeString(node),
+		Result:     !operand.Result,
+		Children:   []*Trace{operand},
+	}
+}
+
+func (node *AndNode) explain(labels Labels) *Trace {
+	trace := &Trace{
+		Expression: nodeString(node),
+		Result:     true,
+	}
+	for _, operand := range node.Operands {
+		child := operand.explain(labels)
+		trace.Result = trace.Result && child.Result
+		trace.Children = append(trace.Children, child)
+	}
+	return trace
+}
+
+func (node *OrNode) explain(labels Labels) *Trace {
+	trace := &Trace{
+		Expression: nodeString(node),
+	}
+	for _, operand := range node.Operands {
+		child := operand.explain(labels)
+		trace.Result = trace.Result || child.Result
+		trace.Children = append(trace.Children, child)
+	}
+	return trace
+}
+
+func (node *AllNode) explain(Labels) *Trace {
+	return &Trace{
+		Expression: nodeString(node),
+		Result:     true,
+		Reason:     "matches everything",
+	}
+}
+
+func (node *GlobalNode) explain(Labels) *Trace {
+	return &Trace{
+		Expression: nodeString(node),
+		Result:     true,
+		Reason:     "matches everything",
+	}
+}
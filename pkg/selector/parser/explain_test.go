@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	sel, err := Parse(`role == "db" && (env in {"prod", "staging"} || !has(canary))`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	labels := map[string]string{"role": "db", "env": "dev", "canary": "true"}
+
+	trace := sel.Explain(labels)
+	if trace.Result != sel.Evaluate(labels) || trace.Result {
+		t.Fatalf("result = %t, want false", trace.Result)
+	}
+	if len(trace.Children) != 2 {
+		t.Fatalf("got %d children, want 2", len(trace.Children))
+	}
+	role, or := trace.Children[0], trace.Children[1]
+	if !role.Result || role.Reason != `label role is "db"` {
+		t.Errorf("role trace = %+v", role)
+	}
+	if or.Result || len(or.Children) != 2 {
+		t.Fatalf("or trace = %+v", or)
+	}
+	if in := or.Children[0]; in.Result || in.Expression != `env in {"prod", "staging"}` {
+		t.Errorf("in trace = %+v", in)
+	}
+	not := or.Children[1]
+	if not.Result || len(not.Children) != 1 || !not.Children[0].Result || not.Children[0].Reason != `label canary is "true"` {
+		t.Errorf("not trace = %+v", not)
+	}
+
+	if trace = sel.Explain(map[string]string{"role": "db"}); !trace.Result {
+		t.Errorf("result without canary = false, want true")
+	}
+}
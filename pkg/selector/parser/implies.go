@@ -0,0 +1,230 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxImpliesCases bounds the label combinations checked by Implies, larger selector pairs are reported as not
+// implying each other. It keeps a comparison within a millisecond, the validation of a policy compares its selector
+// with the ones of many policies.
+const maxImpliesCases = 1 << 10
+
+// Implies reports whether every set of labels matched by a is also matched by b, that is whether a is at most as
+// broad as b.
+//
+// Equality, set membership, has() and numeric comparisons are decided exactly by checking every label combination
+// which can make a difference. Substring and regex predicates are treated as independent conditions, so a pair of
+// selectors which only relate through them, like `a == "xy"` and `a starts with "x"`, is reported as not implying
+// each other. Implies never reports an implication which does not hold.
+func Implies(a, b fmt.Stringer) bool {
+	rootA, rootB := Normalize(a), Normalize(b)
+	if rootA.String() == rootB.String() {
+		return true
+	}
+
+	c := newImpliesCases()
+	c.collect(rootA.root)
+	c.collect(rootB.root)
+	return c.enumerate(func() bool {
+		return !c.evaluate(rootA.root) || c.evaluate(rootB.root)
+	})
+}
+
+// Equivalent reports whether a and b match the same sets of labels, see Implies.
+func Equivalent(a, b fmt.Stringer) bool {
+	if Normalize(a).String() == Normalize(b).String() {
+		return true
+	}
+	return Implies(a, b) && Implies(b, a)
+}
+
+type impliesCases struct {
+	// strings and numbers are the constants compared with each label
+	strings map[string]map[string]bool
+	numbers map[string][]float64
+	// atoms indexes the predicates treated as independent conditions by their string
+	atoms     map[node]int
+	atomNames map[string]int
+
+	labels     map[string]string
+	atomValues []bool
+}
+
+func newImpliesCases() *impliesCases {
+	return &impliesCases{
+		strings:   make(map[string]map[string]bool),
+		numbers:   make(map[string][]float64),
+		atoms:     make(map[node]int),
+		atomNames: make(map[string]int),
+		labels:    make(map[string]string),
+	}
+}
+
+func (c *impliesCases) addStrings(labelName string, values ...string) {
+	if c.strings[labelName] == nil {
+		c.strings[labelName] = make(map[string]bool)
+	}
+	for _, value := range values {
+		c.strings[labelName][value] = true
+	}
+}
+
+func (c *impliesCases) collect(n node) {
+	switch n := n.(type) {
+	case *NotNode:
+		c.collect(n.Operand)
+	case *AndNode:
+		for _, operand := range n.Operands {
+			c.collect(operand)
+		}
+	case *OrNode:
+		for _, operand := range n.Operands {
+			c.collect(operand)
+		}
+	case *LabelEqValueNode:
+		c.addStrings(n.LabelName, n.Value)
+	case *LabelNeValueNode:
+		c.addStrings(n.LabelName, n.Value)
+	case *LabelInSetNode:
+		c.addStrings(n.LabelName, n.Value...)
+	case *LabelNotInSetNode:
+		c.addStrings(n.LabelName, n.Value...)
+	case *HasNode:
+		c.addStrings(n.LabelName)
+	case *LabelCompareNode:
+		c.addStrings(n.LabelName)
+		c.numbers[n.LabelName] = append(c.numbers[n.LabelName], n.Value)
+	case *LabelContainsValueNode, *LabelStartsWithValueNode, *LabelEndsWithValueNode,
+		*LabelRegexMatchNode, *LabelRegexNotMatchNode:
+		name := nodeString(n)
+		idx, ok := c.atomNames[name]
+		if !ok {
+			idx = len(c.atomNames)
+			c.atomNames[name] = idx
+		}
+		c.atoms[n] = idx
+	}
+}
+
+// candidates returns the values of labelName to check, nil standing for the label not being set: every compared
+// string, a number in every interval delimited by the compared numbers and a value equal to none of them.
+func (c *impliesCases) candidates(labelName string) []*string {
+	constants := c.strings[labelName]
+	values := []*string{nil}
+	for value := range constants {
+		values = append(values, &value)
+	}
+
+	var fresh = func(value string) *string {
+		for constants[value] {
+			value = "~" + value
+		}
+		return &value
+	}
+	values = append(values, fresh("~"))
+
+	numbers := c.numbers[labelName]
+	sort.Float64s(numbers)
+	var points []float64
+	for i, number := range numbers {
+		if i == 0 {
+			points = append(points, number-math.Max(1, math.Abs(number)))
+		} else {
+			points = append(points, numbers[i-1]/2+number/2)
+		}
+		points = append(points, number)
+	}
+	if len(numbers) > 0 {
+		last := numbers[len(numbers)-1]
+		points = append(points, last+math.Max(1, math.Abs(last)))
+	}
+	for _, point := range points {
+		value := strconv.FormatFloat(point, 'f', -1, 64)
+		if constants[value] && !strings.Contains(value, ".") {
+			value += ".0"
+		}
+		for constants[value] {
+			// another spelling of the same number, which differs from the compared strings
+			value += "0"
+		}
+		values = append(values, &value)
+	}
+	return values
+}
+
+// enumerate calls check for every combination of label values and atom values until it returns false. It reports
+// whether check held for all of them, or false when there are more than maxImpliesCases combinations.
+func (c *impliesCases) enumerate(check func() bool) bool {
+	var labelNames []string
+	for labelName := range c.strings {
+		labelNames = append(labelNames, labelName)
+	}
+	sort.Strings(labelNames)
+
+	total := 1
+	for range c.atomNames {
+		total *= 2
+		if total > maxImpliesCases {
+			return false
+		}
+	}
+	candidates := make([][]*string, len(labelNames))
+	for i, labelName := range labelNames {
+		candidates[i] = c.candidates(labelName)
+		total *= len(candidates[i])
+		if total > maxImpliesCases {
+			return false
+		}
+	}
+
+	c.atomValues = make([]bool, len(c.atomNames))
+	for i := 0; i < total; i++ {
+		rest := i
+		for j, labelName := range labelNames {
+			value := candidates[j][rest%len(candidates[j])]
+			rest /= len(candidates[j])
+			if value == nil {
+				delete(c.labels, labelName)
+			} else {
+				c.labels[labelName] = *value
+			}
+		}
+		for j := range c.atomValues {
+			c.atomValues[j] = rest&(1<<j) != 0
+		}
+		if !check() {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluate evaluates n with the current combination.
+func (c *impliesCases) evaluate(n node) bool {
+	switch n := n.(type) {
+	case *NotNode:
+		return !c.evaluate(n.Operand)
+	case *AndNode:
+		for _, operand := range n.Operands {
+			if !c.evaluate(operand) {
+				return false
+			}
+		}
+		return true
+	case *OrNode:
+		for _, operand := range n.Operands {
+			if c.evaluate(operand) {
+				return true
+			}
+		}
+		return false
+	}
+	if idx, ok := c.atoms[n]; ok {
+		return c.atomValues[idx]
+	}
+	return n.Evaluate(MapAsLabels(c.labels))
+}
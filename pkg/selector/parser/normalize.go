@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+)
+
+// rootOf returns the parsed form of sel, which must have been returned by Parse.
+func rootOf(sel fmt.Stringer) *selectorRoot {
+	if root, ok := sel.(*selectorRoot); ok {
+		return root
+	}
+	root, err := Parse(sel.String())
+	if err != nil {
+		panic(fmt.Sprintf("selector %q was not returned by Parse: %v", sel.String(), err))
+	}
+	return root
+}
+
+// Normalize returns the canonical form of sel: nested "&&" and "||" are flattened, their operands are sorted and
+// deduplicated, double negations are removed, all() terms are simplified and single value "in"/"not in" sets are
+// turned into "=="/"!=". Selectors written differently but normalizing to the same string are equivalent.
+func Normalize(sel fmt.Stringer) *selectorRoot {
+	return &selectorRoot{root: normalizeNode(rootOf(sel).root)}
+}
+
+func normalizeNode(n node) node {
+	switch n := n.(type) {
+	case *NotNode:
+		operand := normalizeNode(n.Operand)
+		if not, ok := operand.(*NotNode); ok {
+			return not.Operand
+		}
+		return &NotNode{Operand: operand}
+	case *AndNode:
+		var operands []node
+		for _, operand := range n.Operands {
+			operand = normalizeNode(operand)
+			switch operand := operand.(type) {
+			case *AndNode:
+				operands = append(operands, operand.Operands...)
+			case *AllNode:
+				// x && all() is x
+			default:
+				operands = append(operands, operand)
+			}
+		}
+		operands = sortOperands(operands)
+		switch len(operands) {
+		case 0:
+			return &AllNode{}
+		case 1:
+			return operands[0]
+		}
+		return &AndNode{Operands: operands}
+	case *OrNode:
+		var operands []node
+		for _, operand := range n.Operands {
+			operand = normalizeNode(operand)
+			switch operand := operand.(type) {
+			case *OrNode:
+				operands = append(operands, operand.Operands...)
+			case *AllNode:
+				// x || all() is all()
+				return operand
+			default:
+				operands = append(operands, operand)
+			}
+		}
+		operands = sortOperands(operands)
+		if len(operands) == 1 {
+			return operands[0]
+		}
+		return &OrNode{Operands: operands}
+	case *LabelInSetNode:
+		if len(n.Value) == 1 {
+			return &LabelEqValueNode{LabelName: n.LabelName, Value: n.Value[0]}
+		}
+	case *LabelNotInSetNode:
+		if len(n.Value) == 1 {
+			return &LabelNeValueNode{LabelName: n.LabelName, Value: n.Value[0]}
+		}
+	}
+	return n
+}
+
+// sortOperands sorts operands by their string and drops duplicates.
+func sortOperands(operands []node) []node {
+	keys := make(map[node]string, len(operands))
+	for _, operand := range operands {
+		keys[operand] = nodeString(operand)
+	}
+	sort.SliceStable(operands, func(i, j int) bool {
+		return keys[operands[i]] < keys[operands[j]]
+	})
+	out := operands[:0]
+	for _, operand := range operands {
+		if len(out) > 0 && keys[operand] == keys[out[len(out)-1]] {
+			continue
+		}
+		out = append(out, operand)
+	}
+	return out
+}
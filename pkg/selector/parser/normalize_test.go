@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     string
+	}{
+		{`b == "y" && a == "x"`, `(a == "x" && b == "y")`},
+		{`a == "x" && (b == "y" && (c == "z" && a == "x"))`, `(a == "x" && b == "y" && c == "z")`},
+		{`(b == "y" || a == "x") || c == "z"`, `(a == "x" || b == "y" || c == "z")`},
+		{`!!has(a)`, `has(a)`},
+		{`!!!has(a)`, `!has(a)`},
+		{`a in {"x"}`, `a == "x"`},
+		{`a not in {"x"}`, `a != "x"`},
+		{`all() && a == "x"`, `a == "x"`},
+		{`all() || a == "x"`, `all()`},
+		{`(b == "y" || a == "x") && (a == "x" || b == "y")`, `(a == "x" || b == "y")`},
+		{``, `all()`},
+	}
+	for _, tt := range tests {
+		sel, err := Parse(tt.selector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Normalize(sel).String(); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.selector, got, tt.want)
+		}
+	}
+}
+
+func TestImplies(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{`a == "x" && b == "y"`, `b == "y" && a == "x"`, true},
+		{`a == "x" && b == "y"`, `a == "x"`, true},
+		{`a == "x"`, `a == "x" && b == "y"`, false},
+		{`a == "x"`, `has(a)`, true},
+		{`a == "x"`, `a in {"x", "y"}`, true},
+		{`a in {"x", "y"}`, `a == "x"`, false},
+		{`a != "x"`, `!has(a) || a in {"y", "z"}`, false},
+		{`!(a == "x")`, `a != "x"`, true},
+		{`a == "x"`, ``, true},
+		{``, `has(a)`, false},
+		{`tier > 5`, `tier >= 3`, true},
+		{`tier >= 3`, `tier > 3`, false},
+		{`tier == "4"`, `tier > 3 && tier < 5`, true},
+		{`tier > 3 && tier < 5`, `tier == "4"`, false},
+		{`tier < 0`, `tier <= -1`, false},
+		{`env =~ "prod-.*" && a == "x"`, `env =~ "prod-.*"`, true},
+		{`env == "prod-eu"`, `env =~ "prod-.*"`, false},
+		{`env contains "x" && env contains "y"`, `env contains "y"`, true},
+	}
+	for _, tt := range tests {
+		a, err := Parse(tt.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := Parse(tt.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Implies(a, b); got != tt.want {
+			t.Errorf("Implies(%q, %q) = %t, want %t", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestImpliesBounded(t *testing.T) {
+	var labels, atoms []string
+	for i := 0; i < 12; i++ {
+		labels = append(labels, fmt.Sprintf("l%d == \"x\"", i))
+		atoms = append(atoms, fmt.Sprintf("a contains \"%d\"", i))
+	}
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{name: "too many label combinations", a: strings.Join(labels, " && "), b: labels[0]},
+		{name: "too many independent conditions", a: strings.Join(atoms, " && "), b: atoms[0]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := Parse(tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := Parse(tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the implication holds but checking it takes more than maxImpliesCases combinations
+			if Implies(a, b) {
+				t.Errorf("Implies(%q, %q) = true, want the comparison to be given up", tt.a, tt.b)
+			}
+		})
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{`a == "x" && b == "y"`, `b == "y" && a == "x"`, true},
+		{`!(a == "x" || b == "y")`, `!(a == "x") && !(b == "y")`, true},
+		{`a != "x"`, `!(a == "x")`, true},
+		{`a not in {"x", "y"}`, `a != "x" && a != "y"`, true},
+		{`tier >= 3 && tier <= 3`, `tier >= 3.0 && tier <= 3.0`, true},
+		{`a == "x"`, `has(a)`, false},
+	}
+	for _, tt := range tests {
+		a, err := Parse(tt.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := Parse(tt.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Equivalent(a, b); got != tt.want {
+			t.Errorf("Equivalent(%q, %q) = %t, want %t", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
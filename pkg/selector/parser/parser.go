@@ -3,6 +3,10 @@ package parser
 import (
 	"errors"
 	"fmt"
+	"math"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
 
 	"github.com/bamboo-firewall/be/pkg/selector/tokenizer"
 )
@@ -159,6 +163,42 @@ func parseOperator(tokens []tokenizer.Token) (sel node, remainingTokens []tokeni
 			} else {
 				err = errors.New("expected string")
 			}
+		case tokenizer.TokenRegexMatch, tokenizer.TokenRegexNotMatch:
+			if tokens[2].Kind == tokenizer.TokenStringLiteral {
+				pattern := tokens[2].Value.(string)
+				var re *regexp.Regexp
+				re, err = compileLabelRegex(pattern)
+				if err != nil {
+					return
+				}
+				if tokens[1].Kind == tokenizer.TokenRegexMatch {
+					sel = &LabelRegexMatchNode{LabelName: tokens[0].Value.(string), Pattern: pattern, regex: re}
+				} else {
+					sel = &LabelRegexNotMatchNode{LabelName: tokens[0].Value.(string), Pattern: pattern, regex: re}
+				}
+				remainingTokens = tokens[3:]
+			} else {
+				err = errors.New("expected string")
+			}
+		case tokenizer.TokenLt, tokenizer.TokenLe, tokenizer.TokenGt, tokenizer.TokenGe:
+			var value float64
+			switch tokens[2].Kind {
+			case tokenizer.TokenNumberLiteral, tokenizer.TokenStringLiteral:
+				value, err = strconv.ParseFloat(tokens[2].Value.(string), 64)
+				if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+					err = fmt.Errorf("expected number not %q", tokens[2].Value)
+					return
+				}
+			default:
+				err = errors.New("expected number")
+				return
+			}
+			sel = &LabelCompareNode{
+				LabelName: tokens[0].Value.(string),
+				Operator:  compareOperator(tokens[1]),
+				Value:     value,
+			}
+			remainingTokens = tokens[3:]
 		case tokenizer.TokenIn, tokenizer.TokenNotIn:
 			if tokens[2].Kind == tokenizer.TokenLBrace {
 				remainingTokens = tokens[3:]
@@ -194,7 +234,7 @@ func parseOperator(tokens []tokenizer.Token) (sel node, remainingTokens []tokeni
 				err = errors.New("expected set literal")
 			}
 		default:
-			err = errors.New(fmt.Sprint("expected == or != not ", tokens[1]))
+			err = errors.New(fmt.Sprint("expected an operator not ", tokens[1]))
 		}
 	case tokenizer.TokenLParen:
 		// We hit a paren, skip past it, then recurse
@@ -218,3 +258,26 @@ func parseOperator(tokens []tokenizer.Token) (sel node, remainingTokens []tokeni
 	return
 
 }
+
+func compareOperator(token tokenizer.Token) string {
+	switch token.Kind {
+	case tokenizer.TokenLt:
+		return "<"
+	case tokenizer.TokenLe:
+		return "<="
+	case tokenizer.TokenGt:
+		return ">"
+	default:
+		return ">="
+	}
+}
+
+// compileLabelRegex compiles pattern so that it has to match the whole label value.
+func compileLabelRegex(pattern string) (*regexp.Regexp, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex %q: %w", pattern, err)
+	}
+	// Anchor the parsed pattern rather than the pattern itself, \Q quotes everything up to the end of a pattern.
+	return regexp.Compile(`^(?:` + re.String() + `)$`)
+}
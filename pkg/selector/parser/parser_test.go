@@ -0,0 +1,174 @@
+package parser
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestSomething(t *testing.T) {
+	input := "role in {'agent'} && project == 'atao'"
+	sel, err := Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sel.Evaluate(map[string]string{"role": "agent", "project": "atao"}) {
+		t.Errorf("%q does not match", input)
+	}
+}
+
+func TestParseTruncated(t *testing.T) {
+	for _, input := range []string{
+		`a in {"x",`,
+		`a in {"x"`,
+		`a in {`,
+		`a in`,
+		`a ==`,
+		`a <`,
+		`!`,
+		`!!`,
+		`(`,
+		`(a == "x"`,
+		`a == "x" &&`,
+		`a == "x" ||`,
+		`a == "x")`,
+		`a`,
+		`has(`,
+	} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", input)
+		}
+	}
+}
+
+var fuzzSeeds = []string{
+	``,
+	`all()`,
+	`global()`,
+	`has(a)`,
+	`!has(a)`,
+	`a == "x"`,
+	`a != 'x"'`,
+	`a in {"x", "y"}`,
+	`a not in {}`,
+	`a contains "x" || a starts with "y" && a ends with "z"`,
+	`(a == "x" || b == "y") && !(c == "z")`,
+	`a =~ "x.*" && b !~ "[0-9]+"`,
+	`a < 1 && b <= -2.5 && c > .5 && d >= "3"`,
+	`role in {'agent'} && project == 'atao'`,
+}
+
+// FuzzParse checks that Parse never panics and that the string of a parsed selector parses back to the same selector.
+func FuzzParse(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		sel, err := Parse(input)
+		if err != nil {
+			return
+		}
+		reparsed, err := Parse(sel.String())
+		if err != nil {
+			t.Fatalf("Parse(%q) of String() of %q failed: %v", sel.String(), input, err)
+		}
+		if reparsed.String() != sel.String() {
+			t.Fatalf("String() = %q after round-trip, want %q", reparsed.String(), sel.String())
+		}
+		if !Equivalent(sel, reparsed) {
+			t.Fatalf("%q is not equivalent to %q", reparsed.String(), sel.String())
+		}
+	})
+}
+
+// TestParseStringProperty checks that random selectors parse back from their string to an equivalent selector
+// which evaluates the same on random labels.
+func TestParseStringProperty(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		input := randomSelector(rnd, 3)
+		sel, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", input, err)
+		}
+		reparsed, err := Parse(sel.String())
+		if err != nil {
+			t.Fatalf("Parse(%q) of String() of %q: %v", sel.String(), input, err)
+		}
+		if !Equivalent(sel, reparsed) {
+			t.Fatalf("%q is not equivalent to %q", reparsed.String(), input)
+		}
+		if normalized := Normalize(sel); !Equivalent(sel, normalized) {
+			t.Fatalf("Normalize(%q) = %q is not equivalent", input, normalized.String())
+		}
+		for j := 0; j < 20; j++ {
+			labels := randomLabels(rnd)
+			if sel.Evaluate(labels) != reparsed.Evaluate(labels) || sel.Evaluate(labels) != Normalize(sel).Evaluate(labels) {
+				t.Fatalf("%q, %q and %q evaluate differently on %v", input, sel.String(), Normalize(sel).String(), labels)
+			}
+		}
+	}
+}
+
+var (
+	randomLabelNames  = []string{"a", "b", "c"}
+	randomLabelValues = []string{"x", "y", "1", "2.5", `q"`, ""}
+)
+
+func randomSelector(rnd *rand.Rand, depth int) string {
+	label := randomLabelNames[rnd.Intn(len(randomLabelNames))]
+	value := quote(randomLabelValues[rnd.Intn(len(randomLabelValues)-1)])
+	if depth > 0 && rnd.Intn(3) == 0 {
+		var operands []string
+		for n := 1 + rnd.Intn(3); n > 0; n-- {
+			operands = append(operands, randomSelector(rnd, depth-1))
+		}
+		op := " && "
+		if rnd.Intn(2) == 0 {
+			op = " || "
+		}
+		return "(" + strings.Join(operands, op) + ")"
+	}
+	switch rnd.Intn(12) {
+	case 0:
+		return "!" + randomSelector(rnd, depth-1)
+	case 1:
+		return "has(" + label + ")"
+	case 2:
+		return "all()"
+	case 3:
+		return label + " == " + value
+	case 4:
+		return label + " != " + value
+	case 5:
+		return label + " in {" + value + ", " + quote(randomLabelValues[rnd.Intn(2)]) + "}"
+	case 6:
+		return label + " not in {" + value + "}"
+	case 7:
+		return label + " contains " + value
+	case 8:
+		return label + " starts with " + value
+	case 9:
+		return label + " =~ " + quote("[x1]")
+	case 10:
+		return label + " !~ " + quote("y|2.*")
+	}
+	return label + []string{" < ", " <= ", " > ", " >= "}[rnd.Intn(4)] + []string{"1", "2.5", "-1", ".5"}[rnd.Intn(4)]
+}
+
+func quote(s string) string {
+	if strings.Contains(s, `"`) {
+		return "'" + s + "'"
+	}
+	return `"` + s + `"`
+}
+
+func randomLabels(rnd *rand.Rand) map[string]string {
+	labels := make(map[string]string)
+	for _, name := range randomLabelNames {
+		if rnd.Intn(3) > 0 {
+			labels[name] = randomLabelValues[rnd.Intn(len(randomLabelValues))]
+		}
+	}
+	return labels
+}
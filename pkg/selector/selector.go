@@ -1,18 +1,76 @@
 package selector
 
 import (
+	"strconv"
+
 	"github.com/bamboo-firewall/be/pkg/selector/parser"
 )
 
+// TenantLabel is the pseudo-label holding the tenant id of host endpoints and network sets, see WithTenant.
+const TenantLabel = "tenant"
+
 type Selector interface {
 	// Evaluate evaluates the selector against the given labels expressed as a concrete map
 	Evaluate(labels map[string]string) bool
 
+	// EvaluateLabels evaluates the selector against the given labels
+	EvaluateLabels(labels Labels) bool
+
 	// String returns a string that represents this selector
 	String() string
+
+	// Explain evaluates the selector against the given labels and traces every sub-expression
+	Explain(labels map[string]string) *Trace
+
+	// ExplainLabels is Explain against the given labels
+	ExplainLabels(labels Labels) *Trace
+}
+
+// Labels are the labels a selector is evaluated against, see parser.Labels.
+type Labels = parser.Labels
+
+type tenantLabels struct {
+	labels parser.MapAsLabels
+	tenant string
+}
+
+func (l tenantLabels) Get(labelName string) (string, bool) {
+	if labelName == TenantLabel {
+		return l.tenant, true
+	}
+	return l.labels.Get(labelName)
 }
 
+// WithTenant returns labels with the TenantLabel pseudo-label set to tenantID, which takes precedence over a label
+// of the same name. A zero tenantID, for global resources, leaves labels unchanged.
+func WithTenant(labels map[string]string, tenantID uint64) Labels {
+	if tenantID == 0 {
+		return parser.MapAsLabels(labels)
+	}
+	return tenantLabels{labels: labels, tenant: strconv.FormatUint(tenantID, 10)}
+}
+
+// Trace is the evaluation of a selector expression, see parser.Trace.
+type Trace = parser.Trace
+
 // Parse a string representation of a selector expression into a Selector.
 func Parse(selector string) (Selector, error) {
 	return parser.Parse(selector)
 }
+
+// Normalize returns the canonical form of sel, selectors which differ only by the order of their terms, redundant
+// parentheses or double negations normalize to the same string.
+func Normalize(sel Selector) Selector {
+	return parser.Normalize(sel)
+}
+
+// Implies reports whether every set of labels matched by a is also matched by b. It may miss implications which
+// only hold through substring or regex predicates but never reports one which does not hold.
+func Implies(a, b Selector) bool {
+	return parser.Implies(a, b)
+}
+
+// Equivalent reports whether a and b match the same sets of labels, see Implies.
+func Equivalent(a, b Selector) bool {
+	return parser.Equivalent(a, b)
+}
@@ -0,0 +1,31 @@
+package selector
+
+import "testing"
+
+func TestWithTenant(t *testing.T) {
+	labels := map[string]string{"role": "web", TenantLabel: "9"}
+	tests := []struct {
+		selector string
+		tenantID uint64
+		want     bool
+	}{
+		{selector: `tenant == "2"`, tenantID: 2, want: true},
+		{selector: `tenant == "2" && role == "web"`, tenantID: 2, want: true},
+		{selector: `tenant in {"1", "3"}`, tenantID: 2, want: false},
+		{selector: `has(tenant)`, tenantID: 2, want: true},
+		// the pseudo-label takes precedence over a label of the same name
+		{selector: `tenant == "9"`, tenantID: 2, want: false},
+		// global resources have no tenant
+		{selector: `tenant == "9"`, tenantID: 0, want: true},
+		{selector: `role == "web"`, tenantID: 0, want: true},
+	}
+	for _, tt := range tests {
+		sel, err := Parse(tt.selector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := sel.EvaluateLabels(WithTenant(labels, tt.tenantID)); got != tt.want {
+			t.Errorf("%s with tenant %d = %t, want %t", tt.selector, tt.tenantID, got, tt.want)
+		}
+	}
+}
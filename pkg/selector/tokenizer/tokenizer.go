@@ -31,6 +31,13 @@ const (
 	TokenAnd
 	TokenOr
 	TokenGlobal
+	TokenRegexMatch
+	TokenRegexNotMatch
+	TokenLt
+	TokenLe
+	TokenGt
+	TokenGe
+	TokenNumberLiteral
 	TokenEOF
 )
 
@@ -55,6 +62,7 @@ var (
 	notInRegex      = regexp.MustCompile(`^not\s*in\b`)
 	inRegex         = regexp.MustCompile(`^in\b`)
 	globalRegex     = regexp.MustCompile(`^global\(\s*\)`)
+	numberRegex     = regexp.MustCompile(`^[-+]?([0-9]+(\.[0-9]*)?|\.[0-9]+)`)
 )
 
 // Tokenize transform string to token slice
@@ -109,13 +117,35 @@ func Tokenize(s string) ([]Token, error) {
 			if len(s) > 1 && s[1] == '=' {
 				tokens = append(tokens, Token{Kind: TokenEq, Value: nil})
 				s = s[2:]
+			} else if len(s) > 1 && s[1] == '~' {
+				tokens = append(tokens, Token{Kind: TokenRegexMatch, Value: nil})
+				s = s[2:]
+			} else {
+				return nil, errors.New("expect == or =~")
+			}
+		case '<':
+			if len(s) > 1 && s[1] == '=' {
+				tokens = append(tokens, Token{Kind: TokenLe, Value: nil})
+				s = s[2:]
+			} else {
+				tokens = append(tokens, Token{Kind: TokenLt, Value: nil})
+				s = s[1:]
+			}
+		case '>':
+			if len(s) > 1 && s[1] == '=' {
+				tokens = append(tokens, Token{Kind: TokenGe, Value: nil})
+				s = s[2:]
 			} else {
-				return nil, errors.New("expect ==")
+				tokens = append(tokens, Token{Kind: TokenGt, Value: nil})
+				s = s[1:]
 			}
 		case '!':
 			if len(s) > 1 && s[1] == '=' {
 				tokens = append(tokens, Token{Kind: TokenNe, Value: nil})
 				s = s[2:]
+			} else if len(s) > 1 && s[1] == '~' {
+				tokens = append(tokens, Token{Kind: TokenRegexNotMatch, Value: nil})
+				s = s[2:]
 			} else {
 				tokens = append(tokens, Token{Kind: TokenNot, Value: nil})
 				s = s[1:]
@@ -136,7 +166,15 @@ func Tokenize(s string) ([]Token, error) {
 			}
 		default:
 			// Handle less-simple cases with regex matches. We're already stripped any whitespace
-			if lastTokenKind == TokenLabel {
+			if isNumericComparison(lastTokenKind) {
+				// After a numeric comparison, look for a number instead of a label
+				idxs := numberRegex.FindStringIndex(s)
+				if idxs == nil {
+					return nil, errors.New("expect number after numeric comparison")
+				}
+				tokens = append(tokens, Token{Kind: TokenNumberLiteral, Value: s[:idxs[1]]})
+				s = s[idxs[1]:]
+			} else if lastTokenKind == TokenLabel {
 				// IF we just saw a label, look for a contains/starts with/ends with operator instead if another label
 				if idxs := containsRegex.FindStringIndex(s); idxs != nil {
 					// "contains"
@@ -193,3 +231,7 @@ func Tokenize(s string) ([]Token, error) {
 	}
 	return tokens, nil
 }
+
+func isNumericComparison(kind tokenKind) bool {
+	return kind == TokenLt || kind == TokenLe || kind == TokenGt || kind == TokenGe
+}
@@ -0,0 +1,28 @@
+package tokenizer
+
+import (
+	"testing"
+)
+
+// FuzzTokenize checks that Tokenize never panics and always ends the tokens of a valid input with EOF.
+func FuzzTokenize(f *testing.F) {
+	for _, seed := range []string{
+		``,
+		`a == "x" && !has(b)`,
+		`a in {"x", 'y'} || b not in {}`,
+		`a contains "x" && a starts with "y" && a ends with "z"`,
+		`a =~ "x.*" && b !~ "y" && c < 1 && d >= -2.5`,
+		`(all() || global())`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		tokens, err := Tokenize(input)
+		if err != nil {
+			return
+		}
+		if len(tokens) == 0 || tokens[len(tokens)-1].Kind != TokenEOF {
+			t.Fatalf("Tokenize(%q) = %v, want tokens ending with EOF", input, tokens)
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -63,6 +64,16 @@ func (pm *PolicyDB) createIndexes() error {
 				Options: options.Index().SetUnique(true),
 			},
 		},
+		entity2.Tier{}.CollectionName(): {
+			{
+				Keys:    bson.D{{Key: "metadata.name", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "uuid", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
 		entity2.GlobalNetworkPolicy{}.CollectionName(): {
 			{
 				Keys:    bson.D{{Key: "metadata.name", Value: 1}},
@@ -76,6 +87,99 @@ func (pm *PolicyDB) createIndexes() error {
 				Options: options.Index().SetUnique(true),
 			},
 		},
+		entity2.NetworkPolicy{}.CollectionName(): {
+			{
+				Keys:    bson.D{{Key: "metadata.tenant_id", Value: 1}, {Key: "metadata.name", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "uuid", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+		entity2.NetworkSet{}.CollectionName(): {
+			{
+				Keys:    bson.D{{Key: "metadata.tenant_id", Value: 1}, {Key: "metadata.name", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "uuid", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+		entity2.Tenant{}.CollectionName(): {
+			{
+				Keys:    bson.D{{Key: "spec.tenant_id", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "metadata.name", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "uuid", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+		entity2.Agent{}.CollectionName(): {
+			{
+				Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "ip", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+		entity2.EnrollmentToken{}.CollectionName(): {
+			{
+				Keys:    bson.D{{Key: "metadata.name", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "spec.token", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "uuid", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+		entity2.Webhook{}.CollectionName(): {
+			{
+				Keys:    bson.D{{Key: "metadata.name", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "uuid", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+		entity2.AdmissionHook{}.CollectionName(): {
+			{
+				Keys:    bson.D{{Key: "metadata.name", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "uuid", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+		entity2.WebhookDelivery{}.CollectionName(): {
+			{
+				Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+			},
+			{
+				Keys: bson.D{{Key: "webhook_name", Value: 1}, {Key: "created_at", Value: -1}},
+			},
+			{
+				// the delivery log is kept for a week
+				Keys:    bson.D{{Key: "created_at", Value: 1}},
+				Options: options.Index().SetExpireAfterSeconds(int32((7 * 24 * time.Hour).Seconds())),
+			},
+		},
+		entity2.StagedPolicyReport{}.CollectionName(): {
+			{
+				Keys:    bson.D{{Key: "gnp_uuid", Value: 1}, {Key: "tenant_id", Value: 1}, {Key: "ip", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
 	}
 	for collectName, indexes := range indexMap {
 		_, err := pm.Database.Collection(collectName).Indexes().CreateMany(context.TODO(), indexes)
@@ -0,0 +1,291 @@
+package validator
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/bamboo-firewall/be/pkg/entity"
+	"github.com/bamboo-firewall/be/pkg/net"
+	"github.com/bamboo-firewall/be/pkg/selector"
+)
+
+type LintSeverity string
+
+const (
+	LintSeverityError LintSeverity = "error"
+	LintSeverityWarn  LintSeverity = "warn"
+	LintSeverityOff   LintSeverity = "off"
+)
+
+const (
+	LintRuleAnyToAnyAllow     = "any-to-any-allow"
+	LintRuleAllowWithoutPorts = "allow-without-ports"
+	LintRuleDenyAfterAllowAll = "deny-after-allow-all"
+	LintRuleMissingDesc       = "missing-description"
+	LintRuleMissingOwner      = "missing-owner-label"
+	LintRuleBroadNet          = "broad-net"
+	LintRuleUnusedGNS         = "unused-gns"
+)
+
+// LintOwnerLabel is the label naming the owner of a policy or a set.
+const LintOwnerLabel = "owner"
+
+// broadNetMaxBits is the longest prefix length of a net considered overly broad, /8 for ipv4 and /32 for ipv6.
+const (
+	broadNetMaxBitsV4 = 8
+	broadNetMaxBitsV6 = 32
+)
+
+// DefaultLintSeverities are the severities of the rules not configured otherwise.
+var DefaultLintSeverities = map[string]LintSeverity{
+	LintRuleAnyToAnyAllow:     LintSeverityWarn,
+	LintRuleAllowWithoutPorts: LintSeverityWarn,
+	LintRuleDenyAfterAllowAll: LintSeverityWarn,
+	LintRuleMissingDesc:       LintSeverityWarn,
+	LintRuleMissingOwner:      LintSeverityWarn,
+	LintRuleBroadNet:          LintSeverityWarn,
+	LintRuleUnusedGNS:         LintSeverityWarn,
+}
+
+// LintFinding is a rule broken by a policy or a set. Field is the path of the offending field in the resource file.
+type LintFinding struct {
+	Rule     string       `json:"rule"`
+	Severity LintSeverity `json:"severity"`
+	FilePath string       `json:"filePath,omitempty"`
+	Field    string       `json:"field"`
+	Message  string       `json:"message"`
+}
+
+func (f *LintFinding) String() string {
+	location := f.Field
+	if f.FilePath != "" {
+		location = fmt.Sprintf("%s: %s", f.FilePath, f.Field)
+	}
+	return fmt.Sprintf("%s: %s [%s] %s", location, f.Severity, f.Rule, f.Message)
+}
+
+// HasLintError reports whether one of findings has the error severity.
+func HasLintError(findings []*LintFinding) bool {
+	return slices.ContainsFunc(findings, func(f *LintFinding) bool {
+		return f.Severity == LintSeverityError
+	})
+}
+
+// ParseLintSeverities parses a comma separated list of rule=severity, e.g. "broad-net=error,unused-gns=off".
+func ParseLintSeverities(s string) (map[string]LintSeverity, error) {
+	severities := make(map[string]LintSeverity)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		rule, level, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid lint severity %q, expected rule=severity", item)
+		}
+		rule = strings.TrimSpace(rule)
+		if _, ok = DefaultLintSeverities[rule]; !ok {
+			return nil, fmt.Errorf("unknown lint rule %q", rule)
+		}
+		severity := LintSeverity(strings.TrimSpace(level))
+		if !slices.Contains([]LintSeverity{LintSeverityError, LintSeverityWarn, LintSeverityOff}, severity) {
+			return nil, fmt.Errorf("invalid severity %q of lint rule %s, expected error, warn or off", severity, rule)
+		}
+		severities[rule] = severity
+	}
+	return severities, nil
+}
+
+// Linter checks policies and sets against the built-in lint rules.
+type Linter struct {
+	severities map[string]LintSeverity
+}
+
+// NewLinter returns a linter with severities overriding DefaultLintSeverities.
+func NewLinter(severities map[string]LintSeverity) *Linter {
+	merged := make(map[string]LintSeverity, len(DefaultLintSeverities))
+	for rule, severity := range DefaultLintSeverities {
+		merged[rule] = severity
+	}
+	for rule, severity := range severities {
+		merged[rule] = severity
+	}
+	return &Linter{severities: merged}
+}
+
+type lintReport struct {
+	linter   *Linter
+	filePath string
+	findings []*LintFinding
+}
+
+func (r *lintReport) add(rule, field, message string) {
+	severity := r.linter.severities[rule]
+	if severity == LintSeverityOff {
+		return
+	}
+	r.findings = append(r.findings, &LintFinding{
+		Rule:     rule,
+		Severity: severity,
+		FilePath: r.filePath,
+		Field:    field,
+		Message:  message,
+	})
+}
+
+func (r *lintReport) checkMetadata(description string, labels map[string]string) {
+	if strings.TrimSpace(description) == "" {
+		r.add(LintRuleMissingDesc, "description", "description is empty")
+	}
+	if labels[LintOwnerLabel] == "" {
+		r.add(LintRuleMissingOwner, "metadata.labels."+LintOwnerLabel, fmt.Sprintf("label %s is not set", LintOwnerLabel))
+	}
+}
+
+func (r *lintReport) checkNets(field string, nets []string) {
+	for i, n := range nets {
+		prefix, err := net.ParsePrefix(n)
+		if err != nil {
+			continue
+		}
+		maxBits := broadNetMaxBitsV4
+		if prefix.Addr().Is6() {
+			maxBits = broadNetMaxBitsV6
+		}
+		if prefix.Bits() <= maxBits {
+			r.add(LintRuleBroadNet, fmt.Sprintf("%s[%d]", field, i), fmt.Sprintf("net %s is /%d or wider", n, maxBits))
+		}
+	}
+}
+
+// LintPolicy checks a global network policy or a network policy.
+func (l *Linter) LintPolicy(policy *entity.GlobalNetworkPolicy) []*LintFinding {
+	r := &lintReport{linter: l, filePath: policy.FilePath}
+	r.checkMetadata(policy.Description, policy.Metadata.Labels)
+	r.checkRules("spec.ingress", policy.Spec.Ingress)
+	r.checkRules("spec.egress", policy.Spec.Egress)
+	return r.findings
+}
+
+func (r *lintReport) checkRules(field string, rules []entity.GNPSpecRule) {
+	allowAll := -1
+	for i, rule := range rules {
+		ruleField := fmt.Sprintf("%s[%d]", field, i)
+		if rule.Source != nil {
+			r.checkNets(ruleField+".source.nets", rule.Source.Nets)
+		}
+		if rule.Destination != nil {
+			r.checkNets(ruleField+".destination.nets", rule.Destination.Nets)
+		}
+
+		switch entity.RuleAction(rule.Action) {
+		case entity.RuleActionAllow:
+			if rule.Protocol == nil && isAnyRuleEntity(rule.Source) && isAnyRuleEntity(rule.Destination) {
+				r.add(LintRuleAnyToAnyAllow, ruleField, "rule allows any traffic from any source to any destination")
+			}
+			if isPortProtocol(rule.Protocol) && (rule.Destination == nil ||
+				len(rule.Destination.Ports) == 0 && len(rule.Destination.NotPorts) == 0) {
+				r.add(LintRuleAllowWithoutPorts, ruleField+".destination.ports",
+					fmt.Sprintf("rule allows %v without destination ports", rule.Protocol))
+			}
+			if allowAll < 0 && isCatchAllRule(rule) {
+				allowAll = i
+			}
+		case entity.RuleActionDeny:
+			if allowAll >= 0 {
+				r.add(LintRuleDenyAfterAllowAll, ruleField,
+					fmt.Sprintf("deny rule never matches, %s[%d] allows all traffic before it", field, allowAll))
+			}
+		}
+	}
+}
+
+// isCatchAllRule reports whether rule matches any traffic at any time.
+func isCatchAllRule(rule entity.GNPSpecRule) bool {
+	return rule.Protocol == nil && rule.NotProtocol == nil && rule.IPVersion == nil && rule.ICMP == nil &&
+		rule.NotICMP == nil && rule.Schedule.IsZero() && isAnyRuleEntity(rule.Source) && isAnyRuleEntity(rule.Destination)
+}
+
+// isAnyRuleEntity reports whether the source or destination of a rule matches any address and port.
+func isAnyRuleEntity(ruleEntity *entity.GNPSpecRuleEntity) bool {
+	if ruleEntity == nil {
+		return true
+	}
+	if ruleEntity.Selector != "" || len(ruleEntity.NotNets) > 0 || len(ruleEntity.Ports) > 0 || len(ruleEntity.NotPorts) > 0 {
+		return false
+	}
+	for _, n := range ruleEntity.Nets {
+		if prefix, err := net.ParsePrefix(n); err != nil || prefix.Bits() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isPortProtocol reports whether protocol is tcp or udp, by name or number. A number outside 0-255 is no protocol.
+func isPortProtocol(protocol interface{}) bool {
+	var number float64
+	switch p := protocol.(type) {
+	case string:
+		return slices.Contains([]string{entity.ProtocolTCP, entity.ProtocolUDP}, strings.ToLower(p))
+	case float64:
+		number = p
+	case int:
+		number = float64(p)
+	case int32:
+		number = float64(p)
+	case int64:
+		number = float64(p)
+	default:
+		return false
+	}
+	protocolNum, ok := entity.ProtocolNumber(number)
+	return ok && (protocolNum == entity.ProtocolNumTCP || protocolNum == entity.ProtocolNumUDP)
+}
+
+// LintSet checks a global network set or a network set. policies are the policies the set may be selected by; a set
+// matched by no rule selector of a policy in its scope is unused.
+func (l *Linter) LintSet(set *entity.GlobalNetworkSet, policies []*entity.GlobalNetworkPolicy) []*LintFinding {
+	r := &lintReport{linter: l, filePath: set.FilePath}
+	r.checkMetadata(set.Description, set.Metadata.Labels)
+	r.checkNets("spec.nets", set.Spec.Nets)
+	if l.severities[LintRuleUnusedGNS] != LintSeverityOff && !isSetSelected(set, policies) {
+		r.add(LintRuleUnusedGNS, "metadata.labels", "set is matched by no rule selector of any policy")
+	}
+	return r.findings
+}
+
+func isSetSelected(set *entity.GlobalNetworkSet, policies []*entity.GlobalNetworkPolicy) bool {
+	labels := selector.WithTenant(set.Metadata.Labels, set.Metadata.TenantID)
+	var matches = func(ruleEntity *entity.GNPSpecRuleEntity) bool {
+		if ruleEntity == nil || ruleEntity.Selector == "" {
+			return false
+		}
+		sel, err := selector.Parse(ruleEntity.Selector)
+		return err == nil && sel.EvaluateLabels(labels)
+	}
+	for _, policy := range policies {
+		// a network set is only selected by the policies of its tenant
+		if set.Metadata.TenantID != 0 && set.Metadata.TenantID != policy.Metadata.TenantID {
+			continue
+		}
+		for _, rule := range slices.Concat(policy.Spec.Ingress, policy.Spec.Egress) {
+			if matches(rule.Source) || matches(rule.Destination) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// LintRules returns the names of the lint rules, sorted.
+func LintRules() []string {
+	rules := make([]string, 0, len(DefaultLintSeverities))
+	for rule := range DefaultLintSeverities {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+	return rules
+}
@@ -0,0 +1,303 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bamboo-firewall/be/pkg/entity"
+)
+
+// lintedPolicy returns a policy with a description and an owner, breaking no rule but the ones of its rules.
+func lintedPolicy(ingress ...entity.GNPSpecRule) *entity.GlobalNetworkPolicy {
+	return &entity.GlobalNetworkPolicy{
+		Metadata:    entity.GNPMetadata{Name: "web", Labels: map[string]string{LintOwnerLabel: "team"}},
+		Spec:        entity.GNPSpec{Ingress: ingress},
+		Description: "web traffic",
+	}
+}
+
+// lintedSet returns a set with a description and an owner selected by policies, breaking no rule but the ones of
+// its nets.
+func lintedSet(nets ...string) *entity.GlobalNetworkSet {
+	return &entity.GlobalNetworkSet{
+		Metadata:    entity.GNSMetadata{Name: "lb", Labels: map[string]string{LintOwnerLabel: "team", "role": "lb"}},
+		Spec:        entity.GNSSpec{Nets: nets},
+		Description: "load balancers",
+	}
+}
+
+// selectingPolicy returns a policy whose rule source selects sel.
+func selectingPolicy(sel string) *entity.GlobalNetworkPolicy {
+	return lintedPolicy(entity.GNPSpecRule{
+		Action:      string(entity.RuleActionAllow),
+		Protocol:    entity.ProtocolTCP,
+		Source:      &entity.GNPSpecRuleEntity{Selector: sel},
+		Destination: &entity.GNPSpecRuleEntity{Ports: []interface{}{float64(443)}},
+	})
+}
+
+func allowRule(protocol interface{}, source, destination *entity.GNPSpecRuleEntity) entity.GNPSpecRule {
+	return entity.GNPSpecRule{
+		Action:      string(entity.RuleActionAllow),
+		Protocol:    protocol,
+		Source:      source,
+		Destination: destination,
+	}
+}
+
+// ruleFindings returns the rule and field of each finding.
+func ruleFindings(findings []*LintFinding) [][2]string {
+	got := make([][2]string, 0, len(findings))
+	for _, finding := range findings {
+		got = append(got, [2]string{finding.Rule, finding.Field})
+	}
+	return got
+}
+
+func TestLintPolicy(t *testing.T) {
+	web := &entity.GNPSpecRuleEntity{Selector: "app == 'web'"}
+	https := &entity.GNPSpecRuleEntity{Ports: []interface{}{float64(443)}}
+	tests := []struct {
+		name   string
+		policy *entity.GlobalNetworkPolicy
+		want   [][2]string
+	}{
+		{
+			name:   "clean",
+			policy: lintedPolicy(allowRule(entity.ProtocolTCP, web, https)),
+			want:   [][2]string{},
+		},
+		{
+			name: "missing description and owner",
+			policy: &entity.GlobalNetworkPolicy{
+				Spec: entity.GNPSpec{Ingress: []entity.GNPSpecRule{allowRule(entity.ProtocolTCP, web, https)}},
+			},
+			want: [][2]string{
+				{LintRuleMissingDesc, "description"},
+				{LintRuleMissingOwner, "metadata.labels.owner"},
+			},
+		},
+		{
+			name:   "any to any allow",
+			policy: lintedPolicy(allowRule(nil, nil, &entity.GNPSpecRuleEntity{Nets: []string{"0.0.0.0/0"}})),
+			want: [][2]string{
+				{LintRuleBroadNet, "spec.ingress[0].destination.nets[0]"},
+				{LintRuleAnyToAnyAllow, "spec.ingress[0]"},
+			},
+		},
+		{
+			name:   "allow tcp without ports",
+			policy: lintedPolicy(allowRule("TCP", web, nil)),
+			want:   [][2]string{{LintRuleAllowWithoutPorts, "spec.ingress[0].destination.ports"}},
+		},
+		{
+			name:   "allow udp number without ports",
+			policy: lintedPolicy(allowRule(float64(entity.ProtocolNumUDP), web, &entity.GNPSpecRuleEntity{})),
+			want:   [][2]string{{LintRuleAllowWithoutPorts, "spec.ingress[0].destination.ports"}},
+		},
+		{
+			name:   "allow tcp int without ports",
+			policy: lintedPolicy(allowRule(entity.ProtocolNumTCP, web, nil)),
+			want:   [][2]string{{LintRuleAllowWithoutPorts, "spec.ingress[0].destination.ports"}},
+		},
+		{
+			name:   "allow tcp with not ports",
+			policy: lintedPolicy(allowRule(entity.ProtocolTCP, web, &entity.GNPSpecRuleEntity{NotPorts: []interface{}{float64(22)}})),
+			want:   [][2]string{},
+		},
+		{
+			name:   "protocol number wrapping to tcp",
+			policy: lintedPolicy(allowRule(float64(256+entity.ProtocolNumTCP), web, nil)),
+			want:   [][2]string{},
+		},
+		{
+			name:   "negative protocol number wrapping to udp",
+			policy: lintedPolicy(allowRule(int64(entity.ProtocolNumUDP-256), web, nil)),
+			want:   [][2]string{},
+		},
+		{
+			name:   "fractional protocol number",
+			policy: lintedPolicy(allowRule(6.5, web, nil)),
+			want:   [][2]string{},
+		},
+		{
+			name: "deny after allow all",
+			policy: lintedPolicy(
+				allowRule(nil, nil, nil),
+				entity.GNPSpecRule{Action: string(entity.RuleActionDeny), Source: web},
+			),
+			want: [][2]string{
+				{LintRuleAnyToAnyAllow, "spec.ingress[0]"},
+				{LintRuleDenyAfterAllowAll, "spec.ingress[1]"},
+			},
+		},
+		{
+			name: "deny before allow all",
+			policy: lintedPolicy(
+				entity.GNPSpecRule{Action: string(entity.RuleActionDeny), Source: web},
+				allowRule(nil, nil, nil),
+			),
+			want: [][2]string{{LintRuleAnyToAnyAllow, "spec.ingress[1]"}},
+		},
+		{
+			name: "deny after scheduled allow all",
+			policy: lintedPolicy(
+				entity.GNPSpecRule{
+					Action:   string(entity.RuleActionAllow),
+					Schedule: entity.Schedule{Windows: []entity.ScheduleWindow{{Cron: "0 9 * * 1-5", Duration: "8h"}}},
+				},
+				entity.GNPSpecRule{Action: string(entity.RuleActionDeny), Source: web},
+			),
+			want: [][2]string{{LintRuleAnyToAnyAllow, "spec.ingress[0]"}},
+		},
+		{
+			name: "broad nets",
+			policy: lintedPolicy(allowRule(entity.ProtocolTCP,
+				&entity.GNPSpecRuleEntity{Nets: []string{"10.0.0.0/16", "10.0.0.0/8", "2001:db8::/32", "2001:db8::/48"}},
+				https,
+			)),
+			want: [][2]string{
+				{LintRuleBroadNet, "spec.ingress[0].source.nets[1]"},
+				{LintRuleBroadNet, "spec.ingress[0].source.nets[2]"},
+			},
+		},
+	}
+	linter := NewLinter(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ruleFindings(linter.LintPolicy(tt.policy))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("LintPolicy() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLintSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		set      *entity.GlobalNetworkSet
+		policies []*entity.GlobalNetworkPolicy
+		want     [][2]string
+	}{
+		{
+			name:     "clean",
+			set:      lintedSet("192.168.0.0/24"),
+			policies: []*entity.GlobalNetworkPolicy{selectingPolicy("role == 'lb'")},
+			want:     [][2]string{},
+		},
+		{
+			name:     "broad net",
+			set:      lintedSet("192.168.0.0/24", "0.0.0.0/0"),
+			policies: []*entity.GlobalNetworkPolicy{selectingPolicy("role == 'lb'")},
+			want:     [][2]string{{LintRuleBroadNet, "spec.nets[1]"}},
+		},
+		{
+			name:     "unused",
+			set:      lintedSet("192.168.0.0/24"),
+			policies: []*entity.GlobalNetworkPolicy{selectingPolicy("role == 'db'")},
+			want:     [][2]string{{LintRuleUnusedGNS, "metadata.labels"}},
+		},
+		{
+			name: "network set selected by a policy of another tenant",
+			set: func() *entity.GlobalNetworkSet {
+				set := lintedSet("192.168.0.0/24")
+				set.Metadata.TenantID = 2
+				return set
+			}(),
+			policies: []*entity.GlobalNetworkPolicy{func() *entity.GlobalNetworkPolicy {
+				policy := selectingPolicy("role == 'lb'")
+				policy.Metadata.TenantID = 3
+				return policy
+			}()},
+			want: [][2]string{{LintRuleUnusedGNS, "metadata.labels"}},
+		},
+	}
+	linter := NewLinter(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ruleFindings(linter.LintSet(tt.set, tt.policies))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("LintSet() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLintSeverityOverride(t *testing.T) {
+	unusedSet := &entity.GlobalNetworkSet{Spec: entity.GNSSpec{Nets: []string{"0.0.0.0/0"}}}
+	tests := []struct {
+		name       string
+		severities string
+		// want are the severities of the findings by rule
+		want         map[string]LintSeverity
+		wantHasError bool
+	}{
+		{
+			name:       "defaults",
+			severities: "",
+			want: map[string]LintSeverity{
+				LintRuleMissingDesc:  LintSeverityWarn,
+				LintRuleMissingOwner: LintSeverityWarn,
+				LintRuleBroadNet:     LintSeverityWarn,
+				LintRuleUnusedGNS:    LintSeverityWarn,
+			},
+		},
+		{
+			name:       "error",
+			severities: "broad-net=error",
+			want: map[string]LintSeverity{
+				LintRuleMissingDesc:  LintSeverityWarn,
+				LintRuleMissingOwner: LintSeverityWarn,
+				LintRuleBroadNet:     LintSeverityError,
+				LintRuleUnusedGNS:    LintSeverityWarn,
+			},
+			wantHasError: true,
+		},
+		{
+			name:       "off",
+			severities: " missing-description=off , unused-gns=off,missing-owner-label=off",
+			want: map[string]LintSeverity{
+				LintRuleBroadNet: LintSeverityWarn,
+			},
+		},
+		{
+			name:       "all off",
+			severities: "missing-description=off,missing-owner-label=off,broad-net=off,unused-gns=off",
+			want:       map[string]LintSeverity{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			severities, err := ParseLintSeverities(tt.severities)
+			if err != nil {
+				t.Fatalf("ParseLintSeverities(%q) error: %v", tt.severities, err)
+			}
+			findings := NewLinter(severities).LintSet(unusedSet, nil)
+			got := make(map[string]LintSeverity, len(findings))
+			for _, finding := range findings {
+				got[finding.Rule] = finding.Severity
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("finding severities mismatch (-want +got):\n%s", diff)
+			}
+			if HasLintError(findings) != tt.wantHasError {
+				t.Errorf("HasLintError() = %v, want %v", HasLintError(findings), tt.wantHasError)
+			}
+		})
+	}
+}
+
+func TestParseLintSeveritiesInvalid(t *testing.T) {
+	for _, s := range []string{
+		"broad-net",
+		"unknown-rule=warn",
+		"broad-net=fatal",
+		"broad-net=",
+	} {
+		if _, err := ParseLintSeverities(s); err == nil {
+			t.Errorf("ParseLintSeverities(%q) succeeded, want error", s)
+		}
+	}
+}
@@ -3,10 +3,14 @@ package validator
 import (
 	"fmt"
 	"log/slog"
+	"net/netip"
+	"net/url"
+	"path/filepath"
 	"regexp"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 
@@ -14,6 +18,8 @@ import (
 	"github.com/bamboo-firewall/be/pkg/entity"
 	"github.com/bamboo-firewall/be/pkg/httpbase"
 	"github.com/bamboo-firewall/be/pkg/net"
+	"github.com/bamboo-firewall/be/pkg/netsource"
+	"github.com/bamboo-firewall/be/pkg/schedule"
 	"github.com/bamboo-firewall/be/pkg/selector"
 )
 
@@ -33,6 +39,7 @@ func Init() {
 	registerValidator("name", validateName)
 	registerValidator("selector", validateSelector)
 	registerValidator("action", validateAction)
+	registerValidator("tier_action", validateTierAction)
 	registerValidator("ip_version", validateIPVersion)
 	registerValidator("protocol", validateProtocol)
 	registerValidator("port", validatePort)
@@ -40,11 +47,21 @@ func Init() {
 	registerValidator("net", validateIPNetwork)
 	registerValidator("cidr", validateCIDR)
 	registerValidator("ip", validateIP)
+	registerValidator("domain", validateDomain)
+	registerValidator("cron", validateCron)
+	registerValidator("duration", validateDuration)
+	registerValidator("webhook_kind", validateWebhookKind)
+	registerValidator("webhook_action", validateWebhookAction)
+	registerValidator("admission_kind", validateAdmissionKind)
+	registerValidator("admission_operation", validateAdmissionOperation)
 
 	registerStructValidation(validateGNPSpecInput, dto.GNPSpecInput{})
 	registerStructValidation(validateGNPSpecRuleInput, dto.GNPSpecRuleInput{})
 	registerStructValidation(validateGNPSpecRuleEntityInput, dto.GNPSpecRuleEntityInput{})
 	registerStructValidation(validateGNSSpecInput, dto.GNSSpecInput{})
+	registerStructValidation(validateGNSSourceInput, dto.GNSSourceInput{})
+	registerStructValidation(validateScheduleInput, dto.ScheduleInput{})
+	registerStructValidation(validateExplainSelectorInput, dto.ExplainSelectorInput{})
 }
 
 var nameRegex = regexp.MustCompile(`^[-a-zA-Z0-9_\\.]+$`)
@@ -70,6 +87,30 @@ func validateAction(fl validator.FieldLevel) bool {
 	)
 }
 
+func validateTierAction(fl validator.FieldLevel) bool {
+	action := fl.Field().Interface().(string)
+	return slices.Contains(
+		[]entity.RuleAction{entity.RuleActionAllow, entity.RuleActionDeny, entity.RuleActionPass},
+		entity.RuleAction(strings.ToLower(action)),
+	)
+}
+
+func validateWebhookKind(fl validator.FieldLevel) bool {
+	return slices.Contains(entity.WebhookKinds, fl.Field().String())
+}
+
+func validateWebhookAction(fl validator.FieldLevel) bool {
+	return slices.Contains(entity.WebhookActions, fl.Field().String())
+}
+
+func validateAdmissionKind(fl validator.FieldLevel) bool {
+	return slices.Contains(entity.AdmissionKinds, fl.Field().String())
+}
+
+func validateAdmissionOperation(fl validator.FieldLevel) bool {
+	return slices.Contains(entity.AdmissionOperations, fl.Field().String())
+}
+
 func validateIPVersion(fl validator.FieldLevel) bool {
 	ipVersion := fl.Field().Interface().(int)
 	return slices.Contains([]int{entity.IPVersion4, entity.IPVersion6}, ipVersion)
@@ -79,10 +120,10 @@ func validateProtocol(fl validator.FieldLevel) bool {
 	protocol := fl.Field().Interface()
 	switch protocol.(type) {
 	case string:
-		return slices.Contains([]string{entity.ProtocolTCP, entity.ProtocolUDP, entity.ProtocolICMP, entity.ProtocolSCTP, entity.ProtocolUDPLite}, strings.ToLower(protocol.(string)))
+		return slices.Contains([]string{entity.ProtocolTCP, entity.ProtocolUDP, entity.ProtocolICMP, entity.ProtocolICMPv6, entity.ProtocolSCTP, entity.ProtocolUDPLite}, strings.ToLower(protocol.(string)))
 	case float64:
-		protocolNum := uint8(protocol.(float64))
-		return protocolNum != 0
+		protocolNum, ok := entity.ProtocolNumber(protocol.(float64))
+		return ok && protocolNum != 0
 	default:
 		return false
 	}
@@ -107,6 +148,14 @@ func validateIP(fl validator.FieldLevel) bool {
 	return net.ParseIP(fl.Field().String()) != nil
 }
 
+// domainRegex matches a host name, optionally prefixed by *. to match any subdomain.
+var domainRegex = regexp.MustCompile(`^(\*\.)?([a-zA-Z0-9]([-a-zA-Z0-9]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z]([-a-zA-Z0-9]{0,61}[a-zA-Z0-9])?\.?$`)
+
+func validateDomain(fl validator.FieldLevel) bool {
+	domain := fl.Field().String()
+	return len(domain) <= 253 && domainRegex.MatchString(domain)
+}
+
 var portRangeRegex = regexp.MustCompile(`^(\d+):(\d+)$`)
 
 const (
@@ -176,6 +225,24 @@ func validateGNPSpecRuleInput(sl validator.StructLevel) {
 		}
 	}
 
+	icmpVersion := entity.ICMPVersionOfProtocol(input.Protocol)
+	if input.ICMP != nil || input.NotICMP != nil {
+		if entity.ICMPVersionOfProtocol(input.NotProtocol) > 0 {
+			sl.ReportError(input.NotProtocol, "notProtocol", "NotProtocol", "icmp cannot be matched when notProtocol is icmp or icmpv6", "")
+		} else if icmpVersion == 0 {
+			sl.ReportError(input.ICMP, "icmp", "ICMP", "icmp requires protocol icmp or icmpv6", "")
+		}
+		if input.ICMP != nil && input.ICMP.Code != nil && input.ICMP.Type == nil {
+			sl.ReportError(input.ICMP.Code, "icmp.code", "ICMP.Code", "icmp code requires type", "")
+		}
+		if input.NotICMP != nil && input.NotICMP.Code != nil && input.NotICMP.Type == nil {
+			sl.ReportError(input.NotICMP.Code, "notICMP.code", "NotICMP.Code", "icmp code requires type", "")
+		}
+	}
+	if icmpVersion > 0 && input.IPVersion != nil && *input.IPVersion != icmpVersion {
+		sl.ReportError(input.IPVersion, "ipVersion", "IPVersion", "not match with icmp protocol", "")
+	}
+
 	var (
 		seenV4, seenV6 bool
 	)
@@ -214,6 +281,9 @@ func validateGNPSpecRuleInput(sl validator.StructLevel) {
 		scanNets(input.Destination.Nets, "Destination.Nets")
 		scanNets(input.Destination.NotNets, "Destination.NotNets")
 	}
+	if icmpVersion == entity.IPVersion4 && seenV6 || icmpVersion == entity.IPVersion6 && seenV4 {
+		sl.ReportError(input.Protocol, "protocol", "Protocol", "icmp protocol not match with nets", "")
+	}
 }
 
 func isProtocolSupportPort(protocol interface{}) bool {
@@ -221,7 +291,7 @@ func isProtocolSupportPort(protocol interface{}) bool {
 	case string:
 		return slices.Contains([]string{entity.ProtocolTCP, entity.ProtocolUDP, entity.ProtocolSCTP}, strings.ToLower(protocol.(string)))
 	case float64:
-		protocolNum := uint8(protocol.(float64))
+		protocolNum, _ := entity.ProtocolNumber(protocol.(float64))
 		return protocolNum == entity.ProtocolNumTCP || protocolNum == entity.ProtocolNumUDP || protocolNum == entity.ProtocolNumSCTP
 	default:
 		return false
@@ -233,13 +303,35 @@ func validateGNPSpecRuleEntityInput(sl validator.StructLevel) {
 	if len(input.Nets) > 0 && len(input.NotNets) > 0 {
 		sl.ReportError(input.NotNets, "notNets", "NotNets", "cannot use notNets with nets", "")
 	}
-	if len(input.NotPorts) > 0 && len(input.NotPorts) > 0 {
+	if len(input.Ports) > 0 && len(input.NotPorts) > 0 {
 		sl.ReportError(input.NotPorts, "notPorts", "NotPorts", "cannot use notPorts with ports", "")
 	}
 }
 
+// NetOverlapWarnings describes the nets of a set that overlap each other. Overlaps are allowed, the nets of a set are
+// aggregated, but they are likely a mistake. Malformed nets are left to the validators.
+func NetOverlapWarnings(nets []string) []string {
+	prefixes := make([]netip.Prefix, 0, len(nets))
+	for _, netString := range nets {
+		if prefix, err := net.ParsePrefix(netString); err == nil {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	var warnings []string
+	for _, overlap := range net.FindOverlaps(prefixes) {
+		warnings = append(warnings, fmt.Sprintf("net %s overlaps with %s", overlap.Other, overlap.Prefix))
+	}
+	return warnings
+}
+
 func validateGNSSpecInput(sl validator.StructLevel) {
 	input := sl.Current().Interface().(dto.GNSSpecInput)
+	if input.Source == nil && len(input.Nets) == 0 && len(input.AllowedEgressDomains) == 0 {
+		sl.ReportError(input.Nets, "nets", "Nets", "require nets, source or allowedEgressDomains", "")
+	}
+	if input.Source != nil && len(input.Nets) > 0 {
+		sl.ReportError(input.Nets, "nets", "Nets", "cannot use nets with source", "")
+	}
 	cidrMap := make(map[string]struct{})
 	for i, netString := range input.Nets {
 		ip, ipnet, err := net.ParseCIDROrIP(netString)
@@ -260,3 +352,54 @@ func validateGNSSpecInput(sl validator.StructLevel) {
 		}
 	}
 }
+
+func validateGNSSourceInput(sl validator.StructLevel) {
+	input := sl.Current().Interface().(dto.GNSSourceInput)
+	if (input.URL == "") == (input.File == "") {
+		sl.ReportError(input.URL, "url", "URL", "require exactly one of url or file", "")
+	}
+	if input.URL != "" {
+		if sourceURL, err := url.Parse(input.URL); err == nil && sourceURL.Scheme != "http" && sourceURL.Scheme != "https" {
+			sl.ReportError(input.URL, "url", "URL", "url scheme must be http or https", "")
+		}
+	}
+	if input.File != "" && !filepath.IsLocal(input.File) {
+		sl.ReportError(input.File, "file", "File", "file must be relative to the source file directory", "")
+	}
+	if input.Format == entity.GNSSourceFormatJSON {
+		if input.JSONPath == "" {
+			sl.ReportError(input.JSONPath, "jsonPath", "JSONPath", "json format requires jsonPath", "")
+		} else if err := netsource.CheckJSONPath(input.JSONPath); err != nil {
+			sl.ReportError(input.JSONPath, "jsonPath", "JSONPath", "jsonPath is invalid", "")
+		}
+	} else if input.JSONPath != "" {
+		sl.ReportError(input.JSONPath, "jsonPath", "JSONPath", "jsonPath requires json format", "")
+	}
+}
+
+func validateCron(fl validator.FieldLevel) bool {
+	_, err := schedule.ParseCron(fl.Field().String())
+	return err == nil
+}
+
+func validateDuration(fl validator.FieldLevel) bool {
+	d, err := time.ParseDuration(fl.Field().String())
+	return err == nil && d > 0
+}
+
+func validateScheduleInput(sl validator.StructLevel) {
+	input := sl.Current().Interface().(dto.ScheduleInput)
+	if input.ActiveFrom != nil && input.ActiveUntil != nil && !input.ActiveUntil.After(*input.ActiveFrom) {
+		sl.ReportError(input.ActiveUntil, "activeUntil", "ActiveUntil", "activeUntil must be after activeFrom", "")
+	}
+}
+
+func validateExplainSelectorInput(sl validator.StructLevel) {
+	input := sl.Current().Interface().(dto.ExplainSelectorInput)
+	if input.HEP == nil && input.Labels == nil {
+		sl.ReportError(input.Labels, "labels", "Labels", "require hep or labels", "")
+	}
+	if input.HEP != nil && input.Labels != nil {
+		sl.ReportError(input.Labels, "labels", "Labels", "cannot use labels with hep", "")
+	}
+}
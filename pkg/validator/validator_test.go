@@ -0,0 +1,218 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bamboo-firewall/be/api/v1/dto"
+	"github.com/bamboo-firewall/be/pkg/httpbase"
+)
+
+func TestMain(m *testing.M) {
+	Init()
+	os.Exit(m.Run())
+}
+
+// validationTags validates input with the registered validators and returns the tags of the failed validations.
+func validationTags(t *testing.T, input interface{}) []string {
+	t.Helper()
+	ierr := httpbase.ValidateStruct(context.Background(), input)
+	if ierr == nil {
+		return nil
+	}
+	data, err := json.Marshal(ierr.Detail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var details []struct {
+		Tag string `json:"tag"`
+	}
+	if err = json.Unmarshal(data, &details); err != nil {
+		t.Fatalf("unexpected validation detail %s: %v", data, err)
+	}
+	tags := make([]string, 0, len(details))
+	for _, detail := range details {
+		tags = append(tags, detail.Tag)
+	}
+	return tags
+}
+
+// checkTags fails unless tags has a tag containing each of want, or no tag if want is empty.
+func checkTags(t *testing.T, tags []string, want ...string) {
+	t.Helper()
+	if len(want) == 0 && len(tags) > 0 {
+		t.Errorf("validation failed with %v, want none", tags)
+	}
+	for _, w := range want {
+		if !slices.ContainsFunc(tags, func(tag string) bool { return strings.Contains(tag, w) }) {
+			t.Errorf("validation failed with %v, want %q", tags, w)
+		}
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestValidateGNPSpecRuleEntityInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input dto.GNPSpecRuleEntityInput
+		want  []string
+	}{
+		{name: "ports", input: dto.GNPSpecRuleEntityInput{Ports: []interface{}{float64(80)}}},
+		{name: "not ports", input: dto.GNPSpecRuleEntityInput{NotPorts: []interface{}{float64(80)}}},
+		{
+			name:  "ports with not ports",
+			input: dto.GNPSpecRuleEntityInput{Ports: []interface{}{float64(80)}, NotPorts: []interface{}{float64(443)}},
+			want:  []string{"cannot use notPorts with ports"},
+		},
+		{
+			name:  "nets with not nets",
+			input: dto.GNPSpecRuleEntityInput{Nets: []string{"10.0.0.0/8"}, NotNets: []string{"10.1.0.0/16"}},
+			want:  []string{"cannot use notNets with nets"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTags(t, validationTags(t, tt.input), tt.want...)
+		})
+	}
+}
+
+func TestValidateGNPSpecRuleInputICMP(t *testing.T) {
+	allow := "allow"
+	tests := []struct {
+		name  string
+		input dto.GNPSpecRuleInput
+		want  []string
+	}{
+		{
+			name:  "icmp type and code",
+			input: dto.GNPSpecRuleInput{Action: allow, Protocol: "icmp", ICMP: &dto.GNPSpecRuleICMPInput{Type: intPtr(3), Code: intPtr(1)}},
+		},
+		{
+			name:  "icmp by number",
+			input: dto.GNPSpecRuleInput{Action: allow, Protocol: float64(1), ICMP: &dto.GNPSpecRuleICMPInput{Type: intPtr(8)}},
+		},
+		{
+			name:  "not icmp type with icmpv6",
+			input: dto.GNPSpecRuleInput{Action: allow, Protocol: "icmpv6", NotICMP: &dto.GNPSpecRuleICMPInput{Type: intPtr(135)}},
+		},
+		{
+			name:  "icmp without protocol",
+			input: dto.GNPSpecRuleInput{Action: allow, ICMP: &dto.GNPSpecRuleICMPInput{Type: intPtr(8)}},
+			want:  []string{"icmp requires protocol icmp or icmpv6"},
+		},
+		{
+			name:  "icmp with tcp",
+			input: dto.GNPSpecRuleInput{Action: allow, Protocol: "tcp", ICMP: &dto.GNPSpecRuleICMPInput{Type: intPtr(8)}},
+			want:  []string{"icmp requires protocol icmp or icmpv6"},
+		},
+		{
+			name:  "icmp with protocol number wrapping to icmp",
+			input: dto.GNPSpecRuleInput{Action: allow, Protocol: float64(257), ICMP: &dto.GNPSpecRuleICMPInput{Type: intPtr(8)}},
+			want:  []string{"protocol", "icmp requires protocol icmp or icmpv6"},
+		},
+		{
+			name:  "icmp with not protocol icmp",
+			input: dto.GNPSpecRuleInput{Action: allow, NotProtocol: "icmp", ICMP: &dto.GNPSpecRuleICMPInput{Type: intPtr(8)}},
+			want:  []string{"icmp cannot be matched when notProtocol is icmp or icmpv6"},
+		},
+		{
+			name:  "not icmp with not protocol icmpv6",
+			input: dto.GNPSpecRuleInput{Action: allow, NotProtocol: "icmpv6", NotICMP: &dto.GNPSpecRuleICMPInput{Type: intPtr(1)}},
+			want:  []string{"icmp cannot be matched when notProtocol is icmp or icmpv6"},
+		},
+		{
+			name:  "code without type",
+			input: dto.GNPSpecRuleInput{Action: allow, Protocol: "icmp", ICMP: &dto.GNPSpecRuleICMPInput{Code: intPtr(0)}},
+			want:  []string{"icmp code requires type"},
+		},
+		{
+			name:  "type out of range",
+			input: dto.GNPSpecRuleInput{Action: allow, Protocol: "icmp", ICMP: &dto.GNPSpecRuleICMPInput{Type: intPtr(256)}},
+			want:  []string{"max"},
+		},
+		{
+			name:  "icmp with ip version 6",
+			input: dto.GNPSpecRuleInput{Action: allow, Protocol: "icmp", IPVersion: intPtr(6)},
+			want:  []string{"not match with icmp protocol"},
+		},
+		{
+			name: "icmpv6 with ip version 4 nets",
+			input: dto.GNPSpecRuleInput{Action: allow, Protocol: "icmpv6",
+				Source: &dto.GNPSpecRuleEntityInput{Nets: []string{"10.0.0.0/8"}}},
+			want: []string{"icmp protocol not match with nets"},
+		},
+		{
+			name:  "icmp with ports",
+			input: dto.GNPSpecRuleInput{Action: allow, Protocol: "icmp", Destination: &dto.GNPSpecRuleEntityInput{Ports: []interface{}{float64(80)}}},
+			want:  []string{"protocol not support ports"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTags(t, validationTags(t, tt.input), tt.want...)
+		})
+	}
+}
+
+func TestValidateGNSSourceInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input dto.GNSSourceInput
+		want  []string
+	}{
+		{name: "https url", input: dto.GNSSourceInput{URL: "https://www.spamhaus.org/drop/drop.txt"}},
+		{name: "relative file", input: dto.GNSSourceInput{File: "lists/drop.txt"}},
+		{name: "url and file", input: dto.GNSSourceInput{URL: "https://example.com/drop.txt", File: "drop.txt"}, want: []string{"exactly one of url or file"}},
+		{name: "file url", input: dto.GNSSourceInput{URL: "file:///etc/passwd"}, want: []string{"http or https"}},
+		{name: "absolute file", input: dto.GNSSourceInput{File: "/etc/passwd"}, want: []string{"relative to the source file directory"}},
+		{name: "parent file", input: dto.GNSSourceInput{File: "../etc/passwd"}, want: []string{"relative to the source file directory"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.input.RefreshInterval = "1h"
+			checkTags(t, validationTags(t, tt.input), tt.want...)
+		})
+	}
+}
+
+func TestNetOverlapWarnings(t *testing.T) {
+	tests := []struct {
+		name string
+		nets []string
+		want []string
+	}{
+		{name: "disjoint", nets: []string{"10.0.0.0/24", "10.0.1.0/24", "2001:db8::/64"}},
+		{
+			name: "contained",
+			nets: []string{"10.0.0.0/16", "10.0.1.0/24"},
+			want: []string{"net 10.0.1.0/24 overlaps with 10.0.0.0/16"},
+		},
+		{
+			name: "ip in net",
+			nets: []string{"192.168.0.10", "192.168.0.0/24"},
+			want: []string{"net 192.168.0.0/24 overlaps with 192.168.0.10/32"},
+		},
+		{name: "malformed", nets: []string{"10.0.0.0/16", "web"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NetOverlapWarnings(tt.nets)
+			if len(got) != len(tt.want) {
+				t.Fatalf("NetOverlapWarnings() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("NetOverlapWarnings() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
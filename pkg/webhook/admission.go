@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// EventAdmissionReview is the event header of the requests of admission reviews.
+const EventAdmissionReview = "admissionReview"
+
+// maxAdmissionResponse bounds the body of the response of an admission review.
+const maxAdmissionResponse = 64 << 10
+
+// AdmissionReview asks an admission hook whether a change of a resource is allowed. Object is the resource as
+// proposed, null on delete, OldObject the stored one, null on create.
+type AdmissionReview struct {
+	UID       string          `json:"uid"`
+	Kind      string          `json:"kind"`
+	Operation string          `json:"operation"`
+	DryRun    bool            `json:"dryRun"`
+	Object    json.RawMessage `json:"object"`
+	OldObject json.RawMessage `json:"oldObject"`
+}
+
+// AdmissionResponse is the verdict of an admission hook. Message tells why a change is denied.
+type AdmissionResponse struct {
+	Allowed bool   `json:"allowed"`
+	Message string `json:"message,omitempty"`
+}
+
+// Review posts the admission review in the body of req and returns the verdict of the hook. A status other than 2xx
+// or a body which is not a verdict is an error.
+func (s *Sender) Review(ctx context.Context, req Request) (*AdmissionResponse, error) {
+	if req.Event == "" {
+		req.Event = EventAdmissionReview
+	}
+	res, err := s.post(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	response := new(AdmissionResponse)
+	if err = json.NewDecoder(io.LimitReader(res.Body, maxAdmissionResponse)).Decode(response); err != nil {
+		return nil, fmt.Errorf("decode admission response of %s: %w", req.URL, err)
+	}
+	return response, nil
+}
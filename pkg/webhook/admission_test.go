@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReview(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !Verify("s3cret", body, r.Header.Get(HeaderSignature)) {
+			t.Error("review is not signed")
+		}
+		if event := r.Header.Get(HeaderEvent); event != EventAdmissionReview {
+			t.Errorf("event = %q, want %q", event, EventAdmissionReview)
+		}
+		var review AdmissionReview
+		if err := json.Unmarshal(body, &review); err != nil {
+			t.Errorf("decode review: %v", err)
+		}
+		response := AdmissionResponse{Allowed: true}
+		if review.Operation == "delete" {
+			response = AdmissionResponse{Allowed: false, Message: "deletion is frozen"}
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	sender := NewSender(server.Client())
+	for _, tc := range []struct {
+		operation   string
+		wantAllowed bool
+		wantMessage string
+	}{
+		{operation: "create", wantAllowed: true},
+		{operation: "delete", wantAllowed: false, wantMessage: "deletion is frozen"},
+	} {
+		body, _ := json.Marshal(AdmissionReview{UID: "r1", Kind: "globalNetworkPolicy", Operation: tc.operation})
+		response, err := sender.Review(context.Background(), Request{URL: server.URL, Secret: "s3cret", Body: body})
+		if err != nil {
+			t.Fatalf("%s: review: %v", tc.operation, err)
+		}
+		if response.Allowed != tc.wantAllowed || response.Message != tc.wantMessage {
+			t.Fatalf("%s: response = %+v", tc.operation, response)
+		}
+	}
+}
+
+func TestReviewMalformedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	if _, err := NewSender(server.Client()).Review(context.Background(), Request{URL: server.URL, Body: []byte(`{}`)}); err == nil {
+		t.Fatal("expected an error for a response which is not a verdict")
+	}
+}
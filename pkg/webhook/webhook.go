@@ -0,0 +1,114 @@
+// Package webhook posts signed JSON events to HTTP receivers.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Bamboo-Event"
+	HeaderDelivery  = "X-Bamboo-Delivery"
+	HeaderSignature = "X-Bamboo-Signature"
+
+	signaturePrefix = "sha256="
+	// maxErrorBody bounds the part of the body of a failed delivery kept in its error.
+	maxErrorBody = 512
+)
+
+// Request is a delivery of an event to a receiver.
+type Request struct {
+	URL    string
+	Secret string
+	// Event is the kind and the action of the event, e.g. hostEndpoint.delete
+	Event      string
+	DeliveryID string
+	Body       []byte
+}
+
+type Sender struct {
+	client *http.Client
+}
+
+func NewSender(client *http.Client) *Sender {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Sender{
+		client: client,
+	}
+}
+
+// Send posts the body of req, signed with its secret when set. It returns the status code received, 0 when there is
+// no response, and an error unless the status is 2xx.
+func (s *Sender) Send(ctx context.Context, req Request) (int, error) {
+	res, err := s.post(ctx, req)
+	if err != nil {
+		if res != nil {
+			return res.StatusCode, err
+		}
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	return res.StatusCode, nil
+}
+
+// post posts req and returns the response, which body must be closed, unless the status is not 2xx. The response is
+// also returned with an error for an unexpected status, its body is already closed then.
+func (s *Sender) post(ctx context.Context, req Request) (*http.Response, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(HeaderEvent, req.Event)
+	httpReq.Header.Set(HeaderDelivery, req.DeliveryID)
+	if req.Secret != "" {
+		httpReq.Header.Set(HeaderSignature, Sign(req.Secret, req.Body))
+	}
+
+	res, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("post %s: %w", req.URL, err)
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		defer res.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBody))
+		return res, fmt.Errorf("post %s: unexpected status %s: %s", req.URL, res.Status, strings.TrimSpace(string(body)))
+	}
+	return res, nil
+}
+
+// Sign returns the value of the signature header of body, the hex encoded HMAC-SHA256 of body keyed by secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature header of body, receivers use it to authenticate deliveries.
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// Backoff returns the delay before retrying a delivery which failed attempts times: base doubled on every failed
+// attempt, up to max.
+func Backoff(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return min(delay, max)
+}
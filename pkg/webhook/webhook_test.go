@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSendSigned(t *testing.T) {
+	var (
+		gotBody      []byte
+		gotSignature string
+		gotEvent     string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get(HeaderSignature)
+		gotEvent = r.Header.Get(HeaderEvent)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	body := []byte(`{"kind":"hostEndpoint","action":"create","name":"web-1"}`)
+	status, err := NewSender(server.Client()).Send(context.Background(), Request{
+		URL:        server.URL,
+		Secret:     "s3cret",
+		Event:      "hostEndpoint.create",
+		DeliveryID: "d1",
+		Body:       body,
+	})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if status != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", status, http.StatusNoContent)
+	}
+	if string(gotBody) != string(body) {
+		t.Fatalf("body = %s, want %s", gotBody, body)
+	}
+	if gotEvent != "hostEndpoint.create" {
+		t.Fatalf("event = %q", gotEvent)
+	}
+	if !Verify("s3cret", gotBody, gotSignature) {
+		t.Fatalf("signature %q does not verify", gotSignature)
+	}
+	if Verify("other", gotBody, gotSignature) {
+		t.Fatal("signature verifies with another secret")
+	}
+}
+
+func TestSendUnsigned(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Header[HeaderSignature]; ok {
+			t.Error("unexpected signature without secret")
+		}
+	}))
+	defer server.Close()
+
+	if _, err := NewSender(server.Client()).Send(context.Background(), Request{URL: server.URL, Body: []byte(`{}`)}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+}
+
+func TestSendStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "receiver down", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	status, err := NewSender(server.Client()).Send(context.Background(), Request{URL: server.URL, Body: []byte(`{}`)})
+	if err == nil {
+		t.Fatal("expected an error for status 503")
+	}
+	if status != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want %d", status, http.StatusServiceUnavailable)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 10 * time.Second},
+		{attempts: 2, want: 20 * time.Second},
+		{attempts: 4, want: 80 * time.Second},
+		{attempts: 10, want: time.Hour},
+		{attempts: 100, want: time.Hour},
+	}
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts, 10*time.Second, time.Hour); got != tt.want {
+			t.Errorf("Backoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package be
 
 import (
 	"context"
+	"time"
 
 	"github.com/bamboo-firewall/be/domain/model"
 	"github.com/bamboo-firewall/be/pkg/entity"
@@ -13,12 +14,58 @@ type Storage interface {
 	GetHostEndpoint(ctx context.Context, input *model.GetHostEndpointInput) (*entity.HostEndpoint, *ierror.CoreError)
 	DeleteHostEndpoint(ctx context.Context, tenantID uint64, ip uint32) *ierror.CoreError
 	ListHostEndpoints(ctx context.Context, input *model.ListHostEndpointsInput) ([]*entity.HostEndpoint, *ierror.CoreError)
+	CountHostEndpoints(ctx context.Context, tenantID uint64) (int64, *ierror.CoreError)
 	UpsertGroupPolicy(ctx context.Context, gnp *entity.GlobalNetworkPolicy) *ierror.CoreError
 	GetGNPByName(ctx context.Context, name string) (*entity.GlobalNetworkPolicy, *ierror.CoreError)
 	DeleteGNPByName(ctx context.Context, name string) *ierror.CoreError
 	ListGNPs(ctx context.Context, input *model.ListGNPsInput) ([]*entity.GlobalNetworkPolicy, *ierror.CoreError)
 	UpsertGNS(ctx context.Context, gns *entity.GlobalNetworkSet) *ierror.CoreError
+	UpdateGNSExternalState(ctx context.Context, name string, version uint, status *entity.GNSStatus, resolved *entity.GNSResolved) *ierror.CoreError
+	UpdateGNSExternalNets(ctx context.Context, gns *entity.GlobalNetworkSet) *ierror.CoreError
 	GetGNSByName(ctx context.Context, name string) (*entity.GlobalNetworkSet, *ierror.CoreError)
 	DeleteGNSByName(ctx context.Context, name string) *ierror.CoreError
 	ListGNSs(ctx context.Context) ([]*entity.GlobalNetworkSet, *ierror.CoreError)
+	UpsertNetworkPolicy(ctx context.Context, np *entity.GlobalNetworkPolicy) *ierror.CoreError
+	GetNetworkPolicy(ctx context.Context, tenantID uint64, name string) (*entity.GlobalNetworkPolicy, *ierror.CoreError)
+	DeleteNetworkPolicy(ctx context.Context, tenantID uint64, name string) *ierror.CoreError
+	ListNetworkPolicies(ctx context.Context, tenantID uint64) ([]*entity.GlobalNetworkPolicy, *ierror.CoreError)
+	CountNetworkPolicies(ctx context.Context, tenantID uint64) (int64, *ierror.CoreError)
+	UpsertNetworkSet(ctx context.Context, ns *entity.GlobalNetworkSet) *ierror.CoreError
+	GetNetworkSet(ctx context.Context, tenantID uint64, name string) (*entity.GlobalNetworkSet, *ierror.CoreError)
+	DeleteNetworkSet(ctx context.Context, tenantID uint64, name string) *ierror.CoreError
+	ListNetworkSets(ctx context.Context, tenantID uint64) ([]*entity.GlobalNetworkSet, *ierror.CoreError)
+	UpsertTier(ctx context.Context, tier *entity.Tier) *ierror.CoreError
+	GetTierByName(ctx context.Context, name string) (*entity.Tier, *ierror.CoreError)
+	DeleteTierByName(ctx context.Context, name string) *ierror.CoreError
+	ListTiers(ctx context.Context) ([]*entity.Tier, *ierror.CoreError)
+	UpsertTenant(ctx context.Context, tenant *entity.Tenant) *ierror.CoreError
+	GetTenant(ctx context.Context, tenantID uint64) (*entity.Tenant, *ierror.CoreError)
+	DeleteTenant(ctx context.Context, tenantID uint64) *ierror.CoreError
+	ListTenants(ctx context.Context) ([]*entity.Tenant, *ierror.CoreError)
+	IncStagedPolicyReport(ctx context.Context, report *entity.StagedPolicyReport) *ierror.CoreError
+	ListStagedPolicyReports(ctx context.Context, gnpUUID string) ([]*entity.StagedPolicyReport, *ierror.CoreError)
+	DeleteStagedPolicyReports(ctx context.Context, gnpUUID string) *ierror.CoreError
+	RegisterAgent(ctx context.Context, agent *entity.Agent) *ierror.CoreError
+	UpdateAgentHeartbeat(ctx context.Context, agent *entity.Agent) *ierror.CoreError
+	ListAgents(ctx context.Context, tenantID uint64) ([]*entity.Agent, *ierror.CoreError)
+	UpsertEnrollmentToken(ctx context.Context, token *entity.EnrollmentToken) *ierror.CoreError
+	GetEnrollmentTokenByName(ctx context.Context, name string) (*entity.EnrollmentToken, *ierror.CoreError)
+	GetEnrollmentTokenByToken(ctx context.Context, token string) (*entity.EnrollmentToken, *ierror.CoreError)
+	DeleteEnrollmentTokenByName(ctx context.Context, name string) *ierror.CoreError
+	ListEnrollmentTokens(ctx context.Context) ([]*entity.EnrollmentToken, *ierror.CoreError)
+	UpsertWebhook(ctx context.Context, webhook *entity.Webhook) *ierror.CoreError
+	GetWebhookByName(ctx context.Context, name string) (*entity.Webhook, *ierror.CoreError)
+	DeleteWebhookByName(ctx context.Context, name string) *ierror.CoreError
+	ListWebhooks(ctx context.Context) ([]*entity.Webhook, *ierror.CoreError)
+	InsertWebhookDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) *ierror.CoreError
+	ClaimWebhookDelivery(ctx context.Context, now time.Time, lease time.Duration) (*entity.WebhookDelivery, *ierror.CoreError)
+	UpdateWebhookDelivery(ctx context.Context, delivery *entity.WebhookDelivery) *ierror.CoreError
+	ListWebhookDeliveries(ctx context.Context, input *model.ListWebhookDeliveriesInput) ([]*entity.WebhookDelivery, *ierror.CoreError)
+	UpsertAdmissionHook(ctx context.Context, admissionHook *entity.AdmissionHook) *ierror.CoreError
+	GetAdmissionHookByName(ctx context.Context, name string) (*entity.AdmissionHook, *ierror.CoreError)
+	DeleteAdmissionHookByName(ctx context.Context, name string) *ierror.CoreError
+	ListAdmissionHooks(ctx context.Context) ([]*entity.AdmissionHook, *ierror.CoreError)
+	GetBundle(ctx context.Context) (*model.Bundle, *ierror.CoreError)
+	RestoreBundle(ctx context.Context, input *model.RestoreInput) (*model.RestoreOutput, *ierror.CoreError)
+	ApplyBulk(ctx context.Context, items []*model.BulkWriteItem) *ierror.CoreError
 }